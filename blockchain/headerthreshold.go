@@ -0,0 +1,148 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/nargott/godash/chaincfg"
+	"github.com/nargott/godash/wire"
+)
+
+// DeploymentTally houses the signalling statistics of a rule change deployment
+// for the confirmation window which contains the block a threshold state was
+// calculated for.  It mirrors the statistics reported for BIP0009 deployments
+// by the getblockchaininfo RPC of dashd.
+type DeploymentTally struct {
+	// Period is the number of blocks in each confirmation window.
+	Period uint32
+
+	// Threshold is the number of blocks of a window which must signal for
+	// the deployment to lock in.
+	Threshold uint32
+
+	// Elapsed is the number of blocks of the current window which have
+	// been seen so far.
+	Elapsed uint32
+
+	// Count is the number of the elapsed blocks which signal for the
+	// deployment.
+	Count uint32
+
+	// Possible reports whether the threshold can still be reached in the
+	// current window.
+	Possible bool
+}
+
+// deploymentSignals returns whether the passed header signals for the
+// deployment.  It is the same condition deploymentChecker applies to block
+// nodes.
+func deploymentSignals(deployment *chaincfg.ConsensusDeployment,
+	header *wire.BlockHeader) bool {
+
+	conditionMask := uint32(1) << deployment.BitNumber
+	version := uint32(header.Version)
+	return (version&vbTopMask == vbTopBits) && (version&conditionMask != 0)
+}
+
+// CalcDeploymentState returns the BIP0009 threshold state of the given
+// deployment ID for the block AFTER the last of the passed headers along with
+// the signalling statistics of the current confirmation window.  The headers
+// must form the chain starting with the genesis block, since the state of each
+// window depends on the state of the previous one.  The median times the start
+// and expiration times of the deployment are compared against are calculated
+// from the headers per chaincfg.CalcMedianTimePast.
+//
+// The function applies the same rules as BlockChain.ThresholdState, including
+// the confirmation window and activation threshold of deployments which define
+// their own, so it can be used to track the activation of deployments such as
+// DIP0003 and DIP0008 from headers obtained from a peer or the RPC server
+// without a full chain.
+func CalcDeploymentState(params *chaincfg.Params, deploymentID uint32,
+	headers []*wire.BlockHeader) (ThresholdState, DeploymentTally, error) {
+
+	if deploymentID >= uint32(len(params.Deployments)) {
+		return ThresholdFailed, DeploymentTally{},
+			DeploymentError(deploymentID)
+	}
+	deployment := &params.Deployments[deploymentID]
+	window, threshold := deploymentWindow(params, deployment)
+
+	// The state of each window is determined by the median time and the
+	// signalling of the last block of the previous window, so iterate the
+	// windows in order starting with the one after the genesis window,
+	// whose state is defined by definition.
+	state := ThresholdDefined
+	numHeaders := uint32(len(headers))
+	for end := window; end <= numHeaders; end += window {
+		medianTime := chaincfg.CalcMedianTimePast(headers[:end])
+		medianTimeUnix := uint64(medianTime.Unix())
+
+		// The state is simply defined if the start time hasn't been
+		// reached yet.
+		if medianTimeUnix < deployment.StartTime {
+			state = ThresholdDefined
+			continue
+		}
+
+		switch state {
+		case ThresholdDefined:
+			// The deployment of the rule change fails if it expires
+			// before it is accepted and locked in, otherwise it
+			// moves to the started state since its start time has
+			// been reached.
+			if medianTimeUnix >= deployment.ExpireTime {
+				state = ThresholdFailed
+				break
+			}
+			state = ThresholdStarted
+
+		case ThresholdStarted:
+			// The deployment of the rule change fails if it expires
+			// before it is accepted and locked in.
+			if medianTimeUnix >= deployment.ExpireTime {
+				state = ThresholdFailed
+				break
+			}
+
+			// The state is locked in if the number of blocks in the
+			// window that voted for the rule change meets the
+			// activation threshold.
+			var count uint32
+			for _, header := range headers[end-window : end] {
+				if deploymentSignals(deployment, header) {
+					count++
+				}
+			}
+			if count >= threshold {
+				state = ThresholdLockedIn
+			}
+
+		case ThresholdLockedIn:
+			// The new rule becomes active when its previous state
+			// was locked in.
+			state = ThresholdActive
+
+		// Nothing to do if the previous state is active or failed since
+		// they are both terminal states.
+		case ThresholdActive:
+		case ThresholdFailed:
+		}
+	}
+
+	// Tally the signalling blocks of the window the last header belongs
+	// to which follow the last block of the previous window.
+	tally := DeploymentTally{
+		Period:    window,
+		Threshold: threshold,
+		Elapsed:   numHeaders % window,
+	}
+	for _, header := range headers[numHeaders-tally.Elapsed:] {
+		if deploymentSignals(deployment, header) {
+			tally.Count++
+		}
+	}
+	tally.Possible = window-threshold >= tally.Elapsed-tally.Count
+
+	return state, tally, nil
+}
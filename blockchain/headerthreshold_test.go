@@ -0,0 +1,211 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nargott/godash/chaincfg"
+	"github.com/nargott/godash/wire"
+)
+
+// TestCalcDeploymentState ensures the threshold state and tally calculated
+// from headers follow the BIP0009 state transitions.
+func TestCalcDeploymentState(t *testing.T) {
+	t.Parallel()
+
+	// Use small confirmation windows of 10 blocks with a threshold of 8 and
+	// a deployment which starts at time 1000 and expires at time 5000.
+	params := chaincfg.RegressionNetParams
+	params.MinerConfirmationWindow = 10
+	params.RuleChangeActivationThreshold = 8
+	params.Deployments[chaincfg.DeploymentTestDummy] = chaincfg.ConsensusDeployment{
+		BitNumber:  28,
+		StartTime:  1000,
+		ExpireTime: 5000,
+	}
+	signal := int32(vbTopBits | 1<<28)
+
+	// chain returns headers with the passed versions whose timestamps
+	// increase by 10 seconds starting at the passed time.
+	chain := func(start int64, versions ...int32) []*wire.BlockHeader {
+		headers := make([]*wire.BlockHeader, 0, len(versions))
+		for i, version := range versions {
+			headers = append(headers, &wire.BlockHeader{
+				Version:   version,
+				Timestamp: time.Unix(start+int64(i)*10, 0),
+			})
+		}
+		return headers
+	}
+
+	// repeat returns n copies of the passed version.
+	repeat := func(version int32, n int) []int32 {
+		versions := make([]int32, n)
+		for i := range versions {
+			versions[i] = version
+		}
+		return versions
+	}
+
+	// window returns the versions of a window with the passed number of
+	// signalling blocks.
+	window := func(signalling int) []int32 {
+		return append(repeat(signal, signalling),
+			repeat(vbTopBits, 10-signalling)...)
+	}
+
+	// concat joins the passed versions.
+	concat := func(parts ...[]int32) []int32 {
+		var versions []int32
+		for _, part := range parts {
+			versions = append(versions, part...)
+		}
+		return versions
+	}
+
+	tests := []struct {
+		name    string
+		headers []*wire.BlockHeader
+		state   ThresholdState
+		tally   DeploymentTally
+	}{
+		{
+			name:    "no headers",
+			headers: nil,
+			state:   ThresholdDefined,
+			tally:   DeploymentTally{Period: 10, Threshold: 8, Possible: true},
+		},
+		{
+			name:    "genesis window",
+			headers: chain(2000, window(8)[:9]...),
+			state:   ThresholdDefined,
+			tally: DeploymentTally{Period: 10, Threshold: 8,
+				Elapsed: 9, Count: 8, Possible: true},
+		},
+		{
+			name:    "before start time",
+			headers: chain(0, concat(window(0), window(10))...),
+			state:   ThresholdDefined,
+			tally:   DeploymentTally{Period: 10, Threshold: 8, Possible: true},
+		},
+		{
+			name:    "started",
+			headers: chain(2000, window(10)...),
+			state:   ThresholdStarted,
+			tally:   DeploymentTally{Period: 10, Threshold: 8, Possible: true},
+		},
+		{
+			name:    "started below threshold",
+			headers: chain(2000, concat(window(0), window(7), window(3)[:5])...),
+			state:   ThresholdStarted,
+			tally: DeploymentTally{Period: 10, Threshold: 8,
+				Elapsed: 5, Count: 3, Possible: true},
+		},
+		{
+			name:    "threshold no longer possible",
+			headers: chain(2000, concat(window(0), window(0)[:3])...),
+			state:   ThresholdStarted,
+			tally: DeploymentTally{Period: 10, Threshold: 8,
+				Elapsed: 3, Count: 0, Possible: false},
+		},
+		{
+			name:    "locked in",
+			headers: chain(2000, concat(window(0), window(8))...),
+			state:   ThresholdLockedIn,
+			tally:   DeploymentTally{Period: 10, Threshold: 8, Possible: true},
+		},
+		{
+			name:    "active",
+			headers: chain(2000, concat(window(0), window(8), window(0))...),
+			state:   ThresholdActive,
+			tally:   DeploymentTally{Period: 10, Threshold: 8, Possible: true},
+		},
+		{
+			name: "active is terminal",
+			headers: chain(2000, concat(window(0), window(8), window(0),
+				window(0))...),
+			state: ThresholdActive,
+			tally: DeploymentTally{Period: 10, Threshold: 8, Possible: true},
+		},
+		{
+			name:    "expired before start",
+			headers: chain(6000, window(10)...),
+			state:   ThresholdFailed,
+			tally:   DeploymentTally{Period: 10, Threshold: 8, Possible: true},
+		},
+		{
+			name:    "expired while started",
+			headers: chain(4900, concat(window(0), window(10))...),
+			state:   ThresholdFailed,
+			tally:   DeploymentTally{Period: 10, Threshold: 8, Possible: true},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		state, tally, err := CalcDeploymentState(&params,
+			chaincfg.DeploymentTestDummy, test.headers)
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if state != test.state {
+			t.Errorf("Test #%d (%s) state: got %v, want %v", i,
+				test.name, state, test.state)
+		}
+		if tally != test.tally {
+			t.Errorf("Test #%d (%s) tally: got %+v, want %+v", i,
+				test.name, tally, test.tally)
+		}
+	}
+
+	// Deployments which define their own confirmation window and
+	// activation threshold use them instead of those of the params.
+	custom := params
+	custom.Deployments[chaincfg.DeploymentTestDummy].MinerConfirmationWindow = 5
+	custom.Deployments[chaincfg.DeploymentTestDummy].RuleChangeActivationThreshold = 4
+	state, tally, err := CalcDeploymentState(&custom,
+		chaincfg.DeploymentTestDummy, chain(2000, concat(repeat(vbTopBits, 5),
+			repeat(signal, 4), repeat(vbTopBits, 3))...))
+	if err != nil {
+		t.Fatalf("custom window: unexpected error: %v", err)
+	}
+	if state != ThresholdLockedIn {
+		t.Errorf("custom window state: got %v, want %v", state,
+			ThresholdLockedIn)
+	}
+	wantTally := DeploymentTally{Period: 5, Threshold: 4, Elapsed: 2,
+		Possible: false}
+	if tally != wantTally {
+		t.Errorf("custom window tally: got %+v, want %+v", tally,
+			wantTally)
+	}
+
+	// DIP0003 and DIP0008 use windows of 4032 blocks on mainnet.
+	for _, id := range []uint32{chaincfg.DeploymentDIP0003,
+		chaincfg.DeploymentDIP0008} {
+
+		_, tally, err := CalcDeploymentState(&chaincfg.MainNetParams, id,
+			nil)
+		if err != nil {
+			t.Fatalf("deployment %d: unexpected error: %v", id, err)
+		}
+		if tally.Period != 4032 || tally.Threshold != 3226 {
+			t.Errorf("deployment %d: got period %d and threshold %d, "+
+				"want 4032 and 3226", id, tally.Period,
+				tally.Threshold)
+		}
+	}
+
+	// Unknown deployments are rejected.
+	_, _, err = CalcDeploymentState(&params, chaincfg.DefinedDeployments,
+		nil)
+	if _, ok := err.(DeploymentError); !ok {
+		t.Errorf("unexpected error for unknown deployment: %v", err)
+	}
+}
@@ -158,23 +158,44 @@ func (c deploymentChecker) EndTime() uint64 {
 // RuleChangeActivationThreshold is the number of blocks for which the condition
 // must be true in order to lock in a rule change.
 //
-// This implementation returns the value defined by the chain params the checker
-// is associated with.
+// This implementation returns the value defined by the specific deployment the
+// checker is associated with, or by the chain params when the deployment
+// doesn't define one.
 //
 // This is part of the thresholdConditionChecker interface implementation.
 func (c deploymentChecker) RuleChangeActivationThreshold() uint32 {
-	return c.chain.chainParams.RuleChangeActivationThreshold
+	_, threshold := deploymentWindow(c.chain.chainParams, c.deployment)
+	return threshold
 }
 
 // MinerConfirmationWindow is the number of blocks in each threshold state
 // retarget window.
 //
-// This implementation returns the value defined by the chain params the checker
-// is associated with.
+// This implementation returns the value defined by the specific deployment the
+// checker is associated with, or by the chain params when the deployment
+// doesn't define one.
 //
 // This is part of the thresholdConditionChecker interface implementation.
 func (c deploymentChecker) MinerConfirmationWindow() uint32 {
-	return c.chain.chainParams.MinerConfirmationWindow
+	window, _ := deploymentWindow(c.chain.chainParams, c.deployment)
+	return window
+}
+
+// deploymentWindow returns the confirmation window and activation threshold of
+// the passed deployment.  Deployments such as DIP0003 and DIP0008 define their
+// own, the others use those of the passed chain params.
+func deploymentWindow(params *chaincfg.Params,
+	deployment *chaincfg.ConsensusDeployment) (uint32, uint32) {
+
+	window := params.MinerConfirmationWindow
+	if deployment.MinerConfirmationWindow != 0 {
+		window = deployment.MinerConfirmationWindow
+	}
+	threshold := params.RuleChangeActivationThreshold
+	if deployment.RuleChangeActivationThreshold != 0 {
+		threshold = deployment.RuleChangeActivationThreshold
+	}
+	return window, threshold
 }
 
 // Condition returns true when the specific bit defined by the deployment
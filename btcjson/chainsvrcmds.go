@@ -44,6 +44,25 @@ func NewAddNodeCmd(addr string, subCmd AddNodeSubCmd) *AddNodeCmd {
 	}
 }
 
+// DisconnectNodeCmd defines the disconnectnode JSON-RPC command.  The peer is
+// identified by its address, or by its node id when the address is empty.
+type DisconnectNodeCmd struct {
+	Address *string `jsonrpcdefault:"\"\""`
+	NodeID  *int
+}
+
+// NewDisconnectNodeCmd returns a new instance which can be used to issue a
+// disconnectnode JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewDisconnectNodeCmd(address *string, nodeID *int) *DisconnectNodeCmd {
+	return &DisconnectNodeCmd{
+		Address: address,
+		NodeID:  nodeID,
+	}
+}
+
 // TransactionInput represents the inputs to a transaction.  Specifically a
 // transaction hash and output number pair.
 type TransactionInput struct {
@@ -98,6 +117,36 @@ func NewDecodeScriptCmd(hexScript string) *DecodeScriptCmd {
 	}
 }
 
+// EstimateSmartFeeMode defines the different fee estimation modes available
+// for the estimatesmartfee JSON-RPC command.
+type EstimateSmartFeeMode string
+
+// These constants define the fee estimation modes of the estimatesmartfee
+// JSON-RPC command.
+const (
+	EstimateModeUnset        EstimateSmartFeeMode = "UNSET"
+	EstimateModeEconomical   EstimateSmartFeeMode = "ECONOMICAL"
+	EstimateModeConservative EstimateSmartFeeMode = "CONSERVATIVE"
+)
+
+// EstimateSmartFeeCmd defines the estimatesmartfee JSON-RPC command.
+type EstimateSmartFeeCmd struct {
+	ConfTarget   int64
+	EstimateMode *EstimateSmartFeeMode `jsonrpcdefault:"\"CONSERVATIVE\""`
+}
+
+// NewEstimateSmartFeeCmd returns a new instance which can be used to issue an
+// estimatesmartfee JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewEstimateSmartFeeCmd(confTarget int64, mode *EstimateSmartFeeMode) *EstimateSmartFeeCmd {
+	return &EstimateSmartFeeCmd{
+		ConfTarget:   confTarget,
+		EstimateMode: mode,
+	}
+}
+
 // GetAddedNodeInfoCmd defines the getaddednodeinfo JSON-RPC command.
 type GetAddedNodeInfoCmd struct {
 	DNS  bool
@@ -279,21 +328,58 @@ func NewGetBlockTemplateCmd(request *TemplateRequest) *GetBlockTemplateCmd {
 	}
 }
 
+// HashOrHeight identifies a block by either its hash or its height.  Value is
+// a string holding the hash or an int holding the height.
+type HashOrHeight struct {
+	Value interface{}
+}
+
+// MarshalJSON provides a custom Marshal method for HashOrHeight so it is
+// encoded as a bare string or number.
+func (h HashOrHeight) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.Value)
+}
+
+// UnmarshalJSON provides a custom Unmarshal method for HashOrHeight.  This is
+// necessary because the value can either be a string or an integer.
+func (h *HashOrHeight) UnmarshalJSON(data []byte) error {
+	var unmarshalled interface{}
+	if err := json.Unmarshal(data, &unmarshalled); err != nil {
+		return err
+	}
+
+	switch v := unmarshalled.(type) {
+	case string:
+		h.Value = v
+	case float64:
+		if v != float64(int(v)) {
+			str := fmt.Sprintf("invalid block height %v", v)
+			return makeError(ErrInvalidType, str)
+		}
+		h.Value = int(v)
+	default:
+		str := fmt.Sprintf("invalid hash_or_height value %v", unmarshalled)
+		return makeError(ErrInvalidType, str)
+	}
+	return nil
+}
+
 // GetBlockStatsCmd defines the getblockstats JSON-RPC command.
 type GetBlockStatsCmd struct {
-	Hash      string
-	Verbose   *bool   `jsonrpcdefault:"true"`
+	HashOrHeight HashOrHeight
+	Stats        *[]string
 }
 
-// NewGetBlockStatsCmd returns a new instance which can be used to issue a getblockstats
-// JSON-RPC command.
+// NewGetBlockStatsCmd returns a new instance which can be used to issue a
+// getblockstats JSON-RPC command.  Either a block hash or height can be
+// specified.
 //
 // The parameters which are pointers indicate they are optional.  Passing nil
 // for optional parameters will use the default value.
-func NewGetBlockStatsCmd(hash string, verbose *bool) *GetBlockStatsCmd {
+func NewGetBlockStatsCmd(hashOrHeight HashOrHeight, stats *[]string) *GetBlockStatsCmd {
 	return &GetBlockStatsCmd{
-		Hash:      hash,
-		Verbose:   verbose,
+		HashOrHeight: hashOrHeight,
+		Stats:        stats,
 	}
 }
 
@@ -766,7 +852,9 @@ func init() {
 	MustRegisterCmd("createrawtransaction", (*CreateRawTransactionCmd)(nil), flags)
 	MustRegisterCmd("decoderawtransaction", (*DecodeRawTransactionCmd)(nil), flags)
 	MustRegisterCmd("decodescript", (*DecodeScriptCmd)(nil), flags)
+	MustRegisterCmd("disconnectnode", (*DisconnectNodeCmd)(nil), flags)
 	MustRegisterCmd("getaddednodeinfo", (*GetAddedNodeInfoCmd)(nil), flags)
+	MustRegisterCmd("estimatesmartfee", (*EstimateSmartFeeCmd)(nil), flags)
 	MustRegisterCmd("getbestblockhash", (*GetBestBlockHashCmd)(nil), flags)
 	MustRegisterCmd("getblock", (*GetBlockCmd)(nil), flags)
 	MustRegisterCmd("getblockchaininfo", (*GetBlockChainInfoCmd)(nil), flags)
@@ -23,6 +23,8 @@ func TestChainSvrCmds(t *testing.T) {
 	t.Parallel()
 
 	testID := int(1)
+	economical := btcjson.EstimateModeEconomical
+	conservative := btcjson.EstimateModeConservative
 	tests := []struct {
 		name         string
 		newCmd       func() (interface{}, error)
@@ -103,6 +105,31 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"decodescript","params":["00"],"id":1}`,
 			unmarshalled: &btcjson.DecodeScriptCmd{HexScript: "00"},
 		},
+		{
+			name: "disconnectnode address",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("disconnectnode", "127.0.0.1:9999")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewDisconnectNodeCmd(btcjson.String("127.0.0.1:9999"), nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"disconnectnode","params":["127.0.0.1:9999"],"id":1}`,
+			unmarshalled: &btcjson.DisconnectNodeCmd{Address: btcjson.String("127.0.0.1:9999")},
+		},
+		{
+			name: "disconnectnode node id",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("disconnectnode", "", 12)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewDisconnectNodeCmd(btcjson.String(""), btcjson.Int(12))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"disconnectnode","params":["",12],"id":1}`,
+			unmarshalled: &btcjson.DisconnectNodeCmd{
+				Address: btcjson.String(""),
+				NodeID:  btcjson.Int(12),
+			},
+		},
 		{
 			name: "getaddednodeinfo",
 			newCmd: func() (interface{}, error) {
@@ -318,6 +345,46 @@ func TestChainSvrCmds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "getblockstats height",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockstats", btcjson.HashOrHeight{Value: 123})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockStatsCmd(btcjson.HashOrHeight{Value: 123}, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockstats","params":[123],"id":1}`,
+			unmarshalled: &btcjson.GetBlockStatsCmd{
+				HashOrHeight: btcjson.HashOrHeight{Value: 123},
+			},
+		},
+		{
+			name: "getblockstats hash",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockstats", btcjson.HashOrHeight{Value: "deadbeef"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockStatsCmd(btcjson.HashOrHeight{Value: "deadbeef"}, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockstats","params":["deadbeef"],"id":1}`,
+			unmarshalled: &btcjson.GetBlockStatsCmd{
+				HashOrHeight: btcjson.HashOrHeight{Value: "deadbeef"},
+			},
+		},
+		{
+			name: "getblockstats height optional stats",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockstats", btcjson.HashOrHeight{Value: 123}, []string{"avgfee", "maxfee"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockStatsCmd(btcjson.HashOrHeight{Value: 123}, &[]string{"avgfee", "maxfee"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockstats","params":[123,["avgfee","maxfee"]],"id":1}`,
+			unmarshalled: &btcjson.GetBlockStatsCmd{
+				HashOrHeight: btcjson.HashOrHeight{Value: 123},
+				Stats:        &[]string{"avgfee", "maxfee"},
+			},
+		},
 		{
 			name: "getchaintips",
 			newCmd: func() (interface{}, error) {
@@ -397,6 +464,34 @@ func TestChainSvrCmds(t *testing.T) {
 				TxID: "txhash",
 			},
 		},
+		{
+			name: "estimatesmartfee",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("estimatesmartfee", 6)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEstimateSmartFeeCmd(6, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"estimatesmartfee","params":[6],"id":1}`,
+			unmarshalled: &btcjson.EstimateSmartFeeCmd{
+				ConfTarget:   6,
+				EstimateMode: &conservative,
+			},
+		},
+		{
+			name: "estimatesmartfee optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("estimatesmartfee", 6, btcjson.EstimateModeEconomical)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEstimateSmartFeeCmd(6, &economical)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"estimatesmartfee","params":[6,"ECONOMICAL"],"id":1}`,
+			unmarshalled: &btcjson.EstimateSmartFeeCmd{
+				ConfTarget:   6,
+				EstimateMode: &economical,
+			},
+		},
 		{
 			name: "getmempoolinfo",
 			newCmd: func() (interface{}, error) {
@@ -8,20 +8,27 @@ import "encoding/json"
 
 // GetBlockHeaderVerboseResult models the data from the getblockheader command when
 // the verbose flag is set.  When the verbose flag is not set, getblockheader
-// returns a hex-encoded string.
+// returns a hex-encoded string.  Confirmations is -1 for blocks which are not
+// part of the main chain.
 type GetBlockHeaderVerboseResult struct {
 	Hash          string  `json:"hash"`
-	Confirmations uint64  `json:"confirmations"`
+	Confirmations int64   `json:"confirmations"`
 	Height        int32   `json:"height"`
 	Version       int32   `json:"version"`
 	VersionHex    string  `json:"versionHex"`
 	MerkleRoot    string  `json:"merkleroot"`
 	Time          int64   `json:"time"`
+	MedianTime    int64   `json:"mediantime,omitempty"`
 	Nonce         uint64  `json:"nonce"`
 	Bits          string  `json:"bits"`
 	Difficulty    float64 `json:"difficulty"`
+	ChainWork     string  `json:"chainwork,omitempty"`
 	PreviousHash  string  `json:"previousblockhash,omitempty"`
 	NextHash      string  `json:"nextblockhash,omitempty"`
+
+	// ChainLock reports whether the block is locked by a ChainLock
+	// (DIP0008).
+	ChainLock bool `json:"chainlock"`
 }
 
 // GetBlockVerboseResult models the data from the getblock command when the
@@ -29,7 +36,7 @@ type GetBlockHeaderVerboseResult struct {
 // hex-encoded string.
 type GetBlockVerboseResult struct {
 	Hash          string        `json:"hash"`
-	Confirmations uint64        `json:"confirmations"`
+	Confirmations int64         `json:"confirmations"`
 	StrippedSize  int32         `json:"strippedsize"`
 	Size          int32         `json:"size"`
 	Weight        int32         `json:"weight"`
@@ -45,34 +52,78 @@ type GetBlockVerboseResult struct {
 	Difficulty    float64       `json:"difficulty"`
 	PreviousHash  string        `json:"previousblockhash"`
 	NextHash      string        `json:"nextblockhash,omitempty"`
+
+	// Dash specific fields.  CbTx is the decoded payload of the coinbase
+	// special transaction and ChainLock reports whether the block is
+	// locked by a ChainLock (DIP0008).
+	CbTx      *CbTxPayload `json:"cbTx,omitempty"`
+	ChainLock bool         `json:"chainlock"`
+}
+
+// UnmarshalJSON provides a custom Unmarshal method for GetBlockVerboseResult.
+// This is necessary because dashd replies with the decoded transactions in the
+// tx field, instead of just their hashes, when the block is requested with a
+// verbosity of two.  Decoded transactions are stored in RawTx.
+func (r *GetBlockVerboseResult) UnmarshalJSON(data []byte) error {
+	type getBlockVerboseResult GetBlockVerboseResult
+	aux := struct {
+		*getBlockVerboseResult
+		Tx []json.RawMessage `json:"tx,omitempty"`
+	}{getBlockVerboseResult: (*getBlockVerboseResult)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.Tx = nil
+	for _, rawTx := range aux.Tx {
+		var txHash string
+		if err := json.Unmarshal(rawTx, &txHash); err == nil {
+			r.Tx = append(r.Tx, txHash)
+			continue
+		}
+
+		var tx TxRawResult
+		if err := json.Unmarshal(rawTx, &tx); err != nil {
+			return err
+		}
+		r.RawTx = append(r.RawTx, tx)
+	}
+	return nil
 }
 
-// GetBlockStatsResult models the data from the getblockstats command when the
-// hash flag is set.
+// GetBlockStatsResult models the data from the getblockstats command.  Amounts
+// are in duffs and fee rates in duffs per byte.
+//
+// FeeRatePercentiles holds the 10th, 25th, 50th, 75th and 90th percentile fee
+// rates weighted by size.  Older versions of dashd report MedianFeeRate
+// instead.
 type GetBlockStatsResult struct {
-	AvgFee        int64  `json:"avgfee"`
-	AvgFeeRate    int64  `json:"avgfeerate"`
-	AvgTxSize     int64  `json:"avgtxsize"`
-	BlockHash     string `json:"blockhash"`
-	Height        int64  `json:"height"`
-	InPuts        int64  `json:"ins"`
-	MaxFee        int64  `json:"maxfee"`
-	MaxFeeRate    int64  `json:"maxfeerate"`
-	MaxTxSize     int64  `json:"maxtxsize"`
-	MedianFee     int64  `json:"medianfee"`
-	MedianFeeRate int64  `json:"medianfeerate"`
-	MedianTime    int64  `json:"mediantime"`
-	MedianTxSize  int64  `json:"mediantxsize"`
-	MinFee        int64  `json:"minfee"`
-	MinFeeRate    int64  `json:"minfeerate"`
-	MinTxSize     int64  `json:"mintxsize"`
-	OutPuts       int64  `json:"outs"`
-	SubSidy       int64  `json:"subsidy"`
-	Time          int64  `json:"time"`
-	TotalOut      int64  `json:"total_out"`
-	TotalSize     int64  `json:"total_size"`
-	TotalFee      int64  `json:"totalfee"`
-	Txs           int64  `json:"txs"`
+	AvgFee             int64   `json:"avgfee"`
+	AvgFeeRate         int64   `json:"avgfeerate"`
+	AvgTxSize          int64   `json:"avgtxsize"`
+	BlockHash          string  `json:"blockhash"`
+	FeeRatePercentiles []int64 `json:"feerate_percentiles,omitempty"`
+	Height             int64   `json:"height"`
+	Ins                int64   `json:"ins"`
+	MaxFee             int64   `json:"maxfee"`
+	MaxFeeRate         int64   `json:"maxfeerate"`
+	MaxTxSize          int64   `json:"maxtxsize"`
+	MedianFee          int64   `json:"medianfee"`
+	MedianFeeRate      int64   `json:"medianfeerate,omitempty"`
+	MedianTime         int64   `json:"mediantime"`
+	MedianTxSize       int64   `json:"mediantxsize"`
+	MinFee             int64   `json:"minfee"`
+	MinFeeRate         int64   `json:"minfeerate"`
+	MinTxSize          int64   `json:"mintxsize"`
+	Outs               int64   `json:"outs"`
+	Subsidy            int64   `json:"subsidy"`
+	Time               int64   `json:"time"`
+	TotalOut           int64   `json:"total_out"`
+	TotalSize          int64   `json:"total_size"`
+	TotalFee           int64   `json:"totalfee"`
+	Txs                int64   `json:"txs"`
+	UTXOIncrease       int64   `json:"utxo_increase"`
+	UTXOSizeIncrease   int64   `json:"utxo_size_inc"`
 }
 
 // CreateMultiSigResult models the data returned from the createmultisig
@@ -116,7 +167,8 @@ type SoftForkDescription struct {
 }
 
 // Bip9SoftForkDescription describes the current state of a defined BIP0009
-// version bits soft-fork.
+// version bits soft-fork.  Since is the height of the first block the status
+// applies to.
 type Bip9SoftForkDescription struct {
 	Status    string `json:"status"`
 	Bit       uint8  `json:"bit"`
@@ -127,6 +179,9 @@ type Bip9SoftForkDescription struct {
 
 // GetBlockChainInfoResult models the data returned from the getblockchaininfo
 // command.
+//
+// Bip9SoftForks is keyed by the deployment name, for example dip0003 or
+// dip0008, the latter of which activates ChainLocks.
 type GetBlockChainInfoResult struct {
 	Chain                string                              `json:"chain"`
 	Blocks               int32                               `json:"blocks"`
@@ -135,6 +190,7 @@ type GetBlockChainInfoResult struct {
 	Difficulty           float64                             `json:"difficulty"`
 	MedianTime           int64                               `json:"mediantime"`
 	VerificationProgress float64                             `json:"verificationprogress,omitempty"`
+	SizeOnDisk           int64                               `json:"size_on_disk,omitempty"`
 	Pruned               bool                                `json:"pruned"`
 	PruneHeight          int32                               `json:"pruneheight,omitempty"`
 	ChainWork            string                              `json:"chainwork,omitempty"`
@@ -142,6 +198,71 @@ type GetBlockChainInfoResult struct {
 	Bip9SoftForks        map[string]*Bip9SoftForkDescription `json:"bip9_softforks"`
 }
 
+// UnmarshalJSON provides a custom Unmarshal method for GetBlockChainInfoResult.
+// This is necessary because dashd 0.17 and later reply with a softforks object
+// keyed by deployment name in place of the softforks array and the
+// bip9_softforks object.  The BIP0009 deployments of such replies are stored
+// in Bip9SoftForks, and deployments which have been buried at a fixed height
+// are stored there as active since that height.
+func (r *GetBlockChainInfoResult) UnmarshalJSON(data []byte) error {
+	type getBlockChainInfoResult GetBlockChainInfoResult
+	aux := struct {
+		*getBlockChainInfoResult
+		SoftForks json.RawMessage `json:"softforks"`
+	}{getBlockChainInfoResult: (*getBlockChainInfoResult)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.SoftForks = nil
+	if len(aux.SoftForks) == 0 || string(aux.SoftForks) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(aux.SoftForks, &r.SoftForks); err == nil {
+		return nil
+	}
+
+	var softForks map[string]struct {
+		Type   string `json:"type"`
+		Active bool   `json:"active"`
+		Height int32  `json:"height"`
+		Bip9   *struct {
+			Status    string `json:"status"`
+			Bit       uint8  `json:"bit"`
+			StartTime int64  `json:"start_time"`
+			Timeout   int64  `json:"timeout"`
+			Since     int32  `json:"since"`
+		} `json:"bip9"`
+	}
+	if err := json.Unmarshal(aux.SoftForks, &softForks); err != nil {
+		return err
+	}
+	if r.Bip9SoftForks == nil && len(softForks) > 0 {
+		r.Bip9SoftForks = make(map[string]*Bip9SoftForkDescription,
+			len(softForks))
+	}
+	for name, fork := range softForks {
+		if fork.Bip9 == nil {
+			if !fork.Active {
+				continue
+			}
+			r.Bip9SoftForks[name] = &Bip9SoftForkDescription{
+				Status: "active",
+				Since:  fork.Height,
+			}
+			continue
+		}
+		r.Bip9SoftForks[name] = &Bip9SoftForkDescription{
+			Status:    fork.Bip9.Status,
+			Bit:       fork.Bip9.Bit,
+			StartTime: fork.Bip9.StartTime,
+			Timeout:   fork.Bip9.Timeout,
+			Since:     fork.Bip9.Since,
+		}
+	}
+	return nil
+}
+
 // GetBlockTemplateResultTx models the transactions field of the
 // getblocktemplate command.
 type GetBlockTemplateResultTx struct {
@@ -159,6 +280,14 @@ type GetBlockTemplateResultAux struct {
 	Flags string `json:"flags"`
 }
 
+// GetBlockTemplateResultPayee models a single masternode or superblock payment
+// of the getblocktemplate command.  Amount is in duffs.
+type GetBlockTemplateResultPayee struct {
+	Payee  string `json:"payee"`
+	Script string `json:"script"`
+	Amount int64  `json:"amount"`
+}
+
 // GetBlockTemplateResult models the data returned from the getblocktemplate
 // command.
 type GetBlockTemplateResult struct {
@@ -199,6 +328,17 @@ type GetBlockTemplateResult struct {
 	// Block proposal from BIP 0023.
 	Capabilities  []string `json:"capabilities,omitempty"`
 	RejectReasion string   `json:"reject-reason,omitempty"`
+
+	// Dash masternode and superblock payments which the coinbase
+	// transaction must include, and the payload of the coinbase special
+	// transaction (DIP0004).
+	Masternode                 []GetBlockTemplateResultPayee `json:"masternode,omitempty"`
+	MasternodePaymentsStarted  bool                          `json:"masternode_payments_started"`
+	MasternodePaymentsEnforced bool                          `json:"masternode_payments_enforced"`
+	Superblock                 []GetBlockTemplateResultPayee `json:"superblock,omitempty"`
+	SuperblocksStarted         bool                          `json:"superblocks_started"`
+	SuperblocksEnabled         bool                          `json:"superblocks_enabled"`
+	CoinbasePayload            string                        `json:"coinbase_payload,omitempty"`
 }
 
 // GetMempoolEntryResult models the data returned from the getmempoolentry
@@ -220,11 +360,29 @@ type GetMempoolEntryResult struct {
 	Depends          []string `json:"depends"`
 }
 
+// EstimateSmartFeeResult models the data returned from the estimatesmartfee
+// command.  FeeRate is in DASH/kB and is nil when the server does not have
+// enough data for an estimate, in which case Errors describes why.  Blocks is
+// the confirmation target the estimate is valid for.
+type EstimateSmartFeeResult struct {
+	FeeRate *float64 `json:"feerate,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+	Blocks  int64    `json:"blocks"`
+}
+
 // GetMempoolInfoResult models the data returned from the getmempoolinfo
 // command.
+//
+// The fee fields are in DASH/kB.  InstantSendLocks is reported by dashd 0.14
+// and later and is left zero when talking to an older node.
 type GetMempoolInfoResult struct {
-	Size  int64 `json:"size"`
-	Bytes int64 `json:"bytes"`
+	Size             int64   `json:"size"`
+	Bytes            int64   `json:"bytes"`
+	Usage            int64   `json:"usage"`
+	MaxMempool       int64   `json:"maxmempool"`
+	MempoolMinFee    float64 `json:"mempoolminfee"`
+	MinRelayTxFee    float64 `json:"minrelaytxfee"`
+	InstantSendLocks int64   `json:"instantsendlocks"`
 }
 
 // NetworksResult models the networks data from the getnetworkinfo command.
@@ -285,11 +443,23 @@ type GetPeerInfoResult struct {
 	BanScore       int32   `json:"banscore"`
 	FeeFilter      int64   `json:"feefilter"`
 	SyncNode       bool    `json:"syncnode"`
+
+	// The following fields are only reported by dashd.  Masternode is set
+	// for connections made by a masternode to another one, which are
+	// authenticated with the ProRegTx hash and the hash of the operator
+	// public key reported in the verified fields.
+	Masternode           bool   `json:"masternode,omitempty"`
+	VerifiedProRegTxHash string `json:"verified_proregtx_hash,omitempty"`
+	VerifiedPubKeyHash   string `json:"verified_pubkey_hash,omitempty"`
 }
 
 // GetRawMempoolVerboseResult models the data returned from the getrawmempool
 // command when the verbose flag is set.  When the verbose flag is not set,
 // getrawmempool returns an array of transaction hashes.
+//
+// The descendant and ancestor statistics are only reported by dashd and count
+// the transaction itself.  InstantLock is set by dashd when the transaction is
+// locked by InstantSend.
 type GetRawMempoolVerboseResult struct {
 	Size             int32    `json:"size"`
 	Vsize            int32    `json:"vsize"`
@@ -298,7 +468,12 @@ type GetRawMempoolVerboseResult struct {
 	Height           int64    `json:"height"`
 	StartingPriority float64  `json:"startingpriority"`
 	CurrentPriority  float64  `json:"currentpriority"`
+	DescendantCount  int64    `json:"descendantcount,omitempty"`
+	DescendantSize   int64    `json:"descendantsize,omitempty"`
+	AncestorCount    int64    `json:"ancestorcount,omitempty"`
+	AncestorSize     int64    `json:"ancestorsize,omitempty"`
 	Depends          []string `json:"depends"`
+	InstantLock      bool     `json:"instantlock"`
 }
 
 // ScriptPubKeyResult models the scriptPubKey data of a tx script.  It is
@@ -321,6 +496,19 @@ type GetTxOutResult struct {
 	Coinbase      bool               `json:"coinbase"`
 }
 
+// GetTxOutSetInfoResult models the data from the gettxoutsetinfo command.
+// TotalAmount is the sum of all unspent outputs in DASH.
+type GetTxOutSetInfoResult struct {
+	Height         int64   `json:"height"`
+	BestBlock      string  `json:"bestblock"`
+	Transactions   int64   `json:"transactions"`
+	TxOuts         int64   `json:"txouts"`
+	BogoSize       int64   `json:"bogosize"`
+	HashSerialized string  `json:"hash_serialized_2"`
+	DiskSize       int64   `json:"disk_size"`
+	TotalAmount    float64 `json:"total_amount"`
+}
+
 // GetNetTotalsResult models the data returned from the getnettotals command.
 type GetNetTotalsResult struct {
 	TotalBytesRecv uint64 `json:"totalbytesrecv"`
@@ -542,6 +730,25 @@ type TxRawResult struct {
 	Confirmations uint64 `json:"confirmations,omitempty"`
 	Time          int64  `json:"time,omitempty"`
 	Blocktime     int64  `json:"blocktime,omitempty"`
+
+	// Dash special transaction fields.  Type is zero for classical
+	// transactions, in which case the remaining fields are omitted.  Only
+	// the decoded payload matching Type is set.
+	Type             int32               `json:"type,omitempty"`
+	ExtraPayloadSize int32               `json:"extraPayloadSize,omitempty"`
+	ExtraPayload     string              `json:"extraPayload,omitempty"`
+	ProRegTx         *ProRegTxPayload    `json:"proRegTx,omitempty"`
+	ProUpServTx      *ProUpServTxPayload `json:"proUpServTx,omitempty"`
+	ProUpRegTx       *ProUpRegTxPayload  `json:"proUpRegTx,omitempty"`
+	ProUpRevTx       *ProUpRevTxPayload  `json:"proUpRevTx,omitempty"`
+	CbTx             *CbTxPayload        `json:"cbTx,omitempty"`
+	QcTx             *QcTxPayload        `json:"qcTx,omitempty"`
+
+	// InstantLock reports whether the transaction is locked by an
+	// InstantSend lock or its block by a ChainLock.  InstantLockInternal
+	// only reports whether the server holds an InstantSend lock for it.
+	InstantLock         bool `json:"instantlock,omitempty"`
+	InstantLockInternal bool `json:"instantlock_internal,omitempty"`
 }
 
 // SearchRawTransactionsResult models the data from the searchrawtransaction
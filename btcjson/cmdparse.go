@@ -59,6 +59,13 @@ func MarshalCmd(id interface{}, cmd interface{}) ([]byte, error) {
 	// them if they are non-nil.
 	params := makeParams(rt.Elem(), rv.Elem())
 
+	// Commands registered with a sub-command are sent using the base method
+	// with the sub-command as the first positional parameter.
+	if i := strings.IndexByte(method, ' '); i != -1 {
+		params = append([]interface{}{method[i+1:]}, params...)
+		method = method[:i]
+	}
+
 	// Generate and marshal the final JSON-RPC request.
 	rawCmd, err := NewRequest(id, method, params)
 	if err != nil {
@@ -105,16 +112,39 @@ func populateDefaults(numParams int, info *methodInfo, rv reflect.Value) {
 	}
 }
 
+// subCmdMethod returns the registered method name for the provided base method
+// and sub-command, such as "masternode count", along with whether or not a
+// command is registered under that name.
+func subCmdMethod(method, subCmd string) (string, bool) {
+	subMethod := method + " " + subCmd
+	registerLock.RLock()
+	_, ok := methodToConcreteType[subMethod]
+	registerLock.RUnlock()
+	return subMethod, ok
+}
+
 // UnmarshalCmd unmarshals a JSON-RPC request into a suitable concrete command
 // so long as the method type contained within the marshalled request is
 // registered.
 func UnmarshalCmd(r *Request) (interface{}, error) {
+	// Resolve commands registered with a sub-command by the first
+	// positional parameter.
+	method, params := r.Method, r.Params
+	if len(params) > 0 {
+		var subCmd string
+		if err := json.Unmarshal(params[0], &subCmd); err == nil {
+			if subMethod, ok := subCmdMethod(method, subCmd); ok {
+				method, params = subMethod, params[1:]
+			}
+		}
+	}
+
 	registerLock.RLock()
-	rtp, ok := methodToConcreteType[r.Method]
-	info := methodToInfo[r.Method]
+	rtp, ok := methodToConcreteType[method]
+	info := methodToInfo[method]
 	registerLock.RUnlock()
 	if !ok {
-		str := fmt.Sprintf("%q is not registered", r.Method)
+		str := fmt.Sprintf("%q is not registered", method)
 		return nil, makeError(ErrUnregisteredMethod, str)
 	}
 	rt := rtp.Elem()
@@ -122,7 +152,7 @@ func UnmarshalCmd(r *Request) (interface{}, error) {
 	rv := rvp.Elem()
 
 	// Ensure the number of parameters are correct.
-	numParams := len(r.Params)
+	numParams := len(params)
 	if err := checkNumParams(numParams, &info); err != nil {
 		return nil, err
 	}
@@ -133,7 +163,7 @@ func UnmarshalCmd(r *Request) (interface{}, error) {
 		rvf := rv.Field(i)
 		// Unmarshal the parameter into the struct field.
 		concreteVal := rvf.Addr().Interface()
-		if err := json.Unmarshal(r.Params[i], &concreteVal); err != nil {
+		if err := json.Unmarshal(params[i], &concreteVal); err != nil {
 			// The most common error is the wrong type, so
 			// explicitly detect that error and make it nicer.
 			fieldName := strings.ToLower(rt.Field(i).Name)
@@ -510,6 +540,15 @@ func assignField(paramNum int, fieldName string, dest reflect.Value, src reflect
 //     the string as marshalled JSON and calling json.Unmarshal into the
 //     destination field
 func NewCmd(method string, args ...interface{}) (interface{}, error) {
+	// Resolve commands registered with a sub-command by the first argument.
+	if len(args) > 0 {
+		if subCmd, ok := args[0].(string); ok {
+			if subMethod, ok := subCmdMethod(method, subCmd); ok {
+				method, args = subMethod, args[1:]
+			}
+		}
+	}
+
 	// Look up details about the provided method.  Any methods that aren't
 	// registered are an error.
 	registerLock.RLock()
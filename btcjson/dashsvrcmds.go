@@ -0,0 +1,682 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// NOTE: This file is intended to house the RPC commands that are supported by
+// a Dash Core chain server.  Many of the Dash RPCs dispatch on a leading sub
+// command and are therefore registered as "<method> <subcommand>".
+
+package btcjson
+
+// MasternodeCountCmd defines the masternode count JSON-RPC command.
+type MasternodeCountCmd struct{}
+
+// NewMasternodeCountCmd returns a new instance which can be used to issue a
+// masternode count JSON-RPC command.
+func NewMasternodeCountCmd() *MasternodeCountCmd {
+	return &MasternodeCountCmd{}
+}
+
+// MasternodeListCmd defines the masternodelist JSON-RPC command.
+type MasternodeListCmd struct {
+	Mode   *string `jsonrpcdefault:"\"json\""`
+	Filter *string
+}
+
+// NewMasternodeListCmd returns a new instance which can be used to issue a
+// masternodelist JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewMasternodeListCmd(mode, filter *string) *MasternodeListCmd {
+	return &MasternodeListCmd{
+		Mode:   mode,
+		Filter: filter,
+	}
+}
+
+// MasternodePaymentsCmd defines the masternode payments JSON-RPC command.
+type MasternodePaymentsCmd struct {
+	BlockHash *string
+	Count     *int `jsonrpcdefault:"1"`
+}
+
+// NewMasternodePaymentsCmd returns a new instance which can be used to issue a
+// masternode payments JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewMasternodePaymentsCmd(blockHash *string, count *int) *MasternodePaymentsCmd {
+	return &MasternodePaymentsCmd{
+		BlockHash: blockHash,
+		Count:     count,
+	}
+}
+
+// MasternodeWinnersCmd defines the masternode winners JSON-RPC command.
+type MasternodeWinnersCmd struct {
+	Count  *int `jsonrpcdefault:"10"`
+	Filter *string
+}
+
+// NewMasternodeWinnersCmd returns a new instance which can be used to issue a
+// masternode winners JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewMasternodeWinnersCmd(count *int, filter *string) *MasternodeWinnersCmd {
+	return &MasternodeWinnersCmd{
+		Count:  count,
+		Filter: filter,
+	}
+}
+
+// MnSyncStatusCmd defines the mnsync status JSON-RPC command.
+type MnSyncStatusCmd struct{}
+
+// NewMnSyncStatusCmd returns a new instance which can be used to issue a mnsync
+// status JSON-RPC command.
+func NewMnSyncStatusCmd() *MnSyncStatusCmd {
+	return &MnSyncStatusCmd{}
+}
+
+// ProTxListCmd defines the protx list JSON-RPC command.
+type ProTxListCmd struct {
+	Type     *string `jsonrpcdefault:"\"registered\"" jsonrpcusage:"\"registered|valid|wallet\""`
+	Detailed *bool   `jsonrpcdefault:"false"`
+	Height   *int32
+}
+
+// NewProTxListCmd returns a new instance which can be used to issue a protx
+// list JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewProTxListCmd(listType *string, detailed *bool, height *int32) *ProTxListCmd {
+	return &ProTxListCmd{
+		Type:     listType,
+		Detailed: detailed,
+		Height:   height,
+	}
+}
+
+// ProTxInfoCmd defines the protx info JSON-RPC command.
+type ProTxInfoCmd struct {
+	ProTxHash string
+}
+
+// NewProTxInfoCmd returns a new instance which can be used to issue a protx
+// info JSON-RPC command.
+func NewProTxInfoCmd(proTxHash string) *ProTxInfoCmd {
+	return &ProTxInfoCmd{
+		ProTxHash: proTxHash,
+	}
+}
+
+// ProTxDiffCmd defines the protx diff JSON-RPC command.  The blocks are
+// identified by either their hash or their height.
+type ProTxDiffCmd struct {
+	BaseBlock HashOrHeight
+	Block     HashOrHeight
+}
+
+// NewProTxDiffCmd returns a new instance which can be used to issue a protx
+// diff JSON-RPC command.
+func NewProTxDiffCmd(baseBlock, block HashOrHeight) *ProTxDiffCmd {
+	return &ProTxDiffCmd{
+		BaseBlock: baseBlock,
+		Block:     block,
+	}
+}
+
+// QuorumListCmd defines the quorum list JSON-RPC command.
+type QuorumListCmd struct {
+	Count *int
+}
+
+// NewQuorumListCmd returns a new instance which can be used to issue a quorum
+// list JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewQuorumListCmd(count *int) *QuorumListCmd {
+	return &QuorumListCmd{
+		Count: count,
+	}
+}
+
+// QuorumInfoCmd defines the quorum info JSON-RPC command.
+type QuorumInfoCmd struct {
+	LLMQType       int
+	QuorumHash     string
+	IncludeSkShare *bool `jsonrpcdefault:"false"`
+}
+
+// NewQuorumInfoCmd returns a new instance which can be used to issue a quorum
+// info JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewQuorumInfoCmd(llmqType int, quorumHash string, includeSkShare *bool) *QuorumInfoCmd {
+	return &QuorumInfoCmd{
+		LLMQType:       llmqType,
+		QuorumHash:     quorumHash,
+		IncludeSkShare: includeSkShare,
+	}
+}
+
+// QuorumMemberOfCmd defines the quorum memberof JSON-RPC command.
+type QuorumMemberOfCmd struct {
+	ProTxHash        string
+	ScanQuorumsCount *int
+}
+
+// NewQuorumMemberOfCmd returns a new instance which can be used to issue a
+// quorum memberof JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewQuorumMemberOfCmd(proTxHash string, scanQuorumsCount *int) *QuorumMemberOfCmd {
+	return &QuorumMemberOfCmd{
+		ProTxHash:        proTxHash,
+		ScanQuorumsCount: scanQuorumsCount,
+	}
+}
+
+// QuorumSignCmd defines the quorum sign JSON-RPC command.
+type QuorumSignCmd struct {
+	LLMQType   int
+	ID         string
+	MsgHash    string
+	QuorumHash *string
+	Submit     *bool `jsonrpcdefault:"true"`
+}
+
+// NewQuorumSignCmd returns a new instance which can be used to issue a quorum
+// sign JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewQuorumSignCmd(llmqType int, id, msgHash string, quorumHash *string, submit *bool) *QuorumSignCmd {
+	return &QuorumSignCmd{
+		LLMQType:   llmqType,
+		ID:         id,
+		MsgHash:    msgHash,
+		QuorumHash: quorumHash,
+		Submit:     submit,
+	}
+}
+
+// QuorumVerifyCmd defines the quorum verify JSON-RPC command.
+type QuorumVerifyCmd struct {
+	LLMQType   int
+	ID         string
+	MsgHash    string
+	Signature  string
+	QuorumHash *string
+	SignHeight *int32
+}
+
+// NewQuorumVerifyCmd returns a new instance which can be used to issue a
+// quorum verify JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewQuorumVerifyCmd(llmqType int, id, msgHash, signature string,
+	quorumHash *string, signHeight *int32) *QuorumVerifyCmd {
+
+	return &QuorumVerifyCmd{
+		LLMQType:   llmqType,
+		ID:         id,
+		MsgHash:    msgHash,
+		Signature:  signature,
+		QuorumHash: quorumHash,
+		SignHeight: signHeight,
+	}
+}
+
+// SporkCmd defines the spork JSON-RPC command.  The command is used to
+// update the value of the named spork when the server is configured with the
+// spork private key.
+type SporkCmd struct {
+	Name  string
+	Value int64
+}
+
+// NewSporkCmd returns a new instance which can be used to issue a spork
+// JSON-RPC command.
+func NewSporkCmd(name string, value int64) *SporkCmd {
+	return &SporkCmd{
+		Name:  name,
+		Value: value,
+	}
+}
+
+// SporkShowCmd defines the spork show JSON-RPC command.
+type SporkShowCmd struct{}
+
+// NewSporkShowCmd returns a new instance which can be used to issue a spork
+// show JSON-RPC command.
+func NewSporkShowCmd() *SporkShowCmd {
+	return &SporkShowCmd{}
+}
+
+// SporkActiveCmd defines the spork active JSON-RPC command.
+type SporkActiveCmd struct{}
+
+// NewSporkActiveCmd returns a new instance which can be used to issue a spork
+// active JSON-RPC command.
+func NewSporkActiveCmd() *SporkActiveCmd {
+	return &SporkActiveCmd{}
+}
+
+// AddressIndexRequest is the request object of the address index commands
+// served by dashd when started with -addressindex.  The block height range is
+// only used by the getaddressdeltas and getaddresstxids commands and is
+// ignored unless both Start and End are set.
+type AddressIndexRequest struct {
+	Addresses []string `json:"addresses"`
+	Start     int32    `json:"start,omitempty"`
+	End       int32    `json:"end,omitempty"`
+}
+
+// GetAddressBalanceCmd defines the getaddressbalance JSON-RPC command.
+type GetAddressBalanceCmd struct {
+	Request AddressIndexRequest
+}
+
+// NewGetAddressBalanceCmd returns a new instance which can be used to issue a
+// getaddressbalance JSON-RPC command.
+func NewGetAddressBalanceCmd(addresses []string) *GetAddressBalanceCmd {
+	return &GetAddressBalanceCmd{
+		Request: AddressIndexRequest{Addresses: addresses},
+	}
+}
+
+// GetAddressUTXOsCmd defines the getaddressutxos JSON-RPC command.
+type GetAddressUTXOsCmd struct {
+	Request AddressIndexRequest
+}
+
+// NewGetAddressUTXOsCmd returns a new instance which can be used to issue a
+// getaddressutxos JSON-RPC command.
+func NewGetAddressUTXOsCmd(addresses []string) *GetAddressUTXOsCmd {
+	return &GetAddressUTXOsCmd{
+		Request: AddressIndexRequest{Addresses: addresses},
+	}
+}
+
+// GetAddressDeltasCmd defines the getaddressdeltas JSON-RPC command.
+type GetAddressDeltasCmd struct {
+	Request AddressIndexRequest
+}
+
+// NewGetAddressDeltasCmd returns a new instance which can be used to issue a
+// getaddressdeltas JSON-RPC command.
+func NewGetAddressDeltasCmd(addresses []string, start, end int32) *GetAddressDeltasCmd {
+	return &GetAddressDeltasCmd{
+		Request: AddressIndexRequest{
+			Addresses: addresses,
+			Start:     start,
+			End:       end,
+		},
+	}
+}
+
+// GetAddressTxIDsCmd defines the getaddresstxids JSON-RPC command.
+type GetAddressTxIDsCmd struct {
+	Request AddressIndexRequest
+}
+
+// NewGetAddressTxIDsCmd returns a new instance which can be used to issue a
+// getaddresstxids JSON-RPC command.
+func NewGetAddressTxIDsCmd(addresses []string, start, end int32) *GetAddressTxIDsCmd {
+	return &GetAddressTxIDsCmd{
+		Request: AddressIndexRequest{
+			Addresses: addresses,
+			Start:     start,
+			End:       end,
+		},
+	}
+}
+
+// GetBlockHashesOptions houses the optional settings of the getblockhashes
+// command served by dashd when started with -timestampindex.  NoOrphans limits
+// the hashes to blocks of the main chain and LogicalTimes requests the logical
+// timestamp of each block along with its hash.
+type GetBlockHashesOptions struct {
+	NoOrphans    bool `json:"noOrphans,omitempty"`
+	LogicalTimes bool `json:"logicalTimes,omitempty"`
+}
+
+// GetBlockHashesCmd defines the getblockhashes JSON-RPC command.
+type GetBlockHashesCmd struct {
+	High    int64
+	Low     int64
+	Options *GetBlockHashesOptions
+}
+
+// NewGetBlockHashesCmd returns a new instance which can be used to issue a
+// getblockhashes JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetBlockHashesCmd(high, low int64, options *GetBlockHashesOptions) *GetBlockHashesCmd {
+	return &GetBlockHashesCmd{
+		High:    high,
+		Low:     low,
+		Options: options,
+	}
+}
+
+// SpentInfoRequest is the request object of the getspentinfo command served by
+// dashd when started with -spentindex.
+type SpentInfoRequest struct {
+	TxID  string `json:"txid"`
+	Index uint32 `json:"index"`
+}
+
+// GetSpentInfoCmd defines the getspentinfo JSON-RPC command.
+type GetSpentInfoCmd struct {
+	Request SpentInfoRequest
+}
+
+// NewGetSpentInfoCmd returns a new instance which can be used to issue a
+// getspentinfo JSON-RPC command.
+func NewGetSpentInfoCmd(txID string, index uint32) *GetSpentInfoCmd {
+	return &GetSpentInfoCmd{
+		Request: SpentInfoRequest{
+			TxID:  txID,
+			Index: index,
+		},
+	}
+}
+
+// BLSGenerateCmd defines the bls generate JSON-RPC command.
+type BLSGenerateCmd struct{}
+
+// NewBLSGenerateCmd returns a new instance which can be used to issue a bls
+// generate JSON-RPC command.
+func NewBLSGenerateCmd() *BLSGenerateCmd {
+	return &BLSGenerateCmd{}
+}
+
+// BLSFromSecretCmd defines the bls fromsecret JSON-RPC command.
+type BLSFromSecretCmd struct {
+	Secret string
+}
+
+// NewBLSFromSecretCmd returns a new instance which can be used to issue a bls
+// fromsecret JSON-RPC command.
+func NewBLSFromSecretCmd(secret string) *BLSFromSecretCmd {
+	return &BLSFromSecretCmd{
+		Secret: secret,
+	}
+}
+
+// GetBestChainLockCmd defines the getbestchainlock JSON-RPC command.
+type GetBestChainLockCmd struct{}
+
+// NewGetBestChainLockCmd returns a new instance which can be used to issue a
+// getbestchainlock JSON-RPC command.
+func NewGetBestChainLockCmd() *GetBestChainLockCmd {
+	return &GetBestChainLockCmd{}
+}
+
+// GetGovernanceInfoCmd defines the getgovernanceinfo JSON-RPC command.
+type GetGovernanceInfoCmd struct{}
+
+// NewGetGovernanceInfoCmd returns a new instance which can be used to issue a
+// getgovernanceinfo JSON-RPC command.
+func NewGetGovernanceInfoCmd() *GetGovernanceInfoCmd {
+	return &GetGovernanceInfoCmd{}
+}
+
+// GetISLocksCmd defines the getislocks JSON-RPC command.
+type GetISLocksCmd struct {
+	TxIDs []string
+}
+
+// NewGetISLocksCmd returns a new instance which can be used to issue a
+// getislocks JSON-RPC command.
+func NewGetISLocksCmd(txIDs []string) *GetISLocksCmd {
+	return &GetISLocksCmd{
+		TxIDs: txIDs,
+	}
+}
+
+// GetSpecialTxesCmd defines the getspecialtxes JSON-RPC command.
+type GetSpecialTxesCmd struct {
+	BlockHash string
+	Type      *int `jsonrpcdefault:"-1"`
+	Count     *int `jsonrpcdefault:"10"`
+	Skip      *int `jsonrpcdefault:"0"`
+	Verbosity *int `jsonrpcdefault:"0"`
+}
+
+// NewGetSpecialTxesCmd returns a new instance which can be used to issue a
+// getspecialtxes JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetSpecialTxesCmd(blockHash string, txType, count, skip, verbosity *int) *GetSpecialTxesCmd {
+	return &GetSpecialTxesCmd{
+		BlockHash: blockHash,
+		Type:      txType,
+		Count:     count,
+		Skip:      skip,
+		Verbosity: verbosity,
+	}
+}
+
+// GetSuperblockBudgetCmd defines the getsuperblockbudget JSON-RPC command.
+type GetSuperblockBudgetCmd struct {
+	Index int32
+}
+
+// NewGetSuperblockBudgetCmd returns a new instance which can be used to issue a
+// getsuperblockbudget JSON-RPC command.
+func NewGetSuperblockBudgetCmd(index int32) *GetSuperblockBudgetCmd {
+	return &GetSuperblockBudgetCmd{
+		Index: index,
+	}
+}
+
+// GetZMQNotificationsCmd defines the getzmqnotifications JSON-RPC command.
+type GetZMQNotificationsCmd struct{}
+
+// NewGetZMQNotificationsCmd returns a new instance which can be used to issue a
+// getzmqnotifications JSON-RPC command.
+func NewGetZMQNotificationsCmd() *GetZMQNotificationsCmd {
+	return &GetZMQNotificationsCmd{}
+}
+
+// GObjectCheckCmd defines the gobject check JSON-RPC command.
+type GObjectCheckCmd struct {
+	DataHex string
+}
+
+// NewGObjectCheckCmd returns a new instance which can be used to issue a
+// gobject check JSON-RPC command.
+func NewGObjectCheckCmd(dataHex string) *GObjectCheckCmd {
+	return &GObjectCheckCmd{
+		DataHex: dataHex,
+	}
+}
+
+// GObjectGetCmd defines the gobject get JSON-RPC command.
+type GObjectGetCmd struct {
+	Hash string
+}
+
+// NewGObjectGetCmd returns a new instance which can be used to issue a gobject
+// get JSON-RPC command.
+func NewGObjectGetCmd(hash string) *GObjectGetCmd {
+	return &GObjectGetCmd{
+		Hash: hash,
+	}
+}
+
+// GObjectGetCurrentVotesCmd defines the gobject getcurrentvotes JSON-RPC
+// command.
+type GObjectGetCurrentVotesCmd struct {
+	Hash string
+	TxID *string
+	Vout *uint32
+}
+
+// NewGObjectGetCurrentVotesCmd returns a new instance which can be used to
+// issue a gobject getcurrentvotes JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGObjectGetCurrentVotesCmd(hash string, txID *string, vout *uint32) *GObjectGetCurrentVotesCmd {
+	return &GObjectGetCurrentVotesCmd{
+		Hash: hash,
+		TxID: txID,
+		Vout: vout,
+	}
+}
+
+// GObjectGetVotesCmd defines the gobject getvotes JSON-RPC command.
+type GObjectGetVotesCmd struct {
+	Hash string
+}
+
+// NewGObjectGetVotesCmd returns a new instance which can be used to issue a
+// gobject getvotes JSON-RPC command.
+func NewGObjectGetVotesCmd(hash string) *GObjectGetVotesCmd {
+	return &GObjectGetVotesCmd{
+		Hash: hash,
+	}
+}
+
+// GObjectListCmd defines the gobject list JSON-RPC command.
+type GObjectListCmd struct {
+	Signal *string `jsonrpcdefault:"\"valid\"" jsonrpcusage:"\"valid|funding|delete|endorsed|all\""`
+	Type   *string `jsonrpcdefault:"\"all\"" jsonrpcusage:"\"proposals|triggers|all\""`
+}
+
+// NewGObjectListCmd returns a new instance which can be used to issue a gobject
+// list JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGObjectListCmd(signal, objectType *string) *GObjectListCmd {
+	return &GObjectListCmd{
+		Signal: signal,
+		Type:   objectType,
+	}
+}
+
+// GObjectSubmitCmd defines the gobject submit JSON-RPC command.
+type GObjectSubmitCmd struct {
+	ParentHash string
+	Revision   int
+	Time       int64
+	DataHex    string
+	FeeTxID    *string
+}
+
+// NewGObjectSubmitCmd returns a new instance which can be used to issue a
+// gobject submit JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGObjectSubmitCmd(parentHash string, revision int, time int64,
+	dataHex string, feeTxID *string) *GObjectSubmitCmd {
+
+	return &GObjectSubmitCmd{
+		ParentHash: parentHash,
+		Revision:   revision,
+		Time:       time,
+		DataHex:    dataHex,
+		FeeTxID:    feeTxID,
+	}
+}
+
+// VerifyChainLockCmd defines the verifychainlock JSON-RPC command.
+type VerifyChainLockCmd struct {
+	BlockHash   string
+	Signature   string
+	BlockHeight *int32
+}
+
+// NewVerifyChainLockCmd returns a new instance which can be used to issue a
+// verifychainlock JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewVerifyChainLockCmd(blockHash, signature string, blockHeight *int32) *VerifyChainLockCmd {
+	return &VerifyChainLockCmd{
+		BlockHash:   blockHash,
+		Signature:   signature,
+		BlockHeight: blockHeight,
+	}
+}
+
+// VerifyISLockCmd defines the verifyislock JSON-RPC command.
+type VerifyISLockCmd struct {
+	ID        string
+	TxID      string
+	Signature string
+	MaxHeight *int32
+}
+
+// NewVerifyISLockCmd returns a new instance which can be used to issue a
+// verifyislock JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewVerifyISLockCmd(id, txID, signature string, maxHeight *int32) *VerifyISLockCmd {
+	return &VerifyISLockCmd{
+		ID:        id,
+		TxID:      txID,
+		Signature: signature,
+		MaxHeight: maxHeight,
+	}
+}
+
+func init() {
+	// No special flags for commands in this file.
+	flags := UsageFlag(0)
+
+	MustRegisterCmd("bls fromsecret", (*BLSFromSecretCmd)(nil), flags)
+	MustRegisterCmd("bls generate", (*BLSGenerateCmd)(nil), flags)
+	MustRegisterCmd("getaddressbalance", (*GetAddressBalanceCmd)(nil), flags)
+	MustRegisterCmd("getaddressdeltas", (*GetAddressDeltasCmd)(nil), flags)
+	MustRegisterCmd("getaddresstxids", (*GetAddressTxIDsCmd)(nil), flags)
+	MustRegisterCmd("getaddressutxos", (*GetAddressUTXOsCmd)(nil), flags)
+	MustRegisterCmd("getbestchainlock", (*GetBestChainLockCmd)(nil), flags)
+	MustRegisterCmd("getblockhashes", (*GetBlockHashesCmd)(nil), flags)
+	MustRegisterCmd("getgovernanceinfo", (*GetGovernanceInfoCmd)(nil), flags)
+	MustRegisterCmd("getislocks", (*GetISLocksCmd)(nil), flags)
+	MustRegisterCmd("getspecialtxes", (*GetSpecialTxesCmd)(nil), flags)
+	MustRegisterCmd("getspentinfo", (*GetSpentInfoCmd)(nil), flags)
+	MustRegisterCmd("getsuperblockbudget", (*GetSuperblockBudgetCmd)(nil), flags)
+	MustRegisterCmd("getzmqnotifications", (*GetZMQNotificationsCmd)(nil), flags)
+	MustRegisterCmd("gobject check", (*GObjectCheckCmd)(nil), flags)
+	MustRegisterCmd("gobject get", (*GObjectGetCmd)(nil), flags)
+	MustRegisterCmd("gobject getcurrentvotes", (*GObjectGetCurrentVotesCmd)(nil), flags)
+	MustRegisterCmd("gobject getvotes", (*GObjectGetVotesCmd)(nil), flags)
+	MustRegisterCmd("gobject list", (*GObjectListCmd)(nil), flags)
+	MustRegisterCmd("gobject submit", (*GObjectSubmitCmd)(nil), flags)
+	MustRegisterCmd("masternode count", (*MasternodeCountCmd)(nil), flags)
+	MustRegisterCmd("masternode payments", (*MasternodePaymentsCmd)(nil), flags)
+	MustRegisterCmd("masternode winners", (*MasternodeWinnersCmd)(nil), flags)
+	MustRegisterCmd("masternodelist", (*MasternodeListCmd)(nil), flags)
+	MustRegisterCmd("mnsync status", (*MnSyncStatusCmd)(nil), flags)
+	MustRegisterCmd("protx diff", (*ProTxDiffCmd)(nil), flags)
+	MustRegisterCmd("protx info", (*ProTxInfoCmd)(nil), flags)
+	MustRegisterCmd("protx list", (*ProTxListCmd)(nil), flags)
+	MustRegisterCmd("quorum info", (*QuorumInfoCmd)(nil), flags)
+	MustRegisterCmd("quorum list", (*QuorumListCmd)(nil), flags)
+	MustRegisterCmd("quorum memberof", (*QuorumMemberOfCmd)(nil), flags)
+	MustRegisterCmd("quorum sign", (*QuorumSignCmd)(nil), flags)
+	MustRegisterCmd("quorum verify", (*QuorumVerifyCmd)(nil), flags)
+	MustRegisterCmd("spork", (*SporkCmd)(nil), flags)
+	MustRegisterCmd("spork active", (*SporkActiveCmd)(nil), flags)
+	MustRegisterCmd("spork show", (*SporkShowCmd)(nil), flags)
+	MustRegisterCmd("verifychainlock", (*VerifyChainLockCmd)(nil), flags)
+	MustRegisterCmd("verifyislock", (*VerifyISLockCmd)(nil), flags)
+}
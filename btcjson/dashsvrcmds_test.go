@@ -0,0 +1,886 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package btcjson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+)
+
+// TestDashSvrCmds tests all of the Dash chain server commands marshal and
+// unmarshal into valid results include handling of optional fields being
+// omitted in the marshalled command, while optional fields with defaults have
+// the default assigned on unmarshalled commands.
+func TestDashSvrCmds(t *testing.T) {
+	t.Parallel()
+
+	testID := int(1)
+	tests := []struct {
+		name         string
+		newCmd       func() (interface{}, error)
+		staticCmd    func() interface{}
+		marshalled   string
+		unmarshalled interface{}
+	}{
+		{
+			name: "bls generate",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("bls", "generate")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBLSGenerateCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"bls","params":["generate"],"id":1}`,
+			unmarshalled: &btcjson.BLSGenerateCmd{},
+		},
+		{
+			name: "bls fromsecret",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("bls", "fromsecret", "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBLSFromSecretCmd("123")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"bls","params":["fromsecret","123"],"id":1}`,
+			unmarshalled: &btcjson.BLSFromSecretCmd{Secret: "123"},
+		},
+		{
+			name: "getaddressbalance",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddressbalance", btcjson.AddressIndexRequest{
+					Addresses: []string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"},
+				})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressBalanceCmd([]string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddressbalance","params":[{"addresses":["XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"]}],"id":1}`,
+			unmarshalled: &btcjson.GetAddressBalanceCmd{
+				Request: btcjson.AddressIndexRequest{
+					Addresses: []string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"},
+				},
+			},
+		},
+		{
+			name: "getaddressutxos",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddressutxos", btcjson.AddressIndexRequest{
+					Addresses: []string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc", "XgTS8WonvgEbnSzuzmZnsfcKUYy8oqro9s"},
+				})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressUTXOsCmd([]string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc", "XgTS8WonvgEbnSzuzmZnsfcKUYy8oqro9s"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddressutxos","params":[{"addresses":["XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc","XgTS8WonvgEbnSzuzmZnsfcKUYy8oqro9s"]}],"id":1}`,
+			unmarshalled: &btcjson.GetAddressUTXOsCmd{
+				Request: btcjson.AddressIndexRequest{
+					Addresses: []string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc", "XgTS8WonvgEbnSzuzmZnsfcKUYy8oqro9s"},
+				},
+			},
+		},
+		{
+			name: "getaddressdeltas",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddressdeltas", btcjson.AddressIndexRequest{
+					Addresses: []string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"},
+					Start:     1000,
+					End:       2000,
+				})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressDeltasCmd([]string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"}, 1000, 2000)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddressdeltas","params":[{"addresses":["XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"],"start":1000,"end":2000}],"id":1}`,
+			unmarshalled: &btcjson.GetAddressDeltasCmd{
+				Request: btcjson.AddressIndexRequest{
+					Addresses: []string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"},
+					Start:     1000,
+					End:       2000,
+				},
+			},
+		},
+		{
+			name: "getaddresstxids",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddresstxids", btcjson.AddressIndexRequest{
+					Addresses: []string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"},
+				})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressTxIDsCmd([]string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"}, 0, 0)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddresstxids","params":[{"addresses":["XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"]}],"id":1}`,
+			unmarshalled: &btcjson.GetAddressTxIDsCmd{
+				Request: btcjson.AddressIndexRequest{
+					Addresses: []string{"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"},
+				},
+			},
+		},
+		{
+			name: "getblockhashes",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockhashes", 1543622500, 1543622400)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockHashesCmd(1543622500, 1543622400, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockhashes","params":[1543622500,1543622400],"id":1}`,
+			unmarshalled: &btcjson.GetBlockHashesCmd{
+				High: 1543622500,
+				Low:  1543622400,
+			},
+		},
+		{
+			name: "getblockhashes options",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockhashes", 1543622500, 1543622400,
+					btcjson.GetBlockHashesOptions{NoOrphans: true, LogicalTimes: true})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockHashesCmd(1543622500, 1543622400,
+					&btcjson.GetBlockHashesOptions{NoOrphans: true, LogicalTimes: true})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockhashes","params":[1543622500,1543622400,` +
+				`{"noOrphans":true,"logicalTimes":true}],"id":1}`,
+			unmarshalled: &btcjson.GetBlockHashesCmd{
+				High:    1543622500,
+				Low:     1543622400,
+				Options: &btcjson.GetBlockHashesOptions{NoOrphans: true, LogicalTimes: true},
+			},
+		},
+		{
+			name: "getspentinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getspentinfo", btcjson.SpentInfoRequest{
+					TxID:  "0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9",
+					Index: 1,
+				})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetSpentInfoCmd("0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9", 1)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getspentinfo","params":[{"txid":"0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9","index":1}],"id":1}`,
+			unmarshalled: &btcjson.GetSpentInfoCmd{
+				Request: btcjson.SpentInfoRequest{
+					TxID:  "0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9",
+					Index: 1,
+				},
+			},
+		},
+		{
+			name: "getbestchainlock",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getbestchainlock")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBestChainLockCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getbestchainlock","params":[],"id":1}`,
+			unmarshalled: &btcjson.GetBestChainLockCmd{},
+		},
+		{
+			name: "getgovernanceinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getgovernanceinfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetGovernanceInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getgovernanceinfo","params":[],"id":1}`,
+			unmarshalled: &btcjson.GetGovernanceInfoCmd{},
+		},
+		{
+			name: "getislocks",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getislocks", `["123","456"]`)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetISLocksCmd([]string{"123", "456"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getislocks","params":[["123","456"]],"id":1}`,
+			unmarshalled: &btcjson.GetISLocksCmd{
+				TxIDs: []string{"123", "456"},
+			},
+		},
+		{
+			name: "getspecialtxes",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getspecialtxes", "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetSpecialTxesCmd("123", nil, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getspecialtxes","params":["123"],"id":1}`,
+			unmarshalled: &btcjson.GetSpecialTxesCmd{
+				BlockHash: "123",
+				Type:      btcjson.Int(-1),
+				Count:     btcjson.Int(10),
+				Skip:      btcjson.Int(0),
+				Verbosity: btcjson.Int(0),
+			},
+		},
+		{
+			name: "getspecialtxes optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getspecialtxes", "123", 1, 20, 5, 1)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetSpecialTxesCmd("123", btcjson.Int(1),
+					btcjson.Int(20), btcjson.Int(5), btcjson.Int(1))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getspecialtxes","params":["123",1,20,5,1],"id":1}`,
+			unmarshalled: &btcjson.GetSpecialTxesCmd{
+				BlockHash: "123",
+				Type:      btcjson.Int(1),
+				Count:     btcjson.Int(20),
+				Skip:      btcjson.Int(5),
+				Verbosity: btcjson.Int(1),
+			},
+		},
+		{
+			name: "getsuperblockbudget",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getsuperblockbudget", 997408)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetSuperblockBudgetCmd(997408)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getsuperblockbudget","params":[997408],"id":1}`,
+			unmarshalled: &btcjson.GetSuperblockBudgetCmd{
+				Index: 997408,
+			},
+		},
+		{
+			name: "getzmqnotifications",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getzmqnotifications")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetZMQNotificationsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getzmqnotifications","params":[],"id":1}`,
+			unmarshalled: &btcjson.GetZMQNotificationsCmd{},
+		},
+		{
+			name: "gobject check",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "check", "7b7d")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectCheckCmd("7b7d")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["check","7b7d"],"id":1}`,
+			unmarshalled: &btcjson.GObjectCheckCmd{
+				DataHex: "7b7d",
+			},
+		},
+		{
+			name: "gobject get",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "get", "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectGetCmd("123")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["get","123"],"id":1}`,
+			unmarshalled: &btcjson.GObjectGetCmd{
+				Hash: "123",
+			},
+		},
+		{
+			name: "gobject getcurrentvotes",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "getcurrentvotes", "a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectGetCurrentVotesCmd("a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["getcurrentvotes",` +
+				`"a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4"],"id":1}`,
+			unmarshalled: &btcjson.GObjectGetCurrentVotesCmd{
+				Hash: "a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",
+			},
+		},
+		{
+			name: "gobject getcurrentvotes optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "getcurrentvotes", "a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",
+					"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1", 1)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectGetCurrentVotesCmd("a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",
+					btcjson.String("8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1"), btcjson.Uint32(1))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["getcurrentvotes",` +
+				`"a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",` +
+				`"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1",1],"id":1}`,
+			unmarshalled: &btcjson.GObjectGetCurrentVotesCmd{
+				Hash: "a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",
+				TxID: btcjson.String("8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1"),
+				Vout: btcjson.Uint32(1),
+			},
+		},
+		{
+			name: "gobject getvotes",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "getvotes", "a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectGetVotesCmd("a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["getvotes",` +
+				`"a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4"],"id":1}`,
+			unmarshalled: &btcjson.GObjectGetVotesCmd{
+				Hash: "a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",
+			},
+		},
+		{
+			name: "gobject list",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "list")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectListCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["list"],"id":1}`,
+			unmarshalled: &btcjson.GObjectListCmd{
+				Signal: btcjson.String("valid"),
+				Type:   btcjson.String("all"),
+			},
+		},
+		{
+			name: "gobject list optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "list", "funding", "proposals")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectListCmd(btcjson.String("funding"),
+					btcjson.String("proposals"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["list","funding","proposals"],"id":1}`,
+			unmarshalled: &btcjson.GObjectListCmd{
+				Signal: btcjson.String("funding"),
+				Type:   btcjson.String("proposals"),
+			},
+		},
+		{
+			name: "gobject submit",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "submit", "0", 1, 1528384000, "7b7d", "abcd")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectSubmitCmd("0", 1, 1528384000, "7b7d",
+					btcjson.String("abcd"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["submit","0",1,1528384000,"7b7d","abcd"],"id":1}`,
+			unmarshalled: &btcjson.GObjectSubmitCmd{
+				ParentHash: "0",
+				Revision:   1,
+				Time:       1528384000,
+				DataHex:    "7b7d",
+				FeeTxID:    btcjson.String("abcd"),
+			},
+		},
+		{
+			name: "gobject submit trigger",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "submit", "0", 1, 1528384000, "7b7d")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectSubmitCmd("0", 1, 1528384000, "7b7d", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["submit","0",1,1528384000,"7b7d"],"id":1}`,
+			unmarshalled: &btcjson.GObjectSubmitCmd{
+				ParentHash: "0",
+				Revision:   1,
+				Time:       1528384000,
+				DataHex:    "7b7d",
+			},
+		},
+		{
+			name: "masternode count",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("masternode", "count")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewMasternodeCountCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"masternode","params":["count"],"id":1}`,
+			unmarshalled: &btcjson.MasternodeCountCmd{},
+		},
+		{
+			name: "masternode payments",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("masternode", "payments")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewMasternodePaymentsCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"masternode","params":["payments"],"id":1}`,
+			unmarshalled: &btcjson.MasternodePaymentsCmd{
+				Count: btcjson.Int(1),
+			},
+		},
+		{
+			name: "masternode payments optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("masternode", "payments",
+					"000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1", 5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewMasternodePaymentsCmd(
+					btcjson.String("000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1"),
+					btcjson.Int(5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"masternode","params":["payments",` +
+				`"000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1",5],"id":1}`,
+			unmarshalled: &btcjson.MasternodePaymentsCmd{
+				BlockHash: btcjson.String("000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1"),
+				Count:     btcjson.Int(5),
+			},
+		},
+		{
+			name: "masternode winners",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("masternode", "winners")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewMasternodeWinnersCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"masternode","params":["winners"],"id":1}`,
+			unmarshalled: &btcjson.MasternodeWinnersCmd{
+				Count: btcjson.Int(10),
+			},
+		},
+		{
+			name: "masternode winners optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("masternode", "winners", 20, "XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewMasternodeWinnersCmd(btcjson.Int(20),
+					btcjson.String("XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"masternode","params":["winners",20,` +
+				`"XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2"],"id":1}`,
+			unmarshalled: &btcjson.MasternodeWinnersCmd{
+				Count:  btcjson.Int(20),
+				Filter: btcjson.String("XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2"),
+			},
+		},
+		{
+			name: "masternodelist",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("masternodelist")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewMasternodeListCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"masternodelist","params":[],"id":1}`,
+			unmarshalled: &btcjson.MasternodeListCmd{
+				Mode: btcjson.String("json"),
+			},
+		},
+		{
+			name: "masternodelist optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("masternodelist", "addr", "1.2.3.4")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewMasternodeListCmd(btcjson.String("addr"),
+					btcjson.String("1.2.3.4"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"masternodelist","params":["addr","1.2.3.4"],"id":1}`,
+			unmarshalled: &btcjson.MasternodeListCmd{
+				Mode:   btcjson.String("addr"),
+				Filter: btcjson.String("1.2.3.4"),
+			},
+		},
+		{
+			name: "mnsync status",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("mnsync", "status")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewMnSyncStatusCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"mnsync","params":["status"],"id":1}`,
+			unmarshalled: &btcjson.MnSyncStatusCmd{},
+		},
+		{
+			name: "protx diff",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("protx", "diff", btcjson.HashOrHeight{Value: 1000000},
+					btcjson.HashOrHeight{Value: "00000000000000112e41e4b3afda8b233b8cc07c532d2eac5de097b68358c43e"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewProTxDiffCmd(btcjson.HashOrHeight{Value: 1000000},
+					btcjson.HashOrHeight{Value: "00000000000000112e41e4b3afda8b233b8cc07c532d2eac5de097b68358c43e"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"protx","params":["diff",1000000,` +
+				`"00000000000000112e41e4b3afda8b233b8cc07c532d2eac5de097b68358c43e"],"id":1}`,
+			unmarshalled: &btcjson.ProTxDiffCmd{
+				BaseBlock: btcjson.HashOrHeight{Value: 1000000},
+				Block:     btcjson.HashOrHeight{Value: "00000000000000112e41e4b3afda8b233b8cc07c532d2eac5de097b68358c43e"},
+			},
+		},
+		{
+			name: "protx list",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("protx", "list")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewProTxListCmd(nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"protx","params":["list"],"id":1}`,
+			unmarshalled: &btcjson.ProTxListCmd{
+				Type:     btcjson.String("registered"),
+				Detailed: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "protx list optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("protx", "list", "valid", true, 1000000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewProTxListCmd(btcjson.String("valid"),
+					btcjson.Bool(true), btcjson.Int32(1000000))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"protx","params":["list","valid",true,1000000],"id":1}`,
+			unmarshalled: &btcjson.ProTxListCmd{
+				Type:     btcjson.String("valid"),
+				Detailed: btcjson.Bool(true),
+				Height:   btcjson.Int32(1000000),
+			},
+		},
+		{
+			name: "protx info",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("protx", "info", "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewProTxInfoCmd("123")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"protx","params":["info","123"],"id":1}`,
+			unmarshalled: &btcjson.ProTxInfoCmd{ProTxHash: "123"},
+		},
+		{
+			name: "quorum list",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "list")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumListCmd(nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"quorum","params":["list"],"id":1}`,
+			unmarshalled: &btcjson.QuorumListCmd{},
+		},
+		{
+			name: "quorum list optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "list", 5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumListCmd(btcjson.Int(5))
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"quorum","params":["list",5],"id":1}`,
+			unmarshalled: &btcjson.QuorumListCmd{Count: btcjson.Int(5)},
+		},
+		{
+			name: "quorum info",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "info", 1, "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumInfoCmd(1, "123", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"quorum","params":["info",1,"123"],"id":1}`,
+			unmarshalled: &btcjson.QuorumInfoCmd{
+				LLMQType:       1,
+				QuorumHash:     "123",
+				IncludeSkShare: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "quorum memberof",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "memberof", "abc")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumMemberOfCmd("abc", nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"quorum","params":["memberof","abc"],"id":1}`,
+			unmarshalled: &btcjson.QuorumMemberOfCmd{ProTxHash: "abc"},
+		},
+		{
+			name: "quorum memberof optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "memberof", "abc", 10)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumMemberOfCmd("abc", btcjson.Int(10))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"quorum","params":["memberof","abc",10],"id":1}`,
+			unmarshalled: &btcjson.QuorumMemberOfCmd{
+				ProTxHash:        "abc",
+				ScanQuorumsCount: btcjson.Int(10),
+			},
+		},
+		{
+			name: "quorum sign",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "sign", 1, "abc", "def")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumSignCmd(1, "abc", "def", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"quorum","params":["sign",1,"abc","def"],"id":1}`,
+			unmarshalled: &btcjson.QuorumSignCmd{
+				LLMQType: 1,
+				ID:       "abc",
+				MsgHash:  "def",
+				Submit:   btcjson.Bool(true),
+			},
+		},
+		{
+			name: "quorum sign optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "sign", 1, "abc", "def", "123", false)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumSignCmd(1, "abc", "def",
+					btcjson.String("123"), btcjson.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"quorum","params":["sign",1,"abc","def","123",false],"id":1}`,
+			unmarshalled: &btcjson.QuorumSignCmd{
+				LLMQType:   1,
+				ID:         "abc",
+				MsgHash:    "def",
+				QuorumHash: btcjson.String("123"),
+				Submit:     btcjson.Bool(false),
+			},
+		},
+		{
+			name: "quorum verify",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "verify", 1, "abc", "def", "sig")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumVerifyCmd(1, "abc", "def", "sig", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"quorum","params":["verify",1,"abc","def","sig"],"id":1}`,
+			unmarshalled: &btcjson.QuorumVerifyCmd{
+				LLMQType:  1,
+				ID:        "abc",
+				MsgHash:   "def",
+				Signature: "sig",
+			},
+		},
+		{
+			name: "quorum verify optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "verify", 1, "abc", "def", "sig", "", 1000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumVerifyCmd(1, "abc", "def", "sig",
+					btcjson.String(""), btcjson.Int32(1000))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"quorum","params":["verify",1,"abc","def","sig","",1000],"id":1}`,
+			unmarshalled: &btcjson.QuorumVerifyCmd{
+				LLMQType:   1,
+				ID:         "abc",
+				MsgHash:    "def",
+				Signature:  "sig",
+				QuorumHash: btcjson.String(""),
+				SignHeight: btcjson.Int32(1000),
+			},
+		},
+		{
+			name: "quorum info optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("quorum", "info", 1, "123", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewQuorumInfoCmd(1, "123", btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"quorum","params":["info",1,"123",true],"id":1}`,
+			unmarshalled: &btcjson.QuorumInfoCmd{
+				LLMQType:       1,
+				QuorumHash:     "123",
+				IncludeSkShare: btcjson.Bool(true),
+			},
+		},
+		{
+			name: "spork",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("spork", "SPORK_2_INSTANTSEND_ENABLED", 0)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSporkCmd("SPORK_2_INSTANTSEND_ENABLED", 0)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"spork","params":["SPORK_2_INSTANTSEND_ENABLED",0],"id":1}`,
+			unmarshalled: &btcjson.SporkCmd{
+				Name:  "SPORK_2_INSTANTSEND_ENABLED",
+				Value: 0,
+			},
+		},
+		{
+			name: "spork show",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("spork", "show")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSporkShowCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"spork","params":["show"],"id":1}`,
+			unmarshalled: &btcjson.SporkShowCmd{},
+		},
+		{
+			name: "spork active",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("spork", "active")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSporkActiveCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"spork","params":["active"],"id":1}`,
+			unmarshalled: &btcjson.SporkActiveCmd{},
+		},
+		{
+			name: "verifychainlock",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("verifychainlock", "123", "456")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewVerifyChainLockCmd("123", "456", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"verifychainlock","params":["123","456"],"id":1}`,
+			unmarshalled: &btcjson.VerifyChainLockCmd{
+				BlockHash: "123",
+				Signature: "456",
+			},
+		},
+		{
+			name: "verifychainlock optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("verifychainlock", "123", "456", 1000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewVerifyChainLockCmd("123", "456",
+					btcjson.Int32(1000))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"verifychainlock","params":["123","456",1000],"id":1}`,
+			unmarshalled: &btcjson.VerifyChainLockCmd{
+				BlockHash:   "123",
+				Signature:   "456",
+				BlockHeight: btcjson.Int32(1000),
+			},
+		},
+		{
+			name: "verifyislock",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("verifyislock", "123", "456", "789")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewVerifyISLockCmd("123", "456", "789", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"verifyislock","params":["123","456","789"],"id":1}`,
+			unmarshalled: &btcjson.VerifyISLockCmd{
+				ID:        "123",
+				TxID:      "456",
+				Signature: "789",
+			},
+		},
+		{
+			name: "verifyislock optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("verifyislock", "123", "456", "789", 1000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewVerifyISLockCmd("123", "456", "789",
+					btcjson.Int32(1000))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"verifyislock","params":["123","456","789",1000],"id":1}`,
+			unmarshalled: &btcjson.VerifyISLockCmd{
+				ID:        "123",
+				TxID:      "456",
+				Signature: "789",
+				MaxHeight: btcjson.Int32(1000),
+			},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Marshal the command as created by the new static command
+		// creation function.
+		marshalled, err := btcjson.MarshalCmd(testID, test.staticCmd())
+		if err != nil {
+			t.Errorf("MarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !bytes.Equal(marshalled, []byte(test.marshalled)) {
+			t.Errorf("Test #%d (%s) unexpected marshalled data - "+
+				"got %s, want %s", i, test.name, marshalled,
+				test.marshalled)
+			continue
+		}
+
+		// Ensure the command is created without error via the generic
+		// new command creation function.
+		cmd, err := test.newCmd()
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected NewCmd error: %v ",
+				i, test.name, err)
+		}
+
+		// Marshal the command as created by the generic new command
+		// creation function.
+		marshalled, err = btcjson.MarshalCmd(testID, cmd)
+		if err != nil {
+			t.Errorf("MarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !bytes.Equal(marshalled, []byte(test.marshalled)) {
+			t.Errorf("Test #%d (%s) unexpected marshalled data - "+
+				"got %s, want %s", i, test.name, marshalled,
+				test.marshalled)
+			continue
+		}
+
+		var request btcjson.Request
+		if err := json.Unmarshal(marshalled, &request); err != nil {
+			t.Errorf("Test #%d (%s) unexpected error while "+
+				"unmarshalling JSON-RPC request: %v", i,
+				test.name, err)
+			continue
+		}
+
+		cmd, err = btcjson.UnmarshalCmd(&request)
+		if err != nil {
+			t.Errorf("UnmarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(cmd, test.unmarshalled) {
+			t.Errorf("Test #%d (%s) unexpected unmarshalled command "+
+				"- got %s, want %s", i, test.name,
+				fmt.Sprintf("(%T) %+[1]v", cmd),
+				fmt.Sprintf("(%T) %+[1]v\n", test.unmarshalled))
+			continue
+		}
+	}
+}
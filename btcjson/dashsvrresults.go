@@ -0,0 +1,685 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package btcjson
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MasternodeCountResult models the data from the masternode count command.
+type MasternodeCountResult struct {
+	Total   int32 `json:"total"`
+	Enabled int32 `json:"enabled"`
+	Qualify int32 `json:"qualify,omitempty"`
+}
+
+// UnmarshalJSON provides a custom Unmarshal method for MasternodeCountResult.
+// This is necessary because older versions of dashd reply to masternode count
+// with a bare integer of the total number of masternodes rather than an
+// object.
+func (r *MasternodeCountResult) UnmarshalJSON(data []byte) error {
+	var total int32
+	if err := json.Unmarshal(data, &total); err == nil {
+		*r = MasternodeCountResult{Total: total}
+		return nil
+	}
+
+	type masternodeCountResult MasternodeCountResult
+	return json.Unmarshal(data, (*masternodeCountResult)(r))
+}
+
+// MasternodeListEntry models a single masternode from the data returned by the
+// masternodelist command.  The fields are only populated when the json mode is
+// requested.  For the remaining modes the server replies with a single string
+// per masternode which is stored as is in the Info field.
+type MasternodeListEntry struct {
+	ProTxHash         string `json:"proTxHash"`
+	Address           string `json:"address"`
+	Payee             string `json:"payee"`
+	Status            string `json:"status"`
+	PoSePenalty       int32  `json:"pospenaltyscore"`
+	LastPaidTime      int64  `json:"lastpaidtime"`
+	LastPaidBlock     int32  `json:"lastpaidblock"`
+	OwnerAddress      string `json:"owneraddress"`
+	VotingAddress     string `json:"votingaddress"`
+	CollateralAddress string `json:"collateraladdress"`
+	PubKeyOperator    string `json:"pubkeyoperator"`
+	Info              string `json:"-"`
+}
+
+// UnmarshalJSON provides a custom Unmarshal method for MasternodeListEntry.
+// This is necessary because the masternodelist command replies with either an
+// object or a string for each masternode depending on the requested mode.
+func (e *MasternodeListEntry) UnmarshalJSON(data []byte) error {
+	var info string
+	if err := json.Unmarshal(data, &info); err == nil {
+		*e = MasternodeListEntry{Info: info}
+		return nil
+	}
+
+	type masternodeListEntry MasternodeListEntry
+	return json.Unmarshal(data, (*masternodeListEntry)(e))
+}
+
+// MasternodePayee models a single payee of a masternode payment returned by the
+// masternode payments command.  The amount is in duffs.
+type MasternodePayee struct {
+	Address string `json:"address"`
+	Script  string `json:"script"`
+	Amount  int64  `json:"amount"`
+}
+
+// MasternodePayment models the payment to a single masternode in the data
+// returned by the masternode payments command.  The amount is in duffs.
+type MasternodePayment struct {
+	ProTxHash string            `json:"proTxHash"`
+	Amount    int64             `json:"amount"`
+	Payees    []MasternodePayee `json:"payees"`
+}
+
+// MasternodePaymentsResult models the masternode payouts of a single block in
+// the data returned by the masternode payments command.  The amount is the
+// total paid to masternodes in the block in duffs.
+type MasternodePaymentsResult struct {
+	Height      int32               `json:"height"`
+	BlockHash   string              `json:"blockhash"`
+	Amount      int64               `json:"amount"`
+	Masternodes []MasternodePayment `json:"masternodes"`
+}
+
+// MnSyncStatusResult models the data from the mnsync status command.
+type MnSyncStatusResult struct {
+	AssetID            int32  `json:"AssetID"`
+	AssetName          string `json:"AssetName"`
+	AssetStartTime     int64  `json:"AssetStartTime"`
+	Attempt            int32  `json:"Attempt"`
+	IsBlockchainSynced bool   `json:"IsBlockchainSynced"`
+	IsSynced           bool   `json:"IsSynced"`
+}
+
+// ProTxState models the state object of a deterministic masternode returned as
+// part of the protx info and detailed protx list commands.
+type ProTxState struct {
+	Service               string `json:"service"`
+	RegisteredHeight      int32  `json:"registeredHeight"`
+	LastPaidHeight        int32  `json:"lastPaidHeight"`
+	PoSePenalty           int32  `json:"PoSePenalty"`
+	PoSeRevivedHeight     int32  `json:"PoSeRevivedHeight"`
+	PoSeBanHeight         int32  `json:"PoSeBanHeight"`
+	RevocationReason      int32  `json:"revocationReason"`
+	OwnerAddress          string `json:"ownerAddress"`
+	VotingAddress         string `json:"votingAddress"`
+	PayoutAddress         string `json:"payoutAddress"`
+	PubKeyOperator        string `json:"pubKeyOperator"`
+	OperatorPayoutAddress string `json:"operatorPayoutAddress,omitempty"`
+}
+
+// ProTxInfoResult models the data from the protx info command as well as the
+// entries of the protx list command.
+type ProTxInfoResult struct {
+	ProTxHash         string     `json:"proTxHash"`
+	CollateralHash    string     `json:"collateralHash"`
+	CollateralIndex   uint32     `json:"collateralIndex"`
+	CollateralAddress string     `json:"collateralAddress,omitempty"`
+	OperatorReward    float64    `json:"operatorReward"`
+	State             ProTxState `json:"state"`
+	Confirmations     int64      `json:"confirmations"`
+}
+
+// UnmarshalJSON provides a custom Unmarshal method for ProTxInfoResult.  This
+// is necessary because the protx list command replies with just the hash of
+// each ProRegTx unless detailed output is requested.
+func (r *ProTxInfoResult) UnmarshalJSON(data []byte) error {
+	var proTxHash string
+	if err := json.Unmarshal(data, &proTxHash); err == nil {
+		*r = ProTxInfoResult{ProTxHash: proTxHash}
+		return nil
+	}
+
+	type proTxInfoResult ProTxInfoResult
+	return json.Unmarshal(data, (*proTxInfoResult)(r))
+}
+
+// QuorumMember models a single member of a long living masternode quorum as
+// returned by the quorum info command.
+type QuorumMember struct {
+	ProTxHash      string `json:"proTxHash"`
+	Service        string `json:"service,omitempty"`
+	PubKeyOperator string `json:"pubKeyOperator,omitempty"`
+	Valid          bool   `json:"valid"`
+	PubKeyShare    string `json:"pubKeyShare,omitempty"`
+}
+
+// QuorumInfoResult models the data from the quorum info command.
+type QuorumInfoResult struct {
+	Height          int32          `json:"height"`
+	Type            string         `json:"type"`
+	QuorumHash      string         `json:"quorumHash"`
+	MinedBlock      string         `json:"minedBlock"`
+	Members         []QuorumMember `json:"members"`
+	QuorumPublicKey string         `json:"quorumPublicKey"`
+	SecretKeyShare  string         `json:"secretKeyShare,omitempty"`
+}
+
+// QuorumMemberOfResult models a single quorum from the quorum memberof command.
+// Type is the name of the LLMQ type, for example llmq_50_60.
+type QuorumMemberOfResult struct {
+	Height          int32  `json:"height"`
+	Type            string `json:"type"`
+	QuorumHash      string `json:"quorumHash"`
+	MinedBlock      string `json:"minedBlock"`
+	QuorumPublicKey string `json:"quorumPublicKey"`
+	IsValidMember   bool   `json:"isValidMember"`
+	MemberIndex     int    `json:"memberIndex"`
+}
+
+// QuorumSignResult models the data from the quorum sign command.
+//
+// The server only replies with the signature of its quorum member when the
+// request is not submitted to the quorum.  Submitted requests are answered
+// with a boolean, in which case only Submitted is set.
+type QuorumSignResult struct {
+	LLMQType     int    `json:"llmqType"`
+	QuorumHash   string `json:"quorumHash"`
+	QuorumMember int    `json:"quorumMember"`
+	ID           string `json:"id"`
+	MsgHash      string `json:"msgHash"`
+	SignHash     string `json:"signHash"`
+	Signature    string `json:"signature"`
+
+	// Submitted is not part of the server reply.  It is set to the
+	// boolean returned by the server for submitted signing requests.
+	Submitted bool `json:"-"`
+}
+
+// UnmarshalJSON provides a custom unmarshal method for QuorumSignResult so the
+// boolean reply to submitted signing requests is accepted as well.
+func (r *QuorumSignResult) UnmarshalJSON(data []byte) error {
+	var submitted bool
+	if err := json.Unmarshal(data, &submitted); err == nil {
+		*r = QuorumSignResult{Submitted: submitted}
+		return nil
+	}
+
+	type quorumSignResult QuorumSignResult
+	return json.Unmarshal(data, (*quorumSignResult)(r))
+}
+
+// BLSKeyResult models the data from the bls generate and bls fromsecret
+// commands.
+type BLSKeyResult struct {
+	Secret string `json:"secret"`
+	Public string `json:"public"`
+}
+
+// GetAddressBalanceResult models the data from the getaddressbalance command.
+// All amounts are in duffs.  The immature and spendable balances are only
+// reported by dashd 0.17 and later.
+type GetAddressBalanceResult struct {
+	Balance          int64 `json:"balance"`
+	BalanceImmature  int64 `json:"balance_immature"`
+	BalanceSpendable int64 `json:"balance_spendable"`
+	Received         int64 `json:"received"`
+}
+
+// AddressUTXO models a single unspent output from the getaddressutxos command.
+type AddressUTXO struct {
+	Address     string `json:"address"`
+	TxID        string `json:"txid"`
+	OutputIndex uint32 `json:"outputIndex"`
+	Script      string `json:"script"`
+	Satoshis    int64  `json:"satoshis"`
+	Height      int32  `json:"height"`
+}
+
+// AddressDelta models a single balance change from the getaddressdeltas
+// command.  Satoshis is negative for spending inputs, in which case Index is
+// the index of the input rather than of an output.
+type AddressDelta struct {
+	Address    string `json:"address"`
+	TxID       string `json:"txid"`
+	Index      uint32 `json:"index"`
+	BlockIndex uint32 `json:"blockindex"`
+	Satoshis   int64  `json:"satoshis"`
+	Height     int32  `json:"height"`
+}
+
+// GetSpentInfoResult models the data from the getspentinfo command.  TxID and
+// Index identify the input of the transaction that spent the requested output
+// and Height is the height of the block that transaction was mined in.
+type GetSpentInfoResult struct {
+	TxID   string `json:"txid"`
+	Index  uint32 `json:"index"`
+	Height int32  `json:"height"`
+}
+
+// BlockHashTimestamp models a single block of the data returned by the
+// getblockhashes command.  LogicalTS is only reported when logical times are
+// requested and is the timestamp the block is indexed by, which is kept
+// increasing even for blocks with an earlier timestamp than their parent.
+type BlockHashTimestamp struct {
+	BlockHash string `json:"blockhash"`
+	LogicalTS int64  `json:"logicalts"`
+}
+
+// ZMQNotification models a single publisher of the data returned by the
+// getzmqnotifications command.  Type is the name of the notification, such as
+// "pubrawchainlock" or "pubrawtxlock", and Address the endpoint it is published
+// on.  HWM is the outbound message high water mark of the publisher.
+type ZMQNotification struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+	HWM     int32  `json:"hwm"`
+}
+
+// Topic returns the ZMQ topic the notification is published with, which is its
+// type without the "pub" prefix, such as "rawchainlock".
+func (n *ZMQNotification) Topic() string {
+	return strings.TrimPrefix(n.Type, "pub")
+}
+
+// GetBestChainLockResult models the data from the getbestchainlock command.
+type GetBestChainLockResult struct {
+	BlockHash  string `json:"blockhash"`
+	Height     int32  `json:"height"`
+	Signature  string `json:"signature"`
+	KnownBlock bool   `json:"known_block"`
+
+	// Known is not part of the server reply.  It is set when the server
+	// knows about a chainlock and is false when no chainlock exists yet.
+	Known bool `json:"-"`
+}
+
+// GetGovernanceInfoResult models the data from the getgovernanceinfo command.
+type GetGovernanceInfoResult struct {
+	GovernanceMinQuorum          int32   `json:"governanceminquorum"`
+	MasternodeWatchdogMaxSeconds int64   `json:"masternodewatchdogmaxseconds,omitempty"`
+	ProposalFee                  float64 `json:"proposalfee"`
+	SuperblockCycle              int32   `json:"superblockcycle"`
+	SuperblockMaturityWindow     int32   `json:"superblockmaturitywindow,omitempty"`
+	LastSuperblock               int32   `json:"lastsuperblock"`
+	NextSuperblock               int32   `json:"nextsuperblock"`
+	FundingThreshold             int32   `json:"fundingthreshold,omitempty"`
+	GovernanceBudget             float64 `json:"governancebudget,omitempty"`
+	MaxGovObjDataSize            int32   `json:"maxgovobjdatasize,omitempty"`
+}
+
+// ISLockResult models an InstantSend lock as returned by the getislocks
+// command.
+type ISLockResult struct {
+	TxID      string             `json:"txid"`
+	Version   int32              `json:"version,omitempty"`
+	Inputs    []TransactionInput `json:"inputs"`
+	CycleHash string             `json:"cycleHash,omitempty"`
+	Signature string             `json:"signature"`
+	Hex       string             `json:"hex,omitempty"`
+}
+
+// SpecialTxResult models a special transaction as returned by the
+// getspecialtxes command.  Only the Hex field is populated when the command is
+// issued with a verbosity of zero, since the server then replies with just the
+// serialized transaction.
+type SpecialTxResult struct {
+	Hex              string `json:"hex,omitempty"`
+	Txid             string `json:"txid"`
+	Version          int32  `json:"version"`
+	Type             int32  `json:"type"`
+	Size             int32  `json:"size"`
+	LockTime         uint32 `json:"locktime"`
+	Vin              []Vin  `json:"vin"`
+	Vout             []Vout `json:"vout"`
+	ExtraPayloadSize int32  `json:"extraPayloadSize"`
+	ExtraPayload     string `json:"extraPayload"`
+}
+
+// UnmarshalJSON provides a custom Unmarshal method for SpecialTxResult.  This
+// is necessary because the getspecialtxes command replies with either the
+// serialized transaction or its decoded form depending on the verbosity.
+func (r *SpecialTxResult) UnmarshalJSON(data []byte) error {
+	var txHex string
+	if err := json.Unmarshal(data, &txHex); err == nil {
+		*r = SpecialTxResult{Hex: txHex}
+		return nil
+	}
+
+	type specialTxResult SpecialTxResult
+	return json.Unmarshal(data, (*specialTxResult)(r))
+}
+
+// ProRegTxPayload models the decoded payload of a provider registration
+// transaction, which registers a deterministic masternode.
+type ProRegTxPayload struct {
+	Version         int32   `json:"version"`
+	CollateralHash  string  `json:"collateralHash"`
+	CollateralIndex uint32  `json:"collateralIndex"`
+	Service         string  `json:"service"`
+	OwnerAddress    string  `json:"ownerAddress"`
+	VotingAddress   string  `json:"votingAddress"`
+	PayoutAddress   string  `json:"payoutAddress"`
+	PubKeyOperator  string  `json:"pubKeyOperator"`
+	OperatorReward  float64 `json:"operatorReward"`
+	InputsHash      string  `json:"inputsHash"`
+}
+
+// ProUpServTxPayload models the decoded payload of a provider update service
+// transaction, which changes the service address of a masternode.
+type ProUpServTxPayload struct {
+	Version               int32  `json:"version"`
+	ProTxHash             string `json:"proTxHash"`
+	Service               string `json:"service"`
+	OperatorPayoutAddress string `json:"operatorPayoutAddress,omitempty"`
+	InputsHash            string `json:"inputsHash"`
+}
+
+// ProUpRegTxPayload models the decoded payload of a provider update registrar
+// transaction, which changes the keys and payout address of a masternode.
+type ProUpRegTxPayload struct {
+	Version        int32  `json:"version"`
+	ProTxHash      string `json:"proTxHash"`
+	VotingAddress  string `json:"votingAddress"`
+	PayoutAddress  string `json:"payoutAddress"`
+	PubKeyOperator string `json:"pubKeyOperator"`
+	InputsHash     string `json:"inputsHash"`
+}
+
+// ProUpRevTxPayload models the decoded payload of a provider update revocation
+// transaction, which revokes the operator of a masternode.
+type ProUpRevTxPayload struct {
+	Version    int32  `json:"version"`
+	ProTxHash  string `json:"proTxHash"`
+	Reason     int32  `json:"reason"`
+	InputsHash string `json:"inputsHash"`
+}
+
+// CbTxPayload models the decoded payload of a coinbase special transaction.
+type CbTxPayload struct {
+	Version           int32  `json:"version"`
+	Height            int32  `json:"height"`
+	MerkleRootMNList  string `json:"merkleRootMNList"`
+	MerkleRootQuorums string `json:"merkleRootQuorums,omitempty"`
+}
+
+// QuorumCommitmentResult models a final LLMQ commitment as embedded in a
+// quorum commitment special transaction.
+type QuorumCommitmentResult struct {
+	Version           int32  `json:"version"`
+	LLMQType          int32  `json:"llmqType"`
+	QuorumHash        string `json:"quorumHash"`
+	QuorumIndex       int32  `json:"quorumIndex,omitempty"`
+	SignersCount      int32  `json:"signersCount"`
+	Signers           string `json:"signers"`
+	ValidMembersCount int32  `json:"validMembersCount"`
+	ValidMembers      string `json:"validMembers"`
+	QuorumPublicKey   string `json:"quorumPublicKey"`
+	QuorumVvecHash    string `json:"quorumVvecHash"`
+	QuorumSig         string `json:"quorumSig"`
+	MembersSig        string `json:"membersSig"`
+}
+
+// QcTxPayload models the decoded payload of a quorum commitment special
+// transaction.
+type QcTxPayload struct {
+	Version    int32                  `json:"version"`
+	Height     int32                  `json:"height"`
+	Commitment QuorumCommitmentResult `json:"commitment"`
+}
+
+// SMLEntryResult models a single entry of the simplified masternode list as
+// returned in a masternode list diff.  Version and Type are reported by Dash
+// Core 19 and later, PlatformHTTPPort and PlatformNodeID only for Evo
+// masternodes.
+type SMLEntryResult struct {
+	Version          uint16 `json:"nVersion,omitempty"`
+	Type             uint16 `json:"nType,omitempty"`
+	ProRegTxHash     string `json:"proRegTxHash"`
+	ConfirmedHash    string `json:"confirmedHash"`
+	Service          string `json:"service"`
+	PubKeyOperator   string `json:"pubKeyOperator"`
+	VotingAddress    string `json:"votingAddress"`
+	IsValid          bool   `json:"isValid"`
+	PlatformHTTPPort uint16 `json:"platformHTTPPort,omitempty"`
+	PlatformNodeID   string `json:"platformNodeID,omitempty"`
+}
+
+// DeletedQuorumResult identifies a quorum removed from the active quorum set
+// by a masternode list diff.
+type DeletedQuorumResult struct {
+	LLMQType   int32  `json:"llmqType"`
+	QuorumHash string `json:"quorumHash"`
+}
+
+// MnListDiffResult models the changes of the simplified masternode list and
+// the active quorums between two blocks as defined by DIP0004.  CbTx is the
+// hex-encoded coinbase transaction of the block identified by BlockHash and
+// CbTxMerkleTree the hex-encoded partial merkle tree proving its inclusion.
+type MnListDiffResult struct {
+	BaseBlockHash     string                   `json:"baseBlockHash"`
+	BlockHash         string                   `json:"blockHash"`
+	CbTxMerkleTree    string                   `json:"cbTxMerkleTree"`
+	CbTx              string                   `json:"cbTx"`
+	DeletedMNs        []string                 `json:"deletedMNs"`
+	MNList            []SMLEntryResult         `json:"mnList"`
+	DeletedQuorums    []DeletedQuorumResult    `json:"deletedQuorums"`
+	NewQuorums        []QuorumCommitmentResult `json:"newQuorums"`
+	MerkleRootMNList  string                   `json:"merkleRootMNList"`
+	MerkleRootQuorums string                   `json:"merkleRootQuorums,omitempty"`
+}
+
+// QuorumSnapshotResult models the snapshot of the masternode list used to
+// build the members of the rotating quorums of a cycle as defined by DIP0024.
+// ActiveQuorumMembers flags the masternodes of the list which were quorum
+// members, and MnSkipList describes the masternodes skipped according to
+// MnSkipListMode.
+type QuorumSnapshotResult struct {
+	ActiveQuorumMembers []bool  `json:"activeQuorumMembers"`
+	MnSkipListMode      int32   `json:"mnSkipListMode"`
+	MnSkipList          []int32 `json:"mnSkipList"`
+}
+
+// QuorumRotationInfoResult models the data from the quorum rotationinfo
+// command.  It holds what is needed to compute the members of the rotating
+// quorums as defined by DIP0024: the masternode list diffs and quorum
+// snapshots at the height H of the last cycle and at the three previous cycles,
+// spaced by the cycle length C.  The H-4C fields are only set when an extra
+// share was requested.
+type QuorumRotationInfoResult struct {
+	ExtraShare               bool                     `json:"extraShare"`
+	QuorumSnapshotAtHMinusC  QuorumSnapshotResult     `json:"quorumSnapshotAtHMinusC"`
+	QuorumSnapshotAtHMinus2C QuorumSnapshotResult     `json:"quorumSnapshotAtHMinus2C"`
+	QuorumSnapshotAtHMinus3C QuorumSnapshotResult     `json:"quorumSnapshotAtHMinus3C"`
+	QuorumSnapshotAtHMinus4C *QuorumSnapshotResult    `json:"quorumSnapshotAtHMinus4C,omitempty"`
+	MnListDiffTip            MnListDiffResult         `json:"mnListDiffTip"`
+	MnListDiffH              MnListDiffResult         `json:"mnListDiffH"`
+	MnListDiffAtHMinusC      MnListDiffResult         `json:"mnListDiffAtHMinusC"`
+	MnListDiffAtHMinus2C     MnListDiffResult         `json:"mnListDiffAtHMinus2C"`
+	MnListDiffAtHMinus3C     MnListDiffResult         `json:"mnListDiffAtHMinus3C"`
+	MnListDiffAtHMinus4C     *MnListDiffResult        `json:"mnListDiffAtHMinus4C,omitempty"`
+	LastCommitmentPerIndex   []QuorumCommitmentResult `json:"lastCommitmentPerIndex"`
+	QuorumSnapshotList       []QuorumSnapshotResult   `json:"quorumSnapshotList"`
+	MnListDiffList           []MnListDiffResult       `json:"mnListDiffList"`
+}
+
+// GovernanceObject models a governance object, such as a proposal or a trigger,
+// as returned by the gobject list command.
+type GovernanceObject struct {
+	Hash             string `json:"Hash"`
+	CollateralHash   string `json:"CollateralHash"`
+	ObjectType       int32  `json:"ObjectType"`
+	CreationTime     int64  `json:"CreationTime"`
+	DataHex          string `json:"DataHex"`
+	DataString       string `json:"DataString,omitempty"`
+	AbsoluteYesCount int32  `json:"AbsoluteYesCount"`
+	YesCount         int32  `json:"YesCount"`
+	NoCount          int32  `json:"NoCount"`
+	AbstainCount     int32  `json:"AbstainCount"`
+
+	// DataObject is the decoded form of DataHex.  It is not part of the
+	// server reply and is populated by DecodeGovernanceData.
+	DataObject map[string]interface{} `json:"-"`
+}
+
+// Governance object types as reported in the ObjectType field of governance
+// objects.
+const (
+	GovernanceObjectProposal = 1
+	GovernanceObjectTrigger  = 2
+)
+
+// governanceDataJSON decodes the hex-encoded data of a governance object and
+// returns the JSON object it holds.  The data is a JSON object, however,
+// objects created by older versions of dashd wrap it in an array of the form
+// [["<type>", {...}]], in which case the inner object is returned.
+func governanceDataJSON(dataHex string) (json.RawMessage, error) {
+	data, err := hex.DecodeString(dataHex)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err == nil {
+		return data, nil
+	}
+
+	var legacy [][2]json.RawMessage
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, err
+	}
+	if len(legacy) != 1 {
+		str := fmt.Sprintf("governance data has %d objects, expected 1",
+			len(legacy))
+		return nil, makeError(ErrInvalidType, str)
+	}
+	return legacy[0][1], nil
+}
+
+// DecodeGovernanceData decodes the hex-encoded data of a governance object into
+// a generic map.  The data is a JSON object, however, objects created by older
+// versions of dashd wrap it in an array of the form [["<type>", {...}]], in
+// which case the inner object is returned.
+func DecodeGovernanceData(dataHex string) (map[string]interface{}, error) {
+	data, err := governanceDataJSON(dataHex)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// GovernanceProposal models the data of a proposal governance object.  The
+// payment amount is in DASH.
+type GovernanceProposal struct {
+	Type           int32   `json:"type"`
+	Name           string  `json:"name"`
+	StartEpoch     int64   `json:"start_epoch"`
+	EndEpoch       int64   `json:"end_epoch"`
+	PaymentAddress string  `json:"payment_address"`
+	PaymentAmount  float64 `json:"payment_amount"`
+	URL            string  `json:"url"`
+}
+
+// DecodeGovernanceProposal decodes the hex-encoded data of a proposal
+// governance object.  Both the current and the legacy data formats accepted by
+// DecodeGovernanceData are supported.
+func DecodeGovernanceProposal(dataHex string) (*GovernanceProposal, error) {
+	data, err := governanceDataJSON(dataHex)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposal GovernanceProposal
+	if err := json.Unmarshal(data, &proposal); err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+// GovernanceVoteTally models the tally of the votes for a single signal of a
+// governance object.
+type GovernanceVoteTally struct {
+	AbsoluteYesCount int32 `json:"AbsoluteYesCount"`
+	YesCount         int32 `json:"YesCount"`
+	NoCount          int32 `json:"NoCount"`
+	AbstainCount     int32 `json:"AbstainCount"`
+}
+
+// GObjectGetResult models the data from the gobject get command.
+type GObjectGetResult struct {
+	Hash              string              `json:"Hash"`
+	CollateralHash    string              `json:"CollateralHash"`
+	ObjectType        int32               `json:"ObjectType"`
+	CreationTime      int64               `json:"CreationTime"`
+	DataHex           string              `json:"DataHex"`
+	DataString        string              `json:"DataString,omitempty"`
+	SigningMasternode string              `json:"SigningMasternode,omitempty"`
+	FundingResult     GovernanceVoteTally `json:"FundingResult"`
+	ValidResult       GovernanceVoteTally `json:"ValidResult"`
+	DeleteResult      GovernanceVoteTally `json:"DeleteResult"`
+	EndorsedResult    GovernanceVoteTally `json:"EndorsedResult"`
+	LocalValidity     bool                `json:"fLocalValidity"`
+	IsValidReason     string              `json:"IsValidReason"`
+	CachedValid       bool                `json:"fCachedValid"`
+	CachedFunding     bool                `json:"fCachedFunding"`
+	CachedDelete      bool                `json:"fCachedDelete"`
+	CachedEndorsed    bool                `json:"fCachedEndorsed"`
+
+	// Proposal is the decoded form of DataHex for proposal objects.  It is
+	// not part of the server reply and is populated by
+	// DecodeGovernanceProposal.
+	Proposal *GovernanceProposal `json:"-"`
+}
+
+// GObjectCheckResult models the data from the gobject check command.
+type GObjectCheckResult struct {
+	ObjectStatus string `json:"Object status"`
+}
+
+// GovernanceVote models a single vote on a governance object as returned by the
+// gobject getvotes and gobject getcurrentvotes commands.  The masternode which
+// cast the vote is identified by its collateral outpoint in the form
+// "<txid>-<vout>".
+//
+// NOTE: The commands do not report the signatures of the votes.
+type GovernanceVote struct {
+	Hash               string
+	MasternodeOutpoint string
+	Time               int64
+	Outcome            string
+	Signal             string
+}
+
+// ParseGovernanceVote parses a vote in the form reported by the gobject
+// getvotes and gobject getcurrentvotes commands, which reply with an object
+// mapping the hash of each vote to a string of the form
+// "<outpoint>:<time>:<outcome>:<signal>".
+func ParseGovernanceVote(hash, vote string) (*GovernanceVote, error) {
+	fields := strings.Split(vote, ":")
+	if len(fields) != 4 {
+		str := fmt.Sprintf("governance vote %q has %d fields, expected 4",
+			vote, len(fields))
+		return nil, makeError(ErrInvalidType, str)
+	}
+
+	voteTime, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		str := fmt.Sprintf("governance vote %q has an invalid time", vote)
+		return nil, makeError(ErrInvalidType, str)
+	}
+
+	return &GovernanceVote{
+		Hash:               hash,
+		MasternodeOutpoint: fields[0],
+		Time:               voteTime,
+		Outcome:            fields[2],
+		Signal:             fields[3],
+	}, nil
+}
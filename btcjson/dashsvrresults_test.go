@@ -0,0 +1,943 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package btcjson_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+)
+
+// TestDashSvrResults ensures the results returned by a Dash chain server
+// unmarshal into the expected types, including those with custom unmarshal
+// code.
+func TestDashSvrResults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     string
+		result   interface{}
+		expected interface{}
+	}{
+		{
+			name: "getgovernanceinfo",
+			data: `{"governanceminquorum":10,"masternodewatchdogmaxseconds":7200,` +
+				`"proposalfee":5.00000000,"superblockcycle":16616,"lastsuperblock":980792,` +
+				`"nextsuperblock":997408,"maxgovobjdatasize":16384}`,
+			result: new(btcjson.GetGovernanceInfoResult),
+			expected: &btcjson.GetGovernanceInfoResult{
+				GovernanceMinQuorum:          10,
+				MasternodeWatchdogMaxSeconds: 7200,
+				ProposalFee:                  5,
+				SuperblockCycle:              16616,
+				LastSuperblock:               980792,
+				NextSuperblock:               997408,
+				MaxGovObjDataSize:            16384,
+			},
+		},
+		{
+			name: "getislocks",
+			data: `{"txid":"8d6f6b6b5a8ab0631b2e5f2876c0bb5b48fd6d3c2a6ab4d79a8d4a87a0a4b8ea",` +
+				`"version":1,"inputs":[{"txid":"d1f9a0e6925f8d1ac4c1b3c3c9b8aa4c84c7a4118b8e2c3f3c1fd1e9f1e1f6a2","vout":1}],` +
+				`"cycleHash":"000000000000000f0d0ecedd9b45a2e09b8e4b3d5e8aa14a3a2d4a1ccf4c9f87",` +
+				`"signature":"97d5c3","hex":"0101"}`,
+			result: new(btcjson.ISLockResult),
+			expected: &btcjson.ISLockResult{
+				TxID:    "8d6f6b6b5a8ab0631b2e5f2876c0bb5b48fd6d3c2a6ab4d79a8d4a87a0a4b8ea",
+				Version: 1,
+				Inputs: []btcjson.TransactionInput{
+					{
+						Txid: "d1f9a0e6925f8d1ac4c1b3c3c9b8aa4c84c7a4118b8e2c3f3c1fd1e9f1e1f6a2",
+						Vout: 1,
+					},
+				},
+				CycleHash: "000000000000000f0d0ecedd9b45a2e09b8e4b3d5e8aa14a3a2d4a1ccf4c9f87",
+				Signature: "97d5c3",
+				Hex:       "0101",
+			},
+		},
+		{
+			name:     "getspecialtxes hex",
+			data:     `["0300050001"]`,
+			result:   new([]btcjson.SpecialTxResult),
+			expected: &[]btcjson.SpecialTxResult{{Hex: "0300050001"}},
+		},
+		{
+			name: "getspecialtxes verbose",
+			data: `[{"txid":"f88b2cd4a3a8fa6d60e6bbcdc4c43a5bcd77b9627ab6b38914bef64c2bdcb92c",` +
+				`"version":3,"type":5,"size":185,"locktime":0,"vin":[],"vout":[],` +
+				`"extraPayloadSize":70,"extraPayload":"0200"}]`,
+			result: new([]btcjson.SpecialTxResult),
+			expected: &[]btcjson.SpecialTxResult{
+				{
+					Txid:             "f88b2cd4a3a8fa6d60e6bbcdc4c43a5bcd77b9627ab6b38914bef64c2bdcb92c",
+					Version:          3,
+					Type:             5,
+					Size:             185,
+					Vin:              []btcjson.Vin{},
+					Vout:             []btcjson.Vout{},
+					ExtraPayloadSize: 70,
+					ExtraPayload:     "0200",
+				},
+			},
+		},
+		{
+			name: "gobject list",
+			data: `{"a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4":{` +
+				`"DataHex":"7b226e616d65223a2274657374227d","DataString":"{\"name\":\"test\"}",` +
+				`"Hash":"a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",` +
+				`"CollateralHash":"3a8a1ef2c2f0f2fd5a5bd77b0f7ed0b2c1bd6f4f0d0b8b15e2cf8e92c2dd6b0a",` +
+				`"ObjectType":1,"CreationTime":1543622400,"AbsoluteYesCount":512,` +
+				`"YesCount":600,"NoCount":88,"AbstainCount":3,"fBlockchainValidity":true,` +
+				`"IsValidReason":"","fCachedValid":true,"fCachedFunding":true,` +
+				`"fCachedDelete":false,"fCachedEndorsed":false}}`,
+			result: new(map[string]btcjson.GovernanceObject),
+			expected: &map[string]btcjson.GovernanceObject{
+				"a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4": {
+					Hash:             "a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",
+					CollateralHash:   "3a8a1ef2c2f0f2fd5a5bd77b0f7ed0b2c1bd6f4f0d0b8b15e2cf8e92c2dd6b0a",
+					ObjectType:       1,
+					CreationTime:     1543622400,
+					DataHex:          "7b226e616d65223a2274657374227d",
+					DataString:       `{"name":"test"}`,
+					AbsoluteYesCount: 512,
+					YesCount:         600,
+					NoCount:          88,
+					AbstainCount:     3,
+				},
+			},
+		},
+		{
+			name: "gobject get",
+			data: `{"DataHex":"7b7d","DataString":"{}",` +
+				`"Hash":"a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",` +
+				`"CollateralHash":"3a8a1ef2c2f0f2fd5a5bd77b0f7ed0b2c1bd6f4f0d0b8b15e2cf8e92c2dd6b0a",` +
+				`"ObjectType":1,"CreationTime":1543622400,` +
+				`"FundingResult":{"AbsoluteYesCount":512,"YesCount":600,"NoCount":88,"AbstainCount":3},` +
+				`"ValidResult":{"AbsoluteYesCount":0,"YesCount":0,"NoCount":0,"AbstainCount":0},` +
+				`"DeleteResult":{"AbsoluteYesCount":-2,"YesCount":1,"NoCount":3,"AbstainCount":0},` +
+				`"EndorsedResult":{"AbsoluteYesCount":0,"YesCount":0,"NoCount":0,"AbstainCount":0},` +
+				`"fLocalValidity":true,"IsValidReason":"",` +
+				`"fCachedValid":true,"fCachedFunding":true,"fCachedDelete":false,"fCachedEndorsed":false}`,
+			result: new(btcjson.GObjectGetResult),
+			expected: &btcjson.GObjectGetResult{
+				Hash:           "a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",
+				CollateralHash: "3a8a1ef2c2f0f2fd5a5bd77b0f7ed0b2c1bd6f4f0d0b8b15e2cf8e92c2dd6b0a",
+				ObjectType:     btcjson.GovernanceObjectProposal,
+				CreationTime:   1543622400,
+				DataHex:        "7b7d",
+				DataString:     "{}",
+				FundingResult: btcjson.GovernanceVoteTally{
+					AbsoluteYesCount: 512,
+					YesCount:         600,
+					NoCount:          88,
+					AbstainCount:     3,
+				},
+				DeleteResult: btcjson.GovernanceVoteTally{
+					AbsoluteYesCount: -2,
+					YesCount:         1,
+					NoCount:          3,
+				},
+				LocalValidity: true,
+				CachedValid:   true,
+				CachedFunding: true,
+			},
+		},
+		{
+			name:     "gobject check",
+			data:     `{"Object status":"OK"}`,
+			result:   new(btcjson.GObjectCheckResult),
+			expected: &btcjson.GObjectCheckResult{ObjectStatus: "OK"},
+		},
+		{
+			name: "getzmqnotifications",
+			data: `[{"type":"pubhashblock","address":"tcp://127.0.0.1:28332","hwm":1000},` +
+				`{"type":"pubrawchainlock","address":"tcp://127.0.0.1:28332","hwm":1000}]`,
+			result: new([]btcjson.ZMQNotification),
+			expected: &[]btcjson.ZMQNotification{
+				{Type: "pubhashblock", Address: "tcp://127.0.0.1:28332", HWM: 1000},
+				{Type: "pubrawchainlock", Address: "tcp://127.0.0.1:28332", HWM: 1000},
+			},
+		},
+		{
+			name:   "masternode count",
+			data:   `{"total":4813,"enabled":4755}`,
+			result: new(btcjson.MasternodeCountResult),
+			expected: &btcjson.MasternodeCountResult{
+				Total:   4813,
+				Enabled: 4755,
+			},
+		},
+		{
+			name:   "masternode count with qualify",
+			data:   `{"total":4813,"stable":4800,"enabled":4755,"qualify":4500}`,
+			result: new(btcjson.MasternodeCountResult),
+			expected: &btcjson.MasternodeCountResult{
+				Total:   4813,
+				Enabled: 4755,
+				Qualify: 4500,
+			},
+		},
+		{
+			name:     "masternode count bare integer",
+			data:     `4813`,
+			result:   new(btcjson.MasternodeCountResult),
+			expected: &btcjson.MasternodeCountResult{Total: 4813},
+		},
+		{
+			name: "masternode payments",
+			data: `[{"height":1030021,"blockhash":"000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1",` +
+				`"amount":167146500,"masternodes":[{"proTxHash":"f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",` +
+				`"amount":167146500,"payees":[{"address":"XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2",` +
+				`"script":"76a914c9e2a6f1e3ec2a4b1f1c8d5b3a6f8e2d0e6a4b1c88ac","amount":167146500}]}]}]`,
+			result: new([]btcjson.MasternodePaymentsResult),
+			expected: &[]btcjson.MasternodePaymentsResult{
+				{
+					Height:    1030021,
+					BlockHash: "000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1",
+					Amount:    167146500,
+					Masternodes: []btcjson.MasternodePayment{
+						{
+							ProTxHash: "f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",
+							Amount:    167146500,
+							Payees: []btcjson.MasternodePayee{
+								{
+									Address: "XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2",
+									Script:  "76a914c9e2a6f1e3ec2a4b1f1c8d5b3a6f8e2d0e6a4b1c88ac",
+									Amount:  167146500,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "masternodelist json",
+			data: `{"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1":{` +
+				`"proTxHash":"f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",` +
+				`"address":"45.32.237.76:9999","payee":"XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2",` +
+				`"status":"ENABLED","pospenaltyscore":0,"lastpaidtime":1540857512,` +
+				`"lastpaidblock":963294,"owneraddress":"XjG5Fi1cGvCYniXYDK8dDjiv8EV1dYWPcZ",` +
+				`"votingaddress":"XjG5Fi1cGvCYniXYDK8dDjiv8EV1dYWPcZ",` +
+				`"collateraladdress":"XwD4kv7nZFJnEQd4Mi6ZdVxGKk4gJwYTjd",` +
+				`"pubkeyoperator":"8700add2f9b8f4e7ba8726c7d4d58fbb88b6c4ad89c2ec0d9ace9a386e2c3bc00c2d2f69554b2b2a45e80eed9b49e2a1"}}`,
+			result: new(map[string]btcjson.MasternodeListEntry),
+			expected: &map[string]btcjson.MasternodeListEntry{
+				"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1": {
+					ProTxHash:         "f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",
+					Address:           "45.32.237.76:9999",
+					Payee:             "XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2",
+					Status:            "ENABLED",
+					LastPaidTime:      1540857512,
+					LastPaidBlock:     963294,
+					OwnerAddress:      "XjG5Fi1cGvCYniXYDK8dDjiv8EV1dYWPcZ",
+					VotingAddress:     "XjG5Fi1cGvCYniXYDK8dDjiv8EV1dYWPcZ",
+					CollateralAddress: "XwD4kv7nZFJnEQd4Mi6ZdVxGKk4gJwYTjd",
+					PubKeyOperator:    "8700add2f9b8f4e7ba8726c7d4d58fbb88b6c4ad89c2ec0d9ace9a386e2c3bc00c2d2f69554b2b2a45e80eed9b49e2a1",
+				},
+			},
+		},
+		{
+			name:   "masternodelist addr",
+			data:   `{"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1":"45.32.237.76:9999"}`,
+			result: new(map[string]btcjson.MasternodeListEntry),
+			expected: &map[string]btcjson.MasternodeListEntry{
+				"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1": {
+					Info: "45.32.237.76:9999",
+				},
+			},
+		},
+		{
+			name: "mnsync status",
+			data: `{"AssetID":999,"AssetName":"MASTERNODE_SYNC_FINISHED","AssetStartTime":1543622400,` +
+				`"Attempt":0,"IsBlockchainSynced":true,"IsSynced":true,"IsFailed":false}`,
+			result: new(btcjson.MnSyncStatusResult),
+			expected: &btcjson.MnSyncStatusResult{
+				AssetID:            999,
+				AssetName:          "MASTERNODE_SYNC_FINISHED",
+				AssetStartTime:     1543622400,
+				IsBlockchainSynced: true,
+				IsSynced:           true,
+			},
+		},
+		{
+			name: "protx info",
+			data: `{"proTxHash":"f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",` +
+				`"collateralHash":"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1",` +
+				`"collateralIndex":1,"collateralAddress":"XwD4kv7nZFJnEQd4Mi6ZdVxGKk4gJwYTjd",` +
+				`"operatorReward":2.5,"state":{"service":"45.32.237.76:9999","registeredHeight":1028160,` +
+				`"lastPaidHeight":1030020,"PoSePenalty":0,"PoSeRevivedHeight":-1,"PoSeBanHeight":-1,` +
+				`"revocationReason":0,"ownerAddress":"XjG5Fi1cGvCYniXYDK8dDjiv8EV1dYWPcZ",` +
+				`"votingAddress":"XjG5Fi1cGvCYniXYDK8dDjiv8EV1dYWPcZ",` +
+				`"payoutAddress":"XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2","pubKeyOperator":"8700add2"},` +
+				`"confirmations":2012}`,
+			result: new(btcjson.ProTxInfoResult),
+			expected: &btcjson.ProTxInfoResult{
+				ProTxHash:         "f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",
+				CollateralHash:    "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1",
+				CollateralIndex:   1,
+				CollateralAddress: "XwD4kv7nZFJnEQd4Mi6ZdVxGKk4gJwYTjd",
+				OperatorReward:    2.5,
+				State: btcjson.ProTxState{
+					Service:           "45.32.237.76:9999",
+					RegisteredHeight:  1028160,
+					LastPaidHeight:    1030020,
+					PoSeRevivedHeight: -1,
+					PoSeBanHeight:     -1,
+					OwnerAddress:      "XjG5Fi1cGvCYniXYDK8dDjiv8EV1dYWPcZ",
+					VotingAddress:     "XjG5Fi1cGvCYniXYDK8dDjiv8EV1dYWPcZ",
+					PayoutAddress:     "XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2",
+					PubKeyOperator:    "8700add2",
+				},
+				Confirmations: 2012,
+			},
+		},
+		{
+			name:   "protx list",
+			data:   `["f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97"]`,
+			result: new([]btcjson.ProTxInfoResult),
+			expected: &[]btcjson.ProTxInfoResult{
+				{ProTxHash: "f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97"},
+			},
+		},
+		{
+			name: "quorum info",
+			data: `{"height":1034472,"type":"llmq_50_60",` +
+				`"quorumHash":"000000000000001954e1ee8f6d1c7d4d9ab25ec0e3ea1446892106cd5a8e2bd2",` +
+				`"minedBlock":"0000000000000007af3f2a4f1cf1a1bd8d33d4b28d29ef872e1357a3cd4e8e85",` +
+				`"members":[{"proTxHash":"f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",` +
+				`"valid":true,"pubKeyShare":"0e8f"},{"proTxHash":"37c229bb32fd5bc7d1d898dec2633c5a6f40d0ae0a30686ef4bcb102b105441d",` +
+				`"valid":false}],"quorumPublicKey":"9a6b","secretKeyShare":"5c2e"}`,
+			result: new(btcjson.QuorumInfoResult),
+			expected: &btcjson.QuorumInfoResult{
+				Height:     1034472,
+				Type:       "llmq_50_60",
+				QuorumHash: "000000000000001954e1ee8f6d1c7d4d9ab25ec0e3ea1446892106cd5a8e2bd2",
+				MinedBlock: "0000000000000007af3f2a4f1cf1a1bd8d33d4b28d29ef872e1357a3cd4e8e85",
+				Members: []btcjson.QuorumMember{
+					{
+						ProTxHash:   "f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",
+						Valid:       true,
+						PubKeyShare: "0e8f",
+					},
+					{
+						ProTxHash: "37c229bb32fd5bc7d1d898dec2633c5a6f40d0ae0a30686ef4bcb102b105441d",
+					},
+				},
+				QuorumPublicKey: "9a6b",
+				SecretKeyShare:  "5c2e",
+			},
+		},
+		{
+			name:   "quorum list",
+			data:   `{"llmq_50_60":["000000000000001954e1ee8f6d1c7d4d9ab25ec0e3ea1446892106cd5a8e2bd2"],"llmq_400_60":[]}`,
+			result: new(map[string][]string),
+			expected: &map[string][]string{
+				"llmq_50_60":  {"000000000000001954e1ee8f6d1c7d4d9ab25ec0e3ea1446892106cd5a8e2bd2"},
+				"llmq_400_60": {},
+			},
+		},
+		{
+			name:   "getaddressbalance",
+			data:   `{"balance":1500000000,"balance_immature":0,"balance_spendable":1500000000,"received":2500000000}`,
+			result: new(btcjson.GetAddressBalanceResult),
+			expected: &btcjson.GetAddressBalanceResult{
+				Balance:          1500000000,
+				BalanceSpendable: 1500000000,
+				Received:         2500000000,
+			},
+		},
+		{
+			name: "getaddressutxos",
+			data: `[{"address":"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc",` +
+				`"txid":"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1",` +
+				`"outputIndex":1,"script":"76a914d1c1c7e9a34aa5e6ae4a45e8e3d2ec1a51d6a3c588ac",` +
+				`"satoshis":1500000000,"height":1028160}]`,
+			result: new([]btcjson.AddressUTXO),
+			expected: &[]btcjson.AddressUTXO{
+				{
+					Address:     "XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc",
+					TxID:        "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1",
+					OutputIndex: 1,
+					Script:      "76a914d1c1c7e9a34aa5e6ae4a45e8e3d2ec1a51d6a3c588ac",
+					Satoshis:    1500000000,
+					Height:      1028160,
+				},
+			},
+		},
+		{
+			name: "getaddressdeltas",
+			data: `[{"satoshis":-1000000000,` +
+				`"txid":"f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",` +
+				`"index":0,"blockindex":3,"height":1028170,"address":"XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc"}]`,
+			result: new([]btcjson.AddressDelta),
+			expected: &[]btcjson.AddressDelta{
+				{
+					Address:    "XtWqh1Q8nn7cYWBvsbbJ2hn5q7qvJ3tJbc",
+					TxID:       "f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",
+					Index:      0,
+					BlockIndex: 3,
+					Satoshis:   -1000000000,
+					Height:     1028170,
+				},
+			},
+		},
+		{
+			name: "quorum memberof",
+			data: `[{"height":1028160,"type":"llmq_50_60",` +
+				`"quorumHash":"000000000000001954e1ee8f6d1c7d4d9ab25ec0e3ea1446892106cd5a8e2bd2",` +
+				`"minedBlock":"00000000000000145f1b4e7d3b2e6e3b1e8cd3e1d1c6d0d1b8df6a6e8f0a0c4d",` +
+				`"quorumPublicKey":"9a6b","isValidMember":true,"memberIndex":17}]`,
+			result: new([]btcjson.QuorumMemberOfResult),
+			expected: &[]btcjson.QuorumMemberOfResult{
+				{
+					Height:          1028160,
+					Type:            "llmq_50_60",
+					QuorumHash:      "000000000000001954e1ee8f6d1c7d4d9ab25ec0e3ea1446892106cd5a8e2bd2",
+					MinedBlock:      "00000000000000145f1b4e7d3b2e6e3b1e8cd3e1d1c6d0d1b8df6a6e8f0a0c4d",
+					QuorumPublicKey: "9a6b",
+					IsValidMember:   true,
+					MemberIndex:     17,
+				},
+			},
+		},
+		{
+			name: "quorum sign",
+			data: `{"llmqType":100,"quorumHash":"53d959f609a654cf4e5e3c083fd6c3b9fa6e07b7d0f3ed8f2bde6a7ef03cf2a5",` +
+				`"quorumMember":2,"id":"0000000000000000000000000000000000000000000000000000000000000001",` +
+				`"msgHash":"0000000000000000000000000000000000000000000000000000000000000002",` +
+				`"signHash":"3bd7e6f3ae6ab0b5ea0d6b52d7d0a5bc1f2a3ec5e8c1db3e3e1bd2ed48f3fd6f",` +
+				`"signature":"97b2c6a4"}`,
+			result: new(btcjson.QuorumSignResult),
+			expected: &btcjson.QuorumSignResult{
+				LLMQType:     100,
+				QuorumHash:   "53d959f609a654cf4e5e3c083fd6c3b9fa6e07b7d0f3ed8f2bde6a7ef03cf2a5",
+				QuorumMember: 2,
+				ID:           "0000000000000000000000000000000000000000000000000000000000000001",
+				MsgHash:      "0000000000000000000000000000000000000000000000000000000000000002",
+				SignHash:     "3bd7e6f3ae6ab0b5ea0d6b52d7d0a5bc1f2a3ec5e8c1db3e3e1bd2ed48f3fd6f",
+				Signature:    "97b2c6a4",
+			},
+		},
+		{
+			name:     "quorum sign submitted",
+			data:     `true`,
+			result:   new(btcjson.QuorumSignResult),
+			expected: &btcjson.QuorumSignResult{Submitted: true},
+		},
+		{
+			name: "getcoinjoininfo",
+			data: `{"enabled":true,"multisession":false,"max_sessions":4,"max_rounds":4,` +
+				`"max_amount":1000,"denoms_goal":50,"denoms_hardcap":300,"queue_size":1,` +
+				`"running":true,"sessions":[{"protxhash":"f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",` +
+				`"outpoint":"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1",` +
+				`"service":"45.32.237.76:9999","denomination":0.10000100,` +
+				`"state":"POOL_STATE_QUEUE","entries_count":0}],"keys_left":826,"warnings":""}`,
+			result: new(btcjson.GetCoinJoinInfoResult),
+			expected: &btcjson.GetCoinJoinInfoResult{
+				Enabled:       true,
+				MaxSessions:   4,
+				MaxRounds:     4,
+				MaxAmount:     1000,
+				DenomsCount:   50,
+				DenomsHardCap: 300,
+				QueueSize:     1,
+				Running:       true,
+				Sessions: []btcjson.CoinJoinSession{
+					{
+						ProTxHash:    "f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",
+						Outpoint:     "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1",
+						Service:      "45.32.237.76:9999",
+						Denomination: 0.100001,
+						State:        "POOL_STATE_QUEUE",
+					},
+				},
+				KeysLeft: 826,
+			},
+		},
+		{
+			name: "getrawtransaction verbose proregtx",
+			data: `{"hex":"0300010001","txid":"f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",` +
+				`"size":342,"version":3,"type":1,"locktime":0,"vin":[],"vout":[],"extraPayloadSize":214,` +
+				`"extraPayload":"0100","proRegTx":{"version":1,` +
+				`"collateralHash":"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1",` +
+				`"collateralIndex":1,"service":"45.32.237.76:9999","ownerAddress":"XrnS1Y6bR1xCRSVJkXJ4J4N3iCvAxNVYzh",` +
+				`"votingAddress":"XrnS1Y6bR1xCRSVJkXJ4J4N3iCvAxNVYzh","payoutAddress":"XgTS8WonvgEbnSzuzmZnsfcKUYy8oqro9s",` +
+				`"pubKeyOperator":"8f3a4e","operatorReward":0.00,"inputsHash":"6e0f4c"}}`,
+			result: new(btcjson.TxRawResult),
+			expected: &btcjson.TxRawResult{
+				Hex:              "0300010001",
+				Txid:             "f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",
+				Size:             342,
+				Version:          3,
+				Vin:              []btcjson.Vin{},
+				Vout:             []btcjson.Vout{},
+				Type:             1,
+				ExtraPayloadSize: 214,
+				ExtraPayload:     "0100",
+				ProRegTx: &btcjson.ProRegTxPayload{
+					Version:         1,
+					CollateralHash:  "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1",
+					CollateralIndex: 1,
+					Service:         "45.32.237.76:9999",
+					OwnerAddress:    "XrnS1Y6bR1xCRSVJkXJ4J4N3iCvAxNVYzh",
+					VotingAddress:   "XrnS1Y6bR1xCRSVJkXJ4J4N3iCvAxNVYzh",
+					PayoutAddress:   "XgTS8WonvgEbnSzuzmZnsfcKUYy8oqro9s",
+					PubKeyOperator:  "8f3a4e",
+					InputsHash:      "6e0f4c",
+				},
+			},
+		},
+		{
+			name: "getrawtransaction verbose cbtx",
+			data: `{"hex":"0300050001","txid":"5a2e5a7b2c4d1f9e","version":3,"type":5,"locktime":0,` +
+				`"vin":[],"vout":[],"extraPayloadSize":70,"extraPayload":"0200","cbTx":{"version":2,` +
+				`"height":1000000,"merkleRootMNList":"9a1b","merkleRootQuorums":"3c4d"}}`,
+			result: new(btcjson.TxRawResult),
+			expected: &btcjson.TxRawResult{
+				Hex:              "0300050001",
+				Txid:             "5a2e5a7b2c4d1f9e",
+				Version:          3,
+				Vin:              []btcjson.Vin{},
+				Vout:             []btcjson.Vout{},
+				Type:             5,
+				ExtraPayloadSize: 70,
+				ExtraPayload:     "0200",
+				CbTx: &btcjson.CbTxPayload{
+					Version:           2,
+					Height:            1000000,
+					MerkleRootMNList:  "9a1b",
+					MerkleRootQuorums: "3c4d",
+				},
+			},
+		},
+		{
+			name: "getblock verbose chainlocked",
+			data: `{"hash":"000000000000001d9b8a","confirmations":2,"size":1234,"height":1000000,` +
+				`"version":536870912,"versionHex":"20000000","merkleroot":"be1a3eb8",` +
+				`"tx":["5a2e5a7b2c4d1f9e","f49ff4a1e81aeb8e"],"time":1546300800,` +
+				`"bits":"1956b6b5","difficulty":49027351.26,"previousblockhash":"0000000000000030a5e4",` +
+				`"cbTx":{"version":2,"height":1000000,"merkleRootMNList":"9a1b","merkleRootQuorums":"3c4d"},` +
+				`"chainlock":true}`,
+			result: new(btcjson.GetBlockVerboseResult),
+			expected: &btcjson.GetBlockVerboseResult{
+				Hash:          "000000000000001d9b8a",
+				Confirmations: 2,
+				Size:          1234,
+				Height:        1000000,
+				Version:       536870912,
+				VersionHex:    "20000000",
+				MerkleRoot:    "be1a3eb8",
+				Tx:            []string{"5a2e5a7b2c4d1f9e", "f49ff4a1e81aeb8e"},
+				Time:          1546300800,
+				Bits:          "1956b6b5",
+				Difficulty:    49027351.26,
+				PreviousHash:  "0000000000000030a5e4",
+				CbTx: &btcjson.CbTxPayload{
+					Version:           2,
+					Height:            1000000,
+					MerkleRootMNList:  "9a1b",
+					MerkleRootQuorums: "3c4d",
+				},
+				ChainLock: true,
+			},
+		},
+		{
+			name: "getblock verbosity 2 with instantlocked tx",
+			data: `{"hash":"000000000000001d9b8a","confirmations":-1,"height":1000000,` +
+				`"tx":[{"txid":"f49ff4a1e81aeb8e","version":2,"locktime":0,"vin":[],"vout":[],` +
+				`"instantlock":true,"instantlock_internal":true}],"chainlock":false}`,
+			result: new(btcjson.GetBlockVerboseResult),
+			expected: &btcjson.GetBlockVerboseResult{
+				Hash:          "000000000000001d9b8a",
+				Confirmations: -1,
+				Height:        1000000,
+				RawTx: []btcjson.TxRawResult{
+					{
+						Txid:                "f49ff4a1e81aeb8e",
+						Version:             2,
+						Vin:                 []btcjson.Vin{},
+						Vout:                []btcjson.Vout{},
+						InstantLock:         true,
+						InstantLockInternal: true,
+					},
+				},
+			},
+		},
+		{
+			name:   "estimatesmartfee",
+			data:   `{"feerate":0.00001,"blocks":2}`,
+			result: new(btcjson.EstimateSmartFeeResult),
+			expected: &btcjson.EstimateSmartFeeResult{
+				FeeRate: btcjson.Float64(0.00001),
+				Blocks:  2,
+			},
+		},
+		{
+			name:   "estimatesmartfee without estimate",
+			data:   `{"errors":["Insufficient data or no feerate found"],"blocks":0}`,
+			result: new(btcjson.EstimateSmartFeeResult),
+			expected: &btcjson.EstimateSmartFeeResult{
+				Errors: []string{"Insufficient data or no feerate found"},
+			},
+		},
+		{
+			name: "getblockchaininfo with bip9_softforks",
+			data: `{"chain":"main","blocks":1028160,"headers":1028160,` +
+				`"bestblockhash":"000000000000001d9b8a0a1a5d1ce4a4e0e1ba4d0f7d5ef5ba4ac5cbb2ed0b4e",` +
+				`"difficulty":49027351.26,"mediantime":1554112312,"verificationprogress":0.9999,` +
+				`"chainwork":"0000000000000000000000000000000000000000000009d2d4d9bd4dfc6fd9a2",` +
+				`"pruned":false,"softforks":[{"id":"bip34","version":2,"reject":{"status":true}}],` +
+				`"bip9_softforks":{"dip0003":{"status":"active","startTime":1546300800,` +
+				`"timeout":1577836800,"since":1028160},"dip0008":{"status":"started","bit":4,` +
+				`"startTime":1557878400,"timeout":1589500800,"since":1032192}}}`,
+			result: new(btcjson.GetBlockChainInfoResult),
+			expected: &btcjson.GetBlockChainInfoResult{
+				Chain:                "main",
+				Blocks:               1028160,
+				Headers:              1028160,
+				BestBlockHash:        "000000000000001d9b8a0a1a5d1ce4a4e0e1ba4d0f7d5ef5ba4ac5cbb2ed0b4e",
+				Difficulty:           49027351.26,
+				MedianTime:           1554112312,
+				VerificationProgress: 0.9999,
+				ChainWork:            "0000000000000000000000000000000000000000000009d2d4d9bd4dfc6fd9a2",
+				SoftForks: []*btcjson.SoftForkDescription{
+					{
+						ID:      "bip34",
+						Version: 2,
+						Reject: struct {
+							Status bool `json:"status"`
+						}{Status: true},
+					},
+				},
+				Bip9SoftForks: map[string]*btcjson.Bip9SoftForkDescription{
+					"dip0003": {
+						Status:    "active",
+						StartTime: 1546300800,
+						Timeout:   1577836800,
+						Since:     1028160,
+					},
+					"dip0008": {
+						Status:    "started",
+						Bit:       4,
+						StartTime: 1557878400,
+						Timeout:   1589500800,
+						Since:     1032192,
+					},
+				},
+			},
+		},
+		{
+			name: "getblockchaininfo with softforks object",
+			data: `{"chain":"main","blocks":1400000,"headers":1400000,` +
+				`"size_on_disk":25367849821,"pruned":false,"softforks":{` +
+				`"dip0003":{"type":"buried","active":true,"height":1028160},` +
+				`"dip0020":{"type":"buried","active":false,"height":1600000},` +
+				`"dip0008":{"type":"bip9","bip9":{"status":"active","start_time":1557878400,` +
+				`"timeout":1589500800,"since":1088640},"height":1088640,"active":true}}}`,
+			result: new(btcjson.GetBlockChainInfoResult),
+			expected: &btcjson.GetBlockChainInfoResult{
+				Chain:      "main",
+				Blocks:     1400000,
+				Headers:    1400000,
+				SizeOnDisk: 25367849821,
+				Bip9SoftForks: map[string]*btcjson.Bip9SoftForkDescription{
+					"dip0003": {
+						Status: "active",
+						Since:  1028160,
+					},
+					"dip0008": {
+						Status:    "active",
+						StartTime: 1557878400,
+						Timeout:   1589500800,
+						Since:     1088640,
+					},
+				},
+			},
+		},
+		{
+			name: "getblocktemplate with masternode and superblock payments",
+			data: `{"bits":"1956b6b5","curtime":1546300800,"height":1000000,` +
+				`"previousblockhash":"0000000000000030a5e4","transactions":[],"version":536870912,` +
+				`"coinbasevalue":167103775,"masternode":[{"payee":"XgTS8WonvgEbnSzuzmZnsfcKUYy8oqro9s",` +
+				`"script":"76a914ec9b","amount":83551887}],"masternode_payments_started":true,` +
+				`"masternode_payments_enforced":true,"superblock":[{"payee":"XrnS1Y6bR1xCRSVJkXJ4J4N3iCvAxNVYzh",` +
+				`"script":"76a9143c4d","amount":100000000000}],"superblocks_started":true,` +
+				`"superblocks_enabled":true,"coinbase_payload":"020040420f00"}`,
+			result: new(btcjson.GetBlockTemplateResult),
+			expected: &btcjson.GetBlockTemplateResult{
+				Bits:          "1956b6b5",
+				CurTime:       1546300800,
+				Height:        1000000,
+				PreviousHash:  "0000000000000030a5e4",
+				Transactions:  []btcjson.GetBlockTemplateResultTx{},
+				Version:       536870912,
+				CoinbaseValue: btcjson.Int64(167103775),
+				Masternode: []btcjson.GetBlockTemplateResultPayee{
+					{
+						Payee:  "XgTS8WonvgEbnSzuzmZnsfcKUYy8oqro9s",
+						Script: "76a914ec9b",
+						Amount: 83551887,
+					},
+				},
+				MasternodePaymentsStarted:  true,
+				MasternodePaymentsEnforced: true,
+				Superblock: []btcjson.GetBlockTemplateResultPayee{
+					{
+						Payee:  "XrnS1Y6bR1xCRSVJkXJ4J4N3iCvAxNVYzh",
+						Script: "76a9143c4d",
+						Amount: 100000000000,
+					},
+				},
+				SuperblocksStarted: true,
+				SuperblocksEnabled: true,
+				CoinbasePayload:    "020040420f00",
+			},
+		},
+		{
+			name: "gobject vote-many",
+			data: `{"overall":"Voted successfully 1 time(s) and failed 1 time(s).",` +
+				`"detail":{"f49ff4a1":{"result":"success"},"8b2a3382":{"result":"failed",` +
+				`"errorMessage":"Failure to find masternode in list"}}}`,
+			result: new(btcjson.GObjectVoteResult),
+			expected: &btcjson.GObjectVoteResult{
+				Overall: "Voted successfully 1 time(s) and failed 1 time(s).",
+				Detail: map[string]btcjson.GObjectVoteDetail{
+					"f49ff4a1": {Result: "success"},
+					"8b2a3382": {
+						Result:       "failed",
+						ErrorMessage: "Failure to find masternode in list",
+					},
+				},
+			},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		if err := json.Unmarshal([]byte(test.data), test.result); err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.result, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected unmarshalled result "+
+				"- got %+v, want %+v", i, test.name, test.result,
+				test.expected)
+			continue
+		}
+	}
+}
+
+// TestDecodeGovernanceData ensures the hex-encoded data of governance objects
+// decodes as expected for both the current and the legacy formats.
+func TestDecodeGovernanceData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dataHex  string
+		expected map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:    "proposal",
+			dataHex: "7b22656e645f65706f6368223a313534363330303830302c226e616d65223a22746573742d70726f706f73616c222c227061796d656e745f61646472657373223a22586a6261475761476e764574755141556f426744784a5765385a4e76343575704732222c227061796d656e745f616d6f756e74223a31302c2273746172745f65706f6368223a313534333632323430302c2274797065223a312c2275726c223a2268747470733a2f2f7777772e6461736863656e7472616c2e6f72672f702f746573742d70726f706f73616c227d",
+			expected: map[string]interface{}{
+				"end_epoch":       float64(1546300800),
+				"name":            "test-proposal",
+				"payment_address": "XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2",
+				"payment_amount":  float64(10),
+				"start_epoch":     float64(1543622400),
+				"type":            float64(1),
+				"url":             "https://www.dashcentral.org/p/test-proposal",
+			},
+		},
+		{
+			name:    "legacy proposal",
+			dataHex: "5b5b2270726f706f73616c222c7b226e616d65223a226f6c64222c2274797065223a317d5d5d",
+			expected: map[string]interface{}{
+				"name": "old",
+				"type": float64(1),
+			},
+		},
+		{
+			name:    "invalid hex",
+			dataHex: "7b7",
+			wantErr: true,
+		},
+		{
+			name:    "not an object",
+			dataHex: "31",
+			wantErr: true,
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		object, err := btcjson.DecodeGovernanceData(test.dataHex)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Test #%d (%s) expected error", i, test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(object, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected object - got %v, "+
+				"want %v", i, test.name, object, test.expected)
+			continue
+		}
+	}
+}
+
+// TestDecodeGovernanceProposal ensures the hex-encoded data of proposals
+// decodes into the typed proposal as expected.
+func TestDecodeGovernanceProposal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dataHex  string
+		expected *btcjson.GovernanceProposal
+		wantErr  bool
+	}{
+		{
+			name:    "proposal",
+			dataHex: "7b22656e645f65706f6368223a313534363330303830302c226e616d65223a22746573742d70726f706f73616c222c227061796d656e745f61646472657373223a22586a6261475761476e764574755141556f426744784a5765385a4e76343575704732222c227061796d656e745f616d6f756e74223a31302c2273746172745f65706f6368223a313534333632323430302c2274797065223a312c2275726c223a2268747470733a2f2f7777772e6461736863656e7472616c2e6f72672f702f746573742d70726f706f73616c227d",
+			expected: &btcjson.GovernanceProposal{
+				Type:           1,
+				Name:           "test-proposal",
+				StartEpoch:     1543622400,
+				EndEpoch:       1546300800,
+				PaymentAddress: "XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2",
+				PaymentAmount:  10,
+				URL:            "https://www.dashcentral.org/p/test-proposal",
+			},
+		},
+		{
+			name:    "legacy proposal",
+			dataHex: "5b5b2270726f706f73616c222c7b226e616d65223a226f6c64222c2274797065223a317d5d5d",
+			expected: &btcjson.GovernanceProposal{
+				Type: 1,
+				Name: "old",
+			},
+		},
+		{
+			name:    "mistyped field",
+			dataHex: "7b226e616d65223a317d",
+			wantErr: true,
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		proposal, err := btcjson.DecodeGovernanceProposal(test.dataHex)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Test #%d (%s) expected error", i, test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(proposal, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected proposal - got %+v, "+
+				"want %+v", i, test.name, proposal, test.expected)
+			continue
+		}
+	}
+}
+
+// TestParseGovernanceVote ensures the votes reported by the gobject getvotes
+// and gobject getcurrentvotes commands are parsed as expected.
+func TestParseGovernanceVote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		vote     string
+		expected *btcjson.GovernanceVote
+		wantErr  bool
+	}{
+		{
+			name: "funding vote",
+			vote: "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1:1543622400:yes:funding",
+			expected: &btcjson.GovernanceVote{
+				Hash:               "5b8f",
+				MasternodeOutpoint: "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1",
+				Time:               1543622400,
+				Outcome:            "yes",
+				Signal:             "funding",
+			},
+		},
+		{
+			name:    "missing signal",
+			vote:    "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1:1543622400:yes",
+			wantErr: true,
+		},
+		{
+			name:    "invalid time",
+			vote:    "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1:later:yes:funding",
+			wantErr: true,
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		vote, err := btcjson.ParseGovernanceVote("5b8f", test.vote)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Test #%d (%s) expected error", i, test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(vote, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected vote - got %+v, "+
+				"want %+v", i, test.name, vote, test.expected)
+			continue
+		}
+	}
+}
+
+// TestZMQNotificationTopic ensures the topics of the ZMQ notifications are
+// derived from their types as expected.
+func TestZMQNotificationTopic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		notificationType string
+		topic            string
+	}{
+		{"pubhashblock", "hashblock"},
+		{"pubrawchainlock", "rawchainlock"},
+		{"pubrawtxlocksig", "rawtxlocksig"},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		notification := btcjson.ZMQNotification{Type: test.notificationType}
+		if topic := notification.Topic(); topic != test.topic {
+			t.Errorf("Test #%d (%s) unexpected topic - got %s, want %s",
+				i, test.notificationType, topic, test.topic)
+		}
+	}
+}
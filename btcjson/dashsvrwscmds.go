@@ -0,0 +1,56 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// NOTE: This file is intended to house the Dash specific RPC commands that are
+// supported by a chain server, but are only available via websockets.
+
+package btcjson
+
+// NotifyChainLocksCmd defines the notifychainlocks JSON-RPC command.
+type NotifyChainLocksCmd struct{}
+
+// NewNotifyChainLocksCmd returns a new instance which can be used to issue a
+// notifychainlocks JSON-RPC command.
+func NewNotifyChainLocksCmd() *NotifyChainLocksCmd {
+	return &NotifyChainLocksCmd{}
+}
+
+// StopNotifyChainLocksCmd defines the stopnotifychainlocks JSON-RPC command.
+type StopNotifyChainLocksCmd struct{}
+
+// NewStopNotifyChainLocksCmd returns a new instance which can be used to issue
+// a stopnotifychainlocks JSON-RPC command.
+func NewStopNotifyChainLocksCmd() *StopNotifyChainLocksCmd {
+	return &StopNotifyChainLocksCmd{}
+}
+
+// NotifyInstantSendLocksCmd defines the notifyinstantsendlocks JSON-RPC
+// command.
+type NotifyInstantSendLocksCmd struct{}
+
+// NewNotifyInstantSendLocksCmd returns a new instance which can be used to
+// issue a notifyinstantsendlocks JSON-RPC command.
+func NewNotifyInstantSendLocksCmd() *NotifyInstantSendLocksCmd {
+	return &NotifyInstantSendLocksCmd{}
+}
+
+// StopNotifyInstantSendLocksCmd defines the stopnotifyinstantsendlocks
+// JSON-RPC command.
+type StopNotifyInstantSendLocksCmd struct{}
+
+// NewStopNotifyInstantSendLocksCmd returns a new instance which can be used to
+// issue a stopnotifyinstantsendlocks JSON-RPC command.
+func NewStopNotifyInstantSendLocksCmd() *StopNotifyInstantSendLocksCmd {
+	return &StopNotifyInstantSendLocksCmd{}
+}
+
+func init() {
+	// The commands in this file are only usable by websockets.
+	flags := UFWebsocketOnly
+
+	MustRegisterCmd("notifychainlocks", (*NotifyChainLocksCmd)(nil), flags)
+	MustRegisterCmd("notifyinstantsendlocks", (*NotifyInstantSendLocksCmd)(nil), flags)
+	MustRegisterCmd("stopnotifychainlocks", (*StopNotifyChainLocksCmd)(nil), flags)
+	MustRegisterCmd("stopnotifyinstantsendlocks", (*StopNotifyInstantSendLocksCmd)(nil), flags)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package btcjson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+)
+
+// TestDashSvrWsCmds tests all of the Dash chain server websocket-specific
+// commands marshal and unmarshal into valid results.
+func TestDashSvrWsCmds(t *testing.T) {
+	t.Parallel()
+
+	testID := int(1)
+	tests := []struct {
+		name         string
+		newCmd       func() (interface{}, error)
+		staticCmd    func() interface{}
+		marshalled   string
+		unmarshalled interface{}
+	}{
+		{
+			name: "notifychainlocks",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifychainlocks")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyChainLocksCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"notifychainlocks","params":[],"id":1}`,
+			unmarshalled: &btcjson.NotifyChainLocksCmd{},
+		},
+		{
+			name: "stopnotifychainlocks",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("stopnotifychainlocks")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewStopNotifyChainLocksCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifychainlocks","params":[],"id":1}`,
+			unmarshalled: &btcjson.StopNotifyChainLocksCmd{},
+		},
+		{
+			name: "notifyinstantsendlocks",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifyinstantsendlocks")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyInstantSendLocksCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"notifyinstantsendlocks","params":[],"id":1}`,
+			unmarshalled: &btcjson.NotifyInstantSendLocksCmd{},
+		},
+		{
+			name: "stopnotifyinstantsendlocks",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("stopnotifyinstantsendlocks")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewStopNotifyInstantSendLocksCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifyinstantsendlocks","params":[],"id":1}`,
+			unmarshalled: &btcjson.StopNotifyInstantSendLocksCmd{},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Marshal the command as created by the new static command
+		// creation function.
+		marshalled, err := btcjson.MarshalCmd(testID, test.staticCmd())
+		if err != nil {
+			t.Errorf("MarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !bytes.Equal(marshalled, []byte(test.marshalled)) {
+			t.Errorf("Test #%d (%s) unexpected marshalled data - "+
+				"got %s, want %s", i, test.name, marshalled,
+				test.marshalled)
+			continue
+		}
+
+		// Ensure the command is created without error via the generic
+		// new command creation function.
+		cmd, err := test.newCmd()
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected NewCmd error: %v ",
+				i, test.name, err)
+		}
+
+		// Marshal the command as created by the generic new command
+		// creation function.
+		marshalled, err = btcjson.MarshalCmd(testID, cmd)
+		if err != nil {
+			t.Errorf("MarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !bytes.Equal(marshalled, []byte(test.marshalled)) {
+			t.Errorf("Test #%d (%s) unexpected marshalled data - "+
+				"got %s, want %s", i, test.name, marshalled,
+				test.marshalled)
+			continue
+		}
+
+		var request btcjson.Request
+		if err := json.Unmarshal(marshalled, &request); err != nil {
+			t.Errorf("Test #%d (%s) unexpected error while "+
+				"unmarshalling JSON-RPC request: %v", i,
+				test.name, err)
+			continue
+		}
+
+		cmd, err = btcjson.UnmarshalCmd(&request)
+		if err != nil {
+			t.Errorf("UnmarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(cmd, test.unmarshalled) {
+			t.Errorf("Test #%d (%s) unexpected unmarshalled command "+
+				"- got %s, want %s", i, test.name,
+				fmt.Sprintf("(%T) %+[1]v", cmd),
+				fmt.Sprintf("(%T) %+[1]v\n", test.unmarshalled))
+			continue
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// NOTE: This file is intended to house the Dash specific RPC websocket
+// notifications that are supported by a chain server.
+
+package btcjson
+
+const (
+	// ChainLockNtfnMethod is the method used for notifications from the
+	// chain server that a block has been chainlocked.
+	ChainLockNtfnMethod = "chainlock"
+
+	// InstantSendLockNtfnMethod is the method used for notifications from
+	// the chain server that a transaction has been locked by InstantSend.
+	InstantSendLockNtfnMethod = "instantsendlock"
+)
+
+// ChainLockNtfn defines the chainlock JSON-RPC notification.
+type ChainLockNtfn struct {
+	BlockHash string
+	Height    int32
+}
+
+// NewChainLockNtfn returns a new instance which can be used to issue a
+// chainlock JSON-RPC notification.
+func NewChainLockNtfn(blockHash string, height int32) *ChainLockNtfn {
+	return &ChainLockNtfn{
+		BlockHash: blockHash,
+		Height:    height,
+	}
+}
+
+// InstantSendLockNtfn defines the instantsendlock JSON-RPC notification.  The
+// transaction is hex-encoded.
+type InstantSendLockNtfn struct {
+	HexTx string
+}
+
+// NewInstantSendLockNtfn returns a new instance which can be used to issue an
+// instantsendlock JSON-RPC notification.
+func NewInstantSendLockNtfn(hexTx string) *InstantSendLockNtfn {
+	return &InstantSendLockNtfn{HexTx: hexTx}
+}
+
+func init() {
+	// The commands in this file are only usable by websockets and are
+	// notifications.
+	flags := UFWebsocketOnly | UFNotification
+
+	MustRegisterCmd(ChainLockNtfnMethod, (*ChainLockNtfn)(nil), flags)
+	MustRegisterCmd(InstantSendLockNtfnMethod, (*InstantSendLockNtfn)(nil), flags)
+}
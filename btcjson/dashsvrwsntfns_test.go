@@ -0,0 +1,124 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package btcjson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+)
+
+// TestDashSvrWsNtfns tests all of the Dash chain server websocket-specific
+// notifications marshal and unmarshal into valid results.
+func TestDashSvrWsNtfns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		newNtfn      func() (interface{}, error)
+		staticNtfn   func() interface{}
+		marshalled   string
+		unmarshalled interface{}
+	}{
+		{
+			name: "chainlock",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("chainlock", "123", 100000)
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewChainLockNtfn("123", 100000)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"chainlock","params":["123",100000],"id":null}`,
+			unmarshalled: &btcjson.ChainLockNtfn{
+				BlockHash: "123",
+				Height:    100000,
+			},
+		},
+		{
+			name: "instantsendlock",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("instantsendlock", "001122")
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewInstantSendLockNtfn("001122")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"instantsendlock","params":["001122"],"id":null}`,
+			unmarshalled: &btcjson.InstantSendLockNtfn{
+				HexTx: "001122",
+			},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Marshal the notification as created by the new static
+		// creation function.  The ID is nil for notifications.
+		marshalled, err := btcjson.MarshalCmd(nil, test.staticNtfn())
+		if err != nil {
+			t.Errorf("MarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !bytes.Equal(marshalled, []byte(test.marshalled)) {
+			t.Errorf("Test #%d (%s) unexpected marshalled data - "+
+				"got %s, want %s", i, test.name, marshalled,
+				test.marshalled)
+			continue
+		}
+
+		// Ensure the notification is created without error via the
+		// generic new notification creation function.
+		cmd, err := test.newNtfn()
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected NewCmd error: %v ",
+				i, test.name, err)
+		}
+
+		// Marshal the notification as created by the generic new
+		// notification creation function.    The ID is nil for
+		// notifications.
+		marshalled, err = btcjson.MarshalCmd(nil, cmd)
+		if err != nil {
+			t.Errorf("MarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !bytes.Equal(marshalled, []byte(test.marshalled)) {
+			t.Errorf("Test #%d (%s) unexpected marshalled data - "+
+				"got %s, want %s", i, test.name, marshalled,
+				test.marshalled)
+			continue
+		}
+
+		var request btcjson.Request
+		if err := json.Unmarshal(marshalled, &request); err != nil {
+			t.Errorf("Test #%d (%s) unexpected error while "+
+				"unmarshalling JSON-RPC request: %v", i,
+				test.name, err)
+			continue
+		}
+
+		cmd, err = btcjson.UnmarshalCmd(&request)
+		if err != nil {
+			t.Errorf("UnmarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(cmd, test.unmarshalled) {
+			t.Errorf("Test #%d (%s) unexpected unmarshalled command "+
+				"- got %s, want %s", i, test.name,
+				fmt.Sprintf("(%T) %+[1]v", cmd),
+				fmt.Sprintf("(%T) %+[1]v\n", test.unmarshalled))
+			continue
+		}
+	}
+}
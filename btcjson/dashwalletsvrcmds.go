@@ -0,0 +1,178 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// NOTE: This file is intended to house the RPC commands that are supported by
+// a Dash Core wallet server.
+
+package btcjson
+
+// CoinJoinStartCmd defines the coinjoin start JSON-RPC command.
+type CoinJoinStartCmd struct{}
+
+// NewCoinJoinStartCmd returns a new instance which can be used to issue a
+// coinjoin start JSON-RPC command.
+func NewCoinJoinStartCmd() *CoinJoinStartCmd {
+	return &CoinJoinStartCmd{}
+}
+
+// CoinJoinStopCmd defines the coinjoin stop JSON-RPC command.
+type CoinJoinStopCmd struct{}
+
+// NewCoinJoinStopCmd returns a new instance which can be used to issue a
+// coinjoin stop JSON-RPC command.
+func NewCoinJoinStopCmd() *CoinJoinStopCmd {
+	return &CoinJoinStopCmd{}
+}
+
+// GetBalancesCmd defines the getbalances JSON-RPC command.
+type GetBalancesCmd struct{}
+
+// NewGetBalancesCmd returns a new instance which can be used to issue a
+// getbalances JSON-RPC command.
+func NewGetBalancesCmd() *GetBalancesCmd {
+	return &GetBalancesCmd{}
+}
+
+// GetCoinJoinInfoCmd defines the getcoinjoininfo JSON-RPC command.
+type GetCoinJoinInfoCmd struct{}
+
+// NewGetCoinJoinInfoCmd returns a new instance which can be used to issue a
+// getcoinjoininfo JSON-RPC command.
+func NewGetCoinJoinInfoCmd() *GetCoinJoinInfoCmd {
+	return &GetCoinJoinInfoCmd{}
+}
+
+// PrivateSendStartCmd defines the privatesend start JSON-RPC command.  It is
+// the name of the coinjoin start command used by older versions of dashd.
+type PrivateSendStartCmd struct{}
+
+// NewPrivateSendStartCmd returns a new instance which can be used to issue a
+// privatesend start JSON-RPC command.
+func NewPrivateSendStartCmd() *PrivateSendStartCmd {
+	return &PrivateSendStartCmd{}
+}
+
+// PrivateSendStopCmd defines the privatesend stop JSON-RPC command.  It is the
+// name of the coinjoin stop command used by older versions of dashd.
+type PrivateSendStopCmd struct{}
+
+// NewPrivateSendStopCmd returns a new instance which can be used to issue a
+// privatesend stop JSON-RPC command.
+func NewPrivateSendStopCmd() *PrivateSendStopCmd {
+	return &PrivateSendStopCmd{}
+}
+
+// GetPrivateSendInfoCmd defines the getprivatesendinfo JSON-RPC command.  It is
+// the name of the getcoinjoininfo command used by older versions of dashd.
+type GetPrivateSendInfoCmd struct{}
+
+// NewGetPrivateSendInfoCmd returns a new instance which can be used to issue a
+// getprivatesendinfo JSON-RPC command.
+func NewGetPrivateSendInfoCmd() *GetPrivateSendInfoCmd {
+	return &GetPrivateSendInfoCmd{}
+}
+
+// GObjectVoteManyCmd defines the gobject vote-many JSON-RPC command.
+type GObjectVoteManyCmd struct {
+	GovernanceHash string
+	Signal         string
+	Outcome        string
+}
+
+// NewGObjectVoteManyCmd returns a new instance which can be used to issue a
+// gobject vote-many JSON-RPC command.
+func NewGObjectVoteManyCmd(governanceHash, signal, outcome string) *GObjectVoteManyCmd {
+	return &GObjectVoteManyCmd{
+		GovernanceHash: governanceHash,
+		Signal:         signal,
+		Outcome:        outcome,
+	}
+}
+
+// GObjectVoteAliasCmd defines the gobject vote-alias JSON-RPC command.
+type GObjectVoteAliasCmd struct {
+	GovernanceHash string
+	Signal         string
+	Outcome        string
+	ProTxHash      string
+}
+
+// NewGObjectVoteAliasCmd returns a new instance which can be used to issue a
+// gobject vote-alias JSON-RPC command.
+func NewGObjectVoteAliasCmd(governanceHash, signal, outcome, proTxHash string) *GObjectVoteAliasCmd {
+	return &GObjectVoteAliasCmd{
+		GovernanceHash: governanceHash,
+		Signal:         signal,
+		Outcome:        outcome,
+		ProTxHash:      proTxHash,
+	}
+}
+
+// ProTxRegisterPrepareCmd defines the protx register_prepare JSON-RPC command.
+type ProTxRegisterPrepareCmd struct {
+	CollateralHash   string
+	CollateralIndex  uint32
+	IPAndPort        string
+	OwnerAddress     string
+	OperatorPubKey   string
+	VotingAddress    string
+	OperatorReward   float64
+	PayoutAddress    string
+	FeeSourceAddress *string
+}
+
+// NewProTxRegisterPrepareCmd returns a new instance which can be used to issue
+// a protx register_prepare JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewProTxRegisterPrepareCmd(collateralHash string, collateralIndex uint32,
+	ipAndPort, ownerAddress, operatorPubKey, votingAddress string,
+	operatorReward float64, payoutAddress string,
+	feeSourceAddress *string) *ProTxRegisterPrepareCmd {
+
+	return &ProTxRegisterPrepareCmd{
+		CollateralHash:   collateralHash,
+		CollateralIndex:  collateralIndex,
+		IPAndPort:        ipAndPort,
+		OwnerAddress:     ownerAddress,
+		OperatorPubKey:   operatorPubKey,
+		VotingAddress:    votingAddress,
+		OperatorReward:   operatorReward,
+		PayoutAddress:    payoutAddress,
+		FeeSourceAddress: feeSourceAddress,
+	}
+}
+
+// ProTxRegisterSubmitCmd defines the protx register_submit JSON-RPC command.
+type ProTxRegisterSubmitCmd struct {
+	Tx  string
+	Sig string
+}
+
+// NewProTxRegisterSubmitCmd returns a new instance which can be used to issue
+// a protx register_submit JSON-RPC command.
+func NewProTxRegisterSubmitCmd(tx, sig string) *ProTxRegisterSubmitCmd {
+	return &ProTxRegisterSubmitCmd{
+		Tx:  tx,
+		Sig: sig,
+	}
+}
+
+func init() {
+	// The commands in this file are only usable with a wallet server.
+	flags := UFWalletOnly
+
+	MustRegisterCmd("coinjoin start", (*CoinJoinStartCmd)(nil), flags)
+	MustRegisterCmd("coinjoin stop", (*CoinJoinStopCmd)(nil), flags)
+	MustRegisterCmd("getbalances", (*GetBalancesCmd)(nil), flags)
+	MustRegisterCmd("getcoinjoininfo", (*GetCoinJoinInfoCmd)(nil), flags)
+	MustRegisterCmd("getprivatesendinfo", (*GetPrivateSendInfoCmd)(nil), flags)
+	MustRegisterCmd("gobject vote-alias", (*GObjectVoteAliasCmd)(nil), flags)
+	MustRegisterCmd("gobject vote-many", (*GObjectVoteManyCmd)(nil), flags)
+	MustRegisterCmd("privatesend start", (*PrivateSendStartCmd)(nil), flags)
+	MustRegisterCmd("privatesend stop", (*PrivateSendStopCmd)(nil), flags)
+	MustRegisterCmd("protx register_prepare", (*ProTxRegisterPrepareCmd)(nil), flags)
+	MustRegisterCmd("protx register_submit", (*ProTxRegisterSubmitCmd)(nil), flags)
+}
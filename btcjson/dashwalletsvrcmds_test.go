@@ -0,0 +1,265 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package btcjson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+)
+
+// TestDashWalletSvrCmds tests all of the Dash wallet server commands marshal
+// and unmarshal into valid results include handling of optional fields being
+// omitted in the marshalled command, while optional fields with defaults have
+// the default assigned on unmarshalled commands.
+func TestDashWalletSvrCmds(t *testing.T) {
+	t.Parallel()
+
+	testID := int(1)
+	tests := []struct {
+		name         string
+		newCmd       func() (interface{}, error)
+		staticCmd    func() interface{}
+		marshalled   string
+		unmarshalled interface{}
+	}{
+		{
+			name: "coinjoin start",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("coinjoin", "start")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCoinJoinStartCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"coinjoin","params":["start"],"id":1}`,
+			unmarshalled: &btcjson.CoinJoinStartCmd{},
+		},
+		{
+			name: "coinjoin stop",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("coinjoin", "stop")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCoinJoinStopCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"coinjoin","params":["stop"],"id":1}`,
+			unmarshalled: &btcjson.CoinJoinStopCmd{},
+		},
+		{
+			name: "getbalances",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getbalances")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBalancesCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getbalances","params":[],"id":1}`,
+			unmarshalled: &btcjson.GetBalancesCmd{},
+		},
+		{
+			name: "getcoinjoininfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getcoinjoininfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetCoinJoinInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getcoinjoininfo","params":[],"id":1}`,
+			unmarshalled: &btcjson.GetCoinJoinInfoCmd{},
+		},
+		{
+			name: "getprivatesendinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getprivatesendinfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetPrivateSendInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getprivatesendinfo","params":[],"id":1}`,
+			unmarshalled: &btcjson.GetPrivateSendInfoCmd{},
+		},
+		{
+			name: "privatesend start",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("privatesend", "start")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewPrivateSendStartCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"privatesend","params":["start"],"id":1}`,
+			unmarshalled: &btcjson.PrivateSendStartCmd{},
+		},
+		{
+			name: "privatesend stop",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("privatesend", "stop")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewPrivateSendStopCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"privatesend","params":["stop"],"id":1}`,
+			unmarshalled: &btcjson.PrivateSendStopCmd{},
+		},
+		{
+			name: "gobject vote-many",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "vote-many", "abcd", "funding", "yes")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectVoteManyCmd("abcd", "funding", "yes")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["vote-many","abcd","funding","yes"],"id":1}`,
+			unmarshalled: &btcjson.GObjectVoteManyCmd{
+				GovernanceHash: "abcd",
+				Signal:         "funding",
+				Outcome:        "yes",
+			},
+		},
+		{
+			name: "gobject vote-alias",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gobject", "vote-alias", "abcd", "delete", "no", "ef01")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGObjectVoteAliasCmd("abcd", "delete", "no", "ef01")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gobject","params":["vote-alias","abcd","delete","no","ef01"],"id":1}`,
+			unmarshalled: &btcjson.GObjectVoteAliasCmd{
+				GovernanceHash: "abcd",
+				Signal:         "delete",
+				Outcome:        "no",
+				ProTxHash:      "ef01",
+			},
+		},
+		{
+			name: "protx register_prepare",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("protx", "register_prepare", "abcd", 1,
+					"1.2.3.4:9999", "Xowner", "8f3a", "Xvoting", 1.5, "Xpayout")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewProTxRegisterPrepareCmd("abcd", 1,
+					"1.2.3.4:9999", "Xowner", "8f3a", "Xvoting", 1.5, "Xpayout", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"protx","params":["register_prepare","abcd",1,` +
+				`"1.2.3.4:9999","Xowner","8f3a","Xvoting",1.5,"Xpayout"],"id":1}`,
+			unmarshalled: &btcjson.ProTxRegisterPrepareCmd{
+				CollateralHash:  "abcd",
+				CollateralIndex: 1,
+				IPAndPort:       "1.2.3.4:9999",
+				OwnerAddress:    "Xowner",
+				OperatorPubKey:  "8f3a",
+				VotingAddress:   "Xvoting",
+				OperatorReward:  1.5,
+				PayoutAddress:   "Xpayout",
+			},
+		},
+		{
+			name: "protx register_prepare optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("protx", "register_prepare", "abcd", 1,
+					"1.2.3.4:9999", "Xowner", "8f3a", "Xvoting", 0.0, "Xpayout", "Xfee")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewProTxRegisterPrepareCmd("abcd", 1,
+					"1.2.3.4:9999", "Xowner", "8f3a", "Xvoting", 0, "Xpayout",
+					btcjson.String("Xfee"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"protx","params":["register_prepare","abcd",1,` +
+				`"1.2.3.4:9999","Xowner","8f3a","Xvoting",0,"Xpayout","Xfee"],"id":1}`,
+			unmarshalled: &btcjson.ProTxRegisterPrepareCmd{
+				CollateralHash:   "abcd",
+				CollateralIndex:  1,
+				IPAndPort:        "1.2.3.4:9999",
+				OwnerAddress:     "Xowner",
+				OperatorPubKey:   "8f3a",
+				VotingAddress:    "Xvoting",
+				PayoutAddress:    "Xpayout",
+				FeeSourceAddress: btcjson.String("Xfee"),
+			},
+		},
+		{
+			name: "protx register_submit",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("protx", "register_submit", "0300", "H1f2")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewProTxRegisterSubmitCmd("0300", "H1f2")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"protx","params":["register_submit","0300","H1f2"],"id":1}`,
+			unmarshalled: &btcjson.ProTxRegisterSubmitCmd{Tx: "0300", Sig: "H1f2"},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Marshal the command as created by the new static command
+		// creation function.
+		marshalled, err := btcjson.MarshalCmd(testID, test.staticCmd())
+		if err != nil {
+			t.Errorf("MarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !bytes.Equal(marshalled, []byte(test.marshalled)) {
+			t.Errorf("Test #%d (%s) unexpected marshalled data - "+
+				"got %s, want %s", i, test.name, marshalled,
+				test.marshalled)
+			continue
+		}
+
+		// Ensure the command is created without error via the generic
+		// new command creation function.
+		cmd, err := test.newCmd()
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected NewCmd error: %v ",
+				i, test.name, err)
+		}
+
+		// Marshal the command as created by the generic new command
+		// creation function.
+		marshalled, err = btcjson.MarshalCmd(testID, cmd)
+		if err != nil {
+			t.Errorf("MarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !bytes.Equal(marshalled, []byte(test.marshalled)) {
+			t.Errorf("Test #%d (%s) unexpected marshalled data - "+
+				"got %s, want %s", i, test.name, marshalled,
+				test.marshalled)
+			continue
+		}
+
+		var request btcjson.Request
+		if err := json.Unmarshal(marshalled, &request); err != nil {
+			t.Errorf("Test #%d (%s) unexpected error while "+
+				"unmarshalling JSON-RPC request: %v", i,
+				test.name, err)
+			continue
+		}
+
+		cmd, err = btcjson.UnmarshalCmd(&request)
+		if err != nil {
+			t.Errorf("UnmarshalCmd #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(cmd, test.unmarshalled) {
+			t.Errorf("Test #%d (%s) unexpected unmarshalled command "+
+				"- got %s, want %s", i, test.name,
+				fmt.Sprintf("(%T) %+[1]v", cmd),
+				fmt.Sprintf("(%T) %+[1]v\n", test.unmarshalled))
+			continue
+		}
+	}
+}
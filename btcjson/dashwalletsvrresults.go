@@ -0,0 +1,61 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package btcjson
+
+// CoinJoinSession models a single mixing session of the data returned by the
+// getcoinjoininfo command.
+type CoinJoinSession struct {
+	ProTxHash    string  `json:"protxhash"`
+	Outpoint     string  `json:"outpoint"`
+	Service      string  `json:"service"`
+	Denomination float64 `json:"denomination"`
+	State        string  `json:"state"`
+	EntriesCount int32   `json:"entries_count"`
+}
+
+// GetCoinJoinInfoResult models the data from the getcoinjoininfo command, as
+// well as the getprivatesendinfo command of older servers.
+//
+// DenomsCount is the number of outputs of each denomination the wallet aims to
+// keep and DenomsHardCap the number it never exceeds.
+type GetCoinJoinInfoResult struct {
+	Enabled       bool              `json:"enabled"`
+	MultiSession  bool              `json:"multisession"`
+	MaxSessions   int32             `json:"max_sessions"`
+	MaxRounds     int32             `json:"max_rounds"`
+	MaxAmount     float64           `json:"max_amount"`
+	DenomsCount   int32             `json:"denoms_goal"`
+	DenomsHardCap int32             `json:"denoms_hardcap"`
+	QueueSize     int32             `json:"queue_size"`
+	Running       bool              `json:"running"`
+	Sessions      []CoinJoinSession `json:"sessions"`
+	KeysLeft      int32             `json:"keys_left"`
+	Warnings      string            `json:"warnings"`
+}
+
+// GObjectVoteDetail models the outcome of the vote of a single masternode as
+// returned by the gobject vote-many and vote-alias commands.
+type GObjectVoteDetail struct {
+	Result       string `json:"result"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// GObjectVoteResult models the data from the gobject vote-many and vote-alias
+// commands.  Detail maps the ProTx hash of each voting masternode to the
+// outcome of its vote.
+type GObjectVoteResult struct {
+	Overall string                       `json:"overall"`
+	Detail  map[string]GObjectVoteDetail `json:"detail"`
+}
+
+// ProTxRegisterPrepareResult models the data from the protx register_prepare
+// command.  The transaction is returned unsigned along with the message which
+// has to be signed with the key of the collateral address before submitting it
+// with protx register_submit.
+type ProTxRegisterPrepareResult struct {
+	Tx                string `json:"tx"`
+	CollateralAddress string `json:"collateralAddress"`
+	SignMessage       string `json:"signMessage"`
+}
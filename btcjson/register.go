@@ -134,6 +134,12 @@ func isAcceptableKind(kind reflect.Kind) bool {
 // using this function, however it is also exported so callers can easily
 // register custom types.
 //
+// Commands which dispatch on a leading sub-command parameter, as is common for
+// the Dash RPCs such as "masternode count" or "protx info", may be registered
+// by separating the method and sub-command with a single space.  Such commands
+// are marshalled with the base method and the sub-command as the first
+// positional parameter.
+//
 // The type format is very strict since it needs to be able to automatically
 // marshal to and from JSON-RPC 1.0.  The following enumerates the requirements:
 //
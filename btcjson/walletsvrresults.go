@@ -5,6 +5,8 @@
 
 package btcjson
 
+import "encoding/json"
+
 // GetTransactionDetailsResult models the details data from the gettransaction command.
 //
 // This models the "short" version of the ListTransactionsResult type, which
@@ -108,6 +110,9 @@ type ListSinceBlockResult struct {
 }
 
 // ListUnspentResult models a successful response from the listunspent request.
+//
+// PSRounds is the number of CoinJoin mixing rounds the output went through.
+// Dash Core reports negative values for outputs which are not denominated.
 type ListUnspentResult struct {
 	TxID          string  `json:"txid"`
 	Vout          uint32  `json:"vout"`
@@ -118,6 +123,28 @@ type ListUnspentResult struct {
 	Amount        float64 `json:"amount"`
 	Confirmations int64   `json:"confirmations"`
 	Spendable     bool    `json:"spendable"`
+	Solvable      bool    `json:"solvable"`
+	PSRounds      int32   `json:"ps_rounds"`
+}
+
+// UnmarshalJSON provides a custom Unmarshal method for ListUnspentResult.
+// This is necessary because newer versions of dashd report the mixing rounds
+// as coinjoin_rounds instead of ps_rounds.
+func (r *ListUnspentResult) UnmarshalJSON(data []byte) error {
+	type listUnspentResult ListUnspentResult
+	result := struct {
+		*listUnspentResult
+		CoinJoinRounds *int32 `json:"coinjoin_rounds"`
+	}{
+		listUnspentResult: (*listUnspentResult)(r),
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	if result.CoinJoinRounds != nil {
+		r.PSRounds = *result.CoinJoinRounds
+	}
+	return nil
 }
 
 // SignRawTransactionError models the data that contains script verification
@@ -0,0 +1,61 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nargott/godashutil/base58"
+)
+
+// hash160Size is the size in bytes of the RIPEMD160(SHA256(x)) hash carried by
+// pay-to-pubkey-hash and pay-to-script-hash addresses.
+const hash160Size = 20
+
+// ErrUnknownAddressID describes an error where the version byte of a decoded
+// address is neither the pay-to-pubkey-hash nor the pay-to-script-hash
+// identifier of the network it is decoded for.
+var ErrUnknownAddressID = errors.New("address is not for this network")
+
+// EncodeAddress returns the base58check encoding of the passed 20-byte hash160
+// as a pay-to-pubkey-hash address, or a pay-to-script-hash address when
+// isScript is set, using the address identifiers of the network.  For example,
+// main network addresses start with 'X' and '7' respectively.
+func (p *Params) EncodeAddress(hash160 []byte, isScript bool) (string, error) {
+	if len(hash160) != hash160Size {
+		return "", fmt.Errorf("invalid hash160 length %d: must be %d "+
+			"bytes", len(hash160), hash160Size)
+	}
+
+	id := p.PubKeyHashAddrID
+	if isScript {
+		id = p.ScriptHashAddrID
+	}
+	return base58.CheckEncode(hash160, id), nil
+}
+
+// DecodeAddress decodes the passed base58check pay-to-pubkey-hash or
+// pay-to-script-hash address of the network and returns its hash160 along
+// with whether it is a script hash.  ErrUnknownAddressID is returned when the
+// address belongs to another network.
+func (p *Params) DecodeAddress(addr string) (hash160 []byte, isScript bool, err error) {
+	decoded, id, err := base58.CheckDecode(addr)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(decoded) != hash160Size {
+		return nil, false, fmt.Errorf("invalid address length %d: "+
+			"must be %d bytes", len(decoded), hash160Size)
+	}
+
+	switch id {
+	case p.PubKeyHashAddrID:
+		return decoded, false, nil
+	case p.ScriptHashAddrID:
+		return decoded, true, nil
+	}
+	return nil, false, ErrUnknownAddressID
+}
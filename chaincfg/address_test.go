@@ -0,0 +1,92 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// TestAddressRoundTrip ensures hashes are encoded with the address identifiers
+// of each network and decode back to the same hash and type.
+func TestAddressRoundTrip(t *testing.T) {
+	hash160, _ := hex.DecodeString("0e4f4c9a5e3b0d0ea5ac1c8c2977e5f2ed3d4b7a")
+
+	tests := []struct {
+		name     string
+		params   *Params
+		isScript bool
+		addr     string
+	}{
+		{
+			name:   "mainnet p2pkh",
+			params: &MainNetParams,
+			addr:   "XbzWHkEWreorjSwo4A1JvoxgWEXzJBPdTy",
+		},
+		{
+			name:     "mainnet p2sh",
+			params:   &MainNetParams,
+			isScript: true,
+			addr:     "7TiKDEMEFq1HfSabaz2BqHdUjy5PcqFqjQ",
+		},
+		{
+			name:   "testnet p2pkh",
+			params: &TestNet3Params,
+			addr:   "yMd7JhJxJCTw5BsLd1KhxqP2nX2MmTkyRX",
+		},
+		{
+			name:     "testnet p2sh",
+			params:   &TestNet3Params,
+			isScript: true,
+			addr:     "8fj8AZF6PNPv7jzrfF29HfSqdUrDkWS3q2",
+		},
+	}
+
+	for _, test := range tests {
+		addr, err := test.params.EncodeAddress(hash160, test.isScript)
+		if err != nil {
+			t.Errorf("%s: EncodeAddress: unexpected error: %v",
+				test.name, err)
+			continue
+		}
+		if addr != test.addr {
+			t.Errorf("%s: EncodeAddress: got %s, want %s", test.name,
+				addr, test.addr)
+			continue
+		}
+
+		decoded, isScript, err := test.params.DecodeAddress(addr)
+		if err != nil {
+			t.Errorf("%s: DecodeAddress: unexpected error: %v",
+				test.name, err)
+			continue
+		}
+		if !bytes.Equal(decoded, hash160) || isScript != test.isScript {
+			t.Errorf("%s: DecodeAddress: got %x (script %v), want "+
+				"%x (script %v)", test.name, decoded, isScript,
+				hash160, test.isScript)
+		}
+	}
+}
+
+// TestDecodeAddressErrors ensures addresses of other networks, invalid
+// checksums and invalid hash lengths are rejected.
+func TestDecodeAddressErrors(t *testing.T) {
+	_, _, err := MainNetParams.DecodeAddress("yMd7JhJxJCTw5BsLd1KhxqP2nX2MmTkyRX")
+	if err != ErrUnknownAddressID {
+		t.Errorf("testnet address on mainnet: got error %v, want %v",
+			err, ErrUnknownAddressID)
+	}
+
+	_, _, err = MainNetParams.DecodeAddress("XbzWHkEWreorjSwo4A1JvoxgWEXzJBPdTz")
+	if err == nil {
+		t.Error("invalid checksum: expected error")
+	}
+
+	if _, err := MainNetParams.EncodeAddress([]byte{0x01}, false); err == nil {
+		t.Error("short hash160: expected error")
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+// BLSScheme identifies the serialization scheme of the BLS public keys and
+// signatures carried by special transactions and quorum messages.
+type BLSScheme uint8
+
+// These constants define the BLS serialization schemes used by Dash.
+const (
+	// BLSSchemeLegacy is the scheme of the Chia BLS library used before
+	// the v19 hard fork.
+	BLSSchemeLegacy BLSScheme = iota
+
+	// BLSSchemeBasic is the scheme of the IETF BLS signature standard
+	// used as of the v19 hard fork.
+	BLSSchemeBasic
+)
+
+// String returns the BLSScheme in human-readable form.
+func (s BLSScheme) String() string {
+	switch s {
+	case BLSSchemeLegacy:
+		return "legacy"
+	case BLSSchemeBasic:
+		return "basic"
+	}
+	return "unknown"
+}
+
+// BLSSchemeAt returns the BLS serialization scheme in effect for the block at
+// the provided height.  The keys and signatures of blocks before
+// BLSBasicSchemeHeight use the legacy scheme, all later ones use the basic
+// scheme.
+func (p *Params) BLSSchemeAt(height int32) BLSScheme {
+	if height < p.BLSBasicSchemeHeight {
+		return BLSSchemeLegacy
+	}
+	return BLSSchemeBasic
+}
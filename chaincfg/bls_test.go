@@ -0,0 +1,39 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import "testing"
+
+// TestBLSSchemeAt ensures the BLS scheme switches to the basic scheme at the v19
+// hard fork height of each network.
+func TestBLSSchemeAt(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *Params
+		height int32
+		want   BLSScheme
+	}{
+		{"mainnet genesis", &MainNetParams, 0, BLSSchemeLegacy},
+		{"mainnet before fork", &MainNetParams, 1899071, BLSSchemeLegacy},
+		{"mainnet fork", &MainNetParams, 1899072, BLSSchemeBasic},
+		{"mainnet after fork", &MainNetParams, 2000000, BLSSchemeBasic},
+		{"testnet before fork", &TestNet3Params, 850099, BLSSchemeLegacy},
+		{"testnet fork", &TestNet3Params, 850100, BLSSchemeBasic},
+		{"regtest before fork", &RegressionNetParams, 899, BLSSchemeLegacy},
+		{"regtest fork", &RegressionNetParams, 900, BLSSchemeBasic},
+		{"devnet fork", DevNetParams("test"), 300, BLSSchemeBasic},
+	}
+
+	for _, test := range tests {
+		got := test.params.BLSSchemeAt(test.height)
+		if got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	if s := BLSScheme(2).String(); s != "unknown" {
+		t.Errorf("String: got %q for unknown scheme", s)
+	}
+}
@@ -36,9 +36,19 @@ func DoubleHashH(b []byte) Hash {
 	return Hash(sha256.Sum256(first[:]))
 }
 
-//HashX11 calculates x11 hash and returns the resulting bytes as a Hash
-func HashX11(b []byte) Hash {
+// X11 calculates the X11 hash of b, which is the proof of work hash Dash uses
+// for block headers, and returns the resulting bytes as a Hash.  X11 chains
+// the blake, bmw, groestl, skein, jh, keccak, luffa, cubehash, shavite, simd
+// and echo 512-bit hash functions, and truncates the final digest to 256 bits.
+// The implementation is pure Go, so no C library is required.
+func X11(b []byte) Hash {
 	hs, out := x11.New(), [32]byte{}
 	hs.Hash(b, out[:])
 	return Hash(out)
-}
\ No newline at end of file
+}
+
+// HashX11 calculates X11(b) and returns the resulting bytes as a Hash.  It is
+// kept for callers of the original name.
+func HashX11(b []byte) Hash {
+	return X11(b)
+}
@@ -5,6 +5,7 @@
 package chainhash
 
 import (
+	"encoding/hex"
 	"fmt"
 	"testing"
 )
@@ -134,3 +135,67 @@ func TestDoubleHashFuncs(t *testing.T) {
 		}
 	}
 }
+
+// TestX11 ensures the X11 hash functions produce the expected proof of work
+// hashes for known Dash block headers and the published X11 test vectors.
+func TestX11(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		hash   string
+	}{
+		{
+			name: "mainnet genesis",
+			header: "01000000" +
+				"0000000000000000000000000000000000000000000000000000000000000000" +
+				"c762a6567f3cc092f0684bb62b7e00a84890b990f07cc71a6bb58d64b98e02e0" +
+				"022ddb52f0ff0f1ec23fb901",
+			hash: "00000ffd590b1485b3caadc19b22e6379c733355108f107a430458cdf3407ab6",
+		},
+	}
+
+	for _, test := range tests {
+		header, err := hex.DecodeString(test.header)
+		if err != nil {
+			t.Errorf("%s: unexpected error decoding header: %v",
+				test.name, err)
+			continue
+		}
+		if len(header) != 80 {
+			t.Errorf("%s: header is %d bytes, want 80", test.name,
+				len(header))
+			continue
+		}
+
+		if h := X11(header).String(); h != test.hash {
+			t.Errorf("X11(%s) = %s, want %s", test.name, h, test.hash)
+			continue
+		}
+		if h := HashX11(header).String(); h != test.hash {
+			t.Errorf("HashX11(%s) = %s, want %s", test.name, h,
+				test.hash)
+			continue
+		}
+	}
+
+	// The published X11 vectors are digests of strings and are given in
+	// the byte order the digest is produced in rather than reversed like
+	// block hashes.
+	vectors := []struct {
+		out string
+		in  string
+	}{
+		{"51b572209083576ea221c27e62b4e22063257571ccb6cc3dc3cd17eb67584eba", ""},
+		{"fe809ebca8753d907f6ad32cdcf8e5c4e090d7bece5df35b2147e10b88c12d26", "DASH"},
+		{"534536a4e4f16b32447f02f77200449dc2f23b532e3d9878fe111c9de666bc5c", "The quick brown fox jumps over the lazy dog"},
+	}
+
+	for _, test := range vectors {
+		hash := X11([]byte(test.in))
+		h := fmt.Sprintf("%x", hash[:])
+		if h != test.out {
+			t.Errorf("X11(%q) = %s, want %s", test.in, h, test.out)
+			continue
+		}
+	}
+}
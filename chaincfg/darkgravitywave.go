@@ -0,0 +1,84 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/nargott/godash/wire"
+)
+
+// DGWPastBlocks is the number of previous blocks whose targets and timestamps
+// the Dark Gravity Wave difficulty adjustment is based on.
+const DGWPastBlocks = 24
+
+// CalcNextWorkRequired returns the difficulty bits required for the block
+// after the last of the passed headers, which must be ordered from oldest to
+// newest, as calculated by version 3 of the Dark Gravity Wave algorithm Dash
+// uses to retarget the difficulty with every block.
+//
+// The target is the weighted average of the targets of the last DGWPastBlocks
+// headers, scaled by the time those blocks took relative to the time they
+// should have taken per TargetTimePerBlock.  That ratio is limited to a factor
+// of 3 in either direction, and the target never exceeds PowLimit.  The
+// calculation reproduces the integer arithmetic of dashd, including its
+// quirks: the average weights the newest targets less than a true average
+// would, and the actual timespan only covers DGWPastBlocks - 1 intervals while
+// the target timespan covers DGWPastBlocks of them.
+//
+// The bits of PowLimit are returned when fewer than DGWPastBlocks + 1 headers
+// are passed, since they are then taken to be the start of the chain, where
+// dashd does the same.  On networks with PowNoRetargeting set, the bits of the
+// last header are returned unchanged.  The exception which allows blocks with
+// the minimum difficulty on test networks depends on the timestamp of the new
+// block and is not applied.
+func CalcNextWorkRequired(headers []*wire.BlockHeader, p *Params) uint32 {
+	powLimitBits := BigToCompact(p.PowLimit)
+	if p.PowNoRetargeting && len(headers) > 0 {
+		return headers[len(headers)-1].Bits
+	}
+	if len(headers) < DGWPastBlocks+1 {
+		return powLimitBits
+	}
+
+	// Calculate the average target of the past blocks starting with the
+	// most recent one.
+	lastHeader := headers[len(headers)-1]
+	pastTargetAvg := new(big.Int)
+	for i := int64(1); i <= DGWPastBlocks; i++ {
+		target := CompactToBig(headers[int64(len(headers))-i].Bits)
+		if i == 1 {
+			pastTargetAvg.Set(target)
+			continue
+		}
+
+		// (pastTargetAvg * i + target) / (i + 1)
+		pastTargetAvg.Mul(pastTargetAvg, big.NewInt(i))
+		pastTargetAvg.Add(pastTargetAvg, target)
+		pastTargetAvg.Div(pastTargetAvg, big.NewInt(i+1))
+	}
+	firstHeader := headers[len(headers)-DGWPastBlocks]
+
+	// Limit the amount of adjustment that can occur.
+	targetTimespan := DGWPastBlocks * int64(p.TargetTimePerBlock/time.Second)
+	actualTimespan := lastHeader.Timestamp.Unix() -
+		firstHeader.Timestamp.Unix()
+	if actualTimespan < targetTimespan/3 {
+		actualTimespan = targetTimespan / 3
+	}
+	if actualTimespan > targetTimespan*3 {
+		actualTimespan = targetTimespan * 3
+	}
+
+	// Retarget and limit the new target to the proof of work limit.
+	newTarget := new(big.Int).Mul(pastTargetAvg, big.NewInt(actualTimespan))
+	newTarget.Div(newTarget, big.NewInt(targetTimespan))
+	if newTarget.Cmp(p.PowLimit) > 0 {
+		newTarget.Set(p.PowLimit)
+	}
+
+	return BigToCompact(newTarget)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nargott/godash/wire"
+)
+
+// dgwHeaders returns headers with the passed bits and timestamps.
+func dgwHeaders(bits []uint32, timestamps []int64) []*wire.BlockHeader {
+	headers := make([]*wire.BlockHeader, 0, len(bits))
+	for i := range bits {
+		headers = append(headers, &wire.BlockHeader{
+			Bits:      bits[i],
+			Timestamp: time.Unix(timestamps[i], 0),
+		})
+	}
+	return headers
+}
+
+// dgwChain returns n headers with the same bits whose timestamps are spaced by
+// the passed number of seconds.
+func dgwChain(n int, bits uint32, spacing int64) []*wire.BlockHeader {
+	allBits := make([]uint32, n)
+	timestamps := make([]int64, n)
+	for i := range allBits {
+		allBits[i] = bits
+		timestamps[i] = 1500000000 + int64(i)*spacing
+	}
+	return dgwHeaders(allBits, timestamps)
+}
+
+// TestCalcNextWorkRequired ensures the Dark Gravity Wave retarget matches the
+// integer arithmetic of dashd, limits the adjustment and the target, and only
+// depends on the last DGWPastBlocks headers.
+func TestCalcNextWorkRequired(t *testing.T) {
+	// The varying chain alternates between two targets with block times
+	// of 90 to 210 seconds, which average to the target spacing.
+	variedBits := make([]uint32, 30)
+	variedTimes := make([]int64, 30)
+	variedTimes[0] = 1500000000
+	for i := range variedBits {
+		variedBits[i] = 0x1b05a1f2
+		if i%2 == 1 {
+			variedBits[i] = 0x1b0404cb
+		}
+		if i > 0 {
+			variedTimes[i] = variedTimes[i-1] + 90 + int64(i%5)*30
+		}
+	}
+
+	// The same chain with headers before the past blocks which would
+	// change the result if they were taken into account.
+	prefixedBits := append([]uint32(nil), variedBits...)
+	prefixedTimes := append([]int64(nil), variedTimes...)
+	for i := 0; i < len(variedBits)-DGWPastBlocks; i++ {
+		prefixedBits[i] = 0x1e0fffff
+		prefixedTimes[i] = 0
+	}
+
+	tests := []struct {
+		name    string
+		params  *Params
+		headers []*wire.BlockHeader
+		want    uint32
+	}{
+		{
+			name:    "no headers",
+			headers: nil,
+			want:    0x1e0fffff,
+		},
+		{
+			name:    "start of chain",
+			headers: dgwChain(DGWPastBlocks, 0x1b0404cb, 150),
+			want:    0x1e0fffff,
+		},
+		{
+			// The actual timespan covers one interval less than the
+			// target timespan, so blocks on target get harder.
+			name:    "on target",
+			headers: dgwChain(DGWPastBlocks+1, 0x1b0404cb, 150),
+			want:    0x1b03d9ed,
+		},
+		{
+			name:    "fast blocks limited to a third",
+			headers: dgwChain(DGWPastBlocks+1, 0x1b0404cb, 1),
+			want:    0x1b0156ee,
+		},
+		{
+			name:    "slow blocks limited to three times",
+			headers: dgwChain(DGWPastBlocks+1, 0x1b0404cb, 10000),
+			want:    0x1b0c0e61,
+		},
+		{
+			name:    "limited to the proof of work limit",
+			headers: dgwChain(DGWPastBlocks+1, 0x1e0fffff, 10000),
+			want:    0x1e0fffff,
+		},
+		{
+			name:    "varying targets and times",
+			headers: dgwHeaders(variedBits, variedTimes),
+			want:    0x1b04b6a7,
+		},
+		{
+			name:    "earlier headers ignored",
+			headers: dgwHeaders(prefixedBits, prefixedTimes),
+			want:    0x1b04b6a7,
+		},
+		{
+			name:    "testnet proof of work limit",
+			params:  &TestNet3Params,
+			headers: dgwChain(DGWPastBlocks+1, 0x1e0fffff, 10000),
+			want:    0x1e0fffff,
+		},
+		{
+			name:    "regtest start of chain",
+			params:  &RegressionNetParams,
+			headers: nil,
+			want:    0x207fffff,
+		},
+		{
+			// The regression test network never retargets.
+			name:    "regtest keeps the last bits",
+			params:  &RegressionNetParams,
+			headers: dgwChain(DGWPastBlocks+1, 0x1b0404cb, 1),
+			want:    0x1b0404cb,
+		},
+	}
+
+	for _, test := range tests {
+		params := test.params
+		if params == nil {
+			params = &MainNetParams
+		}
+		got := CalcNextWorkRequired(test.headers, params)
+		if got != test.want {
+			t.Errorf("%s: got %#08x, want %#08x", test.name, got,
+				test.want)
+		}
+	}
+}
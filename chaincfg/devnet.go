@@ -0,0 +1,207 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godash/wire"
+)
+
+const (
+	// devNetNamePrefix is prepended to the name passed to DevNetParams to
+	// form the full devnet name, matching Dash Core.
+	devNetNamePrefix = "devnet-"
+
+	// devNetGenesisReward is the value of the devnet genesis coinbase
+	// output in duffs.
+	devNetGenesisReward = 50 * 100000000
+)
+
+// hashToBig converts a chainhash.Hash into a big.Int that can be used to
+// perform math comparisons.
+func hashToBig(hash *chainhash.Hash) *big.Int {
+	// A Hash is in little-endian, but the big package wants the bytes in
+	// big-endian, so reverse them.
+	buf := *hash
+	blen := len(buf)
+	for i := 0; i < blen/2; i++ {
+		buf[i], buf[blen-1-i] = buf[blen-1-i], buf[i]
+	}
+
+	return new(big.Int).SetBytes(buf[:])
+}
+
+// newDevNetGenesisBlock creates the devnet genesis block for the passed full
+// devnet name on top of the passed block and its hash.  The coinbase commits to height 1
+// and the devnet name, and the nonce is searched until the block hash meets
+// the target of the previous block's difficulty bits.
+func newDevNetGenesisBlock(prevBlock *wire.MsgBlock, prevHash *chainhash.Hash,
+	devNetName string) *wire.MsgBlock {
+
+	// OP_1 followed by a single canonical push of the devnet name.
+	nameLen := len(devNetName)
+	sigScript := make([]byte, 0, nameLen+4)
+	sigScript = append(sigScript, 0x51)
+	switch {
+	case nameLen < 0x4c:
+		sigScript = append(sigScript, byte(nameLen))
+	case nameLen <= 0xff:
+		sigScript = append(sigScript, 0x4c, byte(nameLen))
+	default:
+		sigScript = append(sigScript, 0x4d, byte(nameLen), byte(nameLen>>8))
+	}
+	sigScript = append(sigScript, devNetName...)
+
+	coinbaseTx := wire.NewMsgTx(1)
+	coinbaseTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash:  chainhash.Hash{},
+			Index: math.MaxUint32,
+		},
+		SignatureScript: sigScript,
+		Sequence:        math.MaxUint32,
+	})
+	coinbaseTx.AddTxOut(&wire.TxOut{
+		Value:    devNetGenesisReward,
+		PkScript: []byte{0x6a}, // OP_RETURN
+	})
+
+	prevHeader := &prevBlock.Header
+	block := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   4,
+			PrevBlock: *prevHash,
+			Timestamp: prevHeader.Timestamp.Add(time.Second),
+			Bits:      prevHeader.Bits,
+		},
+		Transactions: []*wire.MsgTx{coinbaseTx},
+	}
+	block.Header.MerkleRoot = wire.CalcMerkleRoot(block.Transactions)
+
+	target := CompactToBig(block.Header.Bits)
+	for nonce := uint32(0); nonce < math.MaxUint32; nonce++ {
+		block.Header.Nonce = nonce
+		hash := block.Header.BlockHash()
+		if hashToBig(&hash).Cmp(target) <= 0 {
+			break
+		}
+	}
+
+	return block
+}
+
+// DevNetParams returns the network parameters for the Dash development network
+// with the passed name.  Devnets share the regression test network genesis
+// block and are distinguished by a devnet genesis block at height 1 whose
+// coinbase embeds the devnet name, so the same name always results in the same
+// parameters.
+//
+// Since all devnets use the same network magic, only a single devnet may be
+// registered with Register at a time.
+func DevNetParams(name string) *Params {
+	devNetName := devNetNamePrefix + name
+	devNetGenesisBlock := newDevNetGenesisBlock(&regTestGenesisBlock,
+		&regTestGenesisHash, devNetName)
+	devNetGenesisHash := devNetGenesisBlock.BlockHash()
+
+	return &Params{
+		Name:        devNetName,
+		Net:         wire.DevNet,
+		DefaultPort: "19799",
+		DNSSeeds:    []DNSSeed{},
+
+		// Chain parameters
+		GenesisBlock:             &regTestGenesisBlock,
+		GenesisHash:              &regTestGenesisHash,
+		DevNetGenesisBlock:       devNetGenesisBlock,
+		DevNetGenesisHash:        &devNetGenesisHash,
+		PowLimit:                 regressionPowLimit,
+		PowLimitBits:             0x207fffff,
+		BIP0034Height:            1,
+		BIP0065Height:            1,
+		BIP0066Height:            1,
+		BLSBasicSchemeHeight:     300,
+		CoinbaseMaturity:         100,
+		SubsidyReductionInterval: 210240,
+		TargetTimespan:           time.Hour * 24 * 1, // DASH 1 day
+		TargetTimePerBlock:       time.Second * 150,  // DASH 2.5 minutes
+		RetargetAdjustmentFactor: 4,                  // 25% less, 400% more
+		ReduceMinDifficulty:      true,
+		MinDiffReductionTime:     time.Minute * 20, // TargetTimePerBlock * 2
+		GenerateSupported:        true,
+
+		// Budget and masternode payment parameters
+		BudgetPaymentsStartBlock:         4100,
+		SuperblockStartBlock:             4200,
+		SuperblockCycle:                  24,
+		MasternodePaymentsIncreaseBlock:  4030,
+		MasternodePaymentsIncreasePeriod: 10,
+
+		// Checkpoints ordered from oldest to newest.
+		Checkpoints: nil,
+
+		// Consensus rule change deployments.
+		RuleChangeActivationThreshold: 1512, // 75% of MinerConfirmationWindow
+		MinerConfirmationWindow:       2016,
+		Deployments: [DefinedDeployments]ConsensusDeployment{
+			DeploymentTestDummy: {
+				BitNumber:  28,
+				StartTime:  0,             // Always available for vote
+				ExpireTime: math.MaxInt64, // Never expires
+			},
+			DeploymentCSV: {
+				BitNumber:  0,
+				StartTime:  0,             // Always available for vote
+				ExpireTime: math.MaxInt64, // Never expires
+			},
+			DeploymentSegwit: {
+				BitNumber:  1,
+				StartTime:  0,             // Always available for vote
+				ExpireTime: math.MaxInt64, // Never expires.
+			},
+			DeploymentDIP0003: {
+				BitNumber:  3,
+				StartTime:  0,             // Always available for vote
+				ExpireTime: math.MaxInt64, // Never expires
+			},
+			DeploymentDIP0008: {
+				BitNumber:  4,
+				StartTime:  0,             // Always available for vote
+				ExpireTime: math.MaxInt64, // Never expires
+			},
+		},
+
+		// Enabled LLMQ types
+		Quorums: newQuorums(llmqDevnet, llmqDevnetDIP0024, llmq50_60,
+			llmq60_75, llmq400_60, llmq400_85, llmq100_67),
+
+		// Mempool parameters
+		RelayNonStdTxs: true,
+
+		// Human-readable part for Bech32 encoded segwit addresses, as
+		// defined in BIP 173.
+		Bech32HRPSegwit: "tb", // always tb for test net
+
+		// Address encoding magics
+		PubKeyHashAddrID: 0x8c, // Devnet Dash addresses start with 'y'
+		ScriptHashAddrID: 0x13, // Devnet Dash script addresses start with '8' or '9'
+		PrivateKeyID:     0xef, // starts with 9 (uncompressed) or c (compressed)
+
+		// BIP32 hierarchical deterministic extended key magics
+		HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+		HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+
+		// BIP44 coin type used in the hierarchical deterministic path for
+		// address generation.
+		HDCoinType: 1,
+
+		// Prefix of signed messages.
+		MessageMagic: "DarkCoin Signed Message:\n",
+	}
+}
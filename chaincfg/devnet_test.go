@@ -0,0 +1,52 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nargott/godash/wire"
+)
+
+// TestDevNetParams ensures DevNetParams creates consistent and deterministic
+// parameters for a named devnet.
+func TestDevNetParams(t *testing.T) {
+	t.Parallel()
+
+	params := DevNetParams("test")
+	if params.Name != "devnet-test" {
+		t.Errorf("DevNetParams: unexpected name - got %s, want %s",
+			params.Name, "devnet-test")
+	}
+	if params.Net != wire.DevNet {
+		t.Errorf("DevNetParams: unexpected net - got %v, want %v",
+			params.Net, wire.DevNet)
+	}
+	if err := params.ValidateGenesis(); err != nil {
+		t.Errorf("ValidateGenesis: %v", err)
+	}
+
+	// The coinbase must commit to the devnet name.
+	sigScript := params.DevNetGenesisBlock.Transactions[0].TxIn[0].SignatureScript
+	if !bytes.HasSuffix(sigScript, []byte("devnet-test")) {
+		t.Errorf("DevNetParams: coinbase script %x does not embed the "+
+			"devnet name", sigScript)
+	}
+
+	// The same name must always produce the same devnet genesis block
+	// while a different name must not.
+	again := DevNetParams("test")
+	if !again.DevNetGenesisHash.IsEqual(params.DevNetGenesisHash) {
+		t.Errorf("DevNetParams: devnet genesis hash is not deterministic "+
+			"- got %v, want %v", again.DevNetGenesisHash,
+			params.DevNetGenesisHash)
+	}
+	other := DevNetParams("other")
+	if other.DevNetGenesisHash.IsEqual(params.DevNetGenesisHash) {
+		t.Errorf("DevNetParams: devnets %s and %s share genesis hash %v",
+			params.Name, other.Name, params.DevNetGenesisHash)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import "math/big"
+
+// CompactToBig converts a compact representation of a whole number N to an
+// unsigned 32-bit number.  The representation is similar to IEEE754 floating
+// point numbers:
+//
+//	* the most significant 8 bits represent the unsigned base 256 exponent
+//	* bit 23 (the 24th bit) represents the sign bit
+//	* the least significant 23 bits represent the mantissa
+//
+// The formula to calculate N is:
+//	N = (-1^sign) * mantissa * 256^(exponent-3)
+//
+// It, BigToCompact and CalcWork are the same as the functions of the
+// blockchain package, which can't be used here since that package depends on
+// this one.
+func CompactToBig(compact uint32) *big.Int {
+	// Extract the mantissa, sign bit, and exponent.
+	mantissa := compact & 0x007fffff
+	isNegative := compact&0x00800000 != 0
+	exponent := uint(compact >> 24)
+
+	// Since the base for the exponent is 256, the exponent can be treated
+	// as the number of bytes to represent the full 256-bit number.  So,
+	// treat the exponent as the number of bytes and shift the mantissa
+	// right or left accordingly.
+	var bn *big.Int
+	if exponent <= 3 {
+		mantissa >>= 8 * (3 - exponent)
+		bn = big.NewInt(int64(mantissa))
+	} else {
+		bn = big.NewInt(int64(mantissa))
+		bn.Lsh(bn, 8*(exponent-3))
+	}
+
+	// Make it negative if the sign bit is set.
+	if isNegative {
+		bn = bn.Neg(bn)
+	}
+
+	return bn
+}
+
+// oneLsh256 is 1 shifted left 256 bits.  It is defined here to avoid the
+// overhead of creating it multiple times.
+var oneLsh256 = new(big.Int).Lsh(bigOne, 256)
+
+// BigToCompact converts a whole number N to a compact representation using
+// an unsigned 32-bit number.  The compact representation only provides 23 bits
+// of precision, so values larger than (2^23 - 1) only encode the most
+// significant digits of the number.  See CompactToBig for details.
+func BigToCompact(n *big.Int) uint32 {
+	// No need to do any work if it's zero.
+	if n.Sign() == 0 {
+		return 0
+	}
+
+	// Since the base for the exponent is 256, the exponent can be treated
+	// as the number of bytes.  So, shift the number right or left
+	// accordingly.  This is equivalent to:
+	// mantissa = mantissa / 256^(exponent-3)
+	var mantissa uint32
+	exponent := uint(len(n.Bytes()))
+	if exponent <= 3 {
+		mantissa = uint32(n.Bits()[0])
+		mantissa <<= 8 * (3 - exponent)
+	} else {
+		// Use a copy to avoid modifying the caller's original number.
+		tn := new(big.Int).Set(n)
+		mantissa = uint32(tn.Rsh(tn, 8*(exponent-3)).Bits()[0])
+	}
+
+	// When the mantissa already has the sign bit set, the number is too
+	// large to fit into the available 23-bits, so divide the number by 256
+	// and increment the exponent accordingly.
+	if mantissa&0x00800000 != 0 {
+		mantissa >>= 8
+		exponent++
+	}
+
+	// Pack the exponent, sign bit, and mantissa into an unsigned 32-bit
+	// int and return it.
+	compact := uint32(exponent<<24) | mantissa
+	if n.Sign() < 0 {
+		compact |= 0x00800000
+	}
+	return compact
+}
+
+// CalcWork calculates a work value from difficulty bits.  Since a lower target
+// equates to a higher difficulty, the work is the inverse of the target,
+// calculated as 2^256 / (target + 1) to avoid a division by zero.  Summed over
+// the headers of a chain, it yields the chain work used to select the best of
+// several chain tips.  Bits which don't encode a positive target have no work.
+func CalcWork(bits uint32) *big.Int {
+	difficultyNum := CompactToBig(bits)
+	if difficultyNum.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+
+	// (1 << 256) / (difficultyNum + 1)
+	denominator := new(big.Int).Add(difficultyNum, bigOne)
+	return new(big.Int).Div(oneLsh256, denominator)
+}
+
+// DifficultyOneTarget is the target of difficulty 1 used by the getdifficulty,
+// getblock and getblockheader commands of dashd.  It is the compact target
+// 0x1d00ffff inherited from bitcoin and is lower than the proof-of-work limit
+// of the Dash networks, so their minimum difficulty is below 1.
+var DifficultyOneTarget = new(big.Int).Lsh(big.NewInt(0xffff), 208)
+
+// DifficultyFromBits returns the proof-of-work difficulty of the passed bits
+// field of a block header as a multiple of the passed proof-of-work limit,
+// which is the target of difficulty 1.  Zero is returned for bits which don't
+// encode a positive target.
+//
+// Pass DifficultyOneTarget as the limit to get the same value dashd reports.
+func DifficultyFromBits(bits uint32, powLimit *big.Int) float64 {
+	target := CompactToBig(bits)
+	if target.Sign() <= 0 {
+		return 0
+	}
+
+	difficulty, _ := new(big.Rat).SetFrac(powLimit, target).Float64()
+	return difficulty
+}
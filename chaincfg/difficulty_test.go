@@ -0,0 +1,167 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestCompactToBig ensures CompactToBig handles the sign bit, small exponents
+// which shift the mantissa out and large exponents as expected.
+func TestCompactToBig(t *testing.T) {
+	tests := []struct {
+		name    string
+		compact uint32
+		want    string
+	}{
+		{"zero", 0x00000000, "0"},
+		{"exponent shifts out mantissa", 0x01003456, "0"},
+		{"one byte", 0x01123456, "12"},
+		{"two bytes", 0x02123456, "1234"},
+		{"three bytes", 0x03123456, "123456"},
+		{"four bytes", 0x04123456, "12345600"},
+		{"negative", 0x04923456, "-12345600"},
+		{"negative zero", 0x01803456, "0"},
+		{"dash genesis", 0x1e0ffff0,
+			"ffff0000000000000000000000000000000000000000000000000000000"},
+		{"bitcoin difficulty 1", 0x1d00ffff,
+			"ffff0000000000000000000000000000000000000000000000000000"},
+	}
+
+	for _, test := range tests {
+		want, _ := new(big.Int).SetString(test.want, 16)
+		if got := CompactToBig(test.compact); got.Cmp(want) != 0 {
+			t.Errorf("%s: got %x, want %x", test.name, got, want)
+		}
+	}
+}
+
+// TestDifficultyFromBits ensures difficulties are calculated relative to the
+// passed limit and invalid targets yield zero.
+func TestDifficultyFromBits(t *testing.T) {
+	diff1 := DifficultyOneTarget
+
+	tests := []struct {
+		name     string
+		bits     uint32
+		powLimit *big.Int
+		want     float64
+	}{
+		{"difficulty 1", 0x1d00ffff, diff1, 1},
+		// The difficulty dashd reports for the mainnet genesis block.
+		{"dash genesis", MainNetParams.GenesisBlock.Header.Bits, diff1,
+			0.000244140625},
+		{"mainnet limit", MainNetParams.PowLimitBits,
+			CompactToBig(MainNetParams.PowLimitBits), 1},
+		{"mainnet block", 0x1927e2d8, diff1, 107679137.73629138},
+		{"zero target", 0x00000000, diff1, 0},
+		{"negative target", 0x04923456, diff1, 0},
+	}
+
+	if DifficultyOneTarget.Cmp(CompactToBig(0x1d00ffff)) != 0 {
+		t.Errorf("DifficultyOneTarget: got %x, want the 0x1d00ffff target",
+			DifficultyOneTarget)
+	}
+
+	for _, test := range tests {
+		got := DifficultyFromBits(test.bits, test.powLimit)
+		if math.Abs(got-test.want) > test.want*1e-12 {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+// TestBigToCompact ensures BigToCompact encodes the sign bit, rounds away the
+// digits beyond the precision of the mantissa, and moves a mantissa which would
+// have the sign bit set into the next exponent.
+func TestBigToCompact(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want uint32
+	}{
+		{"zero", "0", 0x00000000},
+		{"one byte", "12", 0x01120000},
+		{"two bytes", "1234", 0x02123400},
+		{"three bytes", "123456", 0x03123456},
+		{"four bytes", "12345600", 0x04123456},
+		{"truncated digits", "123456789a", 0x05123456},
+		{"mantissa sign bit", "80", 0x02008000},
+		{"mantissa sign bit four bytes", "92345600", 0x05009234},
+		{"negative", "-12345600", 0x04923456},
+		{"negative mantissa sign bit", "-80", 0x02808000},
+		{"dash genesis",
+			"ffff0000000000000000000000000000000000000000000000000000000",
+			0x1e0ffff0},
+		{"bitcoin difficulty 1",
+			"ffff0000000000000000000000000000000000000000000000000000",
+			0x1d00ffff},
+		{"regtest pow limit",
+			"7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			0x207fffff},
+	}
+
+	for _, test := range tests {
+		n, ok := new(big.Int).SetString(test.in, 16)
+		if !ok {
+			t.Fatalf("%s: invalid number %q", test.name, test.in)
+		}
+		orig := new(big.Int).Set(n)
+		if got := BigToCompact(n); got != test.want {
+			t.Errorf("%s: got %#08x, want %#08x", test.name, got,
+				test.want)
+		}
+		if n.Cmp(orig) != 0 {
+			t.Errorf("%s: the passed number was modified", test.name)
+		}
+	}
+}
+
+// TestCompactRoundTrip ensures compact values in canonical form survive the
+// conversion to a big integer and back.
+func TestCompactRoundTrip(t *testing.T) {
+	tests := []uint32{
+		0x00000000, 0x01120000, 0x02123400, 0x03123456, 0x04123456,
+		0x04923456, 0x1b0404cb, 0x1d00ffff, 0x1e0ffff0, 0x207fffff,
+	}
+
+	for _, compact := range tests {
+		if got := BigToCompact(CompactToBig(compact)); got != compact {
+			t.Errorf("%#08x: round trip got %#08x", compact, got)
+		}
+	}
+}
+
+// TestCalcWork ensures the work is the inverse of the target and that bits
+// without a positive target have no work.
+func TestCalcWork(t *testing.T) {
+	tests := []struct {
+		name string
+		bits uint32
+		want string
+	}{
+		{"zero target", 0x00000000, "0"},
+		{"negative target", 0x04923456, "0"},
+		{"negative zero target", 0x01803456, "0"},
+		{"dash genesis", 0x1e0ffff0, "100010"},
+		{"bitcoin difficulty 1", 0x1d00ffff, "100010001"},
+		{"regtest pow limit", 0x207fffff, "2"},
+		{"target one", 0x01010000, "8000000000000000000000000000000000000000000000000000000000000000"},
+	}
+
+	for _, test := range tests {
+		want, _ := new(big.Int).SetString(test.want, 16)
+		if got := CalcWork(test.bits); got.Cmp(want) != 0 {
+			t.Errorf("%s: got %x, want %x", test.name, got, want)
+		}
+	}
+
+	// Work increases as the target decreases.
+	if CalcWork(0x1b0404cb).Cmp(CalcWork(0x1d00ffff)) <= 0 {
+		t.Error("lower target does not have more work")
+	}
+}
@@ -22,16 +22,19 @@ var genesisCoinbaseTx = wire.MsgTx{
                 Index: 0xffffffff,
             },
             SignatureScript: []byte{
-                0x04, 0xff, 0xff, 0x00, 0x1d, 0x01, 0x04, 0x45, /* |.......E| */
-                0x54, 0x68, 0x65, 0x20, 0x54, 0x69, 0x6d, 0x65, /* |The Time| */
-                0x73, 0x20, 0x30, 0x33, 0x2f, 0x4a, 0x61, 0x6e, /* |s 03/Jan| */
-                0x2f, 0x32, 0x30, 0x30, 0x39, 0x20, 0x43, 0x68, /* |/2009 Ch| */
-                0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x6f, 0x72, /* |ancellor| */
-                0x20, 0x6f, 0x6e, 0x20, 0x62, 0x72, 0x69, 0x6e, /* | on brin| */
-                0x6b, 0x20, 0x6f, 0x66, 0x20, 0x73, 0x65, 0x63, /* |k of sec|*/
-                0x6f, 0x6e, 0x64, 0x20, 0x62, 0x61, 0x69, 0x6c, /* |ond bail| */
-                0x6f, 0x75, 0x74, 0x20, 0x66, 0x6f, 0x72, 0x20, /* |out for |*/
-                0x62, 0x61, 0x6e, 0x6b, 0x73,                   /* |banks| */
+                0x04, 0xff, 0xff, 0x00, 0x1d, 0x01, 0x04, 0x4c, /* |.......L| */
+                0x59, 0x57, 0x69, 0x72, 0x65, 0x64, 0x20, 0x30, /* |YWired 0| */
+                0x39, 0x2f, 0x4a, 0x61, 0x6e, 0x2f, 0x32, 0x30, /* |9/Jan/20| */
+                0x31, 0x34, 0x20, 0x54, 0x68, 0x65, 0x20, 0x47, /* |14 The G| */
+                0x72, 0x61, 0x6e, 0x64, 0x20, 0x45, 0x78, 0x70, /* |rand Exp| */
+                0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x20, /* |eriment | */
+                0x47, 0x6f, 0x65, 0x73, 0x20, 0x4c, 0x69, 0x76, /* |Goes Liv| */
+                0x65, 0x3a, 0x20, 0x4f, 0x76, 0x65, 0x72, 0x73, /* |e: Overs| */
+                0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x63, 0x6f, 0x6d, /* |tock.com| */
+                0x20, 0x49, 0x73, 0x20, 0x4e, 0x6f, 0x77, 0x20, /* | Is Now | */
+                0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, /* |Acceptin| */
+                0x67, 0x20, 0x42, 0x69, 0x74, 0x63, 0x6f, 0x69, /* |g Bitcoi| */
+                0x6e, 0x73,                                     /* |ns| */
             },
             Sequence: 0xffffffff,
         },
@@ -40,15 +43,15 @@ var genesisCoinbaseTx = wire.MsgTx{
         {
             Value: 0x12a05f200,
             PkScript: []byte{
-                0x41, 0x04, 0x67, 0x8a, 0xfd, 0xb0, 0xfe, 0x55, /* |A.g....U| */
-                0x48, 0x27, 0x19, 0x67, 0xf1, 0xa6, 0x71, 0x30, /* |H'.g..q0| */
-                0xb7, 0x10, 0x5c, 0xd6, 0xa8, 0x28, 0xe0, 0x39, /* |..\..(.9| */
-                0x09, 0xa6, 0x79, 0x62, 0xe0, 0xea, 0x1f, 0x61, /* |..yb...a| */
-                0xde, 0xb6, 0x49, 0xf6, 0xbc, 0x3f, 0x4c, 0xef, /* |..I..?L.| */
-                0x38, 0xc4, 0xf3, 0x55, 0x04, 0xe5, 0x1e, 0xc1, /* |8..U....| */
-                0x12, 0xde, 0x5c, 0x38, 0x4d, 0xf7, 0xba, 0x0b, /* |..\8M...| */
-                0x8d, 0x57, 0x8a, 0x4c, 0x70, 0x2b, 0x6b, 0xf1, /* |.W.Lp+k.| */
-                0x1d, 0x5f, 0xac,                               /* |._.| */
+                0x41, 0x04, 0x01, 0x84, 0x71, 0x0f, 0xa6, 0x89, /* |A...q...| */
+                0xad, 0x50, 0x23, 0x69, 0x0c, 0x80, 0xf3, 0xa4, /* |.P#i....| */
+                0x9c, 0x8f, 0x13, 0xf8, 0xd4, 0x5b, 0x8c, 0x85, /* |.....[..| */
+                0x7f, 0xbc, 0xbc, 0x8b, 0xc4, 0xa8, 0xe4, 0xd3, /* |........| */
+                0xeb, 0x4b, 0x10, 0xf4, 0xd4, 0x60, 0x4f, 0xa0, /* |.K...`O.| */
+                0x8d, 0xce, 0x60, 0x1a, 0xaf, 0x0f, 0x47, 0x02, /* |..`...G.| */
+                0x16, 0xfe, 0x1b, 0x51, 0x85, 0x0b, 0x4a, 0xcf, /* |...Q..J.| */
+                0x21, 0xb1, 0x79, 0xc4, 0x50, 0x70, 0xac, 0x7b, /* |!.y.Pp.{| */
+                0x03, 0xa9, 0xac,                               /* |...| */
             },
         },
     },
@@ -82,7 +85,7 @@ var genesisBlock = wire.MsgBlock{
         MerkleRoot: genesisMerkleRoot,        // DASH e0028eb9648db56b1ac77cf090b99048a8007e2bb64b68f092c03c7f56a662c7
         Timestamp:  time.Unix(0x52DB2D02, 0), // DASH Unix 1390095618
         Bits:       0x1e0ffff0,               // DASH
-        Nonce:      0x121b062,                // 28917698 DASH
+        Nonce:      0x1b93fc2,                // 28917698 DASH
     },
     Transactions: []*wire.MsgTx{&genesisCoinbaseTx},
 }
@@ -0,0 +1,30 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import "fmt"
+
+// BIP44Purpose is the purpose index of the first level of BIP44 derivation
+// paths.  It is always used hardened.
+const BIP44Purpose = 44
+
+// IsHDPrivateKeyID returns whether the passed version bytes identify a
+// hierarchical deterministic private extended key of the network.
+func (p *Params) IsHDPrivateKeyID(id [4]byte) bool {
+	return id == p.HDPrivateKeyID
+}
+
+// IsHDPublicKeyID returns whether the passed version bytes identify a
+// hierarchical deterministic public extended key of the network.
+func (p *Params) IsHDPublicKeyID(id [4]byte) bool {
+	return id == p.HDPublicKeyID
+}
+
+// BIP44PathPrefix returns the BIP44 derivation path prefix of the network,
+// m/44'/coinType', under which the accounts of a wallet are derived.  The main
+// network uses coin type 5 and the test networks coin type 1.
+func (p *Params) BIP44PathPrefix() string {
+	return fmt.Sprintf("m/%d'/%d'", BIP44Purpose, p.HDCoinType)
+}
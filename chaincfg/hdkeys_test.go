@@ -0,0 +1,71 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestHDKeyIDs ensures the extended key version bytes of each network are
+// recognized and converted between their private and public forms.
+func TestHDKeyIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *Params
+		prefix string
+	}{
+		{"mainnet", &MainNetParams, "m/44'/5'"},
+		{"testnet", &TestNet3Params, "m/44'/1'"},
+		{"regtest", &RegressionNetParams, "m/44'/1'"},
+	}
+
+	for _, test := range tests {
+		params := test.params
+		if !params.IsHDPrivateKeyID(params.HDPrivateKeyID) ||
+			params.IsHDPrivateKeyID(params.HDPublicKeyID) {
+
+			t.Errorf("%s: IsHDPrivateKeyID: unexpected result",
+				test.name)
+		}
+		if !params.IsHDPublicKeyID(params.HDPublicKeyID) ||
+			params.IsHDPublicKeyID(params.HDPrivateKeyID) {
+
+			t.Errorf("%s: IsHDPublicKeyID: unexpected result",
+				test.name)
+		}
+
+		privID, err := HDPublicKeyToPrivateKeyID(params.HDPublicKeyID[:])
+		if err != nil {
+			t.Errorf("%s: HDPublicKeyToPrivateKeyID: unexpected "+
+				"error: %v", test.name, err)
+		} else if !bytes.Equal(privID, params.HDPrivateKeyID[:]) {
+			t.Errorf("%s: HDPublicKeyToPrivateKeyID: got %x, want "+
+				"%x", test.name, privID, params.HDPrivateKeyID)
+		}
+
+		if prefix := params.BIP44PathPrefix(); prefix != test.prefix {
+			t.Errorf("%s: BIP44PathPrefix: got %s, want %s",
+				test.name, prefix, test.prefix)
+		}
+	}
+
+	if !MainNetParams.IsHDPublicKeyID([4]byte{0x04, 0x88, 0xb2, 0x1e}) {
+		t.Error("IsHDPublicKeyID: xpub not recognized on mainnet")
+	}
+	if MainNetParams.IsHDPublicKeyID(TestNet3Params.HDPublicKeyID) {
+		t.Error("IsHDPublicKeyID: tpub recognized on mainnet")
+	}
+
+	_, err := HDPublicKeyToPrivateKeyID([]byte{0xff, 0xff, 0xff, 0xff})
+	if err != ErrUnknownHDKeyID {
+		t.Errorf("HDPublicKeyToPrivateKeyID: got error %v, want %v",
+			err, ErrUnknownHDKeyID)
+	}
+	if _, err := HDPublicKeyToPrivateKeyID([]byte{0x04}); err != ErrUnknownHDKeyID {
+		t.Errorf("HDPublicKeyToPrivateKeyID: got error %v, want %v",
+			err, ErrUnknownHDKeyID)
+	}
+}
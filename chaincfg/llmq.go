@@ -0,0 +1,319 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"fmt"
+
+	"github.com/nargott/godash/wire"
+)
+
+// These constants define the LLMQ types as defined by DIP0006 and used in
+// quorum commitments, islocks and the quorum RPCs.
+const (
+	LLMQType50_60           = 1   // 50 members, 30 (60%) threshold
+	LLMQType400_60          = 2   // 400 members, 240 (60%) threshold
+	LLMQType400_85          = 3   // 400 members, 340 (85%) threshold
+	LLMQType100_67          = 4   // 100 members, 67 (67%) threshold
+	LLMQType60_75           = 5   // 60 members, 45 (75%) threshold, rotating
+	LLMQType25_67           = 6   // 25 members, 17 (67%) threshold
+	LLMQTypeTest            = 100 // 3 members, 2 (66%) threshold
+	LLMQTypeDevnet          = 101 // 12 members, 6 (50%) threshold
+	LLMQTypeTestV17         = 102 // 3 members, 2 (66%) threshold
+	LLMQTypeTestDIP0024     = 103 // 4 members, 2 (66%) threshold, rotating
+	LLMQTypeTestInstantSend = 104 // 3 members, 2 (66%) threshold
+	LLMQTypeDevnetDIP0024   = 105 // 8 members, 4 (50%) threshold, rotating
+)
+
+// LLMQParams defines the parameters of a long living masternode quorum type as
+// defined by DIP0006.
+type LLMQParams struct {
+	// Type is the LLMQ type identifying the quorums on the network.
+	Type int
+
+	// Name is the name of the LLMQ type, such as "llmq_50_60".
+	Name string
+
+	// UseRotation defines whether the quorums are rotated as defined by
+	// DIP0024 instead of being created at once by a single DKG session.
+	UseRotation bool
+
+	// Size is the number of members of a quorum.
+	Size int
+
+	// MinSize is the minimum number of valid members required for a DKG
+	// session to result in a quorum.
+	MinSize int
+
+	// Threshold is the number of signature shares required to recover the
+	// quorum signature.
+	Threshold int
+
+	// DKGInterval is the number of blocks between two DKG sessions.
+	DKGInterval int
+
+	// DKGPhaseBlocks is the number of blocks each phase of a DKG session
+	// lasts.
+	DKGPhaseBlocks int
+
+	// DKGMiningWindowStart and DKGMiningWindowEnd define the offsets from
+	// the start of a DKG session between which the final commitment may be
+	// mined.
+	DKGMiningWindowStart int
+	DKGMiningWindowEnd   int
+
+	// DKGBadVotesThreshold is the number of complaints after which a member
+	// is considered bad.
+	DKGBadVotesThreshold int
+
+	// SigningActiveQuorumCount is the number of the most recent quorums
+	// which are active for signing at any time.
+	SigningActiveQuorumCount int
+
+	// KeepOldConnections is the number of the most recent quorums whose
+	// members keep connections to each other.
+	KeepOldConnections int
+
+	// RecoveryMembers is the number of members which attempt to recover a
+	// signature from the collected shares.
+	RecoveryMembers int
+}
+
+var (
+	llmq50_60 = LLMQParams{
+		Type:                     LLMQType50_60,
+		Name:                     "llmq_50_60",
+		Size:                     50,
+		MinSize:                  40,
+		Threshold:                30,
+		DKGInterval:              24, // one DKG per hour
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     10,
+		DKGMiningWindowEnd:       18,
+		DKGBadVotesThreshold:     40,
+		SigningActiveQuorumCount: 24, // a full day worth of LLMQs
+		KeepOldConnections:       25,
+		RecoveryMembers:          25,
+	}
+
+	llmq400_60 = LLMQParams{
+		Type:                     LLMQType400_60,
+		Name:                     "llmq_400_60",
+		Size:                     400,
+		MinSize:                  300,
+		Threshold:                240,
+		DKGInterval:              24 * 12, // one DKG every 12 hours
+		DKGPhaseBlocks:           4,
+		DKGMiningWindowStart:     20,
+		DKGMiningWindowEnd:       28,
+		DKGBadVotesThreshold:     300,
+		SigningActiveQuorumCount: 4, // two days worth of LLMQs
+		KeepOldConnections:       5,
+		RecoveryMembers:          100,
+	}
+
+	llmq400_85 = LLMQParams{
+		Type:                     LLMQType400_85,
+		Name:                     "llmq_400_85",
+		Size:                     400,
+		MinSize:                  350,
+		Threshold:                340,
+		DKGInterval:              24 * 24, // one DKG every 24 hours
+		DKGPhaseBlocks:           4,
+		DKGMiningWindowStart:     20,
+		DKGMiningWindowEnd:       48, // give it a larger mining window
+		DKGBadVotesThreshold:     300,
+		SigningActiveQuorumCount: 4, // four days worth of LLMQs
+		KeepOldConnections:       5,
+		RecoveryMembers:          100,
+	}
+
+	llmq100_67 = LLMQParams{
+		Type:                     LLMQType100_67,
+		Name:                     "llmq_100_67",
+		Size:                     100,
+		MinSize:                  80,
+		Threshold:                67,
+		DKGInterval:              24, // one DKG per hour
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     10,
+		DKGMiningWindowEnd:       18,
+		DKGBadVotesThreshold:     80,
+		SigningActiveQuorumCount: 24, // a full day worth of LLMQs
+		KeepOldConnections:       25,
+		RecoveryMembers:          50,
+	}
+
+	llmq60_75 = LLMQParams{
+		Type:                     LLMQType60_75,
+		Name:                     "llmq_60_75",
+		UseRotation:              true,
+		Size:                     60,
+		MinSize:                  50,
+		Threshold:                45,
+		DKGInterval:              24 * 12, // one DKG cycle every 12 hours
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     42,
+		DKGMiningWindowEnd:       50,
+		DKGBadVotesThreshold:     48,
+		SigningActiveQuorumCount: 32,
+		KeepOldConnections:       64,
+		RecoveryMembers:          25,
+	}
+
+	llmq25_67 = LLMQParams{
+		Type:                     LLMQType25_67,
+		Name:                     "llmq_25_67",
+		Size:                     25,
+		MinSize:                  22,
+		Threshold:                17,
+		DKGInterval:              24, // one DKG per hour
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     10,
+		DKGMiningWindowEnd:       18,
+		DKGBadVotesThreshold:     22,
+		SigningActiveQuorumCount: 24, // a full day worth of LLMQs
+		KeepOldConnections:       25,
+		RecoveryMembers:          12,
+	}
+
+	llmqTest = LLMQParams{
+		Type:                     LLMQTypeTest,
+		Name:                     "llmq_test",
+		Size:                     3,
+		MinSize:                  2,
+		Threshold:                2,
+		DKGInterval:              24,
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     10,
+		DKGMiningWindowEnd:       18,
+		DKGBadVotesThreshold:     2,
+		SigningActiveQuorumCount: 2,
+		KeepOldConnections:       3,
+		RecoveryMembers:          3,
+	}
+
+	llmqTestInstantSend = LLMQParams{
+		Type:                     LLMQTypeTestInstantSend,
+		Name:                     "llmq_test_instantsend",
+		Size:                     3,
+		MinSize:                  2,
+		Threshold:                2,
+		DKGInterval:              24,
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     10,
+		DKGMiningWindowEnd:       18,
+		DKGBadVotesThreshold:     2,
+		SigningActiveQuorumCount: 2,
+		KeepOldConnections:       3,
+		RecoveryMembers:          3,
+	}
+
+	llmqTestV17 = LLMQParams{
+		Type:                     LLMQTypeTestV17,
+		Name:                     "llmq_test_v17",
+		Size:                     3,
+		MinSize:                  2,
+		Threshold:                2,
+		DKGInterval:              24,
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     10,
+		DKGMiningWindowEnd:       18,
+		DKGBadVotesThreshold:     2,
+		SigningActiveQuorumCount: 2,
+		KeepOldConnections:       3,
+		RecoveryMembers:          3,
+	}
+
+	llmqTestDIP0024 = LLMQParams{
+		Type:                     LLMQTypeTestDIP0024,
+		Name:                     "llmq_test_dip0024",
+		UseRotation:              true,
+		Size:                     4,
+		MinSize:                  4,
+		Threshold:                2,
+		DKGInterval:              24,
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     12,
+		DKGMiningWindowEnd:       20,
+		DKGBadVotesThreshold:     2,
+		SigningActiveQuorumCount: 2,
+		KeepOldConnections:       4,
+		RecoveryMembers:          3,
+	}
+
+	llmqDevnet = LLMQParams{
+		Type:                     LLMQTypeDevnet,
+		Name:                     "llmq_devnet",
+		Size:                     12,
+		MinSize:                  7,
+		Threshold:                6,
+		DKGInterval:              24,
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     10,
+		DKGMiningWindowEnd:       18,
+		DKGBadVotesThreshold:     7,
+		SigningActiveQuorumCount: 4,
+		KeepOldConnections:       5,
+		RecoveryMembers:          6,
+	}
+
+	llmqDevnetDIP0024 = LLMQParams{
+		Type:                     LLMQTypeDevnetDIP0024,
+		Name:                     "llmq_devnet_dip0024",
+		UseRotation:              true,
+		Size:                     8,
+		MinSize:                  6,
+		Threshold:                4,
+		DKGInterval:              48,
+		DKGPhaseBlocks:           2,
+		DKGMiningWindowStart:     12,
+		DKGMiningWindowEnd:       20,
+		DKGBadVotesThreshold:     7,
+		SigningActiveQuorumCount: 2,
+		KeepOldConnections:       4,
+		RecoveryMembers:          4,
+	}
+)
+
+// newQuorums returns a map of the passed LLMQ parameters keyed by their type
+// as used for the Quorums field of the network parameters.
+func newQuorums(params ...LLMQParams) map[int]LLMQParams {
+	quorums := make(map[int]LLMQParams, len(params))
+	for _, p := range params {
+		quorums[p.Type] = p
+	}
+	return quorums
+}
+
+// QuorumParams returns the parameters of the LLMQ type with the given name,
+// such as "llmq_50_60", and whether the type is enabled on the network.
+func (p *Params) QuorumParams(name string) (LLMQParams, bool) {
+	for _, quorum := range p.Quorums {
+		if quorum.Name == name {
+			return quorum, true
+		}
+	}
+	return LLMQParams{}, false
+}
+
+// CheckCommitmentSize returns an error unless the signers and valid members
+// bitsets of the passed final commitment hold one bit per member of the
+// quorums of the LLMQ type, as dashd requires of commitments.
+func (q *LLMQParams) CheckCommitmentSize(c *wire.QuorumCommitment) error {
+	if int(c.LLMQType) != q.Type {
+		return fmt.Errorf("commitment of LLMQ type %d checked against "+
+			"type %d", c.LLMQType, q.Type)
+	}
+	if len(c.Signers) != q.Size {
+		return fmt.Errorf("commitment has %d signers bits, want %d",
+			len(c.Signers), q.Size)
+	}
+	if len(c.ValidMembers) != q.Size {
+		return fmt.Errorf("commitment has %d valid members bits, want %d",
+			len(c.ValidMembers), q.Size)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"testing"
+
+	"github.com/nargott/godash/wire"
+)
+
+// TestQuorums ensures the expected LLMQ types are enabled on each network and
+// their parameters are consistent.
+func TestQuorums(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *Params
+		types  []int
+	}{
+		{"mainnet", &MainNetParams, []int{LLMQType50_60, LLMQType60_75,
+			LLMQType400_60, LLMQType400_85, LLMQType100_67}},
+		{"regtest", &RegressionNetParams, []int{LLMQTypeTest,
+			LLMQTypeTestInstantSend, LLMQTypeTestV17, LLMQTypeTestDIP0024}},
+		{"testnet", &TestNet3Params, []int{LLMQType50_60, LLMQType60_75,
+			LLMQType400_60, LLMQType400_85, LLMQType100_67, LLMQType25_67}},
+		{"devnet", DevNetParams("test"), []int{LLMQTypeDevnet,
+			LLMQTypeDevnetDIP0024, LLMQType50_60, LLMQType60_75,
+			LLMQType400_60, LLMQType400_85, LLMQType100_67}},
+	}
+
+	for _, test := range tests {
+		if len(test.params.Quorums) != len(test.types) {
+			t.Errorf("%s: got %d LLMQ types, want %d", test.name,
+				len(test.params.Quorums), len(test.types))
+		}
+		for _, llmqType := range test.types {
+			quorum, ok := test.params.Quorums[llmqType]
+			if !ok {
+				t.Errorf("%s: LLMQ type %d not enabled", test.name,
+					llmqType)
+				continue
+			}
+			if quorum.Type != llmqType {
+				t.Errorf("%s: LLMQ type %d has type %d", test.name,
+					llmqType, quorum.Type)
+			}
+			if quorum.Threshold > quorum.MinSize ||
+				quorum.MinSize > quorum.Size {
+				t.Errorf("%s: %s: inconsistent sizes", test.name,
+					quorum.Name)
+			}
+			if quorum.Size > wire.MaxLLMQSize {
+				t.Errorf("%s: %s: size %d exceeds wire.MaxLLMQSize",
+					test.name, quorum.Name, quorum.Size)
+			}
+			if quorum.DKGMiningWindowEnd > quorum.DKGInterval ||
+				quorum.DKGMiningWindowStart > quorum.DKGMiningWindowEnd {
+				t.Errorf("%s: %s: inconsistent mining window",
+					test.name, quorum.Name)
+			}
+
+			byName, ok := test.params.QuorumParams(quorum.Name)
+			if !ok || byName != quorum {
+				t.Errorf("%s: QuorumParams(%q) mismatch", test.name,
+					quorum.Name)
+			}
+		}
+	}
+
+	quorum := MainNetParams.Quorums[LLMQType400_60]
+	if quorum.Name != "llmq_400_60" || quorum.Size != 400 ||
+		quorum.Threshold != 240 || quorum.DKGInterval != 288 {
+		t.Errorf("unexpected llmq_400_60 parameters: %+v", quorum)
+	}
+	if _, ok := MainNetParams.QuorumParams("llmq_test"); ok {
+		t.Error("llmq_test unexpectedly enabled on mainnet")
+	}
+}
+
+// TestCheckCommitmentSize ensures the bitsets of a final commitment must hold
+// one bit per member of the quorums of its LLMQ type.
+func TestCheckCommitmentSize(t *testing.T) {
+	quorum := MainNetParams.Quorums[LLMQType50_60]
+	bits := func(n int) []bool { return make([]bool, n) }
+
+	tests := []struct {
+		name         string
+		llmqType     uint8
+		signers      []bool
+		validMembers []bool
+		valid        bool
+	}{
+		{"quorum size", LLMQType50_60, bits(50), bits(50), true},
+		{"other LLMQ type", LLMQType400_60, bits(50), bits(50), false},
+		{"short signers", LLMQType50_60, bits(49), bits(50), false},
+		{"long valid members", LLMQType50_60, bits(50), bits(51), false},
+	}
+
+	for _, test := range tests {
+		c := &wire.QuorumCommitment{
+			Version:      wire.QcVersionLegacyBLS,
+			LLMQType:     test.llmqType,
+			Signers:      test.signers,
+			ValidMembers: test.validMembers,
+		}
+		err := quorum.CheckCommitmentSize(c)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
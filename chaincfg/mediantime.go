@@ -0,0 +1,46 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"sort"
+	"time"
+
+	"github.com/nargott/godash/wire"
+)
+
+// MedianTimeBlocks is the number of blocks whose timestamps are used to
+// calculate the median time past of a block.
+const MedianTimeBlocks = 11
+
+// CalcMedianTimePast returns the median time past of the last of the passed
+// headers, which must be ordered from oldest to newest.  It is the median of
+// the timestamps of the last MedianTimeBlocks headers, which BIP0113 uses for
+// lock times and BIP0009 uses to compare against the StartTime and ExpireTime
+// of a ConsensusDeployment.  The zero time is returned when no headers are
+// passed.
+//
+// Near the start of the chain there are fewer than MedianTimeBlocks headers.
+// For an even number of headers, the consensus rules take the later of the
+// two middle timestamps rather than averaging them, and so does this function.
+// It matches CalcPastMedianTime of the blockchain package, which can't be used
+// here since that package depends on this one.
+func CalcMedianTimePast(headers []*wire.BlockHeader) time.Time {
+	if len(headers) > MedianTimeBlocks {
+		headers = headers[len(headers)-MedianTimeBlocks:]
+	}
+	if len(headers) == 0 {
+		return time.Time{}
+	}
+
+	timestamps := make([]int64, 0, len(headers))
+	for _, header := range headers {
+		timestamps = append(timestamps, header.Timestamp.Unix())
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+	return time.Unix(timestamps[len(timestamps)/2], 0)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nargott/godash/wire"
+)
+
+// TestCalcMedianTimePast ensures the median time past is the median of at most
+// the last 11 timestamps regardless of their order, and the later of the two
+// middle timestamps for an even number of headers.
+func TestCalcMedianTimePast(t *testing.T) {
+	tests := []struct {
+		name       string
+		timestamps []int64
+		want       int64
+	}{
+		{"no headers", nil, 0},
+		{"single header", []int64{1390095618}, 1390095618},
+		{"two headers", []int64{100, 200}, 200},
+		{"even unordered", []int64{400, 100, 300, 200}, 300},
+		{"odd unordered", []int64{500, 100, 400, 200, 300}, 300},
+		{"eleven headers", []int64{11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, 6},
+		{"only last eleven", []int64{1000, 1000, 1000, 1, 2, 3, 4, 5, 6,
+			7, 8, 9, 10, 11}, 6},
+	}
+
+	for i, test := range tests {
+		headers := make([]*wire.BlockHeader, 0, len(test.timestamps))
+		for _, timestamp := range test.timestamps {
+			headers = append(headers, &wire.BlockHeader{
+				Timestamp: time.Unix(timestamp, 0),
+			})
+		}
+
+		want := time.Unix(test.want, 0)
+		if test.timestamps == nil {
+			want = time.Time{}
+		}
+		got := CalcMedianTimePast(headers)
+		if !got.Equal(want) {
+			t.Errorf("Test #%d (%s) got %v, want %v", i, test.name,
+				got.Unix(), want.Unix())
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"bytes"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godash/wire"
+)
+
+// MagicMessageHash returns the hash which is signed when the message is signed
+// with the key of an address of the network, such as by the signmessage RPC.
+// It is the double SHA-256 of the network's MessageMagic prefix followed by the
+// message, each serialized as a variable length string.  The compact signature
+// returned by signmessage can be verified offline by recovering the public key
+// from it and this hash.
+func MagicMessageHash(p *Params, message string) chainhash.Hash {
+	var buf bytes.Buffer
+	// Writing to a bytes.Buffer never fails, so the errors are ignored.
+	_ = wire.WriteVarString(&buf, 0, p.MessageMagic)
+	_ = wire.WriteVarString(&buf, 0, message)
+	return chainhash.DoubleHashH(buf.Bytes())
+}
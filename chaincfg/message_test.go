@@ -0,0 +1,33 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import "testing"
+
+// TestMagicMessageHash ensures signed messages are hashed with the Dash message
+// prefix on every network.
+func TestMagicMessageHash(t *testing.T) {
+	// The expected hash is the double SHA-256 of the serialized
+	// "DarkCoin Signed Message:\n" prefix followed by the serialized
+	// message "hello".
+	want := "29ea95bc6f33ac3172d61315d5c50683c1f9ae320a0348a1120476dffb6f39a5"
+
+	tests := []struct {
+		name   string
+		params *Params
+	}{
+		{"mainnet", &MainNetParams},
+		{"testnet", &TestNet3Params},
+		{"regtest", &RegressionNetParams},
+		{"devnet", DevNetParams("test")},
+	}
+
+	for _, test := range tests {
+		hash := MagicMessageHash(test.params, "hello")
+		if hash.String() != want {
+			t.Errorf("%s: got %v, want %v", test.name, hash, want)
+		}
+	}
+}
@@ -6,8 +6,10 @@ package chaincfg
 
 import (
     "errors"
+    "fmt"
     "math"
     "math/big"
+    "sort"
     "strings"
     "time"
 
@@ -22,24 +24,32 @@ var (
     // the overhead of creating it multiple times.
     bigOne = big.NewInt(1)
 
-    // mainPowLimit is the highest proof of work value a Bitcoin block can
-    // have for the main network.  It is the value 2^224 - 1.
-    mainPowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 224), bigOne)
+    // mainPowLimit is the highest proof of work value a Dash block can
+    // have for the main network.  It is the value 0x0fffff * 2^216, which
+    // is 00000fffff000000000000000000000000000000000000000000000000000000.
+    mainPowLimit = new(big.Int).Lsh(big.NewInt(0x0fffff), 216)
 
     // regressionPowLimit is the highest proof of work value a Bitcoin block
     // can have for the regression test network.  It is the value 2^255 - 1.
     regressionPowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 255), bigOne)
 
-    // testNet3PowLimit is the highest proof of work value a Bitcoin block
+    // testNet3PowLimit is the highest proof of work value a Dash block
     // can have for the test network (version 3).  It is the value
-    // 2^224 - 1.
-    testNet3PowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 224), bigOne)
+    // 0x0fffff * 2^216 like on the main network.
+    testNet3PowLimit = new(big.Int).Lsh(big.NewInt(0x0fffff), 216)
 
     // simNetPowLimit is the highest proof of work value a Bitcoin block
     // can have for the simulation test network.  It is the value 2^255 - 1.
     simNetPowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 255), bigOne)
 )
 
+// MasternodeRewardStep defines the share of the block reward in basis points
+// which is paid to the masternode of the blocks from a given height on.
+type MasternodeRewardStep struct {
+    Height int32
+    Ratio  int64
+}
+
 // Checkpoint identifies a known good point in the block chain.  Using
 // checkpoints allows a few optimizations for old blocks during initial download
 // and also prevents forks from old blocks.
@@ -76,6 +86,17 @@ type ConsensusDeployment struct {
     // ExpireTime is the median block time after which the attempted
     // deployment expires.
     ExpireTime uint64
+
+    // MinerConfirmationWindow is the number of blocks in each threshold
+    // state retarget window of the deployment.  The MinerConfirmationWindow
+    // of the chain parameters is used when it is zero.
+    MinerConfirmationWindow uint32
+
+    // RuleChangeActivationThreshold is the number of blocks of a window
+    // which must signal for the deployment to lock in.  The
+    // RuleChangeActivationThreshold of the chain parameters is used when
+    // it is zero.
+    RuleChangeActivationThreshold uint32
 }
 
 // Constants that define the deployment offset in the deployments field of the
@@ -96,6 +117,14 @@ const (
     // includes the deployment of BIPS 141, 142, 144, 145, 147 and 173.
     DeploymentSegwit
 
+    // DeploymentDIP0003 defines the rule change deployment ID for the
+    // deterministic masternode lists soft-fork as defined by DIP0003.
+    DeploymentDIP0003
+
+    // DeploymentDIP0008 defines the rule change deployment ID for the
+    // LLMQ based ChainLocks soft-fork as defined by DIP0008.
+    DeploymentDIP0008
+
     // NOTE: DefinedDeployments must always come last since it is used to
     // determine how many defined deployments there currently are.
 
@@ -126,6 +155,14 @@ type Params struct {
     // GenesisHash is the starting block hash.
     GenesisHash *chainhash.Hash
 
+    // DevNetGenesisBlock defines the devnet specific block which directly
+    // follows GenesisBlock on a devnet.  It is nil for all other networks.
+    DevNetGenesisBlock *wire.MsgBlock
+
+    // DevNetGenesisHash is the hash of DevNetGenesisBlock.  It is nil for
+    // all other networks.
+    DevNetGenesisHash *chainhash.Hash
+
     // PowLimit defines the highest allowed proof of work value for a block
     // as a uint256.
     PowLimit *big.Int
@@ -140,6 +177,11 @@ type Params struct {
     BIP0065Height int32
     BIP0066Height int32
 
+    // BLSBasicSchemeHeight is the height of the v19 hard fork at which the
+    // serialization of BLS public keys and signatures switched from the
+    // legacy scheme to the basic scheme.
+    BLSBasicSchemeHeight int32
+
     // CoinbaseMaturity is the number of blocks required before newly mined
     // coins (coinbase transactions) can be spent.
     CoinbaseMaturity uint16
@@ -148,6 +190,29 @@ type Params struct {
     // is reduced.
     SubsidyReductionInterval int32
 
+    // BudgetPaymentsStartBlock is the height after which 10% of the block
+    // subsidy is set aside for governance superblocks.
+    BudgetPaymentsStartBlock int32
+
+    // SuperblockStartBlock is the first height at which governance
+    // superblocks may occur, and SuperblockCycle is the number of blocks
+    // between superblocks.  Superblocks occur at the heights at or after
+    // the start block which are a multiple of the cycle.
+    SuperblockStartBlock int32
+    SuperblockCycle      int32
+
+    // MasternodePaymentsIncreaseBlock is the height after which the
+    // masternode share of the block reward starts growing from 20%, and
+    // MasternodePaymentsIncreasePeriod is the number of blocks between each
+    // increase until it reaches 50%.
+    MasternodePaymentsIncreaseBlock  int32
+    MasternodePaymentsIncreasePeriod int32
+
+    // MasternodeRewardReallocation is the schedule of the block reward
+    // reallocation which raises the masternode share from 50% to 60%.
+    // See MasternodeRewardRatio.
+    MasternodeRewardReallocation []MasternodeRewardStep
+
     // TargetTimespan is the desired amount of time that should elapse
     // before the block difficulty requirement is examined to determine how
     // it should be changed in order to maintain the desired block
@@ -175,6 +240,11 @@ type Params struct {
     // NOTE: This only applies if ReduceMinDifficulty is true.
     MinDiffReductionTime time.Duration
 
+    // PowNoRetargeting defines whether the difficulty stays at the bits of
+    // the previous block instead of being retargeted.  This is only useful
+    // for the regression test network.
+    PowNoRetargeting bool
+
     // GenerateSupported specifies whether or not CPU mining is allowed.
     GenerateSupported bool
 
@@ -198,6 +268,10 @@ type Params struct {
     MinerConfirmationWindow       uint32
     Deployments                   [DefinedDeployments]ConsensusDeployment
 
+    // Quorums defines the parameters of the LLMQ types enabled on the
+    // network keyed by their type.
+    Quorums map[int]LLMQParams
+
     // Mempool parameters
     RelayNonStdTxs bool
 
@@ -219,6 +293,10 @@ type Params struct {
     // BIP44 coin type used in the hierarchical deterministic path for
     // address generation.
     HDCoinType uint32
+
+    // MessageMagic is the prefix of the messages signed with the key of an
+    // address, such as by the signmessage RPC.
+    MessageMagic string
 }
 
 // MainNetParams defines the network parameters for the main Bitcoin network.
@@ -237,10 +315,11 @@ var MainNetParams = Params{
     GenesisBlock:             &genesisBlock,
     GenesisHash:              &genesisHash,
     PowLimit:                 mainPowLimit,
-    PowLimitBits:             0x1d00ffff, //? DASH 00000fffff000000000000000000000000000000000000000000000000000000
+    PowLimitBits:             0x1e0fffff,
     BIP0034Height:            1, // DASH 000007d91d1254d60e2dd1ae580383070a4ddffa4c64c2eeb4a2f9ecc0414343
     BIP0065Height:            388381, // 000000000000000004c2b624ed5d7756c508d90fd0da2c7c679febfa6c4735f0
     BIP0066Height:            363725, // 00000000000000000379eaa19dce8c9b722d46ae6a57c2f1a988119488b50931
+    BLSBasicSchemeHeight:     1899072, // v19 hard fork
     CoinbaseMaturity:         100,
     SubsidyReductionInterval: 210240,
     TargetTimespan:           24 * 60 * 60,      // Dash: 1 day
@@ -250,6 +329,37 @@ var MainNetParams = Params{
     MinDiffReductionTime:     0,
     GenerateSupported:        false,
 
+    // Budget and masternode payment parameters
+    BudgetPaymentsStartBlock:         328008,
+    SuperblockStartBlock:             614820,
+    SuperblockCycle:                  16616, // ~1 month
+    MasternodePaymentsIncreaseBlock:  158000,
+    MasternodePaymentsIncreasePeriod: 576 * 30,
+
+    // The reallocation activated at block 1374912 and takes effect from
+    // the next superblock cycle, raising the share every 3 cycles.
+    MasternodeRewardReallocation: []MasternodeRewardStep{
+        {1379128, 5130},
+        {1428976, 5260},
+        {1478824, 5330},
+        {1528672, 5400},
+        {1578520, 5460},
+        {1628368, 5520},
+        {1678216, 5570},
+        {1728064, 5620},
+        {1777912, 5670},
+        {1827760, 5720},
+        {1877608, 5770},
+        {1927456, 5820},
+        {1977304, 5850},
+        {2027152, 5880},
+        {2077000, 5910},
+        {2126848, 5940},
+        {2176696, 5970},
+        {2226544, 5990},
+        {2276392, 6000},
+    },
+
     // Checkpoints ordered from oldest to newest for DASH
     Checkpoints: []Checkpoint{
         {4991, newHashFromStr("000000003b01809551952460744d5dbb8fcbd6cbae3c220267bf7fa43f837367")},
@@ -296,8 +406,26 @@ var MainNetParams = Params{
             StartTime:  1508025600, // Oct 15th, 2017
             ExpireTime: 1539561600, // Oct 15th, 2018
         },
+        DeploymentDIP0003: {
+            BitNumber:                     3,
+            StartTime:                     1546300800, // Jan 1st, 2019
+            ExpireTime:                    1577836800, // Jan 1st, 2020
+            MinerConfirmationWindow:       4032,
+            RuleChangeActivationThreshold: 3226, // 80% of 4032
+        },
+        DeploymentDIP0008: {
+            BitNumber:                     4,
+            StartTime:                     1557878400, // May 15th, 2019
+            ExpireTime:                    1589500800, // May 15th, 2020
+            MinerConfirmationWindow:       4032,
+            RuleChangeActivationThreshold: 3226, // 80% of 4032
+        },
     },
 
+    // Enabled LLMQ types
+    Quorums: newQuorums(llmq50_60, llmq60_75, llmq400_60, llmq400_85,
+        llmq100_67),
+
     // Mempool parameters
     RelayNonStdTxs: false,
 
@@ -319,6 +447,9 @@ var MainNetParams = Params{
     // BIP44 coin type used in the hierarchical deterministic path for
     // address generation.
     HDCoinType: 5, //for DASH
+
+    // Prefix of signed messages.
+    MessageMagic: "DarkCoin Signed Message:\n",
 }
 
 // RegressionNetParams defines the network parameters for the regression test
@@ -339,14 +470,23 @@ var RegressionNetParams = Params{
     BIP0034Height:            100000000, // Not active - Permit ver 1 blocks
     BIP0065Height:            1351,      // Used by regression tests
     BIP0066Height:            1251,      // Used by regression tests
+    BLSBasicSchemeHeight:     900,       // v19 hard fork
     SubsidyReductionInterval: 150,
     TargetTimespan:           time.Hour * 24 * 1, // DASH 1 day
     TargetTimePerBlock:       time.Second * 150,    // DASH 2.5 minutes
     RetargetAdjustmentFactor: 4,                   // 25% less, 400% more
     ReduceMinDifficulty:      true,
     MinDiffReductionTime:     time.Minute * 20, // TargetTimePerBlock * 2
+    PowNoRetargeting:         true,
     GenerateSupported:        true,
 
+    // Budget and masternode payment parameters
+    BudgetPaymentsStartBlock:         1000,
+    SuperblockStartBlock:             1500,
+    SuperblockCycle:                  10,
+    MasternodePaymentsIncreaseBlock:  350,
+    MasternodePaymentsIncreasePeriod: 10,
+
     // Checkpoints ordered from oldest to newest.
     Checkpoints: nil,
 
@@ -372,8 +512,22 @@ var RegressionNetParams = Params{
             StartTime:  0,             // Always available for vote
             ExpireTime: math.MaxInt64, // Never expires.
         },
+        DeploymentDIP0003: {
+            BitNumber:  3,
+            StartTime:  0,             // Always available for vote
+            ExpireTime: math.MaxInt64, // Never expires
+        },
+        DeploymentDIP0008: {
+            BitNumber:  4,
+            StartTime:  0,             // Always available for vote
+            ExpireTime: math.MaxInt64, // Never expires
+        },
     },
 
+    // Enabled LLMQ types
+    Quorums: newQuorums(llmqTest, llmqTestInstantSend, llmqTestV17,
+        llmqTestDIP0024),
+
     // Mempool parameters
     RelayNonStdTxs: true,
 
@@ -393,6 +547,9 @@ var RegressionNetParams = Params{
     // BIP44 coin type used in the hierarchical deterministic path for
     // address generation.
     HDCoinType: 1,
+
+    // Prefix of signed messages.
+    MessageMagic: "DarkCoin Signed Message:\n",
 }
 
 // TestNet3Params defines the network parameters for the test Bitcoin network
@@ -411,10 +568,11 @@ var TestNet3Params = Params{
     GenesisBlock:             &testNet3GenesisBlock,
     GenesisHash:              &testNet3GenesisHash,
     PowLimit:                 testNet3PowLimit,
-    PowLimitBits:             0x1d00ffff,
+    PowLimitBits:             0x1e0fffff,
     BIP0034Height:            1,  // 0000047d24635e347be3aaaeb66c26be94901a2f962feccd4f95090191f208c1
     BIP0065Height:            581885, // 00000000007f6655f22f98e72ed80d8b06dc761d5da09df0fa1dc4be4f861eb6
     BIP0066Height:            330776, // 000000002104c8c45e99a8853285a3b592602a3ccde2b832481da85e9e4ba182
+    BLSBasicSchemeHeight:     850100, // v19 hard fork
     CoinbaseMaturity:         100,
     SubsidyReductionInterval: 210240,
     TargetTimespan:           time.Hour * 24 * 1, // DASH 1 day
@@ -424,6 +582,13 @@ var TestNet3Params = Params{
     MinDiffReductionTime:     time.Minute * 20, // TargetTimePerBlock * 2
     GenerateSupported:        false,
 
+    // Budget and masternode payment parameters
+    BudgetPaymentsStartBlock:         4100,
+    SuperblockStartBlock:             4200,
+    SuperblockCycle:                  24,
+    MasternodePaymentsIncreaseBlock:  4030,
+    MasternodePaymentsIncreasePeriod: 10,
+
     // Checkpoints ordered from oldest to newest.
     Checkpoints: []Checkpoint{
         {261, newHashFromStr("00000c26026d0815a7e2ce4fa270775f61403c040647ff2c3091f99e894a4618")},
@@ -453,8 +618,26 @@ var TestNet3Params = Params{
             StartTime:  1462060800, // May 1, 2016 UTC
             ExpireTime: 1493596800, // May 1, 2017 UTC.
         },
+        DeploymentDIP0003: {
+            BitNumber:                     3,
+            StartTime:                     1544655600, // Dec 13th, 2018
+            ExpireTime:                    1576191600, // Dec 13th, 2019
+            MinerConfirmationWindow:       100,
+            RuleChangeActivationThreshold: 50, // 50% of 100
+        },
+        DeploymentDIP0008: {
+            BitNumber:                     4,
+            StartTime:                     1553126400, // Mar 21st, 2019
+            ExpireTime:                    1584748800, // Mar 21st, 2020
+            MinerConfirmationWindow:       100,
+            RuleChangeActivationThreshold: 50, // 50% of 100
+        },
     },
 
+    // Enabled LLMQ types
+    Quorums: newQuorums(llmq50_60, llmq60_75, llmq400_60, llmq400_85,
+        llmq100_67, llmq25_67),
+
     // Mempool parameters
     RelayNonStdTxs: true,
 
@@ -476,6 +659,9 @@ var TestNet3Params = Params{
     // BIP44 coin type used in the hierarchical deterministic path for
     // address generation.
     HDCoinType: 1,
+
+    // Prefix of signed messages.
+    MessageMagic: "DarkCoin Signed Message:\n",
 }
 
 var (
@@ -486,16 +672,17 @@ var (
 
     // ErrUnknownHDKeyID describes an error where the provided id which
     // is intended to identify the network for a hierarchical deterministic
-    // private extended key is not registered.
+    // private or public extended key is not registered.
     ErrUnknownHDKeyID = errors.New("unknown hd private extended key bytes")
 )
 
 var (
-    registeredNets       = make(map[wire.DASHNet]struct{})
+    registeredNets       = make(map[wire.DASHNet]*Params)
     pubKeyHashAddrIDs    = make(map[byte]struct{})
     scriptHashAddrIDs    = make(map[byte]struct{})
     bech32SegwitPrefixes = make(map[string]struct{})
     hdPrivToPubKeyIDs    = make(map[[4]byte][]byte)
+    hdPubToPrivKeyIDs    = make(map[[4]byte][]byte)
 )
 
 // String returns the hostname of the DNS seed in human-readable form.
@@ -516,10 +703,11 @@ func Register(params *Params) error {
     if _, ok := registeredNets[params.Net]; ok {
         return ErrDuplicateNet
     }
-    registeredNets[params.Net] = struct{}{}
+    registeredNets[params.Net] = params
     pubKeyHashAddrIDs[params.PubKeyHashAddrID] = struct{}{}
     scriptHashAddrIDs[params.ScriptHashAddrID] = struct{}{}
     hdPrivToPubKeyIDs[params.HDPrivateKeyID] = params.HDPublicKeyID[:]
+    hdPubToPrivKeyIDs[params.HDPublicKeyID] = params.HDPrivateKeyID[:]
 
     // A valid Bech32 encoded segwit address always has as prefix the
     // human-readable part for the given net followed by '1'.
@@ -527,6 +715,58 @@ func Register(params *Params) error {
     return nil
 }
 
+// Unregister removes the network parameters of a previously registered network
+// and the address encoding magics and prefixes registered for it.  Magics and
+// prefixes which are shared with another registered network, such as the ones
+// of the test networks, remain registered.  Unregistering a network which is
+// not registered has no effect.
+//
+// This is primarily intended for tests that register networks, such as
+// devnets, which would otherwise fail with ErrDuplicateNet when registered
+// again.
+func Unregister(params *Params) {
+    if registered, ok := registeredNets[params.Net]; !ok || registered != params {
+        return
+    }
+    delete(registeredNets, params.Net)
+
+    // Rebuild the remaining maps from the networks that are still registered
+    // since their entries may be shared between networks.
+    nets := registeredNets
+    clearRegistration()
+    for _, params := range nets {
+        if err := Register(params); err != nil {
+            panic("failed to re-register network: " + err.Error())
+        }
+    }
+}
+
+// ResetRegistration restores the registered networks to the default networks,
+// removing any networks added with Register.  It is intended to be used by
+// tests to avoid leaking registered networks between them.
+func ResetRegistration() {
+    clearRegistration()
+    registerDefaultNets()
+}
+
+// clearRegistration removes all registered networks along with their address
+// encoding magics and prefixes.
+func clearRegistration() {
+    registeredNets = make(map[wire.DASHNet]*Params)
+    pubKeyHashAddrIDs = make(map[byte]struct{})
+    scriptHashAddrIDs = make(map[byte]struct{})
+    bech32SegwitPrefixes = make(map[string]struct{})
+    hdPrivToPubKeyIDs = make(map[[4]byte][]byte)
+    hdPubToPrivKeyIDs = make(map[[4]byte][]byte)
+}
+
+// registerDefaultNets registers all default networks.
+func registerDefaultNets() {
+    mustRegister(&MainNetParams)
+    mustRegister(&TestNet3Params)
+    mustRegister(&RegressionNetParams)
+}
+
 // mustRegister performs the same function as Register except it panics if there
 // is an error.  This should only be called from package init functions.
 func mustRegister(params *Params) {
@@ -535,6 +775,40 @@ func mustRegister(params *Params) {
     }
 }
 
+// registeredNames returns the sorted names of all registered networks.
+func registeredNames() string {
+    names := make([]string, 0, len(registeredNets))
+    for _, params := range registeredNets {
+        names = append(names, params.Name)
+    }
+    sort.Strings(names)
+    return strings.Join(names, ", ")
+}
+
+// ParamsByName returns the parameters of the default or registered network
+// with the passed name, such as "mainnet" or "testnet3".  An error listing the
+// known network names is returned when no such network is registered.
+func ParamsByName(name string) (*Params, error) {
+    for _, params := range registeredNets {
+        if params.Name == name {
+            return params, nil
+        }
+    }
+    return nil, fmt.Errorf("unknown network %q (known networks: %s)", name,
+        registeredNames())
+}
+
+// ParamsByNet returns the parameters of the default or registered network
+// identified by the passed magic.  An error listing the known network names is
+// returned when no such network is registered.
+func ParamsByNet(net wire.DASHNet) (*Params, error) {
+    if params, ok := registeredNets[net]; ok {
+        return params, nil
+    }
+    return nil, fmt.Errorf("unknown network %v (known networks: %s)", net,
+        registeredNames())
+}
+
 // IsPubKeyHashAddrID returns whether the id is an identifier known to prefix a
 // pay-to-pubkey-hash address on any default or registered network.  This is
 // used when decoding an address string into a specific address type.  It is up
@@ -584,6 +858,24 @@ func HDPrivateKeyToPublicKeyID(id []byte) ([]byte, error) {
     return pubBytes, nil
 }
 
+// HDPublicKeyToPrivateKeyID accepts a public hierarchical deterministic
+// extended key id and returns the associated private key id.  When the provided
+// id is not registered, the ErrUnknownHDKeyID error will be returned.
+func HDPublicKeyToPrivateKeyID(id []byte) ([]byte, error) {
+    if len(id) != 4 {
+        return nil, ErrUnknownHDKeyID
+    }
+
+    var key [4]byte
+    copy(key[:], id)
+    privBytes, ok := hdPubToPrivKeyIDs[key]
+    if !ok {
+        return nil, ErrUnknownHDKeyID
+    }
+
+    return privBytes, nil
+}
+
 // newHashFromStr converts the passed big-endian hex string into a
 // chainhash.Hash.  It only differs from the one available in chainhash in that
 // it panics on an error since it will only (and must only) be called with
@@ -603,9 +895,63 @@ func newHashFromStr(hexStr string) *chainhash.Hash {
     return hash
 }
 
+// ValidateGenesis recomputes the merkle root of the genesis block from its
+// transactions and the X11 hash of its header, and ensures they match the
+// MerkleRoot in the genesis block header and GenesisHash respectively.  This is
+// primarily useful to catch errors in the hard-coded values when defining the
+// parameters for a new network.  The devnet genesis block, when present, is
+// checked the same way.
+func (p *Params) ValidateGenesis() error {
+    if p.GenesisBlock == nil {
+        return fmt.Errorf("%s: no genesis block defined", p.Name)
+    }
+    if p.GenesisHash == nil {
+        return fmt.Errorf("%s: no genesis hash defined", p.Name)
+    }
+
+    merkleRoot := wire.CalcMerkleRoot(p.GenesisBlock.Transactions)
+    if !p.GenesisBlock.Header.MerkleRoot.IsEqual(&merkleRoot) {
+        return fmt.Errorf("%s: genesis block merkle root %v does not "+
+            "match calculated merkle root %v", p.Name,
+            p.GenesisBlock.Header.MerkleRoot, merkleRoot)
+    }
+
+    hash := p.GenesisBlock.BlockHash()
+    if !p.GenesisHash.IsEqual(&hash) {
+        return fmt.Errorf("%s: genesis hash %v does not match calculated "+
+            "block hash %v", p.Name, p.GenesisHash, hash)
+    }
+
+    // Devnets additionally define a devnet genesis block which must build
+    // on the genesis block.
+    if p.DevNetGenesisBlock == nil {
+        return nil
+    }
+    if p.DevNetGenesisHash == nil {
+        return fmt.Errorf("%s: no devnet genesis hash defined", p.Name)
+    }
+    if !p.DevNetGenesisBlock.Header.PrevBlock.IsEqual(p.GenesisHash) {
+        return fmt.Errorf("%s: devnet genesis block does not build on "+
+            "genesis hash %v", p.Name, p.GenesisHash)
+    }
+
+    merkleRoot = wire.CalcMerkleRoot(p.DevNetGenesisBlock.Transactions)
+    if !p.DevNetGenesisBlock.Header.MerkleRoot.IsEqual(&merkleRoot) {
+        return fmt.Errorf("%s: devnet genesis block merkle root %v does "+
+            "not match calculated merkle root %v", p.Name,
+            p.DevNetGenesisBlock.Header.MerkleRoot, merkleRoot)
+    }
+
+    hash = p.DevNetGenesisBlock.BlockHash()
+    if !p.DevNetGenesisHash.IsEqual(&hash) {
+        return fmt.Errorf("%s: devnet genesis hash %v does not match "+
+            "calculated block hash %v", p.Name, p.DevNetGenesisHash, hash)
+    }
+
+    return nil
+}
+
 func init() {
     // Register all default networks when the package is initialized.
-    mustRegister(&MainNetParams)
-    mustRegister(&TestNet3Params)
-    mustRegister(&RegressionNetParams)
+    registerDefaultNets()
 }
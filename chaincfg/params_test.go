@@ -5,7 +5,10 @@
 
 package chaincfg
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 // TestInvalidHashStr ensures the newShaHashFromStr function panics when used to
 // with an invalid hash string.
@@ -34,3 +37,154 @@ func TestMustRegisterPanic(t *testing.T) {
 	// Intentionally try to register duplicate params to force a panic.
 	mustRegister(&MainNetParams)
 }
+
+// TestValidateGenesis ensures the hard-coded genesis blocks, merkle roots and
+// hashes of all default networks are consistent with each other.
+func TestValidateGenesis(t *testing.T) {
+	t.Parallel()
+
+	for _, params := range []*Params{&MainNetParams, &RegressionNetParams,
+		&TestNet3Params} {
+
+		if err := params.ValidateGenesis(); err != nil {
+			t.Errorf("ValidateGenesis: %v", err)
+		}
+	}
+}
+
+// TestValidateGenesisMismatch ensures ValidateGenesis reports an error when the
+// genesis hash does not match the genesis block.
+func TestValidateGenesisMismatch(t *testing.T) {
+	t.Parallel()
+
+	params := MainNetParams
+	params.GenesisHash = TestNet3Params.GenesisHash
+	if err := params.ValidateGenesis(); err == nil {
+		t.Error("ValidateGenesis: did not fail for mismatched genesis hash")
+	}
+}
+
+// TestParamsLookup ensures the default networks can be looked up by name and
+// magic and that unknown networks produce an error naming the known ones.
+func TestParamsLookup(t *testing.T) {
+	t.Parallel()
+
+	for _, params := range []*Params{&MainNetParams, &RegressionNetParams,
+		&TestNet3Params} {
+
+		got, err := ParamsByName(params.Name)
+		if err != nil {
+			t.Errorf("ParamsByName(%q): %v", params.Name, err)
+		} else if got != params {
+			t.Errorf("ParamsByName(%q): got %s params", params.Name,
+				got.Name)
+		}
+
+		got, err = ParamsByNet(params.Net)
+		if err != nil {
+			t.Errorf("ParamsByNet(%v): %v", params.Net, err)
+		} else if got != params {
+			t.Errorf("ParamsByNet(%v): got %s params", params.Net,
+				got.Name)
+		}
+	}
+
+	_, err := ParamsByName("banana")
+	if err == nil {
+		t.Fatal("ParamsByName: did not fail for unknown network")
+	}
+	if !strings.Contains(err.Error(), "mainnet") {
+		t.Errorf("ParamsByName: error %q does not list known networks",
+			err)
+	}
+
+	// The magic must differ from the mock network registered by
+	// TestRegister, which runs before this parallel test.
+	if _, err := ParamsByNet(0x01020304); err == nil {
+		t.Error("ParamsByNet: did not fail for unknown network")
+	}
+}
+
+// TestUnregister ensures registered networks can be removed again without
+// affecting the address magics of the networks which remain registered.
+func TestUnregister(t *testing.T) {
+	defer ResetRegistration()
+
+	devNet := DevNetParams("unregister")
+	if err := Register(devNet); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if _, err := ParamsByName(devNet.Name); err != nil {
+		t.Fatalf("ParamsByName: %v", err)
+	}
+
+	// The devnet shares its address magics with the test networks, so
+	// they must still be known after removing it.
+	Unregister(devNet)
+	if _, err := ParamsByName(devNet.Name); err == nil {
+		t.Errorf("ParamsByName: found unregistered network %s",
+			devNet.Name)
+	}
+	if !IsPubKeyHashAddrID(TestNet3Params.PubKeyHashAddrID) {
+		t.Error("Unregister: removed a pubkey hash magic in use by " +
+			"testnet3")
+	}
+	if _, err := HDPrivateKeyToPublicKeyID(TestNet3Params.HDPrivateKeyID[:]); err != nil {
+		t.Errorf("Unregister: removed an hd key magic in use by "+
+			"testnet3: %v", err)
+	}
+
+	// A devnet may be registered again once the previous one is removed.
+	otherDevNet := DevNetParams("unregister-other")
+	if err := Register(otherDevNet); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	// Unregistering params that are not the ones registered for the net
+	// must not remove the registered network.
+	Unregister(devNet)
+	if _, err := ParamsByName(otherDevNet.Name); err != nil {
+		t.Errorf("ParamsByName: %v", err)
+	}
+}
+
+// TestResetRegistration ensures ResetRegistration removes user registered
+// networks and their magics while keeping the default networks.
+func TestResetRegistration(t *testing.T) {
+	mockNet := Params{
+		Name:             "resetnet",
+		Net:              0xfffffffe,
+		PubKeyHashAddrID: 0x9e,
+		ScriptHashAddrID: 0xf8,
+		Bech32HRPSegwit:  "tr",
+		HDPrivateKeyID:   [4]byte{0x01, 0x02, 0x03, 0x05},
+		HDPublicKeyID:    [4]byte{0x05, 0x06, 0x07, 0x09},
+	}
+	if err := Register(&mockNet); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	ResetRegistration()
+	if _, err := ParamsByName(mockNet.Name); err == nil {
+		t.Errorf("ParamsByName: found network %s after reset",
+			mockNet.Name)
+	}
+	if IsPubKeyHashAddrID(mockNet.PubKeyHashAddrID) ||
+		IsScriptHashAddrID(mockNet.ScriptHashAddrID) ||
+		IsBech32SegwitPrefix(mockNet.Bech32HRPSegwit+"1") {
+
+		t.Error("ResetRegistration: address magics still registered")
+	}
+	if _, err := HDPrivateKeyToPublicKeyID(mockNet.HDPrivateKeyID[:]); err != ErrUnknownHDKeyID {
+		t.Errorf("ResetRegistration: unexpected hd key lookup error "+
+			"- got %v, want %v", err, ErrUnknownHDKeyID)
+	}
+	for _, params := range []*Params{&MainNetParams, &RegressionNetParams,
+		&TestNet3Params} {
+
+		if err := Register(params); err != ErrDuplicateNet {
+			t.Errorf("Register(%s): unexpected error - got %v, "+
+				"want %v", params.Name, err, ErrDuplicateNet)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+var (
+	// ErrUnknownLLMQType describes an error where the provided LLMQ type is
+	// not enabled on the network.
+	ErrUnknownLLMQType = errors.New("unknown LLMQ type")
+
+	// ErrLLMQNotRotating describes an error where a quorum rotation helper
+	// is used with an LLMQ type which does not rotate its quorums as
+	// defined by DIP0024.
+	ErrLLMQNotRotating = errors.New("LLMQ type does not use quorum rotation")
+)
+
+// rotatingQuorum returns the parameters of the passed LLMQ type, which must be
+// enabled on the network and use quorum rotation.
+func (p *Params) rotatingQuorum(llmqType int) (LLMQParams, error) {
+	quorum, ok := p.Quorums[llmqType]
+	if !ok {
+		return LLMQParams{}, ErrUnknownLLMQType
+	}
+	if !quorum.UseRotation {
+		return LLMQParams{}, ErrLLMQNotRotating
+	}
+	return quorum, nil
+}
+
+// QuorumCycleHeight returns the height of the first block of the DKG cycle of
+// the passed rotating LLMQ type the block at the given height belongs to.  The
+// hash of the block at the returned height is the cycle hash an isdlock signed
+// during the cycle references.
+func (p *Params) QuorumCycleHeight(llmqType int, height int32) (int32, error) {
+	quorum, err := p.rotatingQuorum(llmqType)
+	if err != nil {
+		return 0, err
+	}
+	if height < 0 {
+		return 0, fmt.Errorf("invalid height %d", height)
+	}
+	return height - height%int32(quorum.DKGInterval), nil
+}
+
+// QuorumCycleSignHeight returns the height at which the active quorums of the
+// passed rotating LLMQ type sign for the cycle starting at cycleHeight.  The
+// quorum responsible for an isdlock which references the hash of the block at
+// cycleHeight is selected among the quorums active at the returned height.
+func (p *Params) QuorumCycleSignHeight(llmqType int, cycleHeight int32) (int32, error) {
+	quorum, err := p.rotatingQuorum(llmqType)
+	if err != nil {
+		return 0, err
+	}
+	interval := int32(quorum.DKGInterval)
+	if cycleHeight < 0 || cycleHeight%interval != 0 {
+		return 0, fmt.Errorf("height %d is not the start of a %s "+
+			"cycle", cycleHeight, quorum.Name)
+	}
+	return cycleHeight + interval - 1, nil
+}
+
+// QuorumDKGHeight returns the height at which the DKG session of the rotating
+// quorum with the given index starts during the cycle starting at cycleHeight.
+// The hash of the block at the returned height is the quorum hash of the
+// resulting quorum.
+func (p *Params) QuorumDKGHeight(llmqType int, cycleHeight int32, quorumIndex int) (int32, error) {
+	quorum, err := p.rotatingQuorum(llmqType)
+	if err != nil {
+		return 0, err
+	}
+	if cycleHeight < 0 || cycleHeight%int32(quorum.DKGInterval) != 0 {
+		return 0, fmt.Errorf("height %d is not the start of a %s "+
+			"cycle", cycleHeight, quorum.Name)
+	}
+	if quorumIndex < 0 || quorumIndex >= quorum.SigningActiveQuorumCount {
+		return 0, fmt.Errorf("invalid %s quorum index %d: must be "+
+			"less than %d", quorum.Name, quorumIndex,
+			quorum.SigningActiveQuorumCount)
+	}
+	return cycleHeight + int32(quorumIndex), nil
+}
+
+// QuorumIndexForRequestID returns the index of the rotating quorum of the
+// passed LLMQ type which is responsible for signing the given request ID.  It
+// mirrors the selection done by Dash Core, which takes the leading bits of the
+// last 64 bits of the request ID.
+func (p *Params) QuorumIndexForRequestID(llmqType int, requestID *chainhash.Hash) (int, error) {
+	quorum, err := p.rotatingQuorum(llmqType)
+	if err != nil {
+		return 0, err
+	}
+	n := uint(bits.Len(uint(quorum.SigningActiveQuorumCount)) - 1)
+	b := binary.LittleEndian.Uint64(requestID[24:])
+	return int((b >> (64 - n - 1)) & (1<<n - 1)), nil
+}
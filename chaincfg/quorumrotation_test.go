@@ -0,0 +1,103 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"testing"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestQuorumCycles ensures the DKG cycle of rotating quorums is located
+// properly for a height.
+func TestQuorumCycles(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     *Params
+		llmqType   int
+		height     int32
+		cycle      int32
+		signHeight int32
+	}{
+		{"mainnet cycle start", &MainNetParams, LLMQType60_75, 1728000, 1728000, 1728287},
+		{"mainnet cycle end", &MainNetParams, LLMQType60_75, 1728287, 1728000, 1728287},
+		{"mainnet next cycle", &MainNetParams, LLMQType60_75, 1728288, 1728288, 1728575},
+		{"mainnet genesis", &MainNetParams, LLMQType60_75, 0, 0, 287},
+		{"regtest", &RegressionNetParams, LLMQTypeTestDIP0024, 1001, 984, 1007},
+	}
+
+	for _, test := range tests {
+		cycle, err := test.params.QuorumCycleHeight(test.llmqType, test.height)
+		if err != nil {
+			t.Errorf("%s: QuorumCycleHeight: %v", test.name, err)
+			continue
+		}
+		if cycle != test.cycle {
+			t.Errorf("%s: got cycle %d, want %d", test.name, cycle,
+				test.cycle)
+		}
+		signHeight, err := test.params.QuorumCycleSignHeight(test.llmqType, cycle)
+		if err != nil {
+			t.Errorf("%s: QuorumCycleSignHeight: %v", test.name, err)
+			continue
+		}
+		if signHeight != test.signHeight {
+			t.Errorf("%s: got sign height %d, want %d", test.name,
+				signHeight, test.signHeight)
+		}
+	}
+
+	// Heights which are not the start of a cycle and quorum indexes out of
+	// range must be rejected.
+	if _, err := MainNetParams.QuorumCycleSignHeight(LLMQType60_75, 1728001); err == nil {
+		t.Error("QuorumCycleSignHeight: expected error for height 1728001")
+	}
+	dkgHeight, err := MainNetParams.QuorumDKGHeight(LLMQType60_75, 1728000, 31)
+	if err != nil || dkgHeight != 1728031 {
+		t.Errorf("QuorumDKGHeight: got %d (%v), want 1728031", dkgHeight, err)
+	}
+	if _, err := MainNetParams.QuorumDKGHeight(LLMQType60_75, 1728000, 32); err == nil {
+		t.Error("QuorumDKGHeight: expected error for quorum index 32")
+	}
+
+	// Only rotating LLMQ types enabled on the network are accepted.
+	if _, err := MainNetParams.QuorumCycleHeight(LLMQType400_60, 100); err != ErrLLMQNotRotating {
+		t.Errorf("QuorumCycleHeight: got %v, want %v", err, ErrLLMQNotRotating)
+	}
+	if _, err := MainNetParams.QuorumCycleHeight(LLMQTypeTestDIP0024, 100); err != ErrUnknownLLMQType {
+		t.Errorf("QuorumCycleHeight: got %v, want %v", err, ErrUnknownLLMQType)
+	}
+}
+
+// TestQuorumIndexForRequestID ensures the rotating quorum responsible for a
+// request ID is selected like Dash Core does.
+func TestQuorumIndexForRequestID(t *testing.T) {
+	tests := []struct {
+		params    *Params
+		llmqType  int
+		requestID string
+		want      int
+	}{
+		{&MainNetParams, LLMQType60_75, "b3bf8849c16a0f05a4635d7cd0cf03c72d2024c11aa783b6ac716c1817fd4f14", 12},
+		{&MainNetParams, LLMQType60_75, "deec0b7ab06e98626724153f57c9effad8d60e1675745e722c4430aad946452d", 23},
+		{&RegressionNetParams, LLMQTypeTestDIP0024, "deec0b7ab06e98626724153f57c9effad8d60e1675745e722c4430aad946452d", 1},
+	}
+
+	for i, test := range tests {
+		requestID, err := chainhash.NewHashFromStr(test.requestID)
+		if err != nil {
+			t.Fatalf("NewHashFromStr: %v", err)
+		}
+		got, err := test.params.QuorumIndexForRequestID(test.llmqType, requestID)
+		if err != nil {
+			t.Errorf("#%d: QuorumIndexForRequestID: %v", i, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("#%d: got quorum index %d, want %d", i, got,
+				test.want)
+		}
+	}
+}
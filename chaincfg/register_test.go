@@ -68,11 +68,6 @@ func TestRegister(t *testing.T) {
 					params: &TestNet3Params,
 					err:    ErrDuplicateNet,
 				},
-				{
-					name:   "duplicate simnet",
-					params: &SimNetParams,
-					err:    ErrDuplicateNet,
-				},
 			},
 			p2pkhMagics: []magicTest{
 				{
@@ -87,10 +82,6 @@ func TestRegister(t *testing.T) {
 					magic: RegressionNetParams.PubKeyHashAddrID,
 					valid: true,
 				},
-				{
-					magic: SimNetParams.PubKeyHashAddrID,
-					valid: true,
-				},
 				{
 					magic: mockNetParams.PubKeyHashAddrID,
 					valid: false,
@@ -113,10 +104,6 @@ func TestRegister(t *testing.T) {
 					magic: RegressionNetParams.ScriptHashAddrID,
 					valid: true,
 				},
-				{
-					magic: SimNetParams.ScriptHashAddrID,
-					valid: true,
-				},
 				{
 					magic: mockNetParams.ScriptHashAddrID,
 					valid: false,
@@ -139,10 +126,6 @@ func TestRegister(t *testing.T) {
 					prefix: RegressionNetParams.Bech32HRPSegwit + "1",
 					valid:  true,
 				},
-				{
-					prefix: SimNetParams.Bech32HRPSegwit + "1",
-					valid:  true,
-				},
 				{
 					prefix: strings.ToUpper(MainNetParams.Bech32HRPSegwit + "1"),
 					valid:  true,
@@ -180,11 +163,6 @@ func TestRegister(t *testing.T) {
 					want: RegressionNetParams.HDPublicKeyID[:],
 					err:  nil,
 				},
-				{
-					priv: SimNetParams.HDPrivateKeyID[:],
-					want: SimNetParams.HDPublicKeyID[:],
-					err:  nil,
-				},
 				{
 					priv: mockNetParams.HDPrivateKeyID[:],
 					err:  ErrUnknownHDKeyID,
@@ -221,10 +199,6 @@ func TestRegister(t *testing.T) {
 					magic: RegressionNetParams.PubKeyHashAddrID,
 					valid: true,
 				},
-				{
-					magic: SimNetParams.PubKeyHashAddrID,
-					valid: true,
-				},
 				{
 					magic: mockNetParams.PubKeyHashAddrID,
 					valid: true,
@@ -247,10 +221,6 @@ func TestRegister(t *testing.T) {
 					magic: RegressionNetParams.ScriptHashAddrID,
 					valid: true,
 				},
-				{
-					magic: SimNetParams.ScriptHashAddrID,
-					valid: true,
-				},
 				{
 					magic: mockNetParams.ScriptHashAddrID,
 					valid: true,
@@ -273,10 +243,6 @@ func TestRegister(t *testing.T) {
 					prefix: RegressionNetParams.Bech32HRPSegwit + "1",
 					valid:  true,
 				},
-				{
-					prefix: SimNetParams.Bech32HRPSegwit + "1",
-					valid:  true,
-				},
 				{
 					prefix: strings.ToUpper(MainNetParams.Bech32HRPSegwit + "1"),
 					valid:  true,
@@ -324,11 +290,6 @@ func TestRegister(t *testing.T) {
 					params: &TestNet3Params,
 					err:    ErrDuplicateNet,
 				},
-				{
-					name:   "duplicate simnet",
-					params: &SimNetParams,
-					err:    ErrDuplicateNet,
-				},
 				{
 					name:   "duplicate mocknet",
 					params: &mockNetParams,
@@ -348,10 +309,6 @@ func TestRegister(t *testing.T) {
 					magic: RegressionNetParams.PubKeyHashAddrID,
 					valid: true,
 				},
-				{
-					magic: SimNetParams.PubKeyHashAddrID,
-					valid: true,
-				},
 				{
 					magic: mockNetParams.PubKeyHashAddrID,
 					valid: true,
@@ -374,10 +331,6 @@ func TestRegister(t *testing.T) {
 					magic: RegressionNetParams.ScriptHashAddrID,
 					valid: true,
 				},
-				{
-					magic: SimNetParams.ScriptHashAddrID,
-					valid: true,
-				},
 				{
 					magic: mockNetParams.ScriptHashAddrID,
 					valid: true,
@@ -400,10 +353,6 @@ func TestRegister(t *testing.T) {
 					prefix: RegressionNetParams.Bech32HRPSegwit + "1",
 					valid:  true,
 				},
-				{
-					prefix: SimNetParams.Bech32HRPSegwit + "1",
-					valid:  true,
-				},
 				{
 					prefix: strings.ToUpper(MainNetParams.Bech32HRPSegwit + "1"),
 					valid:  true,
@@ -441,11 +390,6 @@ func TestRegister(t *testing.T) {
 					want: RegressionNetParams.HDPublicKeyID[:],
 					err:  nil,
 				},
-				{
-					priv: SimNetParams.HDPrivateKeyID[:],
-					want: SimNetParams.HDPublicKeyID[:],
-					err:  nil,
-				},
 				{
 					priv: mockNetParams.HDPrivateKeyID[:],
 					want: mockNetParams.HDPublicKeyID[:],
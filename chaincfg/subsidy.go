@@ -0,0 +1,136 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+const (
+	// duffsPerDash is the number of duffs in one DASH.
+	duffsPerDash = 100000000
+
+	// baseSubsidy is the starting block subsidy in duffs before any of the
+	// yearly reductions are applied.  This is the minimum the difficulty
+	// based subsidy formula yields, which it has done on the main network
+	// since the early GPU mining era.
+	baseSubsidy = 5 * duffsPerDash
+)
+
+// CalcBlockSubsidy returns the subsidy amount in duffs a block at the provided
+// height should have, excluding the part which is set aside for governance
+// superblocks.  This is the total reward shared between the miner and the
+// masternode that is paid in the coinbase of the block.
+//
+// The subsidy starts at 5 DASH and is reduced by 1/14th (~7.14%) every
+// SubsidyReductionInterval blocks.  Once past BudgetPaymentsStartBlock, 10% of
+// the subsidy is reserved for superblocks.
+//
+// NOTE: The subsidy of the very early main network blocks depended on the
+// difficulty of the previous block, which is not modeled here.
+func (p *Params) CalcBlockSubsidy(height int32) int64 {
+	// The Dash schedule is defined in terms of the height of the previous
+	// block.
+	prevHeight := height - 1
+
+	subsidy := int64(baseSubsidy)
+	if p.SubsidyReductionInterval > 0 {
+		interval := p.SubsidyReductionInterval
+		for i := interval; i <= prevHeight; i += interval {
+			subsidy -= subsidy / 14
+		}
+	}
+
+	if prevHeight > p.BudgetPaymentsStartBlock {
+		subsidy -= subsidy / 10
+	}
+
+	return subsidy
+}
+
+// masternodeRewardIncreases are the increments of the masternode share of the
+// block reward applied once the height passes the associated number of
+// MasternodePaymentsIncreasePeriod periods after the
+// MasternodePaymentsIncreaseBlock.  Note that the eighth period is skipped as
+// in Dash Core.
+var masternodeRewardIncreases = []struct {
+	periods int32
+	divisor int64
+}{
+	{0, 20}, // 25.0%
+	{1, 20}, // 30.0%
+	{2, 20}, // 35.0%
+	{3, 40}, // 37.5%
+	{4, 40}, // 40.0%
+	{5, 40}, // 42.5%
+	{6, 40}, // 45.0%
+	{7, 40}, // 47.5%
+	{9, 40}, // 50.0%
+}
+
+// reallocationStep returns the step of the block reward reallocation which
+// applies to the provided height, or nil before the reallocation.
+func (p *Params) reallocationStep(height int32) *MasternodeRewardStep {
+	steps := p.MasternodeRewardReallocation
+	for i := len(steps) - 1; i >= 0; i-- {
+		if height >= steps[i].Height {
+			return &steps[i]
+		}
+	}
+	return nil
+}
+
+// MasternodeRewardRatio returns the share in basis points of the total block
+// reward that is paid to the masternode of a block at the provided height.  The
+// share starts at 20% and grows in steps every MasternodePaymentsIncreasePeriod
+// blocks after MasternodePaymentsIncreaseBlock until it reaches 50%.  It is
+// then raised to 60% per the MasternodeRewardReallocation table.
+//
+// NOTE: The v20 hard fork replaced the schedule with a fixed 75% of the block
+// reward after the treasury share, which is not modeled here.
+func (p *Params) MasternodeRewardRatio(height int32) int64 {
+	if step := p.reallocationStep(height); step != nil {
+		return step.Ratio
+	}
+
+	ratio := int64(2000)
+	for _, increase := range masternodeRewardIncreases {
+		start := p.MasternodePaymentsIncreaseBlock +
+			p.MasternodePaymentsIncreasePeriod*increase.periods
+		if height > start {
+			ratio += 10000 / increase.divisor
+		}
+	}
+	return ratio
+}
+
+// MasternodeReward returns the part in duffs of the passed total block reward
+// that is paid to the masternode of a block at the provided height per
+// MasternodeRewardRatio.  The remainder of the total goes to the miner.
+//
+// The amount is rounded the same way as in Dash Core, where each increment of
+// the share before the reallocation is rounded down separately.
+func (p *Params) MasternodeReward(height int32, total int64) int64 {
+	if step := p.reallocationStep(height); step != nil {
+		return total * step.Ratio / 10000
+	}
+
+	reward := total / 5
+	for _, increase := range masternodeRewardIncreases {
+		start := p.MasternodePaymentsIncreaseBlock +
+			p.MasternodePaymentsIncreasePeriod*increase.periods
+		if height > start {
+			reward += total / increase.divisor
+		}
+	}
+
+	return reward
+}
+
+// BlockRewardSplit returns the parts in duffs of the coinbase of a block at the
+// provided height that are paid to the miner and the masternode.  The total is
+// the subsidy per CalcBlockSubsidy plus the passed transaction fees, and the
+// masternode part is calculated from it per MasternodeReward.
+func (p *Params) BlockRewardSplit(height int32, fees int64) (miner, masternode int64) {
+	total := p.CalcBlockSubsidy(height) + fees
+	masternode = p.MasternodeReward(height, total)
+	return total - masternode, masternode
+}
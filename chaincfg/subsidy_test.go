@@ -0,0 +1,137 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import "testing"
+
+// TestCalcBlockSubsidy ensures the block subsidy follows the Dash schedule at a
+// few known heights.
+func TestCalcBlockSubsidy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		params *Params
+		height int32
+		want   int64
+	}{
+		{"mainnet first interval", &MainNetParams, 210240, 500000000},
+		{"mainnet first reduction", &MainNetParams, 210241, 464285715},
+		{"mainnet before budget", &MainNetParams, 328009, 464285715},
+		{"mainnet after budget", &MainNetParams, 328010, 417857144},
+		{"mainnet 1000000", &MainNetParams, 1000000, 334559821},
+		{"testnet3 before budget", &TestNet3Params, 4101, 500000000},
+		{"testnet3 after budget", &TestNet3Params, 4102, 450000000},
+		{"regtest reductions", &RegressionNetParams, 301, 431122450},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for _, test := range tests {
+		got := test.params.CalcBlockSubsidy(test.height)
+		if got != test.want {
+			t.Errorf("%s: CalcBlockSubsidy(%d) got %d, want %d",
+				test.name, test.height, got, test.want)
+		}
+	}
+}
+
+// TestMasternodeReward ensures the masternode share of the block reward grows
+// as scheduled.
+func TestMasternodeReward(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		params *Params
+		height int32
+		total  int64
+		want   int64
+	}{
+		{"mainnet 20%", &MainNetParams, 158000, 500000000, 100000000},
+		{"mainnet 25%", &MainNetParams, 158001, 500000000, 125000000},
+		{"mainnet 37.5%", &MainNetParams, 209841, 500000000, 187500000},
+		{"mainnet 47.5%", &MainNetParams, 296240, 500000000, 237500000},
+		{"mainnet 50%", &MainNetParams, 313521, 500000000, 250000000},
+		{"testnet3 50%", &TestNet3Params, 4121, 450000000, 225000000},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for _, test := range tests {
+		got := test.params.MasternodeReward(test.height, test.total)
+		if got != test.want {
+			t.Errorf("%s: MasternodeReward(%d, %d) got %d, want %d",
+				test.name, test.height, test.total, got,
+				test.want)
+		}
+	}
+}
+
+// TestMasternodeRewardRatio ensures the masternode share follows the increases
+// to 50% and the block reward reallocation to 60%.
+func TestMasternodeRewardRatio(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		params *Params
+		height int32
+		want   int64
+	}{
+		{"mainnet 20%", &MainNetParams, 158000, 2000},
+		{"mainnet 25%", &MainNetParams, 158001, 2500},
+		{"mainnet 37.5%", &MainNetParams, 209841, 3750},
+		{"mainnet 50%", &MainNetParams, 313521, 5000},
+		{"mainnet before reallocation", &MainNetParams, 1379127, 5000},
+		{"mainnet reallocation period 1", &MainNetParams, 1379128, 5130},
+		{"mainnet reallocation period 2", &MainNetParams, 1428976, 5260},
+		{"mainnet reallocation period 18", &MainNetParams, 2276391, 5990},
+		{"mainnet 60%", &MainNetParams, 2276392, 6000},
+		{"mainnet after reallocation", &MainNetParams, 3000000, 6000},
+		{"testnet3 50%", &TestNet3Params, 1000000, 5000},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for _, test := range tests {
+		got := test.params.MasternodeRewardRatio(test.height)
+		if got != test.want {
+			t.Errorf("%s: MasternodeRewardRatio(%d) got %d, want %d",
+				test.name, test.height, got, test.want)
+		}
+	}
+}
+
+// TestBlockRewardSplit ensures the coinbase of a block is split between the
+// miner and the masternode per the subsidy and the masternode share.
+func TestBlockRewardSplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		params     *Params
+		height     int32
+		fees       int64
+		miner      int64
+		masternode int64
+	}{
+		{"mainnet 20%", &MainNetParams, 158000, 0, 400000000, 100000000},
+		{"mainnet 50% rounded down per increment", &MainNetParams, 1000000, 1000,
+			167280414, 167280407},
+		{"mainnet reallocation period 1", &MainNetParams, 1379128, 0,
+			140486108, 147986392},
+		{"mainnet 60%", &MainNetParams, 2276392, 12345, 85792768,
+			128689150},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for _, test := range tests {
+		miner, masternode := test.params.BlockRewardSplit(test.height,
+			test.fees)
+		if miner != test.miner || masternode != test.masternode {
+			t.Errorf("%s: BlockRewardSplit(%d, %d) got (%d, %d), "+
+				"want (%d, %d)", test.name, test.height, test.fees,
+				miner, masternode, test.miner, test.masternode)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+// IsSuperblock returns whether a governance superblock may occur at the
+// provided height, which is the case for heights at or after
+// SuperblockStartBlock that are a multiple of SuperblockCycle.
+func (p *Params) IsSuperblock(height int32) bool {
+	if p.SuperblockCycle <= 0 {
+		return false
+	}
+	return height >= p.SuperblockStartBlock && height%p.SuperblockCycle == 0
+}
+
+// NextSuperblock returns the height of the first governance superblock after
+// the provided height.  Zero is returned when the network has no superblocks.
+//
+// Unlike the GetNextSuperblock function of Dash Core, which returns the start
+// block for heights before it even when it is not a multiple of the cycle, the
+// returned height is always one for which IsSuperblock returns true.
+func (p *Params) NextSuperblock(height int32) int32 {
+	cycle := p.SuperblockCycle
+	if cycle <= 0 {
+		return 0
+	}
+
+	if height < p.SuperblockStartBlock {
+		height = p.SuperblockStartBlock - 1
+	}
+	return height - height%cycle + cycle
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import "testing"
+
+// TestSuperblocks ensures superblock heights are recognized and the next
+// superblock is found around the start block and cycle boundaries.
+func TestSuperblocks(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       *Params
+		height       int32
+		isSuperblock bool
+		next         int32
+	}{
+		{"mainnet genesis", &MainNetParams, 0, false, 631408},
+		{"mainnet start block", &MainNetParams, 614820, false, 631408},
+		{"mainnet cycle before start", &MainNetParams, 614792, false, 631408},
+		{"mainnet before first", &MainNetParams, 631407, false, 631408},
+		{"mainnet first", &MainNetParams, 631408, true, 648024},
+		{"mainnet after first", &MainNetParams, 631409, false, 648024},
+		{"mainnet later", &MainNetParams, 1528672, true, 1545288},
+		{"testnet before start", &TestNet3Params, 4199, false, 4200},
+		{"testnet start block", &TestNet3Params, 4200, true, 4224},
+		{"testnet cycle", &TestNet3Params, 4223, false, 4224},
+		{"testnet cycle before start", &TestNet3Params, 4176, false, 4200},
+		{"regtest start block", &RegressionNetParams, 1500, true, 1510},
+		{"regtest after start", &RegressionNetParams, 1509, false, 1510},
+	}
+
+	for _, test := range tests {
+		isSuperblock := test.params.IsSuperblock(test.height)
+		if isSuperblock != test.isSuperblock {
+			t.Errorf("%s: IsSuperblock(%d): got %v, want %v",
+				test.name, test.height, isSuperblock,
+				test.isSuperblock)
+		}
+		next := test.params.NextSuperblock(test.height)
+		if next != test.next {
+			t.Errorf("%s: NextSuperblock(%d): got %d, want %d",
+				test.name, test.height, next, test.next)
+		}
+		if !test.params.IsSuperblock(next) {
+			t.Errorf("%s: NextSuperblock(%d) returned %d which is "+
+				"not a superblock", test.name, test.height, next)
+		}
+	}
+
+	// Networks without a superblock cycle never have superblocks.
+	var params Params
+	if params.IsSuperblock(0) || params.NextSuperblock(0) != 0 {
+		t.Error("unexpected superblock on network without cycle")
+	}
+}
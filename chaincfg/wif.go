@@ -0,0 +1,71 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nargott/godashutil/base58"
+)
+
+const (
+	// privKeySize is the size in bytes of a serialized secp256k1 private
+	// key.
+	privKeySize = 32
+
+	// compressMagic is the byte appended to the private key of a WIF
+	// string to mark that the public key is serialized compressed.
+	compressMagic = 0x01
+)
+
+// ErrUnknownWIFID describes an error where the version byte of a decoded WIF
+// private key is not the private key identifier of the network it is decoded
+// for.
+var ErrUnknownWIFID = errors.New("private key is not for this network")
+
+// EncodeWIF returns the Wallet Import Format encoding of the passed 32-byte
+// private key using the private key identifier of the network.  The
+// compressed flag marks that the public key of the private key is to be
+// serialized compressed.  Main network keys start with '7' when uncompressed
+// and 'X' when compressed.
+func (p *Params) EncodeWIF(privKey []byte, compressed bool) (string, error) {
+	if len(privKey) != privKeySize {
+		return "", fmt.Errorf("invalid private key length %d: must be "+
+			"%d bytes", len(privKey), privKeySize)
+	}
+
+	payload := make([]byte, 0, privKeySize+1)
+	payload = append(payload, privKey...)
+	if compressed {
+		payload = append(payload, compressMagic)
+	}
+	return base58.CheckEncode(payload, p.PrivateKeyID), nil
+}
+
+// DecodeWIF decodes the passed Wallet Import Format string of the network and
+// returns the private key along with whether its public key is serialized
+// compressed.  The checksum of the string is verified, and ErrUnknownWIFID is
+// returned when the key belongs to a network with another private key
+// identifier.
+func (p *Params) DecodeWIF(wif string) (privKey []byte, compressed bool, err error) {
+	decoded, id, err := base58.CheckDecode(wif)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch {
+	case len(decoded) == privKeySize:
+	case len(decoded) == privKeySize+1 && decoded[privKeySize] == compressMagic:
+		compressed = true
+	default:
+		return nil, false, errors.New("malformed private key")
+	}
+
+	if id != p.PrivateKeyID {
+		return nil, false, ErrUnknownWIFID
+	}
+	return decoded[:privKeySize], compressed, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// TestWIFRoundTrip ensures private keys are encoded with the private key
+// identifier of each network and decode back to the same key.
+func TestWIFRoundTrip(t *testing.T) {
+	privKey, _ := hex.DecodeString("0c28fca386c7a227600b2fe50b7cae11" +
+		"ec86d3bf1fbe471be89827e19d72aa1d")
+
+	tests := []struct {
+		name       string
+		params     *Params
+		compressed bool
+		wif        string
+	}{
+		{
+			name:   "mainnet uncompressed",
+			params: &MainNetParams,
+			wif:    "7qeDbtaZch9AUt8KHxP1fbKkf2YqFAcXRe1jZ8Cq1JMQ6nMVBe4",
+		},
+		{
+			name:       "mainnet compressed",
+			params:     &MainNetParams,
+			compressed: true,
+			wif:        "XBhGczf8xYB2r4fHjqS9wLVmgqqVwXRoHkNEpnoCtKb2x5RsXrCP",
+		},
+		{
+			name:   "testnet uncompressed",
+			params: &TestNet3Params,
+			wif:    "91gGn1HgSap6CbU12F6z3pJri26xzp7Ay1VW6NHCoEayNXwRpu2",
+		},
+		{
+			name:       "testnet compressed",
+			params:     &TestNet3Params,
+			compressed: true,
+			wif:        "cMzLdeGd5vEqxB8B6VFQoRopQ3sLAAvEzDAoQgvX54xwofSWj1fx",
+		},
+	}
+
+	for _, test := range tests {
+		wif, err := test.params.EncodeWIF(privKey, test.compressed)
+		if err != nil {
+			t.Errorf("%s: EncodeWIF: unexpected error: %v", test.name,
+				err)
+			continue
+		}
+		if wif != test.wif {
+			t.Errorf("%s: EncodeWIF: got %s, want %s", test.name, wif,
+				test.wif)
+			continue
+		}
+
+		decoded, compressed, err := test.params.DecodeWIF(wif)
+		if err != nil {
+			t.Errorf("%s: DecodeWIF: unexpected error: %v", test.name,
+				err)
+			continue
+		}
+		if !bytes.Equal(decoded, privKey) || compressed != test.compressed {
+			t.Errorf("%s: DecodeWIF: got %x (compressed %v), want "+
+				"%x (compressed %v)", test.name, decoded,
+				compressed, privKey, test.compressed)
+		}
+	}
+}
+
+// TestDecodeWIFErrors ensures keys of other networks, such as Bitcoin keys,
+// and invalid checksums are rejected.
+func TestDecodeWIFErrors(t *testing.T) {
+	// Bitcoin main network WIF of the same key.
+	_, _, err := MainNetParams.DecodeWIF("5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ")
+	if err != ErrUnknownWIFID {
+		t.Errorf("bitcoin key on mainnet: got error %v, want %v", err,
+			ErrUnknownWIFID)
+	}
+
+	_, _, err = MainNetParams.DecodeWIF("XBhGczf8xYB2r4fHjqS9wLVmgqqVwXRoHkNEpnoCtKb2x5RsXrCQ")
+	if err == nil {
+		t.Error("invalid checksum: expected error")
+	}
+
+	if _, err := MainNetParams.EncodeWIF([]byte{0x01}, true); err == nil {
+		t.Error("short private key: expected error")
+	}
+}
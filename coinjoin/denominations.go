@@ -0,0 +1,76 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package coinjoin
+
+// The standard mixing denominations in duffs.
+const (
+	// DenomTen is the 10.0001 DASH denomination.
+	DenomTen int64 = 1000010000
+
+	// DenomOne is the 1.00001 DASH denomination.
+	DenomOne int64 = 100001000
+
+	// DenomTenth is the 0.100001 DASH denomination.
+	DenomTenth int64 = 10000100
+
+	// DenomHundredth is the 0.0100001 DASH denomination.
+	DenomHundredth int64 = 1000010
+
+	// DenomThousandth is the 0.00100001 DASH denomination.
+	DenomThousandth int64 = 100001
+)
+
+// Denominations lists the standard mixing denominations from the largest to
+// the smallest.  The index of a denomination in this list is the one returned
+// by DenominationForAmount and determines its bit in dsq messages.
+var Denominations = []int64{
+	DenomTen,
+	DenomOne,
+	DenomTenth,
+	DenomHundredth,
+	DenomThousandth,
+}
+
+// DenominationForAmount returns the index in Denominations of the passed
+// amount in duffs and whether the amount is a standard denomination at all.
+func DenominationForAmount(amount int64) (int, bool) {
+	for i, denom := range Denominations {
+		if amount == denom {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// AmountToDenomination returns the bit representation of the passed amount in
+// duffs as used by the Denomination field of dsq messages, which is 1 shifted
+// left by the index of the denomination.  Zero is returned when the amount is
+// not a standard denomination.
+func AmountToDenomination(amount int64) int32 {
+	index, ok := DenominationForAmount(amount)
+	if !ok {
+		return 0
+	}
+	return 1 << uint(index)
+}
+
+// DenominationToAmount returns the amount in duffs of the passed bit
+// representation of a denomination as used by dsq messages and whether it
+// represents exactly one standard denomination.
+func DenominationToAmount(denom int32) (int64, bool) {
+	for i, amount := range Denominations {
+		if denom == 1<<uint(i) {
+			return amount, true
+		}
+	}
+	return 0, false
+}
+
+// IsDenominatedAmount returns whether the passed amount in duffs is one of the
+// standard mixing denominations.
+func IsDenominatedAmount(amount int64) bool {
+	_, ok := DenominationForAmount(amount)
+	return ok
+}
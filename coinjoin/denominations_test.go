@@ -0,0 +1,66 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package coinjoin
+
+import "testing"
+
+// TestDenominations ensures amounts are mapped to their denomination index and
+// dsq bit representation and back.
+func TestDenominations(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		index  int
+		bits   int32
+	}{
+		{"10.0001", 1000010000, 0, 1},
+		{"1.00001", 100001000, 1, 2},
+		{"0.100001", 10000100, 2, 4},
+		{"0.0100001", 1000010, 3, 8},
+		{"0.00100001", 100001, 4, 16},
+	}
+
+	for _, test := range tests {
+		index, ok := DenominationForAmount(test.amount)
+		if !ok || index != test.index {
+			t.Errorf("%s: DenominationForAmount: got %d (%v), want %d",
+				test.name, index, ok, test.index)
+		}
+		if !IsDenominatedAmount(test.amount) {
+			t.Errorf("%s: IsDenominatedAmount: got false", test.name)
+		}
+		if bits := AmountToDenomination(test.amount); bits != test.bits {
+			t.Errorf("%s: AmountToDenomination: got %d, want %d",
+				test.name, bits, test.bits)
+		}
+		amount, ok := DenominationToAmount(test.bits)
+		if !ok || amount != test.amount {
+			t.Errorf("%s: DenominationToAmount: got %d (%v), want %d",
+				test.name, amount, ok, test.amount)
+		}
+	}
+}
+
+// TestNonDenominatedAmounts ensures amounts and bit representations which are
+// not exactly one standard denomination are rejected.
+func TestNonDenominatedAmounts(t *testing.T) {
+	for _, amount := range []int64{0, 100000, 1000000000, 1000010001, -100001} {
+		if _, ok := DenominationForAmount(amount); ok {
+			t.Errorf("DenominationForAmount(%d): unexpected match",
+				amount)
+		}
+		if bits := AmountToDenomination(amount); bits != 0 {
+			t.Errorf("AmountToDenomination(%d): got %d, want 0",
+				amount, bits)
+		}
+	}
+
+	for _, denom := range []int32{0, 3, 32, -1} {
+		if _, ok := DenominationToAmount(denom); ok {
+			t.Errorf("DenominationToAmount(%d): unexpected match",
+				denom)
+		}
+	}
+}
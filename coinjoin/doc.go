@@ -0,0 +1,15 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package coinjoin provides the constants and helpers needed to work with Dash
+CoinJoin (formerly PrivateSend) mixing outside of a full node.
+
+Mixing only operates on outputs of a few standard denominations, each of which
+is slightly larger than a power of ten DASH so that denominated outputs are
+easy to tell apart from regular payments.  This package lists those
+denominations and converts between amounts, their index in the list and the
+bit representation carried by the Denomination field of dsq messages.
+*/
+package coinjoin
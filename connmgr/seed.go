@@ -33,12 +33,20 @@ type LookupFunc func(string) ([]net.IP, error)
 func SeedFromDNS(chainParams *chaincfg.Params, reqServices wire.ServiceFlag,
 	lookupFn LookupFunc, seedFn OnSeed) {
 
+	// Seeders only know about a subset of the service flags and return no
+	// peers at all when asked to filter by any other flag, so only request
+	// filtering by the flags they understand.
+	filterServices := reqServices & wire.SFDNSSeedFilterable
+
 	for _, dnsseed := range chainParams.DNSSeeds {
 		var host string
-		if !dnsseed.HasFiltering || reqServices == wire.SFNodeNetwork {
+		if !dnsseed.HasFiltering || filterServices == wire.SFNodeNetwork ||
+			filterServices == 0 {
+
 			host = dnsseed.Host
 		} else {
-			host = fmt.Sprintf("x%x.%s", uint64(reqServices), dnsseed.Host)
+			host = fmt.Sprintf("x%x.%s", uint64(filterServices),
+				dnsseed.Host)
 		}
 
 		go func(host string) {
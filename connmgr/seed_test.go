@@ -0,0 +1,50 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package connmgr
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nargott/godash/chaincfg"
+	"github.com/nargott/godash/wire"
+)
+
+// TestSeedFromDNSFiltering ensures the service flags requested from filtering
+// DNS seeds are limited to the flags seeders are able to filter by.
+func TestSeedFromDNSFiltering(t *testing.T) {
+	params := chaincfg.MainNetParams
+	params.DNSSeeds = []chaincfg.DNSSeed{{Host: "seed.example", HasFiltering: true}}
+
+	tests := []struct {
+		services wire.ServiceFlag
+		want     string
+	}{
+		{wire.SFNodeNetwork, "seed.example"},
+		{wire.SFNodeNetwork | wire.SFNodeBloom, "x5.seed.example"},
+		{wire.SFNodeNetwork | wire.SFNodeWitness, "seed.example"},
+		{wire.SFNodeNetworkLimited | wire.SFNodeGetUTXO, "x400.seed.example"},
+	}
+
+	for i, test := range tests {
+		hosts := make(chan string, 1)
+		lookup := func(host string) ([]net.IP, error) {
+			hosts <- host
+			return nil, nil
+		}
+		SeedFromDNS(&params, test.services, lookup, func([]*wire.NetAddress) {})
+
+		select {
+		case host := <-hosts:
+			if host != test.want {
+				t.Errorf("SeedFromDNS #%d: wrong host - got %s, "+
+					"want %s", i, host, test.want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("SeedFromDNS #%d: no lookup performed", i)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nargott/godashutil"
+)
+
+// amountDecimals is the number of decimal places of a DASH amount.
+const amountDecimals = 8
+
+// parseAmount parses the decimal representation of a DASH amount, such as the
+// JSON numbers returned by the server, into duffs.  Unlike godashutil.NewAmount
+// the conversion is exact since it never goes through a float64.
+func parseAmount(s string) (godashutil.Amount, error) {
+	str := s
+	negative := strings.HasPrefix(str, "-")
+	if negative {
+		str = str[1:]
+	}
+
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	if intPart == "" || strings.Trim(intPart+fracPart, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	// Digits beyond the smallest unit are only allowed when they are zero.
+	if len(fracPart) > amountDecimals {
+		if strings.Trim(fracPart[amountDecimals:], "0") != "" {
+			return 0, fmt.Errorf("amount %q has more than %d decimal "+
+				"places", s, amountDecimals)
+		}
+		fracPart = fracPart[:amountDecimals]
+	}
+	fracPart += strings.Repeat("0", amountDecimals-len(fracPart))
+
+	duffs, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %v", s, err)
+	}
+	if negative {
+		duffs = -duffs
+	}
+	return godashutil.Amount(duffs), nil
+}
+
+// DashAmount is an amount in duffs which decodes from the DASH amounts returned
+// by the server as JSON numbers.  The decimal representation is converted
+// exactly, which avoids the rounding errors introduced when the amounts are
+// decoded into a float64 first.  Amounts returned as JSON strings, as done by
+// some servers, are accepted as well.
+type DashAmount godashutil.Amount
+
+// Amount returns the amount as a godashutil.Amount.
+func (a DashAmount) Amount() godashutil.Amount {
+	return godashutil.Amount(a)
+}
+
+// MarshalJSON provides a custom Marshal method for DashAmount which encodes the
+// amount in DASH as a JSON number with all of its decimal places.
+func (a DashAmount) MarshalJSON() ([]byte, error) {
+	duffs := int64(a)
+	sign := ""
+	if duffs < 0 {
+		sign = "-"
+		duffs = -duffs
+	}
+	unit := int64(godashutil.SatoshiPerBitcoin)
+	return []byte(fmt.Sprintf("%s%d.%08d", sign, duffs/unit, duffs%unit)), nil
+}
+
+// UnmarshalJSON provides a custom Unmarshal method for DashAmount.
+func (a *DashAmount) UnmarshalJSON(data []byte) error {
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+
+	amount, err := parseAmount(number.String())
+	if err != nil {
+		return err
+	}
+	*a = DashAmount(amount)
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nargott/godashutil"
+)
+
+// TestParseAmount ensures decimal DASH amounts are converted to duffs exactly.
+func TestParseAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		str      string
+		expected godashutil.Amount
+		wantErr  bool
+	}{
+		{name: "integer", str: "5", expected: 500000000},
+		{name: "full precision", str: "1.23456789", expected: 123456789},
+		{name: "short fraction", str: "0.1", expected: 10000000},
+		{name: "float64 rounding", str: "0.29", expected: 29000000},
+		{name: "large", str: "20999999.99999999", expected: 2099999999999999},
+		{name: "negative", str: "-0.00000001", expected: -1},
+		{name: "trailing zeros", str: "1.0000000000", expected: 100000000},
+		{name: "too precise", str: "0.000000001", wantErr: true},
+		{name: "exponent", str: "1e-8", wantErr: true},
+		{name: "missing integer", str: ".5", wantErr: true},
+		{name: "empty", str: "", wantErr: true},
+		{name: "overflow", str: "100000000000", wantErr: true},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		amount, err := parseAmount(test.str)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Test #%d (%s) expected error", i, test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if amount != test.expected {
+			t.Errorf("Test #%d (%s) unexpected amount - got %d, "+
+				"want %d", i, test.name, amount, test.expected)
+		}
+	}
+}
+
+// TestDashAmountJSON ensures DashAmount decodes DASH amounts exactly from both
+// JSON numbers and strings, and encodes them back with all decimal places.
+func TestDashAmountJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     string
+		expected DashAmount
+		encoded  string
+		wantErr  bool
+	}{
+		{
+			name:     "number",
+			data:     `1.10000001`,
+			expected: 110000001,
+			encoded:  `1.10000001`,
+		},
+		{
+			name:     "string",
+			data:     `"0.29"`,
+			expected: 29000000,
+			encoded:  `0.29000000`,
+		},
+		{
+			name:     "negative",
+			data:     `-0.0001`,
+			expected: -10000,
+			encoded:  `-0.00010000`,
+		},
+		{
+			name:    "not a number",
+			data:    `"abc"`,
+			wantErr: true,
+		},
+		{
+			name:    "boolean",
+			data:    `true`,
+			wantErr: true,
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		var amount DashAmount
+		err := json.Unmarshal([]byte(test.data), &amount)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Test #%d (%s) expected error", i, test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if amount != test.expected {
+			t.Errorf("Test #%d (%s) unexpected amount - got %d, "+
+				"want %d", i, test.name, amount, test.expected)
+			continue
+		}
+
+		encoded, err := json.Marshal(amount)
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected marshal error: %v", i,
+				test.name, err)
+			continue
+		}
+		if string(encoded) != test.encoded {
+			t.Errorf("Test #%d (%s) unexpected encoding - got %s, "+
+				"want %s", i, test.name, encoded, test.encoded)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2014-2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// batchResponse is a partially-unmarshaled JSON-RPC response which is part of
+// a batch reply.  The ID is kept so replies can be routed back to the command
+// that produced them since servers are free to reorder batch replies.
+type batchResponse struct {
+	ID *uint64 `json:"id"`
+	rawResponse
+}
+
+// BatchClient accumulates commands and sends them to the RPC server as a single
+// JSON-RPC batch request, which avoids one HTTP round trip per command.
+//
+// The Async methods return the same futures as their Client counterparts,
+// however, none of them are delivered until Send is invoked.  Each future
+// receives its own result or error, so a failing command does not affect the
+// others in the batch.
+//
+// Batching is only available when the client is running in HTTP POST mode.
+type BatchClient struct {
+	client *Client
+
+	mtx      sync.Mutex
+	requests []*jsonRequest
+}
+
+// Batch returns a new BatchClient which queues commands to be sent to the RPC
+// server associated with the client in a single batch request.
+func (c *Client) Batch() *BatchClient {
+	return &BatchClient{client: c}
+}
+
+// queueCmd marshals the passed command and adds it to the pending batch.  It
+// returns a response channel on which the reply will be delivered once the
+// batch has been sent.
+func (b *BatchClient) queueCmd(cmd interface{}) chan *response {
+	// Get the method associated with the command.
+	method, err := btcjson.CmdMethod(cmd)
+	if err != nil {
+		return newFutureError(err)
+	}
+
+	// Marshal the command.
+	id := b.client.NextID()
+	marshalledJSON, err := btcjson.MarshalCmd(id, cmd)
+	if err != nil {
+		return newFutureError(err)
+	}
+
+	responseChan := make(chan *response, 1)
+	jReq := &jsonRequest{
+		id:             id,
+		method:         method,
+		cmd:            cmd,
+		marshalledJSON: marshalledJSON,
+		responseChan:   responseChan,
+	}
+
+	b.mtx.Lock()
+	b.requests = append(b.requests, jReq)
+	b.mtx.Unlock()
+
+	return responseChan
+}
+
+// Len returns the number of commands waiting to be sent.
+func (b *BatchClient) Len() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return len(b.requests)
+}
+
+// CmdAsync queues the passed registered btcjson command and returns a future
+// which delivers its raw result once the batch has been sent.  This allows
+// any command to be batched, including those without a dedicated method.
+func (b *BatchClient) CmdAsync(cmd interface{}) FutureRawResult {
+	return b.queueCmd(cmd)
+}
+
+// GetBestBlockHashAsync queues a getbestblockhash command.
+//
+// See Client.GetBestBlockHash for more details.
+func (b *BatchClient) GetBestBlockHashAsync() FutureGetBestBlockHashResult {
+	cmd := btcjson.NewGetBestBlockHashCmd()
+	return b.queueCmd(cmd)
+}
+
+// GetBlockCountAsync queues a getblockcount command.
+//
+// See Client.GetBlockCount for more details.
+func (b *BatchClient) GetBlockCountAsync() FutureGetBlockCountResult {
+	cmd := btcjson.NewGetBlockCountCmd()
+	return b.queueCmd(cmd)
+}
+
+// GetBlockHashAsync queues a getblockhash command for the given height.
+//
+// See Client.GetBlockHash for more details.
+func (b *BatchClient) GetBlockHashAsync(blockHeight int64) FutureGetBlockHashResult {
+	cmd := btcjson.NewGetBlockHashCmd(blockHeight)
+	return b.queueCmd(cmd)
+}
+
+// GetBlockAsync queues a getblock command requesting the raw block.
+//
+// See Client.GetBlock for more details.
+func (b *BatchClient) GetBlockAsync(blockHash *chainhash.Hash) FutureGetBlockResult {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+
+	cmd := btcjson.NewGetBlockCmd(hash, btcjson.Bool(false), nil)
+	return b.queueCmd(cmd)
+}
+
+// GetBlockVerboseAsync queues a getblock command requesting the verbose block
+// data structure.
+//
+// See Client.GetBlockVerbose for more details.
+func (b *BatchClient) GetBlockVerboseAsync(blockHash *chainhash.Hash) FutureGetBlockVerboseResult {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+
+	cmd := btcjson.NewGetBlockCmd(hash, btcjson.Bool(true), nil)
+	return b.queueCmd(cmd)
+}
+
+// GetRawTransactionAsync queues a getrawtransaction command requesting the
+// serialized transaction.
+//
+// See Client.GetRawTransaction for more details.
+func (b *BatchClient) GetRawTransactionAsync(txHash *chainhash.Hash) FutureGetRawTransactionResult {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+
+	cmd := btcjson.NewGetRawTransactionCmd(hash, btcjson.Int(0))
+	return b.queueCmd(cmd)
+}
+
+// GetRawTransactionVerboseAsync queues a getrawtransaction command requesting
+// the verbose transaction data structure.
+//
+// See Client.GetRawTransactionVerbose for more details.
+func (b *BatchClient) GetRawTransactionVerboseAsync(txHash *chainhash.Hash) FutureGetRawTransactionVerboseResult {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+
+	cmd := btcjson.NewGetRawTransactionCmd(hash, btcjson.Int(1))
+	return b.queueCmd(cmd)
+}
+
+// Send sends all queued commands to the RPC server in a single batch request
+// and delivers the replies to their futures.  The batch is emptied, so the
+// BatchClient may be reused afterwards.
+//
+// The returned error only describes failures affecting the batch as a whole,
+// such as transport errors, in which case every queued future receives the
+// same error.  Errors for individual commands are delivered on their futures.
+func (b *BatchClient) Send() error {
+	return b.SendCtx(context.Background())
+}
+
+// SendCtx is the same as Send except the batch request is bound to the passed
+// context.
+func (b *BatchClient) SendCtx(ctx context.Context) error {
+	b.mtx.Lock()
+	requests := b.requests
+	b.requests = nil
+	b.mtx.Unlock()
+
+	if len(requests) == 0 {
+		return nil
+	}
+
+	err := b.send(ctx, requests)
+	if err != nil {
+		for _, jReq := range requests {
+			jReq.responseChan <- &response{err: err}
+		}
+	}
+	return err
+}
+
+// send performs the batch request for the passed requests and routes each
+// reply to the matching response channel.  Requests are left untouched when
+// an error is returned.
+func (b *BatchClient) send(ctx context.Context, requests []*jsonRequest) error {
+	c := b.client
+	if !c.config.HTTPPostMode {
+		return ErrNotHTTPPostClient
+	}
+
+	// Don't send the batch if shutting down.
+	select {
+	case <-c.shutdown:
+		return ErrClientShutdown
+	default:
+	}
+
+	// Wrap the marshalled commands in a JSON array.
+	var body bytes.Buffer
+	body.WriteByte('[')
+	for i, jReq := range requests {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(jReq.marshalledJSON)
+	}
+	body.WriteByte(']')
+
+	httpReq, err := c.newPostRequest(body.Bytes())
+	if err != nil {
+		return err
+	}
+	httpReq = httpReq.WithContext(ctx)
+
+	log.Tracef("Sending batch of %d commands", len(requests))
+	// The batch may only be retried when all of its commands are
+	// read-only.
+	retry := true
+	for _, jReq := range requests {
+		retry = retry && isRetryable(jReq)
+	}
+	httpResponse, err := c.doPostRetry(httpReq, body.Bytes(), retry)
+	if err != nil {
+		return err
+	}
+
+	// Read the raw bytes and close the response.
+	respBytes, err := ioutil.ReadAll(httpResponse.Body)
+	httpResponse.Body.Close()
+	if err != nil {
+		return fmt.Errorf("error reading json reply: %v", err)
+	}
+
+	var resps []batchResponse
+	if err := json.Unmarshal(respBytes, &resps); err != nil {
+		// Servers that reject the batch as a whole reply with a single
+		// JSON-RPC error object rather than an array.
+		var resp rawResponse
+		if json.Unmarshal(respBytes, &resp) == nil && resp.Error != nil {
+			return resp.Error
+		}
+
+		return fmt.Errorf("status code: %d, response: %q",
+			httpResponse.StatusCode, string(respBytes))
+	}
+
+	replies := make(map[uint64]*batchResponse, len(resps))
+	for i := range resps {
+		if resps[i].ID != nil {
+			replies[*resps[i].ID] = &resps[i]
+		}
+	}
+	for _, jReq := range requests {
+		reply, ok := replies[jReq.id]
+		if !ok {
+			err := fmt.Errorf("no reply for batched command [%s] "+
+				"with id %d", jReq.method, jReq.id)
+			jReq.responseChan <- &response{err: err}
+			continue
+		}
+
+		res, err := reply.result()
+		jReq.responseChan <- &response{result: res, err: err}
+	}
+
+	return nil
+}
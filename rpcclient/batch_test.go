@@ -0,0 +1,86 @@
+// Copyright (c) 2014-2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+)
+
+// TestBatchClient ensures batched commands are sent in a single request and
+// every future receives its own reply, including individual errors.
+func TestBatchClient(t *testing.T) {
+	t.Parallel()
+
+	var posts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+
+		var reqs []btcjson.Request
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Errorf("batch request is not an array: %v", err)
+			return
+		}
+
+		// Reply in reverse order to ensure replies are routed by id.
+		var replies []map[string]interface{}
+		for i := len(reqs) - 1; i >= 0; i-- {
+			reply := map[string]interface{}{"id": reqs[i].ID}
+			switch reqs[i].Method {
+			case "getblockcount":
+				reply["result"] = 1234
+				reply["error"] = nil
+			default:
+				reply["result"] = nil
+				reply["error"] = btcjson.RPCError{
+					Code:    btcjson.ErrRPCInvalidParameter,
+					Message: "invalid height",
+				}
+			}
+			replies = append(replies, reply)
+		}
+		json.NewEncoder(w).Encode(replies)
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	batch := client.Batch()
+	countFuture := batch.GetBlockCountAsync()
+	hashFuture := batch.GetBlockHashAsync(-1)
+	if batch.Len() != 2 {
+		t.Fatalf("Len: got %d, want 2", batch.Len())
+	}
+
+	if err := batch.Send(); err != nil {
+		t.Fatalf("Send: unexpected error: %v", err)
+	}
+	if posts != 1 {
+		t.Fatalf("Send: got %d requests, want 1", posts)
+	}
+	if batch.Len() != 0 {
+		t.Fatalf("Len: got %d after send, want 0", batch.Len())
+	}
+
+	count, err := countFuture.Receive()
+	if err != nil {
+		t.Fatalf("GetBlockCountAsync: unexpected error: %v", err)
+	}
+	if count != 1234 {
+		t.Fatalf("GetBlockCountAsync: got %d, want 1234", count)
+	}
+
+	_, err = hashFuture.Receive()
+	rpcErr, ok := err.(*btcjson.RPCError)
+	if !ok || rpcErr.Code != btcjson.ErrRPCInvalidParameter {
+		t.Fatalf("GetBlockHashAsync: got error %v, want %v", err,
+			btcjson.ErrRPCInvalidParameter)
+	}
+}
@@ -7,8 +7,11 @@ package rpcclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/jiangjinyuan/godash/btcjson"
 	"github.com/nargott/godash/chaincfg/chainhash"
@@ -52,6 +55,13 @@ func (c *Client) GetBestBlockHash() (*chainhash.Hash, error) {
 	return c.GetBestBlockHashAsync().Receive()
 }
 
+// GetBestBlockHashCtx is the same as GetBestBlockHash except the request is
+// bound to the passed context.
+func (c *Client) GetBestBlockHashCtx(ctx context.Context) (*chainhash.Hash, error) {
+	cmd := btcjson.NewGetBestBlockHashCmd()
+	return FutureGetBestBlockHashResult(c.sendCmdCtx(ctx, cmd)).Receive()
+}
+
 // FutureGetBlockResult is a future promise to deliver the result of a
 // GetBlockAsync RPC invocation (or an applicable error).
 type FutureGetBlockResult chan *response
@@ -109,6 +119,18 @@ func (c *Client) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
 	return c.GetBlockAsync(blockHash).Receive()
 }
 
+// GetBlockCtx is the same as GetBlock except the request is bound to the
+// passed context.
+func (c *Client) GetBlockCtx(ctx context.Context, blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+
+	cmd := btcjson.NewGetBlockCmd(hash, btcjson.Bool(false), nil)
+	return FutureGetBlockResult(c.sendCmdCtx(ctx, cmd)).Receive()
+}
+
 // FutureGetBlockVerboseResult is a future promise to deliver the result of a
 // GetBlockVerboseAsync RPC invocation (or an applicable error).
 type FutureGetBlockVerboseResult chan *response
@@ -154,38 +176,172 @@ func (c *Client) GetBlockVerbose(blockHash *chainhash.Hash) (*btcjson.GetBlockVe
 	return c.GetBlockVerboseAsync(blockHash).Receive()
 }
 
-// For Dash rpc method getblockstats
+// GetBlockVerboseCtx is the same as GetBlockVerbose except the request is
+// bound to the passed context.
+func (c *Client) GetBlockVerboseCtx(ctx context.Context, blockHash *chainhash.Hash) (*btcjson.GetBlockVerboseResult, error) {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+
+	cmd := btcjson.NewGetBlockCmd(hash, btcjson.Bool(true), nil)
+	return FutureGetBlockVerboseResult(c.sendCmdCtx(ctx, cmd)).Receive()
+}
+
+// FutureGetBlockStatsResult is a future promise to deliver the result of a
+// GetBlockStatsAsync or GetBlockStatsByHeightAsync RPC invocation (or an
+// applicable error).
 type FutureGetBlockStatsResult chan *response
 
+// Receive waits for the response promised by the future and returns the
+// statistics of the requested block.
 func (r FutureGetBlockStatsResult) Receive() (*btcjson.GetBlockStatsResult, error) {
 	res, err := receiveFuture(r)
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal the raw result into a BlockResult.
-	var blockResult btcjson.GetBlockStatsResult
-	err = json.Unmarshal(res, &blockResult)
+	// Unmarshal result as a getblockstats result object.
+	var blockStats btcjson.GetBlockStatsResult
+	err = json.Unmarshal(res, &blockStats)
 	if err != nil {
 		return nil, err
 	}
-	return &blockResult, nil
+	return &blockStats, nil
 }
 
+// GetBlockStatsAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetBlockStats for the blocking version and more details.
 func (c *Client) GetBlockStatsAsync(blockHash *chainhash.Hash) FutureGetBlockStatsResult {
 	hash := ""
 	if blockHash != nil {
 		hash = blockHash.String()
 	}
 
-	cmd := btcjson.NewGetBlockStatsCmd(hash, nil)
+	cmd := btcjson.NewGetBlockStatsCmd(btcjson.HashOrHeight{Value: hash}, nil)
 	return c.sendCmd(cmd)
 }
 
-func (c *Client) GetBlockStats(blockHash *chainhash.Hash) (*btcjson.GetBlockStatsResult,error) {
+// GetBlockStats returns fee, size and transaction statistics of the block
+// with the given hash.
+//
+// NOTE: This requires the server to not be pruned past the block.
+func (c *Client) GetBlockStats(blockHash *chainhash.Hash) (*btcjson.GetBlockStatsResult, error) {
 	return c.GetBlockStatsAsync(blockHash).Receive()
 }
 
+// GetBlockStatsByHeightAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetBlockStatsByHeight for the blocking version and more details.
+func (c *Client) GetBlockStatsByHeightAsync(height int32) FutureGetBlockStatsResult {
+	cmd := btcjson.NewGetBlockStatsCmd(btcjson.HashOrHeight{Value: int(height)},
+		nil)
+	return c.sendCmd(cmd)
+}
+
+// GetBlockStatsByHeight returns fee, size and transaction statistics of the
+// block at the given height in the main chain.
+//
+// NOTE: This requires the server to not be pruned past the block.
+func (c *Client) GetBlockStatsByHeight(height int32) (*btcjson.GetBlockStatsResult, error) {
+	return c.GetBlockStatsByHeightAsync(height).Receive()
+}
+
+// blockStatsFields houses the names of the statistics the getblockstats
+// command can be limited to.
+var blockStatsFields = map[string]struct{}{
+	"avgfee":              {},
+	"avgfeerate":          {},
+	"avgtxsize":           {},
+	"blockhash":           {},
+	"feerate_percentiles": {},
+	"height":              {},
+	"ins":                 {},
+	"maxfee":              {},
+	"maxfeerate":          {},
+	"maxtxsize":           {},
+	"medianfee":           {},
+	"medianfeerate":       {},
+	"mediantime":          {},
+	"mediantxsize":        {},
+	"minfee":              {},
+	"minfeerate":          {},
+	"mintxsize":           {},
+	"outs":                {},
+	"subsidy":             {},
+	"time":                {},
+	"total_out":           {},
+	"total_size":          {},
+	"totalfee":            {},
+	"txs":                 {},
+	"utxo_increase":       {},
+	"utxo_size_inc":       {},
+}
+
+// GetBlockStatsFieldsAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetBlockStatsFields for the blocking version and more details.
+func (c *Client) GetBlockStatsFieldsAsync(hashOrHeight interface{}, stats []string) FutureGetBlockStatsResult {
+	var value interface{}
+	switch v := hashOrHeight.(type) {
+	case *chainhash.Hash:
+		if v == nil {
+			return newFutureError(errors.New("no block hash specified"))
+		}
+		value = v.String()
+	case chainhash.Hash:
+		value = v.String()
+	case string:
+		value = v
+	case int:
+		value = v
+	case int32:
+		value = int(v)
+	case int64:
+		value = int(v)
+	case uint32:
+		value = int(v)
+	default:
+		return newFutureError(fmt.Errorf("invalid block identifier type "+
+			"%T: must be a block hash or height", hashOrHeight))
+	}
+
+	for _, stat := range stats {
+		if _, ok := blockStatsFields[stat]; !ok {
+			return newFutureError(fmt.Errorf("unknown block stat %q",
+				stat))
+		}
+	}
+
+	var statsParam *[]string
+	if len(stats) > 0 {
+		statsParam = &stats
+	}
+	cmd := btcjson.NewGetBlockStatsCmd(btcjson.HashOrHeight{Value: value},
+		statsParam)
+	return c.sendCmd(cmd)
+}
+
+// GetBlockStatsFields is the same as GetBlockStats except the block is
+// identified by either its hash, given as a *chainhash.Hash, chainhash.Hash or
+// hex string, or its height, given as an integer, and only the passed
+// statistics are computed, for example []string{"avgfeerate", "height"}.  The
+// fields of the result which were not requested are left at their zero value.
+// All statistics are computed when stats is empty.
+//
+// An error is returned without contacting the server when one of the stats is
+// not known.
+func (c *Client) GetBlockStatsFields(hashOrHeight interface{}, stats []string) (*btcjson.GetBlockStatsResult, error) {
+	return c.GetBlockStatsFieldsAsync(hashOrHeight, stats).Receive()
+}
+
 // GetBlockVerboseTxAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
@@ -246,6 +402,13 @@ func (c *Client) GetBlockCount() (int64, error) {
 	return c.GetBlockCountAsync().Receive()
 }
 
+// GetBlockCountCtx is the same as GetBlockCount except the request is bound to
+// the passed context.
+func (c *Client) GetBlockCountCtx(ctx context.Context) (int64, error) {
+	cmd := btcjson.NewGetBlockCountCmd()
+	return FutureGetBlockCountResult(c.sendCmdCtx(ctx, cmd)).Receive()
+}
+
 // FutureGetDifficultyResult is a future promise to deliver the result of a
 // GetDifficultyAsync RPC invocation (or an applicable error).
 type FutureGetDifficultyResult chan *response
@@ -279,6 +442,9 @@ func (c *Client) GetDifficultyAsync() FutureGetDifficultyResult {
 
 // GetDifficulty returns the proof-of-work difficulty as a multiple of the
 // minimum difficulty.
+//
+// See chaincfg.DifficultyFromBits with chaincfg.DifficultyOneTarget to compute
+// the difficulty of a block from the bits field of its header instead.
 func (c *Client) GetDifficulty() (float64, error) {
 	return c.GetDifficultyAsync().Receive()
 }
@@ -314,7 +480,9 @@ func (c *Client) GetBlockChainInfoAsync() FutureGetBlockChainInfoResult {
 
 // GetBlockChainInfo returns information related to the processing state of
 // various chain-specific details such as the current difficulty from the tip
-// of the main chain.
+// of the main chain.  The status of the Dash deployments, such as dip0008
+// which activates ChainLocks, is reported in Bip9SoftForks by every server
+// version.
 func (c *Client) GetBlockChainInfo() (*btcjson.GetBlockChainInfoResult, error) {
 	return c.GetBlockChainInfoAsync().Receive()
 }
@@ -356,6 +524,13 @@ func (c *Client) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
 	return c.GetBlockHashAsync(blockHeight).Receive()
 }
 
+// GetBlockHashCtx is the same as GetBlockHash except the request is bound to
+// the passed context.
+func (c *Client) GetBlockHashCtx(ctx context.Context, blockHeight int64) (*chainhash.Hash, error) {
+	cmd := btcjson.NewGetBlockHashCmd(blockHeight)
+	return FutureGetBlockHashResult(c.sendCmdCtx(ctx, cmd)).Receive()
+}
+
 // FutureGetBlockHeaderResult is a future promise to deliver the result of a
 // GetBlockHeaderAsync RPC invocation (or an applicable error).
 type FutureGetBlockHeaderResult chan *response
@@ -497,6 +672,98 @@ func (c *Client) GetMempoolEntry(txHash string) (*btcjson.GetMempoolEntryResult,
 	return c.GetMempoolEntryAsync(txHash).Receive()
 }
 
+// FutureEstimateSmartFeeResult is a future promise to deliver the result of a
+// EstimateSmartFeeAsync RPC invocation (or an applicable error).
+type FutureEstimateSmartFeeResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// estimated fee rate.
+func (r FutureEstimateSmartFeeResult) Receive() (*btcjson.EstimateSmartFeeResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an estimatesmartfee result object.
+	var feeResult btcjson.EstimateSmartFeeResult
+	err = json.Unmarshal(res, &feeResult)
+	if err != nil {
+		return nil, err
+	}
+	return &feeResult, nil
+}
+
+// EstimateSmartFeeAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See EstimateSmartFee for the blocking version and more details.
+func (c *Client) EstimateSmartFeeAsync(confTarget int64, mode btcjson.EstimateSmartFeeMode) FutureEstimateSmartFeeResult {
+	var modeParam *btcjson.EstimateSmartFeeMode
+	switch mode {
+	case "":
+	case btcjson.EstimateModeUnset, btcjson.EstimateModeEconomical,
+		btcjson.EstimateModeConservative:
+
+		modeParam = &mode
+	default:
+		return newFutureError(fmt.Errorf("invalid fee estimation mode %q",
+			mode))
+	}
+
+	cmd := btcjson.NewEstimateSmartFeeCmd(confTarget, modeParam)
+	return c.sendCmd(cmd)
+}
+
+// EstimateSmartFee returns the fee rate in DASH/kB a transaction needs to pay
+// to begin confirmation within confTarget blocks.  The mode is one of UNSET,
+// ECONOMICAL or CONSERVATIVE, and the server default is used when it is
+// empty.  The FeeRate of the returned result is nil when the server does not
+// have enough data for an estimate and can otherwise be converted with
+// godashutil.NewAmount.
+func (c *Client) EstimateSmartFee(confTarget int64, mode btcjson.EstimateSmartFeeMode) (*btcjson.EstimateSmartFeeResult, error) {
+	return c.EstimateSmartFeeAsync(confTarget, mode).Receive()
+}
+
+// FutureGetMempoolInfoResult is a future promise to deliver the result of a
+// GetMempoolInfoAsync RPC invocation (or an applicable error).
+type FutureGetMempoolInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// current state of the memory pool.
+func (r FutureGetMempoolInfoResult) Receive() (*btcjson.GetMempoolInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getmempoolinfo result object.
+	var mempoolInfo btcjson.GetMempoolInfoResult
+	err = json.Unmarshal(res, &mempoolInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mempoolInfo, nil
+}
+
+// GetMempoolInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetMempoolInfo for the blocking version and more details.
+func (c *Client) GetMempoolInfoAsync() FutureGetMempoolInfoResult {
+	cmd := btcjson.NewGetMempoolInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetMempoolInfo returns the size, memory usage and fee limits of the memory
+// pool along with the number of InstantSend locked transactions it holds.
+// InstantSendLocks is zero on dashd versions that do not report it.
+func (c *Client) GetMempoolInfo() (*btcjson.GetMempoolInfoResult, error) {
+	return c.GetMempoolInfoAsync().Receive()
+}
+
 // FutureGetRawMempoolResult is a future promise to deliver the result of a
 // GetRawMempoolAsync RPC invocation (or an applicable error).
 type FutureGetRawMempoolResult chan *response
@@ -582,7 +849,8 @@ func (c *Client) GetRawMempoolVerboseAsync() FutureGetRawMempoolVerboseResult {
 
 // GetRawMempoolVerbose returns a map of transaction hashes to an associated
 // data structure with information about the transaction for all transactions in
-// the memory pool.
+// the memory pool.  When connected to dashd, the InstantLock field of each
+// entry reports whether the transaction is locked by InstantSend.
 //
 // See GetRawMempool to retrieve only the transaction hashes instead.
 func (c *Client) GetRawMempoolVerbose() (map[string]btcjson.GetRawMempoolVerboseResult, error) {
@@ -727,6 +995,173 @@ func (c *Client) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*
 	return c.GetTxOutAsync(txHash, index, mempool).Receive()
 }
 
+// FutureGetTxOutSetInfoResult is a future promise to deliver the result of a
+// GetTxOutSetInfoAsync RPC invocation (or an applicable error).
+type FutureGetTxOutSetInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// statistics of the unspent transaction output set.
+func (r FutureGetTxOutSetInfoResult) Receive() (*btcjson.GetTxOutSetInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a gettxoutsetinfo result object.
+	var txOutSetInfo btcjson.GetTxOutSetInfoResult
+	err = json.Unmarshal(res, &txOutSetInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txOutSetInfo, nil
+}
+
+// GetTxOutSetInfoAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetTxOutSetInfo for the blocking version and more details.
+func (c *Client) GetTxOutSetInfoAsync() FutureGetTxOutSetInfoResult {
+	cmd := btcjson.NewGetTxOutSetInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetTxOutSetInfo returns statistics of the unspent transaction output set,
+// such as the number of outputs, the serialized hash of the set and the total
+// amount, which may be used to audit the coin supply.
+//
+// NOTE: The server walks the whole set to answer, which can take minutes.
+// See GetTxOutSetInfoCtx to bound the time waited for the reply.
+func (c *Client) GetTxOutSetInfo() (*btcjson.GetTxOutSetInfoResult, error) {
+	return c.GetTxOutSetInfoAsync().Receive()
+}
+
+// GetTxOutSetInfoCtx is the same as GetTxOutSetInfo except the request is
+// bound to the passed context.
+func (c *Client) GetTxOutSetInfoCtx(ctx context.Context) (*btcjson.GetTxOutSetInfoResult, error) {
+	cmd := btcjson.NewGetTxOutSetInfoCmd()
+	return FutureGetTxOutSetInfoResult(c.sendCmdCtx(ctx, cmd)).Receive()
+}
+
+// FutureGetTxOutProofResult is a future promise to deliver the result of a
+// GetTxOutProofAsync RPC invocation (or an applicable error).
+type FutureGetTxOutProofResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// hex-encoded merkle proof of the requested transactions.
+func (r FutureGetTxOutProofResult) Receive() (string, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return "", err
+	}
+
+	// Unmarshal result as a string.
+	var proof string
+	err = json.Unmarshal(res, &proof)
+	if err != nil {
+		return "", err
+	}
+	return proof, nil
+}
+
+// GetTxOutProofAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetTxOutProof for the blocking version and more details.
+func (c *Client) GetTxOutProofAsync(txids []*chainhash.Hash, blockHash *chainhash.Hash) FutureGetTxOutProofResult {
+	txIDStrs := make([]string, 0, len(txids))
+	for _, txid := range txids {
+		txIDStrs = append(txIDStrs, txid.String())
+	}
+
+	var hash *string
+	if blockHash != nil {
+		hash = btcjson.String(blockHash.String())
+	}
+
+	cmd := btcjson.NewGetTxOutProofCmd(txIDStrs, hash)
+	return c.sendCmd(cmd)
+}
+
+// GetTxOutProof returns the hex-encoded proof that the transactions with the
+// given hashes were included in a block.  All of the transactions must be in
+// the same block.  The server can only find the block itself when blockHash is
+// nil if it maintains a transaction index or one of the transactions has an
+// unspent output.
+//
+// See ParseTxOutProof to decode the proof for local validation.
+func (c *Client) GetTxOutProof(txids []*chainhash.Hash, blockHash *chainhash.Hash) (string, error) {
+	return c.GetTxOutProofAsync(txids, blockHash).Receive()
+}
+
+// ParseTxOutProof decodes a hex-encoded merkle proof as returned by
+// GetTxOutProof.  The proof is serialized the same way as a merkleblock
+// message, so the returned message holds the header of the block and the
+// partial merkle tree which commits to the proven transactions.
+func ParseTxOutProof(proof string) (*wire.MsgMerkleBlock, error) {
+	serializedProof, err := hex.DecodeString(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	var merkleBlock wire.MsgMerkleBlock
+	err = merkleBlock.BtcDecode(bytes.NewReader(serializedProof),
+		wire.ProtocolVersion, wire.BaseEncoding)
+	if err != nil {
+		return nil, err
+	}
+	return &merkleBlock, nil
+}
+
+// FutureVerifyTxOutProofResult is a future promise to deliver the result of a
+// VerifyTxOutProofAsync RPC invocation (or an applicable error).
+type FutureVerifyTxOutProofResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// hashes of the transactions the proof commits to.
+func (r FutureVerifyTxOutProofResult) Receive() ([]*chainhash.Hash, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of strings.
+	var txIDStrs []string
+	err = json.Unmarshal(res, &txIDStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs := make([]*chainhash.Hash, 0, len(txIDStrs))
+	for _, txIDStr := range txIDStrs {
+		txID, err := chainhash.NewHashFromStr(txIDStr)
+		if err != nil {
+			return nil, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, nil
+}
+
+// VerifyTxOutProofAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See VerifyTxOutProof for the blocking version and more details.
+func (c *Client) VerifyTxOutProofAsync(proof string) FutureVerifyTxOutProofResult {
+	cmd := btcjson.NewVerifyTxOutProofCmd(proof)
+	return c.sendCmd(cmd)
+}
+
+// VerifyTxOutProof returns the hashes of the transactions the hex-encoded
+// merkle proof commits to.  The list is empty when the proof is invalid or the
+// block it refers to is not in the main chain of the server.
+func (c *Client) VerifyTxOutProof(proof string) ([]*chainhash.Hash, error) {
+	return c.VerifyTxOutProofAsync(proof).Receive()
+}
+
 // FutureRescanBlocksResult is a future promise to deliver the result of a
 // RescanBlocksAsync RPC invocation (or an applicable error).
 //
@@ -0,0 +1,413 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godash/wire"
+)
+
+// TestGetBlockHeaderVerboseReceive ensures the Dash specific fields of the
+// verbose getblockheader reply are decoded.
+func TestGetBlockHeaderVerboseReceive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		result   string
+		expected *btcjson.GetBlockHeaderVerboseResult
+	}{
+		{
+			name: "chainlocked block",
+			result: `{"hash":"000000000000001d9b8a0a1a5d1ce4a4e0e1ba4d0f7d5ef5ba4ac5cbb2ed0b4e",` +
+				`"confirmations":3,"height":1028160,"version":536870912,` +
+				`"versionHex":"20000000","merkleroot":"be1a3eb8b8a5a8c5b0f4e50a36e86a8fdcfda0e1a3f4c2a3e8d2b2ebcbe0db7d",` +
+				`"time":1554112722,"mediantime":1554112312,"nonce":1125404079,` +
+				`"bits":"1956b6b5","difficulty":49027351.26,` +
+				`"chainwork":"0000000000000000000000000000000000000000000009d2d4d9bd4dfc6fd9a2",` +
+				`"previousblockhash":"0000000000000030a5e4ee083c9d4fc915ed1ced24c4b7e7a7e53d4c40e0fc2a",` +
+				`"nextblockhash":"00000000000000145f1b4e7d3b2e6e3b1e8cd3e1d1c6d0d1b8df6a6e8f0a0c4d",` +
+				`"chainlock":true}`,
+			expected: &btcjson.GetBlockHeaderVerboseResult{
+				Hash:          "000000000000001d9b8a0a1a5d1ce4a4e0e1ba4d0f7d5ef5ba4ac5cbb2ed0b4e",
+				Confirmations: 3,
+				Height:        1028160,
+				Version:       536870912,
+				VersionHex:    "20000000",
+				MerkleRoot:    "be1a3eb8b8a5a8c5b0f4e50a36e86a8fdcfda0e1a3f4c2a3e8d2b2ebcbe0db7d",
+				Time:          1554112722,
+				MedianTime:    1554112312,
+				Nonce:         1125404079,
+				Bits:          "1956b6b5",
+				Difficulty:    49027351.26,
+				ChainWork:     "0000000000000000000000000000000000000000000009d2d4d9bd4dfc6fd9a2",
+				PreviousHash:  "0000000000000030a5e4ee083c9d4fc915ed1ced24c4b7e7a7e53d4c40e0fc2a",
+				NextHash:      "00000000000000145f1b4e7d3b2e6e3b1e8cd3e1d1c6d0d1b8df6a6e8f0a0c4d",
+				ChainLock:     true,
+			},
+		},
+		{
+			name: "block off the main chain",
+			result: `{"hash":"000000000000001d9b8a0a1a5d1ce4a4e0e1ba4d0f7d5ef5ba4ac5cbb2ed0b4e",` +
+				`"confirmations":-1,"height":1028160,"chainlock":false}`,
+			expected: &btcjson.GetBlockHeaderVerboseResult{
+				Hash:          "000000000000001d9b8a0a1a5d1ce4a4e0e1ba4d0f7d5ef5ba4ac5cbb2ed0b4e",
+				Confirmations: -1,
+				Height:        1028160,
+			},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		future := make(FutureGetBlockHeaderVerboseResult, 1)
+		future <- &response{result: []byte(test.result)}
+		result, err := future.Receive()
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected result - got %+v, "+
+				"want %+v", i, test.name, result, test.expected)
+		}
+	}
+}
+
+// TestGetMempoolInfoReceive ensures getmempoolinfo replies are decoded both
+// from nodes that report the instantsendlocks count and from ones that don't.
+func TestGetMempoolInfoReceive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		result   string
+		expected *btcjson.GetMempoolInfoResult
+	}{
+		{
+			name: "dashd with instantsend locks",
+			result: `{"loaded":true,"size":42,"bytes":10821,"usage":48736,` +
+				`"maxmempool":300000000,"mempoolminfee":0.00001,` +
+				`"minrelaytxfee":0.00001,"instantsendlocks":7}`,
+			expected: &btcjson.GetMempoolInfoResult{
+				Size:             42,
+				Bytes:            10821,
+				Usage:            48736,
+				MaxMempool:       300000000,
+				MempoolMinFee:    0.00001,
+				MinRelayTxFee:    0.00001,
+				InstantSendLocks: 7,
+			},
+		},
+		{
+			name: "older dashd without instantsend locks",
+			result: `{"size":3,"bytes":678,"usage":3264,` +
+				`"maxmempool":300000000,"mempoolminfee":0}`,
+			expected: &btcjson.GetMempoolInfoResult{
+				Size:       3,
+				Bytes:      678,
+				Usage:      3264,
+				MaxMempool: 300000000,
+			},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		future := make(FutureGetMempoolInfoResult, 1)
+		future <- &response{result: []byte(test.result)}
+		result, err := future.Receive()
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected result - got %+v, "+
+				"want %+v", i, test.name, result, test.expected)
+		}
+	}
+}
+
+// TestGetBlockStatsReceive ensures a getblockstats reply from dashd is decoded
+// into the typed result.
+func TestGetBlockStatsReceive(t *testing.T) {
+	t.Parallel()
+
+	future := make(FutureGetBlockStatsResult, 1)
+	future <- &response{
+		result: []byte(`{"avgfee":1121,"avgfeerate":4,"avgtxsize":259,` +
+			`"blockhash":"000000000000001eb8b11f5be9f2e2041d26d3e2ba6e1ca32a9dee9fe85e9d48",` +
+			`"feerate_percentiles":[1,1,2,10,10],"height":1520000,` +
+			`"ins":41,"maxfee":2260,"maxfeerate":10,"maxtxsize":1142,` +
+			`"medianfee":227,"mediantime":1626845145,"mediantxsize":226,` +
+			`"minfee":191,"minfeerate":1,"mintxsize":191,"outs":58,` +
+			`"subsidy":288446298,"time":1626846500,"total_out":5011293842,` +
+			`"total_size":3885,"totalfee":15694,"txs":15,` +
+			`"utxo_increase":17,"utxo_size_inc":1258}`),
+	}
+
+	expected := &btcjson.GetBlockStatsResult{
+		AvgFee:             1121,
+		AvgFeeRate:         4,
+		AvgTxSize:          259,
+		BlockHash:          "000000000000001eb8b11f5be9f2e2041d26d3e2ba6e1ca32a9dee9fe85e9d48",
+		FeeRatePercentiles: []int64{1, 1, 2, 10, 10},
+		Height:             1520000,
+		Ins:                41,
+		MaxFee:             2260,
+		MaxFeeRate:         10,
+		MaxTxSize:          1142,
+		MedianFee:          227,
+		MedianTime:         1626845145,
+		MedianTxSize:       226,
+		MinFee:             191,
+		MinFeeRate:         1,
+		MinTxSize:          191,
+		Outs:               58,
+		Subsidy:            288446298,
+		Time:               1626846500,
+		TotalOut:           5011293842,
+		TotalSize:          3885,
+		TotalFee:           15694,
+		Txs:                15,
+		UTXOIncrease:       17,
+		UTXOSizeIncrease:   1258,
+	}
+
+	stats, err := future.Receive()
+	if err != nil {
+		t.Fatalf("Receive: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Fatalf("Receive: mismatched result - got %v, want %v",
+			spew.Sdump(stats), spew.Sdump(expected))
+	}
+}
+
+// TestGetBlockStatsFields ensures the block identifier and the requested
+// stats are sent as expected and unknown stats are rejected without
+// contacting the server.
+func TestGetBlockStatsFields(t *testing.T) {
+	t.Parallel()
+
+	var params []json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		params = req.Params
+		w.Write([]byte(`{"result":{"avgfeerate":4,"height":1520000},` +
+			`"error":null,"id":1}`))
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	hash, err := chainhash.NewHashFromStr("000000000000001eb8b11f5be9f2e2041d26d3e2ba6e1ca32a9dee9fe85e9d48")
+	if err != nil {
+		t.Fatalf("NewHashFromStr: unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		hashOrHeight interface{}
+		stats        []string
+		params       string
+	}{
+		{
+			name:         "height with stats",
+			hashOrHeight: int32(1520000),
+			stats:        []string{"avgfeerate", "height"},
+			params:       `[1520000,["avgfeerate","height"]]`,
+		},
+		{
+			name:         "hash without stats",
+			hashOrHeight: hash,
+			params:       `["000000000000001eb8b11f5be9f2e2041d26d3e2ba6e1ca32a9dee9fe85e9d48"]`,
+		},
+	}
+
+	for _, test := range tests {
+		stats, err := client.GetBlockStatsFields(test.hashOrHeight, test.stats)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if stats.AvgFeeRate != 4 || stats.Height != 1520000 {
+			t.Errorf("%s: unexpected result %v", test.name,
+				spew.Sdump(stats))
+		}
+		gotParams, _ := json.Marshal(params)
+		if string(gotParams) != test.params {
+			t.Errorf("%s: unexpected params - got %s, want %s",
+				test.name, gotParams, test.params)
+		}
+	}
+
+	params = nil
+	_, err = client.GetBlockStatsFields(1520000, []string{"avgfeerat"})
+	if err == nil {
+		t.Error("GetBlockStatsFields: expected error for unknown stat")
+	}
+	_, err = client.GetBlockStatsFields(1.5, nil)
+	if err == nil {
+		t.Error("GetBlockStatsFields: expected error for invalid block")
+	}
+	if params != nil {
+		t.Errorf("GetBlockStatsFields: invalid requests reached the " +
+			"server")
+	}
+}
+
+// TestGetTxOutSetInfoReceive ensures a gettxoutsetinfo reply from dashd is
+// decoded as expected.
+func TestGetTxOutSetInfoReceive(t *testing.T) {
+	t.Parallel()
+
+	future := make(FutureGetTxOutSetInfoResult, 1)
+	future <- &response{
+		result: []byte(`{"height":1520000,` +
+			`"bestblock":"000000000000001eb8b11f5be9f2e2041d26d3e2ba6e1ca32a9dee9fe85e9d48",` +
+			`"transactions":3667653,"txouts":5069435,"bogosize":376577354,` +
+			`"hash_serialized_2":"9d3bbbd2fd2a1bd3f1e8ab7d2ff93a5e6f29cfbc39ef049f4950d4c70b9ba3d6",` +
+			`"disk_size":327394827,"total_amount":10375304.51252443}`),
+	}
+
+	expected := &btcjson.GetTxOutSetInfoResult{
+		Height:         1520000,
+		BestBlock:      "000000000000001eb8b11f5be9f2e2041d26d3e2ba6e1ca32a9dee9fe85e9d48",
+		Transactions:   3667653,
+		TxOuts:         5069435,
+		BogoSize:       376577354,
+		HashSerialized: "9d3bbbd2fd2a1bd3f1e8ab7d2ff93a5e6f29cfbc39ef049f4950d4c70b9ba3d6",
+		DiskSize:       327394827,
+		TotalAmount:    10375304.51252443,
+	}
+
+	info, err := future.Receive()
+	if err != nil {
+		t.Fatalf("Receive: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(info, expected) {
+		t.Fatalf("Receive: mismatched result - got %v, want %v",
+			spew.Sdump(info), spew.Sdump(expected))
+	}
+}
+
+// TestGetRawMempoolVerboseReceive ensures the Dash specific fields of the
+// verbose getrawmempool reply are decoded.
+func TestGetRawMempoolVerboseReceive(t *testing.T) {
+	t.Parallel()
+
+	future := make(FutureGetRawMempoolVerboseResult, 1)
+	future <- &response{
+		result: []byte(`{"6d1d4c5e2e3bd0b4cd8785e0cbe3f6bbd5c5977ec3fd2d28b3b8b76c2682d0df":` +
+			`{"size":226,"fee":0.00000226,"modifiedfee":0.00000226,` +
+			`"time":1626846500,"height":1520000,"descendantcount":2,` +
+			`"descendantsize":452,"descendantfees":452,"ancestorcount":1,` +
+			`"ancestorsize":226,"ancestorfees":226,"depends":[],` +
+			`"spentby":[],"instantlock":true}}`),
+	}
+
+	expected := map[string]btcjson.GetRawMempoolVerboseResult{
+		"6d1d4c5e2e3bd0b4cd8785e0cbe3f6bbd5c5977ec3fd2d28b3b8b76c2682d0df": {
+			Size:            226,
+			Fee:             0.00000226,
+			Time:            1626846500,
+			Height:          1520000,
+			DescendantCount: 2,
+			DescendantSize:  452,
+			AncestorCount:   1,
+			AncestorSize:    226,
+			Depends:         []string{},
+			InstantLock:     true,
+		},
+	}
+
+	entries, err := future.Receive()
+	if err != nil {
+		t.Fatalf("Receive: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("Receive: mismatched result - got %v, want %v",
+			spew.Sdump(entries), spew.Sdump(expected))
+	}
+}
+
+// TestTxOutProof ensures the proofs returned by gettxoutproof are decoded into
+// merkle blocks and the txids returned by verifytxoutproof are parsed.
+func TestTxOutProof(t *testing.T) {
+	t.Parallel()
+
+	prevHash := &chainhash.Hash{0x01}
+	merkleRoot := &chainhash.Hash{0x02}
+	txHash := &chainhash.Hash{0x03}
+	header := wire.NewBlockHeader(536870912, prevHash, merkleRoot,
+		0x1956b6b5, 1125404079)
+	header.Timestamp = time.Unix(1554112722, 0)
+	merkleBlock := wire.NewMsgMerkleBlock(header)
+	merkleBlock.Transactions = 5
+	merkleBlock.AddTxHash(txHash)
+	merkleBlock.Flags = []byte{0x1d}
+
+	var buf bytes.Buffer
+	err := merkleBlock.BtcEncode(&buf, wire.ProtocolVersion, wire.BaseEncoding)
+	if err != nil {
+		t.Fatalf("BtcEncode: %v", err)
+	}
+	proof := hex.EncodeToString(buf.Bytes())
+
+	proofFuture := make(FutureGetTxOutProofResult, 1)
+	proofFuture <- &response{result: []byte(`"` + proof + `"`)}
+	gotProof, err := proofFuture.Receive()
+	if err != nil {
+		t.Fatalf("GetTxOutProof Receive: unexpected error: %v", err)
+	}
+	if gotProof != proof {
+		t.Fatalf("GetTxOutProof Receive: unexpected proof - got %s, "+
+			"want %s", gotProof, proof)
+	}
+
+	parsed, err := ParseTxOutProof(gotProof)
+	if err != nil {
+		t.Fatalf("ParseTxOutProof: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, merkleBlock) {
+		t.Errorf("ParseTxOutProof: unexpected merkle block - got %v, "+
+			"want %v", spew.Sdump(parsed), spew.Sdump(merkleBlock))
+	}
+
+	if _, err := ParseTxOutProof(proof[:len(proof)-2]); err == nil {
+		t.Errorf("ParseTxOutProof: expected error for truncated proof")
+	}
+
+	verifyFuture := make(FutureVerifyTxOutProofResult, 1)
+	verifyFuture <- &response{result: []byte(`["` + txHash.String() + `"]`)}
+	txIDs, err := verifyFuture.Receive()
+	if err != nil {
+		t.Fatalf("VerifyTxOutProof Receive: unexpected error: %v", err)
+	}
+	if len(txIDs) != 1 || !txIDs[0].IsEqual(txHash) {
+		t.Errorf("VerifyTxOutProof Receive: unexpected txids - got %v, "+
+			"want [%v]", txIDs, txHash)
+	}
+}
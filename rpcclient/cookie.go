@@ -0,0 +1,71 @@
+// Copyright (c) 2014-2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// readCookieFile reads and parses the authentication cookie file dashd writes
+// to its data directory when no rpcuser is configured.  The file holds a
+// single "username:password" line, where the username is typically
+// "__cookie__".
+func readCookieFile(path string) (username, password string, err error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	s := strings.TrimSpace(string(b))
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", fmt.Errorf("malformed cookie file %s", path)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// getAuth returns the username and passphrase to authenticate with.  When a
+// cookie path is configured the credentials are read from the cookie file,
+// otherwise the configured User and Pass are returned.
+func (config *ConnConfig) getAuth() (username, passphrase string, err error) {
+	if config.CookiePath == "" {
+		return config.User, config.Pass, nil
+	}
+
+	return readCookieFile(config.CookiePath)
+}
+
+// postAuth returns the credentials to use for HTTP POST requests.  Credentials
+// read from a cookie file are cached until invalidateCookie is called so the
+// file is not read for every request.
+func (c *Client) postAuth() (username, passphrase string, err error) {
+	if c.config.CookiePath == "" {
+		return c.config.User, c.config.Pass, nil
+	}
+
+	c.cookieMtx.Lock()
+	defer c.cookieMtx.Unlock()
+
+	if c.cookieUser == "" {
+		c.cookieUser, c.cookiePass, err = c.config.getAuth()
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return c.cookieUser, c.cookiePass, nil
+}
+
+// invalidateCookie discards the cached cookie credentials so they are read
+// again on the next request.  dashd writes a new cookie every time it starts,
+// so this is done when the server rejects the cached credentials.
+func (c *Client) invalidateCookie() {
+	c.cookieMtx.Lock()
+	c.cookieUser, c.cookiePass = "", ""
+	c.cookieMtx.Unlock()
+}
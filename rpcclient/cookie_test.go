@@ -0,0 +1,132 @@
+// Copyright (c) 2014-2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestCookieAuth ensures credentials are read from the cookie file and reread
+// once the server rejects them after the cookie has been rotated.
+func TestCookieAuth(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "rpcclient-cookie")
+	if err != nil {
+		t.Fatalf("TempDir: unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cookiePath := filepath.Join(dir, ".cookie")
+	writeCookie := func(pass string) {
+		err := ioutil.WriteFile(cookiePath, []byte("__cookie__:"+pass), 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: unexpected error: %v", err)
+		}
+	}
+	writeCookie("first")
+
+	var mtx sync.Mutex
+	wantPass := "first"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "__cookie__" || pass != wantPass {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"result":1,"error":null,"id":1}`))
+	}))
+	defer srv.Close()
+
+	client, err := New(&ConnConfig{
+		Host:         strings.TrimPrefix(srv.URL, "http://"),
+		CookiePath:   cookiePath,
+		HTTPPostMode: true,
+		DisableTLS:   true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("New: unexpected error: %v", err)
+	}
+	defer client.Shutdown()
+
+	if _, err := client.GetBlockCount(); err != nil {
+		t.Fatalf("GetBlockCount: unexpected error: %v", err)
+	}
+
+	// Simulate a dashd restart rotating the cookie.
+	writeCookie("second")
+	mtx.Lock()
+	wantPass = "second"
+	mtx.Unlock()
+
+	if _, err := client.GetBlockCount(); err != nil {
+		t.Fatalf("GetBlockCount after rotation: unexpected error: %v", err)
+	}
+
+	// A missing cookie file must be reported by New.
+	_, err = New(&ConnConfig{
+		Host:         strings.TrimPrefix(srv.URL, "http://"),
+		CookiePath:   filepath.Join(dir, "missing"),
+		HTTPPostMode: true,
+		DisableTLS:   true,
+	}, nil)
+	if err == nil {
+		t.Fatal("New: expected error for missing cookie file")
+	}
+}
+
+// TestReadCookieFile ensures malformed cookie files are rejected.
+func TestReadCookieFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "rpcclient-cookie")
+	if err != nil {
+		t.Fatalf("TempDir: unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name     string
+		contents string
+		user     string
+		pass     string
+		wantErr  bool
+	}{
+		{"valid", "__cookie__:abc123", "__cookie__", "abc123", false},
+		{"trailing newline", "__cookie__:abc123\n", "__cookie__", "abc123", false},
+		{"colon in password", "user:pa:ss", "user", "pa:ss", false},
+		{"no separator", "__cookie__", "", "", true},
+		{"empty", "", "", "", true},
+	}
+
+	for i, test := range tests {
+		path := filepath.Join(dir, test.name)
+		err := ioutil.WriteFile(path, []byte(test.contents), 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: unexpected error: %v", err)
+		}
+
+		user, pass, err := readCookieFile(path)
+		if (err != nil) != test.wantErr {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if user != test.user || pass != test.pass {
+			t.Errorf("Test #%d (%s) got %s:%s, want %s:%s", i,
+				test.name, user, pass, test.user, test.pass)
+		}
+	}
+}
@@ -0,0 +1,1057 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godashutil"
+)
+
+// FutureGetMasternodeCountResult is a future promise to deliver the result of
+// a GetMasternodeCountAsync RPC invocation (or an applicable error).
+type FutureGetMasternodeCountResult chan *response
+
+// Receive waits for the response promised by the future and returns the number
+// of masternodes known to the server.
+func (r FutureGetMasternodeCountResult) Receive() (*btcjson.MasternodeCountResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a masternode count result object.
+	var countResult btcjson.MasternodeCountResult
+	err = json.Unmarshal(res, &countResult)
+	if err != nil {
+		return nil, err
+	}
+	return &countResult, nil
+}
+
+// GetMasternodeCountAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetMasternodeCount for the blocking version and more details.
+func (c *Client) GetMasternodeCountAsync() FutureGetMasternodeCountResult {
+	cmd := btcjson.NewMasternodeCountCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetMasternodeCount returns the total, enabled and, on older servers,
+// qualifying number of masternodes known to the server.  Servers which only
+// report a bare total have just the Total field populated.
+func (c *Client) GetMasternodeCount() (*btcjson.MasternodeCountResult, error) {
+	return c.GetMasternodeCountAsync().Receive()
+}
+
+// FutureGetMasternodeListResult is a future promise to deliver the result of a
+// GetMasternodeListAsync RPC invocation (or an applicable error).
+type FutureGetMasternodeListResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// masternodes known to the server keyed by their collateral outpoint.
+func (r FutureGetMasternodeListResult) Receive() (map[string]btcjson.MasternodeListEntry, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a map of masternode list entries.
+	var masternodes map[string]btcjson.MasternodeListEntry
+	err = json.Unmarshal(res, &masternodes)
+	if err != nil {
+		return nil, err
+	}
+	return masternodes, nil
+}
+
+// GetMasternodeListAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetMasternodeList for the blocking version and more details.
+func (c *Client) GetMasternodeListAsync(mode, filter string) FutureGetMasternodeListResult {
+	if mode == "" {
+		mode = "json"
+	}
+
+	var filterParam *string
+	if filter != "" {
+		filterParam = &filter
+	}
+
+	cmd := btcjson.NewMasternodeListCmd(&mode, filterParam)
+	return c.sendCmd(cmd)
+}
+
+// GetMasternodeList returns the masternodes known to the server keyed by their
+// collateral outpoint.  The mode selects the information reported for each
+// masternode and matches the modes supported by dashd, such as "json", "full"
+// or "addr".  An empty mode defaults to "json", which is the only mode that
+// populates the individual fields of the returned entries; the other modes only
+// populate the Info field.  The optional filter limits the results to those
+// masternodes which match it.
+func (c *Client) GetMasternodeList(mode, filter string) (map[string]btcjson.MasternodeListEntry, error) {
+	return c.GetMasternodeListAsync(mode, filter).Receive()
+}
+
+// FutureGetMasternodeWinnersResult is a future promise to deliver the result
+// of a GetMasternodeWinnersAsync RPC invocation (or an applicable error).
+type FutureGetMasternodeWinnersResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// masternode payees keyed by block height.
+func (r FutureGetMasternodeWinnersResult) Receive() (map[int32]string, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a map of payees keyed by the height as a string.
+	var winners map[string]string
+	err = json.Unmarshal(res, &winners)
+	if err != nil {
+		return nil, err
+	}
+
+	payees := make(map[int32]string, len(winners))
+	for heightStr, payee := range winners {
+		height, err := strconv.ParseInt(heightStr, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		payees[int32(height)] = payee
+	}
+	return payees, nil
+}
+
+// GetMasternodeWinnersAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetMasternodeWinners for the blocking version and more details.
+func (c *Client) GetMasternodeWinnersAsync(count int, filter string) FutureGetMasternodeWinnersResult {
+	var filterParam *string
+	if filter != "" {
+		filterParam = &filter
+	}
+
+	cmd := btcjson.NewMasternodeWinnersCmd(&count, filterParam)
+	return c.sendCmd(cmd)
+}
+
+// GetMasternodeWinners returns the masternode payees of the last count blocks
+// along with the predicted payees of the upcoming blocks keyed by block height.
+// Blocks paying several masternodes report their payees as a comma separated
+// list, and heights for which the payee can't be determined yet are reported
+// as "Unknown".  The optional filter limits the results to those payees which
+// match it.
+func (c *Client) GetMasternodeWinners(count int, filter string) (map[int32]string, error) {
+	return c.GetMasternodeWinnersAsync(count, filter).Receive()
+}
+
+// FutureGetMasternodePaymentsResult is a future promise to deliver the result
+// of a GetMasternodePaymentsAsync RPC invocation (or an applicable error).
+type FutureGetMasternodePaymentsResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// masternode payouts of the requested blocks.
+func (r FutureGetMasternodePaymentsResult) Receive() ([]btcjson.MasternodePaymentsResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of masternode payments results.
+	var payments []btcjson.MasternodePaymentsResult
+	err = json.Unmarshal(res, &payments)
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// GetMasternodePaymentsAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetMasternodePayments for the blocking version and more details.
+func (c *Client) GetMasternodePaymentsAsync(blockHash *chainhash.Hash, count int) FutureGetMasternodePaymentsResult {
+	if blockHash != nil {
+		hash := blockHash.String()
+		cmd := btcjson.NewMasternodePaymentsCmd(&hash, &count)
+		return c.sendCmd(cmd)
+	}
+
+	// The server starts at the chain tip when the block hash is null, which
+	// registered btcjson commands can't express ahead of a set count, so
+	// the request is built by hand.
+	rawParams := make([]json.RawMessage, 0, 3)
+	for _, param := range []interface{}{"payments", nil, count} {
+		marshalled, err := json.Marshal(param)
+		if err != nil {
+			return newFutureError(err)
+		}
+		rawParams = append(rawParams, marshalled)
+	}
+
+	return FutureGetMasternodePaymentsResult(c.rawRequestCtx(
+		context.Background(), "masternode", rawParams))
+}
+
+// GetMasternodePayments returns the masternode payouts of count blocks
+// starting at the block with the given hash, or at the chain tip when the hash
+// is nil.  A negative count walks back from the starting block instead of
+// forward.  All amounts are in duffs.
+func (c *Client) GetMasternodePayments(blockHash *chainhash.Hash, count int) ([]btcjson.MasternodePaymentsResult, error) {
+	return c.GetMasternodePaymentsAsync(blockHash, count).Receive()
+}
+
+// SporkOffValue is the value reported for a spork which is turned off.  Sporks
+// hold the time after which they become active, so a spork is active when its
+// value is a time in the past.
+const SporkOffValue = 4070908800
+
+// IsSporkActive returns whether a spork with the provided value, as returned by
+// GetSporks, is active at the passed time.
+func IsSporkActive(value int64, t time.Time) bool {
+	return value < t.Unix()
+}
+
+// FutureGetAddressBalanceResult is a future promise to deliver the result of a
+// GetAddressBalanceAsync RPC invocation (or an applicable error).
+type FutureGetAddressBalanceResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// combined balance of the requested addresses.
+func (r FutureGetAddressBalanceResult) Receive() (*btcjson.GetAddressBalanceResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an address balance result object.
+	var balance btcjson.GetAddressBalanceResult
+	err = json.Unmarshal(res, &balance)
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// GetAddressBalanceAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetAddressBalance for the blocking version and more details.
+func (c *Client) GetAddressBalanceAsync(addrs []string) FutureGetAddressBalanceResult {
+	cmd := btcjson.NewGetAddressBalanceCmd(addrs)
+	return c.sendCmd(cmd)
+}
+
+// GetAddressBalance returns the combined current balance of, and the total
+// amount received by, the passed addresses.
+//
+// NOTE: This is a dashd extension which requires the server to be started
+// with -addressindex.
+func (c *Client) GetAddressBalance(addrs []string) (*btcjson.GetAddressBalanceResult, error) {
+	return c.GetAddressBalanceAsync(addrs).Receive()
+}
+
+// FutureGetAddressUTXOsResult is a future promise to deliver the result of a
+// GetAddressUTXOsAsync RPC invocation (or an applicable error).
+type FutureGetAddressUTXOsResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// unspent outputs of the requested addresses.
+func (r FutureGetAddressUTXOsResult) Receive() ([]btcjson.AddressUTXO, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of address utxo objects.
+	var utxos []btcjson.AddressUTXO
+	err = json.Unmarshal(res, &utxos)
+	if err != nil {
+		return nil, err
+	}
+	return utxos, nil
+}
+
+// GetAddressUTXOsAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetAddressUTXOs for the blocking version and more details.
+func (c *Client) GetAddressUTXOsAsync(addrs []string) FutureGetAddressUTXOsResult {
+	cmd := btcjson.NewGetAddressUTXOsCmd(addrs)
+	return c.sendCmd(cmd)
+}
+
+// GetAddressUTXOs returns all confirmed unspent outputs paying to the passed
+// addresses.
+//
+// NOTE: This is a dashd extension which requires the server to be started
+// with -addressindex.
+func (c *Client) GetAddressUTXOs(addrs []string) ([]btcjson.AddressUTXO, error) {
+	return c.GetAddressUTXOsAsync(addrs).Receive()
+}
+
+// FutureGetAddressDeltasResult is a future promise to deliver the result of a
+// GetAddressDeltasAsync RPC invocation (or an applicable error).
+type FutureGetAddressDeltasResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// balance changes of the requested addresses.
+func (r FutureGetAddressDeltasResult) Receive() ([]btcjson.AddressDelta, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of address delta objects.
+	var deltas []btcjson.AddressDelta
+	err = json.Unmarshal(res, &deltas)
+	if err != nil {
+		return nil, err
+	}
+	return deltas, nil
+}
+
+// GetAddressDeltasAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetAddressDeltas for the blocking version and more details.
+func (c *Client) GetAddressDeltasAsync(addrs []string, start, end int32) FutureGetAddressDeltasResult {
+	cmd := btcjson.NewGetAddressDeltasCmd(addrs, start, end)
+	return c.sendCmd(cmd)
+}
+
+// GetAddressDeltas returns every confirmed balance change of the passed
+// addresses.  The changes are limited to the blocks from start to end,
+// inclusive, when both heights are positive.
+//
+// NOTE: This is a dashd extension which requires the server to be started
+// with -addressindex.
+func (c *Client) GetAddressDeltas(addrs []string, start, end int32) ([]btcjson.AddressDelta, error) {
+	return c.GetAddressDeltasAsync(addrs, start, end).Receive()
+}
+
+// FutureGetAddressTxIDsResult is a future promise to deliver the result of a
+// GetAddressTxIDsAsync RPC invocation (or an applicable error).
+type FutureGetAddressTxIDsResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// hashes of the transactions involving the requested addresses.
+func (r FutureGetAddressTxIDsResult) Receive() ([]*chainhash.Hash, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of strings.
+	var txIDStrs []string
+	err = json.Unmarshal(res, &txIDStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs := make([]*chainhash.Hash, 0, len(txIDStrs))
+	for _, txIDStr := range txIDStrs {
+		txID, err := chainhash.NewHashFromStr(txIDStr)
+		if err != nil {
+			return nil, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, nil
+}
+
+// GetAddressTxIDsAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetAddressTxIDs for the blocking version and more details.
+func (c *Client) GetAddressTxIDsAsync(addrs []string, start, end int32) FutureGetAddressTxIDsResult {
+	cmd := btcjson.NewGetAddressTxIDsCmd(addrs, start, end)
+	return c.sendCmd(cmd)
+}
+
+// GetAddressTxIDs returns the hashes of all confirmed transactions involving
+// the passed addresses, limited to the blocks from start to end, inclusive,
+// when both heights are positive.
+//
+// NOTE: This is a dashd extension which requires the server to be started
+// with -addressindex.
+func (c *Client) GetAddressTxIDs(addrs []string, start, end int32) ([]*chainhash.Hash, error) {
+	return c.GetAddressTxIDsAsync(addrs, start, end).Receive()
+}
+
+// ErrOutpointNotSpent is returned by GetSpentInfo when the server does not know
+// of any transaction spending the requested output.
+var ErrOutpointNotSpent = errors.New("outpoint is not spent")
+
+// FutureGetBlockHashesByTimeResult is a future promise to deliver the result
+// of a GetBlockHashesByTimeAsync RPC invocation (or an applicable error).
+type FutureGetBlockHashesByTimeResult chan *response
+
+// Receive waits for the response promised by the future and returns the blocks
+// with a timestamp in the requested range.
+func (r FutureGetBlockHashesByTimeResult) Receive() ([]btcjson.BlockHashTimestamp, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server replies with bare hashes unless logical times are
+	// requested, in which case it replies with objects.
+	var hashes []string
+	if err := json.Unmarshal(res, &hashes); err == nil {
+		blocks := make([]btcjson.BlockHashTimestamp, 0, len(hashes))
+		for _, hash := range hashes {
+			blocks = append(blocks, btcjson.BlockHashTimestamp{
+				BlockHash: hash,
+			})
+		}
+		return blocks, nil
+	}
+
+	// Unmarshal result as an array of block hash and timestamp objects.
+	var blocks []btcjson.BlockHashTimestamp
+	err = json.Unmarshal(res, &blocks)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetBlockHashesByTimeAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetBlockHashesByTime for the blocking version and more details.
+func (c *Client) GetBlockHashesByTimeAsync(high, low int64,
+	options *btcjson.GetBlockHashesOptions) FutureGetBlockHashesByTimeResult {
+
+	cmd := btcjson.NewGetBlockHashesCmd(high, low, options)
+	return c.sendCmd(cmd)
+}
+
+// GetBlockHashesByTime returns the blocks with a timestamp from low to high,
+// inclusive, ordered by their timestamp.  The options may be nil, in which case
+// blocks which are not part of the main chain are included and the LogicalTS
+// field of the returned blocks is left zero.
+//
+// NOTE: This is a dashd extension which requires the server to be started
+// with -timestampindex.
+func (c *Client) GetBlockHashesByTime(high, low int64,
+	options *btcjson.GetBlockHashesOptions) ([]btcjson.BlockHashTimestamp, error) {
+
+	return c.GetBlockHashesByTimeAsync(high, low, options).Receive()
+}
+
+// FutureGetZMQNotificationsResult is a future promise to deliver the result of
+// a GetZMQNotificationsAsync RPC invocation (or an applicable error).
+type FutureGetZMQNotificationsResult chan *response
+
+// Receive waits for the response promised by the future and returns the ZMQ
+// notifications published by the server.
+func (r FutureGetZMQNotificationsResult) Receive() ([]btcjson.ZMQNotification, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of zmq notifications.
+	var notifications []btcjson.ZMQNotification
+	err = json.Unmarshal(res, &notifications)
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// GetZMQNotificationsAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetZMQNotifications for the blocking version and more details.
+func (c *Client) GetZMQNotificationsAsync() FutureGetZMQNotificationsResult {
+	cmd := btcjson.NewGetZMQNotificationsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetZMQNotifications returns the ZMQ notifications the server is configured
+// to publish along with the endpoint each of them is published on.  Besides
+// the block and transaction notifications these include the Dash specific
+// ones, such as the chainlock and InstantSend lock notifications.  The result
+// is empty when the server publishes no notifications.
+func (c *Client) GetZMQNotifications() ([]btcjson.ZMQNotification, error) {
+	return c.GetZMQNotificationsAsync().Receive()
+}
+
+// FutureGetSpentInfoResult is a future promise to deliver the result of a
+// GetSpentInfoAsync RPC invocation (or an applicable error).
+type FutureGetSpentInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the input
+// spending the requested output.  ErrOutpointNotSpent is returned when the
+// output is unspent.
+func (r FutureGetSpentInfoResult) Receive() (*btcjson.GetSpentInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		// The server replies with an invalid address or key error
+		// rather than an empty result for outputs which are unspent.
+		if jerr, ok := err.(*btcjson.RPCError); ok &&
+			jerr.Code == btcjson.ErrRPCInvalidAddressOrKey &&
+			strings.Contains(strings.ToLower(jerr.Message),
+				"unable to get spent info") {
+
+			return nil, ErrOutpointNotSpent
+		}
+		return nil, err
+	}
+
+	// Unmarshal result as a spent info result object.
+	var spentInfo btcjson.GetSpentInfoResult
+	err = json.Unmarshal(res, &spentInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &spentInfo, nil
+}
+
+// GetSpentInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetSpentInfo for the blocking version and more details.
+func (c *Client) GetSpentInfoAsync(txid *chainhash.Hash, index uint32) FutureGetSpentInfoResult {
+	cmd := btcjson.NewGetSpentInfoCmd(hashParam(txid), index)
+	return c.sendCmd(cmd)
+}
+
+// GetSpentInfo returns the transaction input spending the output with the
+// given transaction hash and index along with the height it was mined at.
+// ErrOutpointNotSpent is returned when the output has not been spent in the
+// main chain.
+//
+// NOTE: This is a dashd extension which requires the server to be started
+// with -spentindex.
+func (c *Client) GetSpentInfo(txid *chainhash.Hash, index uint32) (*btcjson.GetSpentInfoResult, error) {
+	return c.GetSpentInfoAsync(txid, index).Receive()
+}
+
+// FutureGetSporksResult is a future promise to deliver the result of a
+// GetSporksAsync RPC invocation (or an applicable error).
+type FutureGetSporksResult chan *response
+
+// Receive waits for the response promised by the future and returns the value
+// of each spork keyed by its name.
+func (r FutureGetSporksResult) Receive() (map[string]int64, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a map of spork values.
+	var sporks map[string]int64
+	err = json.Unmarshal(res, &sporks)
+	if err != nil {
+		return nil, err
+	}
+	return sporks, nil
+}
+
+// GetSporksAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetSporks for the blocking version and more details.
+func (c *Client) GetSporksAsync() FutureGetSporksResult {
+	cmd := btcjson.NewSporkShowCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetSporks returns the value of each spork known to the server keyed by its
+// name, such as "SPORK_17_QUORUM_DKG_ENABLED".
+//
+// The value of a spork is the time after which it is active, and SporkOffValue
+// when it is turned off.  See IsSporkActive to interpret the values, or
+// GetActiveSporks to have the server do so.
+func (c *Client) GetSporks() (map[string]int64, error) {
+	return c.GetSporksAsync().Receive()
+}
+
+// FutureGetActiveSporksResult is a future promise to deliver the result of a
+// GetActiveSporksAsync RPC invocation (or an applicable error).
+type FutureGetActiveSporksResult chan *response
+
+// Receive waits for the response promised by the future and returns whether
+// each spork is active keyed by its name.
+func (r FutureGetActiveSporksResult) Receive() (map[string]bool, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a map of spork states.
+	var sporks map[string]bool
+	err = json.Unmarshal(res, &sporks)
+	if err != nil {
+		return nil, err
+	}
+	return sporks, nil
+}
+
+// GetActiveSporksAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetActiveSporks for the blocking version and more details.
+func (c *Client) GetActiveSporksAsync() FutureGetActiveSporksResult {
+	cmd := btcjson.NewSporkActiveCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetActiveSporks returns whether each spork known to the server is currently
+// active keyed by its name.
+func (c *Client) GetActiveSporks() (map[string]bool, error) {
+	return c.GetActiveSporksAsync().Receive()
+}
+
+// FutureSetSporkResult is a future promise to deliver the result of a
+// SetSporkAsync RPC invocation (or an applicable error).
+type FutureSetSporkResult chan *response
+
+// Receive waits for the response promised by the future and returns an error
+// if the spork could not be updated.
+func (r FutureSetSporkResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// SetSporkAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See SetSpork for the blocking version and more details.
+func (c *Client) SetSporkAsync(name string, value int64) FutureSetSporkResult {
+	cmd := btcjson.NewSporkCmd(name, value)
+	return c.sendCmd(cmd)
+}
+
+// SetSpork updates the value of the named spork and relays it to the network.
+//
+// NOTE: This only succeeds when the server is configured with the spork
+// private key.
+func (c *Client) SetSpork(name string, value int64) error {
+	return c.SetSporkAsync(name, value).Receive()
+}
+
+// FutureGetGovernanceObjectsResult is a future promise to deliver the result
+// of a GetGovernanceObjectsAsync RPC invocation (or an applicable error).
+type FutureGetGovernanceObjectsResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// governance objects known to the server ordered by their creation time.
+func (r FutureGetGovernanceObjectsResult) Receive() ([]btcjson.GovernanceObject, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a map of governance objects.
+	var objectMap map[string]btcjson.GovernanceObject
+	err = json.Unmarshal(res, &objectMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the data of each object when possible.  Objects with data
+	// which can't be decoded are still returned with a nil DataObject.
+	objects := make([]btcjson.GovernanceObject, 0, len(objectMap))
+	for _, object := range objectMap {
+		object.DataObject, _ = btcjson.DecodeGovernanceData(object.DataHex)
+		objects = append(objects, object)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].CreationTime != objects[j].CreationTime {
+			return objects[i].CreationTime < objects[j].CreationTime
+		}
+		return objects[i].Hash < objects[j].Hash
+	})
+	return objects, nil
+}
+
+// GetGovernanceObjectsAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetGovernanceObjects for the blocking version and more details.
+func (c *Client) GetGovernanceObjectsAsync() FutureGetGovernanceObjectsResult {
+	cmd := btcjson.NewGObjectListCmd(btcjson.String("all"),
+		btcjson.String("all"))
+	return c.sendCmd(cmd)
+}
+
+// GetGovernanceObjects returns all of the governance objects, such as
+// proposals and triggers, known to the server ordered by their creation time.
+// The DataObject field of each object holds its decoded data.
+func (c *Client) GetGovernanceObjects() ([]btcjson.GovernanceObject, error) {
+	return c.GetGovernanceObjectsAsync().Receive()
+}
+
+// FutureSubmitGovernanceObjectResult is a future promise to deliver the result
+// of a SubmitGovernanceObjectAsync RPC invocation (or an applicable error).
+type FutureSubmitGovernanceObjectResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash
+// of the submitted governance object.
+func (r FutureSubmitGovernanceObjectResult) Receive() (*chainhash.Hash, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a string.
+	var hashStr string
+	err = json.Unmarshal(res, &hashStr)
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(hashStr)
+}
+
+// SubmitGovernanceObjectAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See SubmitGovernanceObject for the blocking version and more details.
+func (c *Client) SubmitGovernanceObjectAsync(parentHash *chainhash.Hash,
+	revision int, time int64, dataHex string,
+	txHash *chainhash.Hash) FutureSubmitGovernanceObjectResult {
+
+	// Root objects, such as proposals, have a parent hash of zero.
+	parent := "0"
+	if parentHash != nil {
+		parent = parentHash.String()
+	}
+
+	var feeTxID *string
+	if txHash != nil {
+		feeTxID = btcjson.String(txHash.String())
+	}
+
+	cmd := btcjson.NewGObjectSubmitCmd(parent, revision, time, dataHex,
+		feeTxID)
+	return c.sendCmd(cmd)
+}
+
+// SubmitGovernanceObject submits the hex-encoded data of a governance object,
+// such as a proposal, to the network and returns the hash of the object.  The
+// parent hash may be nil for root objects.  The transaction hash identifies
+// the collateral transaction previously prepared for the object and may be nil
+// for objects which do not require one, such as triggers.
+func (c *Client) SubmitGovernanceObject(parentHash *chainhash.Hash,
+	revision int, time int64, dataHex string,
+	txHash *chainhash.Hash) (*chainhash.Hash, error) {
+
+	return c.SubmitGovernanceObjectAsync(parentHash, revision, time,
+		dataHex, txHash).Receive()
+}
+
+// FutureGetGovernanceObjectVotesResult is a future promise to deliver the
+// result of a GetGovernanceObjectVotesAsync or
+// GetGovernanceObjectCurrentVotesAsync RPC invocation (or an applicable
+// error).
+type FutureGetGovernanceObjectVotesResult chan *response
+
+// Receive waits for the response promised by the future and returns the votes
+// on the governance object ordered by their time.
+func (r FutureGetGovernanceObjectVotesResult) Receive() ([]btcjson.GovernanceVote, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a map of votes keyed by their hash.
+	var voteMap map[string]string
+	err = json.Unmarshal(res, &voteMap)
+	if err != nil {
+		return nil, err
+	}
+
+	votes := make([]btcjson.GovernanceVote, 0, len(voteMap))
+	for hash, voteStr := range voteMap {
+		vote, err := btcjson.ParseGovernanceVote(hash, voteStr)
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, *vote)
+	}
+	sort.Slice(votes, func(i, j int) bool {
+		if votes[i].Time != votes[j].Time {
+			return votes[i].Time < votes[j].Time
+		}
+		return votes[i].Hash < votes[j].Hash
+	})
+	return votes, nil
+}
+
+// GetGovernanceObjectVotesAsync returns an instance of a type that can be used
+// to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetGovernanceObjectVotes for the blocking version and more details.
+func (c *Client) GetGovernanceObjectVotesAsync(govHash *chainhash.Hash) FutureGetGovernanceObjectVotesResult {
+	cmd := btcjson.NewGObjectGetVotesCmd(hashParam(govHash))
+	return c.sendCmd(cmd)
+}
+
+// GetGovernanceObjectVotes returns all of the votes the server knows of on the
+// governance object with the given hash ordered by their time.  Masternodes
+// which changed their vote are reported once for each of their votes.
+//
+// See GetGovernanceObjectCurrentVotes for only the latest vote of each
+// masternode.
+func (c *Client) GetGovernanceObjectVotes(govHash *chainhash.Hash) ([]btcjson.GovernanceVote, error) {
+	return c.GetGovernanceObjectVotesAsync(govHash).Receive()
+}
+
+// GetGovernanceObjectCurrentVotesAsync returns an instance of a type that can
+// be used to get the result of the RPC at some future time by invoking the
+// Receive function on the returned instance.
+//
+// See GetGovernanceObjectCurrentVotes for the blocking version and more
+// details.
+func (c *Client) GetGovernanceObjectCurrentVotesAsync(govHash *chainhash.Hash) FutureGetGovernanceObjectVotesResult {
+	cmd := btcjson.NewGObjectGetCurrentVotesCmd(hashParam(govHash), nil, nil)
+	return c.sendCmd(cmd)
+}
+
+// GetGovernanceObjectCurrentVotes returns the votes on the governance object
+// with the given hash which are currently in effect ordered by their time.
+// Unlike GetGovernanceObjectVotes, the server only reports the latest vote of
+// each masternode for each signal.
+func (c *Client) GetGovernanceObjectCurrentVotes(govHash *chainhash.Hash) ([]btcjson.GovernanceVote, error) {
+	return c.GetGovernanceObjectCurrentVotesAsync(govHash).Receive()
+}
+
+// FutureGetSuperblockBudgetResult is a future promise to deliver the result of
+// a GetSuperblockBudgetAsync RPC invocation (or an applicable error).
+type FutureGetSuperblockBudgetResult chan *response
+
+// Receive waits for the response promised by the future and returns the budget
+// available for the requested superblock.
+func (r FutureGetSuperblockBudgetResult) Receive() (godashutil.Amount, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return 0, err
+	}
+
+	// Unmarshal result as an exact amount.
+	var budget DashAmount
+	err = json.Unmarshal(res, &budget)
+	if err != nil {
+		return 0, err
+	}
+	return budget.Amount(), nil
+}
+
+// GetSuperblockBudgetAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetSuperblockBudget for the blocking version and more details.
+func (c *Client) GetSuperblockBudgetAsync(height int32) FutureGetSuperblockBudgetResult {
+	cmd := btcjson.NewGetSuperblockBudgetCmd(height)
+	return c.sendCmd(cmd)
+}
+
+// GetSuperblockBudget returns the total amount available to proposals in the
+// superblock at the given height, such as the next superblock reported by
+// GetGovernanceInfo.  The budget is zero for heights which are not superblocks.
+func (c *Client) GetSuperblockBudget(height int32) (godashutil.Amount, error) {
+	return c.GetSuperblockBudgetAsync(height).Receive()
+}
+
+// FutureGetGovernanceObjectResult is a future promise to deliver the result of
+// a GetGovernanceObjectAsync RPC invocation (or an applicable error).
+type FutureGetGovernanceObjectResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// requested governance object.
+func (r FutureGetGovernanceObjectResult) Receive() (*btcjson.GObjectGetResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a gobject get result object.
+	var object btcjson.GObjectGetResult
+	err = json.Unmarshal(res, &object)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the data of proposals when possible.  Proposals with data
+	// which can't be decoded are still returned with a nil Proposal.
+	if object.ObjectType == btcjson.GovernanceObjectProposal {
+		object.Proposal, _ = btcjson.DecodeGovernanceProposal(object.DataHex)
+	}
+	return &object, nil
+}
+
+// GetGovernanceObjectAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetGovernanceObject for the blocking version and more details.
+func (c *Client) GetGovernanceObjectAsync(hash *chainhash.Hash) FutureGetGovernanceObjectResult {
+	cmd := btcjson.NewGObjectGetCmd(hashParam(hash))
+	return c.sendCmd(cmd)
+}
+
+// GetGovernanceObject returns the governance object with the given hash along
+// with the tallies of the votes for each of its signals.  The Proposal field
+// holds the decoded data of proposal objects.
+func (c *Client) GetGovernanceObject(hash *chainhash.Hash) (*btcjson.GObjectGetResult, error) {
+	return c.GetGovernanceObjectAsync(hash).Receive()
+}
+
+// FutureCheckGovernanceObjectResult is a future promise to deliver the result
+// of a CheckGovernanceObjectAsync RPC invocation (or an applicable error).
+type FutureCheckGovernanceObjectResult chan *response
+
+// Receive waits for the response promised by the future and returns an error
+// if the governance object data is not valid.
+func (r FutureCheckGovernanceObjectResult) Receive() error {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal result as a gobject check result object.
+	var checkResult btcjson.GObjectCheckResult
+	err = json.Unmarshal(res, &checkResult)
+	if err != nil {
+		return err
+	}
+	if checkResult.ObjectStatus != "OK" {
+		return fmt.Errorf("governance object check failed: %s",
+			checkResult.ObjectStatus)
+	}
+	return nil
+}
+
+// CheckGovernanceObjectAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See CheckGovernanceObject for the blocking version and more details.
+func (c *Client) CheckGovernanceObjectAsync(dataHex string) FutureCheckGovernanceObjectResult {
+	cmd := btcjson.NewGObjectCheckCmd(dataHex)
+	return c.sendCmd(cmd)
+}
+
+// CheckGovernanceObject asks the server to validate the hex-encoded data of a
+// proposal without submitting it.  An error is returned when the data is not
+// valid, in which case the server reports the reason.
+//
+// NOTE: Only the proposal data is validated.  The collateral transaction and
+// the object signature are only checked on submission.
+func (c *Client) CheckGovernanceObject(dataHex string) error {
+	return c.CheckGovernanceObjectAsync(dataHex).Receive()
+}
+
+// FutureGetGovernanceInfoResult is a future promise to deliver the result of a
+// GetGovernanceInfoAsync RPC invocation (or an applicable error).
+type FutureGetGovernanceInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// governance parameters of the server.
+func (r FutureGetGovernanceInfoResult) Receive() (*btcjson.GetGovernanceInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getgovernanceinfo result object.
+	var infoResult btcjson.GetGovernanceInfoResult
+	err = json.Unmarshal(res, &infoResult)
+	if err != nil {
+		return nil, err
+	}
+	return &infoResult, nil
+}
+
+// GetGovernanceInfoAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetGovernanceInfo for the blocking version and more details.
+func (c *Client) GetGovernanceInfoAsync() FutureGetGovernanceInfoResult {
+	cmd := btcjson.NewGetGovernanceInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetGovernanceInfo returns the governance parameters of the server, such as
+// the proposal fee, the superblock cycle and the heights of the last and next
+// superblocks.
+func (c *Client) GetGovernanceInfo() (*btcjson.GetGovernanceInfoResult, error) {
+	return c.GetGovernanceInfoAsync().Receive()
+}
+
+// FutureGetMnSyncStatusResult is a future promise to deliver the result of a
+// GetMnSyncStatusAsync RPC invocation (or an applicable error).
+type FutureGetMnSyncStatusResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// masternode sync status of the server.
+func (r FutureGetMnSyncStatusResult) Receive() (*btcjson.MnSyncStatusResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a mnsync status result object.
+	var statusResult btcjson.MnSyncStatusResult
+	err = json.Unmarshal(res, &statusResult)
+	if err != nil {
+		return nil, err
+	}
+	return &statusResult, nil
+}
+
+// GetMnSyncStatusAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetMnSyncStatus for the blocking version and more details.
+func (c *Client) GetMnSyncStatusAsync() FutureGetMnSyncStatusResult {
+	cmd := btcjson.NewMnSyncStatusCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetMnSyncStatus returns the progress of the server syncing the masternode
+// and governance data.  The masternode related information reported by the
+// server should not be relied upon until IsSynced is set.
+func (c *Client) GetMnSyncStatus() (*btcjson.MnSyncStatusResult, error) {
+	return c.GetMnSyncStatusAsync().Receive()
+}
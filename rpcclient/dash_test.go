@@ -0,0 +1,368 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestGetSpentInfoReceive ensures the getspentinfo replies, including the
+// error returned by servers for unspent outputs, are handled as expected.
+func TestGetSpentInfoReceive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resp     *response
+		expected *btcjson.GetSpentInfoResult
+		err      error
+		wantErr  bool
+	}{
+		{
+			name: "spent output",
+			resp: &response{
+				result: []byte(`{"txid":"f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",` +
+					`"index":2,"height":1028170}`),
+			},
+			expected: &btcjson.GetSpentInfoResult{
+				TxID:   "f49ff4a1e81aeb8ecb9009e4d5ff3ac5b1b5d9d1f8589f07f0de8d1c1e2c8a97",
+				Index:  2,
+				Height: 1028170,
+			},
+		},
+		{
+			name: "unspent output",
+			resp: &response{
+				err: &btcjson.RPCError{
+					Code:    btcjson.ErrRPCInvalidAddressOrKey,
+					Message: "Unable to get spent info",
+				},
+			},
+			err:     ErrOutpointNotSpent,
+			wantErr: true,
+		},
+		{
+			name: "spent index disabled",
+			resp: &response{
+				err: &btcjson.RPCError{
+					Code:    btcjson.ErrRPCInternal.Code,
+					Message: "Spent index not enabled",
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		future := make(FutureGetSpentInfoResult, 1)
+		future <- test.resp
+		result, err := future.Receive()
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Test #%d (%s) expected error", i, test.name)
+				continue
+			}
+			if test.err != nil && err != test.err {
+				t.Errorf("Test #%d (%s) unexpected error - got %v, "+
+					"want %v", i, test.name, err, test.err)
+			}
+			if test.err == nil && err == ErrOutpointNotSpent {
+				t.Errorf("Test #%d (%s) unexpected not spent error",
+					i, test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected result - got %+v, "+
+				"want %+v", i, test.name, result, test.expected)
+		}
+	}
+}
+
+// TestMasternodePayments ensures the masternode winners and payments requests
+// are built as expected and their replies are decoded.
+func TestMasternodePayments(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		params := make([]string, 0, len(req.Params))
+		for _, param := range req.Params {
+			params = append(params, string(param))
+		}
+		requests = append(requests, req.Method+" "+strings.Join(params, " "))
+
+		if string(req.Params[0]) == `"winners"` {
+			w.Write([]byte(`{"result":{"1030020":"XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2",` +
+				`"1030021":"Unknown"},"error":null,"id":1}`))
+			return
+		}
+		w.Write([]byte(`{"result":[{"height":1030020,"blockhash":` +
+			`"000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1",` +
+			`"amount":167146500,"masternodes":[]}],"error":null,"id":1}`))
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	winners, err := client.GetMasternodeWinners(10, "")
+	if err != nil {
+		t.Fatalf("GetMasternodeWinners: unexpected error: %v", err)
+	}
+	wantWinners := map[int32]string{
+		1030020: "XjbaGWaGnvEtuQAUoBgDxJWe8ZNv45upG2",
+		1030021: "Unknown",
+	}
+	if !reflect.DeepEqual(winners, wantWinners) {
+		t.Errorf("GetMasternodeWinners: got %v, want %v", winners,
+			wantWinners)
+	}
+
+	if _, err := client.GetMasternodeWinners(5, "Xjba"); err != nil {
+		t.Errorf("GetMasternodeWinners: unexpected error: %v", err)
+	}
+
+	payments, err := client.GetMasternodePayments(nil, -2)
+	if err != nil {
+		t.Fatalf("GetMasternodePayments: unexpected error: %v", err)
+	}
+	if len(payments) != 1 || payments[0].Height != 1030020 ||
+		payments[0].Amount != 167146500 {
+
+		t.Errorf("GetMasternodePayments: unexpected result %+v", payments)
+	}
+
+	hash, err := chainhash.NewHashFromStr("000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1")
+	if err != nil {
+		t.Fatalf("NewHashFromStr: unexpected error: %v", err)
+	}
+	if _, err := client.GetMasternodePayments(hash, 3); err != nil {
+		t.Errorf("GetMasternodePayments: unexpected error: %v", err)
+	}
+
+	want := []string{
+		`masternode "winners" 10`,
+		`masternode "winners" 5 "Xjba"`,
+		`masternode "payments" null -2`,
+		`masternode "payments" "000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1" 3`,
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("unexpected requests - got %v, want %v", requests, want)
+	}
+}
+
+// TestGovernanceObjectReceive ensures the gobject get replies have the data of
+// proposals decoded and the gobject check replies are handled as expected.
+func TestGovernanceObjectReceive(t *testing.T) {
+	t.Parallel()
+
+	// The data of the proposal decodes to {"name":"old","type":1}.
+	future := make(FutureGetGovernanceObjectResult, 1)
+	future <- &response{
+		result: []byte(`{"DataHex":"5b5b2270726f706f73616c222c7b226e616d65223a226f6c64222c2274797065223a317d5d5d",` +
+			`"Hash":"a7e1b9e1b5a5f0b0f1bcad2a9a1bbde1e7c5df53d1df8418088f6d0e1a6ab0e4",` +
+			`"ObjectType":1,"CreationTime":1543622400}`),
+	}
+	object, err := future.Receive()
+	if err != nil {
+		t.Fatalf("GetGovernanceObject: unexpected error: %v", err)
+	}
+	wantProposal := &btcjson.GovernanceProposal{Type: 1, Name: "old"}
+	if !reflect.DeepEqual(object.Proposal, wantProposal) {
+		t.Errorf("GetGovernanceObject: unexpected proposal - got %+v, "+
+			"want %+v", object.Proposal, wantProposal)
+	}
+
+	// Triggers are not decoded as proposals.
+	future = make(FutureGetGovernanceObjectResult, 1)
+	future <- &response{result: []byte(`{"DataHex":"7b7d","ObjectType":2}`)}
+	object, err = future.Receive()
+	if err != nil {
+		t.Fatalf("GetGovernanceObject: unexpected error: %v", err)
+	}
+	if object.Proposal != nil {
+		t.Errorf("GetGovernanceObject: unexpected proposal for trigger %+v",
+			object.Proposal)
+	}
+
+	tests := []struct {
+		name    string
+		resp    *response
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			resp: &response{result: []byte(`{"Object status":"OK"}`)},
+		},
+		{
+			name: "invalid",
+			resp: &response{
+				err: &btcjson.RPCError{
+					Code:    btcjson.ErrRPCInvalidParameter,
+					Message: "Governance object is not valid - invalid proposal name",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unexpected status",
+			resp:    &response{result: []byte(`{"Object status":"ERROR"}`)},
+			wantErr: true,
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		future := make(FutureCheckGovernanceObjectResult, 1)
+		future <- test.resp
+		err := future.Receive()
+		if test.wantErr != (err != nil) {
+			t.Errorf("Test #%d (%s) unexpected error - got %v, "+
+				"want error %v", i, test.name, err, test.wantErr)
+		}
+	}
+}
+
+// TestGetSuperblockBudgetReceive ensures the getsuperblockbudget replies are
+// converted to amounts without losing precision.
+func TestGetSuperblockBudgetReceive(t *testing.T) {
+	t.Parallel()
+
+	future := make(FutureGetSuperblockBudgetResult, 1)
+	future <- &response{result: []byte(`5367.93151918`)}
+	budget, err := future.Receive()
+	if err != nil {
+		t.Fatalf("GetSuperblockBudget: unexpected error: %v", err)
+	}
+	if budget != 536793151918 {
+		t.Errorf("GetSuperblockBudget: got %d, want %d", budget,
+			536793151918)
+	}
+}
+
+// TestGetGovernanceObjectVotesReceive ensures the gobject getvotes replies are
+// parsed into votes ordered by their time.
+func TestGetGovernanceObjectVotesReceive(t *testing.T) {
+	t.Parallel()
+
+	future := make(FutureGetGovernanceObjectVotesResult, 1)
+	future <- &response{
+		result: []byte(`{"c3":"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1:1543622500:no:funding",` +
+			`"a1":"3a8a1ef2c2f0f2fd5a5bd77b0f7ed0b2c1bd6f4f0d0b8b15e2cf8e92c2dd6b0a-0:1543622400:yes:funding",` +
+			`"b2":"8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1:1543622400:abstain:delete"}`),
+	}
+	votes, err := future.Receive()
+	if err != nil {
+		t.Fatalf("GetGovernanceObjectVotes: unexpected error: %v", err)
+	}
+	want := []btcjson.GovernanceVote{
+		{
+			Hash:               "a1",
+			MasternodeOutpoint: "3a8a1ef2c2f0f2fd5a5bd77b0f7ed0b2c1bd6f4f0d0b8b15e2cf8e92c2dd6b0a-0",
+			Time:               1543622400,
+			Outcome:            "yes",
+			Signal:             "funding",
+		},
+		{
+			Hash:               "b2",
+			MasternodeOutpoint: "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1",
+			Time:               1543622400,
+			Outcome:            "abstain",
+			Signal:             "delete",
+		},
+		{
+			Hash:               "c3",
+			MasternodeOutpoint: "8b2a338282d848c0c7ab8b10a3a5adcb4ed69d23d4fd4a7b2a1f5c0a4f9f4ef1-1",
+			Time:               1543622500,
+			Outcome:            "no",
+			Signal:             "funding",
+		},
+	}
+	if !reflect.DeepEqual(votes, want) {
+		t.Errorf("GetGovernanceObjectVotes: got %+v, want %+v", votes, want)
+	}
+
+	future = make(FutureGetGovernanceObjectVotesResult, 1)
+	future <- &response{result: []byte(`{"d4":"not a vote"}`)}
+	if _, err := future.Receive(); err == nil {
+		t.Error("GetGovernanceObjectVotes: expected error for malformed vote")
+	}
+}
+
+// TestGetBlockHashesByTimeReceive ensures the getblockhashes replies are
+// decoded both with and without logical times.
+func TestGetBlockHashesByTimeReceive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		result   string
+		expected []btcjson.BlockHashTimestamp
+	}{
+		{
+			name: "hashes",
+			result: `["000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1",` +
+				`"0000000000000009c1e5d3b3a8c2a0b7b6ec1e0cf3f07f00b9ad0a1a4c12d6e4"]`,
+			expected: []btcjson.BlockHashTimestamp{
+				{BlockHash: "000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1"},
+				{BlockHash: "0000000000000009c1e5d3b3a8c2a0b7b6ec1e0cf3f07f00b9ad0a1a4c12d6e4"},
+			},
+		},
+		{
+			name: "logical times",
+			result: `[{"blockhash":"000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1",` +
+				`"logicalts":1543622400}]`,
+			expected: []btcjson.BlockHashTimestamp{
+				{
+					BlockHash: "000000000000001b8d7a6b1a2e0f4ad1c9a7f5e8e2a8f1d6c1c5a0e0c2e4f3a1",
+					LogicalTS: 1543622400,
+				},
+			},
+		},
+		{
+			name:     "empty range",
+			result:   `[]`,
+			expected: []btcjson.BlockHashTimestamp{},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		future := make(FutureGetBlockHashesByTimeResult, 1)
+		future <- &response{result: []byte(test.result)}
+		blocks, err := future.Receive()
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(blocks, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected result - got %+v, "+
+				"want %+v", i, test.name, blocks, test.expected)
+		}
+	}
+}
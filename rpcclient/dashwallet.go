@@ -0,0 +1,480 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godashutil"
+)
+
+// isMethodNotFound returns whether the passed error is the reply of a server
+// which does not support the requested method.  It is used to fall back to the
+// names older versions of dashd use for a few of the commands.
+func isMethodNotFound(err error) bool {
+	jerr, ok := err.(*btcjson.RPCError)
+	return ok && jerr.Code == btcjson.ErrRPCMethodNotFound.Code
+}
+
+// FutureCoinJoinResult is a future promise to deliver the result of a
+// CoinJoinStartAsync or CoinJoinStopAsync RPC invocation (or an applicable
+// error).
+type FutureCoinJoinResult chan *response
+
+// Receive waits for the response promised by the future and returns an error
+// if the mixing could not be started or stopped.
+func (r FutureCoinJoinResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// CoinJoinStartAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See CoinJoinStart for the blocking version and more details.
+func (c *Client) CoinJoinStartAsync() FutureCoinJoinResult {
+	if c.usePrivateSend() {
+		cmd := btcjson.NewPrivateSendStartCmd()
+		return c.sendCmd(cmd)
+	}
+
+	cmd := btcjson.NewCoinJoinStartCmd()
+	return c.sendCmd(cmd)
+}
+
+// CoinJoinStart starts mixing the funds of the wallet.
+//
+// Servers which do not support the coinjoin command are sent the privatesend
+// command used by older versions of dashd instead.  See the AutoDetectDialect
+// connection option for choosing the name up front.
+//
+// NOTE: This is a dashd wallet extension.
+func (c *Client) CoinJoinStart() error {
+	err := c.CoinJoinStartAsync().Receive()
+	if isMethodNotFound(err) && !c.usePrivateSend() {
+		cmd := btcjson.NewPrivateSendStartCmd()
+		err = FutureCoinJoinResult(c.sendCmd(cmd)).Receive()
+	}
+	return err
+}
+
+// CoinJoinStopAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See CoinJoinStop for the blocking version and more details.
+func (c *Client) CoinJoinStopAsync() FutureCoinJoinResult {
+	if c.usePrivateSend() {
+		cmd := btcjson.NewPrivateSendStopCmd()
+		return c.sendCmd(cmd)
+	}
+
+	cmd := btcjson.NewCoinJoinStopCmd()
+	return c.sendCmd(cmd)
+}
+
+// CoinJoinStop stops mixing the funds of the wallet.
+//
+// Servers which do not support the coinjoin command are sent the privatesend
+// command used by older versions of dashd instead.  See the AutoDetectDialect
+// connection option for choosing the name up front.
+//
+// NOTE: This is a dashd wallet extension.
+func (c *Client) CoinJoinStop() error {
+	err := c.CoinJoinStopAsync().Receive()
+	if isMethodNotFound(err) && !c.usePrivateSend() {
+		cmd := btcjson.NewPrivateSendStopCmd()
+		err = FutureCoinJoinResult(c.sendCmd(cmd)).Receive()
+	}
+	return err
+}
+
+// FutureGetCoinJoinInfoResult is a future promise to deliver the result of a
+// GetCoinJoinInfoAsync RPC invocation (or an applicable error).
+type FutureGetCoinJoinInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the mixing
+// state of the wallet.
+func (r FutureGetCoinJoinInfoResult) Receive() (*btcjson.GetCoinJoinInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getcoinjoininfo result object.
+	var infoResult btcjson.GetCoinJoinInfoResult
+	err = json.Unmarshal(res, &infoResult)
+	if err != nil {
+		return nil, err
+	}
+	return &infoResult, nil
+}
+
+// GetCoinJoinInfoAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetCoinJoinInfo for the blocking version and more details.
+func (c *Client) GetCoinJoinInfoAsync() FutureGetCoinJoinInfoResult {
+	if c.usePrivateSend() {
+		cmd := btcjson.NewGetPrivateSendInfoCmd()
+		return c.sendCmd(cmd)
+	}
+
+	cmd := btcjson.NewGetCoinJoinInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetCoinJoinInfo returns the mixing state of the wallet, including whether
+// mixing is running, the number of keys left and the active sessions.
+//
+// Servers which do not support the getcoinjoininfo command are sent the
+// getprivatesendinfo command used by older versions of dashd instead.  See the
+// AutoDetectDialect connection option for choosing the name up front.
+//
+// NOTE: This is a dashd wallet extension.
+func (c *Client) GetCoinJoinInfo() (*btcjson.GetCoinJoinInfoResult, error) {
+	info, err := c.GetCoinJoinInfoAsync().Receive()
+	if isMethodNotFound(err) && !c.usePrivateSend() {
+		cmd := btcjson.NewGetPrivateSendInfoCmd()
+		return FutureGetCoinJoinInfoResult(c.sendCmd(cmd)).Receive()
+	}
+	return info, err
+}
+
+// BalanceDetails models the balances of a single category of the outputs of a
+// wallet, such as those the wallet can spend or those it only watches, in the
+// data returned by the getbalances command.
+//
+// Used is only reported by wallets which avoid address reuse.  CoinJoin is the
+// part of the trusted balance which has completed mixing.  Denominated and
+// Anonymized break down the mixing progress of servers which report them.
+// Each of them is nil when the server does not report it.
+type BalanceDetails struct {
+	Trusted          DashAmount  `json:"trusted"`
+	UntrustedPending DashAmount  `json:"untrusted_pending"`
+	Immature         DashAmount  `json:"immature"`
+	Used             *DashAmount `json:"used,omitempty"`
+	CoinJoin         *DashAmount `json:"coinjoin,omitempty"`
+	Denominated      *DashAmount `json:"denominated,omitempty"`
+	Anonymized       *DashAmount `json:"anonymized,omitempty"`
+}
+
+// GetBalancesResult models the data from the getbalances command.  WatchOnly
+// is nil unless the wallet holds watch-only addresses.
+//
+// The amounts are decoded exactly rather than through the float64 amounts of
+// the btcjson result types, which is why the result is defined here.
+type GetBalancesResult struct {
+	Mine      BalanceDetails  `json:"mine"`
+	WatchOnly *BalanceDetails `json:"watchonly,omitempty"`
+}
+
+// FutureGetBalancesResult is a future promise to deliver the result of a
+// GetBalancesAsync RPC invocation (or an applicable error).
+type FutureGetBalancesResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// balances of the wallet broken down by category.
+func (r FutureGetBalancesResult) Receive() (*GetBalancesResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getbalances result object.
+	var balances GetBalancesResult
+	err = json.Unmarshal(res, &balances)
+	if err != nil {
+		return nil, err
+	}
+	return &balances, nil
+}
+
+// GetBalancesAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetBalances for the blocking version and more details.
+func (c *Client) GetBalancesAsync() FutureGetBalancesResult {
+	cmd := btcjson.NewGetBalancesCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetBalances returns the trusted, pending and immature balances of the wallet
+// for both the outputs it can spend and those it only watches, along with the
+// CoinJoin balances which show how much of the funds have been mixed.
+//
+// See GetBalanceMinConf for the balance of a single account.
+//
+// NOTE: This is a dashd wallet extension.
+func (c *Client) GetBalances() (*GetBalancesResult, error) {
+	return c.GetBalancesAsync().Receive()
+}
+
+// ListUnspentMinRounds returns the unspent transaction outputs known to a
+// wallet which went through at least minRounds CoinJoin mixing rounds, using
+// the specified number of minimum and maximum number of confirmations as a
+// filter.  The outputs are further limited to those paying to any of the
+// passed addresses unless none are passed.  This is useful to select the
+// already mixed outputs to spend privately.
+//
+// The outputs are filtered by their mixing rounds on the client, since dashd
+// has no such filter.  See ListUnspentMinMaxAddressesAsync for the
+// asynchronous request the outputs are fetched with.
+//
+// NOTE: This is a dashd wallet extension.
+func (c *Client) ListUnspentMinRounds(minConf, maxConf int,
+	addrs []godashutil.Address, minRounds int32) ([]btcjson.ListUnspentResult, error) {
+
+	var future FutureListUnspentResult
+	if len(addrs) > 0 {
+		future = c.ListUnspentMinMaxAddressesAsync(minConf, maxConf, addrs)
+	} else {
+		future = c.ListUnspentMinMaxAsync(minConf, maxConf)
+	}
+	unspent, err := future.Receive()
+	if err != nil {
+		return nil, err
+	}
+
+	mixed := make([]btcjson.ListUnspentResult, 0, len(unspent))
+	for _, output := range unspent {
+		if output.PSRounds >= minRounds {
+			mixed = append(mixed, output)
+		}
+	}
+	return mixed, nil
+}
+
+// ProTxRegisterParams houses the arguments of the protx register_prepare
+// command which registers a deterministic masternode backed by an existing
+// collateral output.
+type ProTxRegisterParams struct {
+	// CollateralHash and CollateralIndex identify the collateral output.
+	CollateralHash  *chainhash.Hash
+	CollateralIndex uint32
+
+	// IPAndPort is the service address of the masternode in the form
+	// "IP:PORT".  It may be empty, in which case a ProUpServTx is required
+	// afterwards.
+	IPAndPort string
+
+	// OwnerAddress is the address of the owner key.  It must not be reused
+	// by other masternodes.
+	OwnerAddress string
+
+	// OperatorPubKey is the hex-encoded BLS public key of the operator.
+	OperatorPubKey string
+
+	// VotingAddress is the address of the voting key.  The owner address is
+	// used when it is empty.
+	VotingAddress string
+
+	// OperatorReward is the percentage of the masternode reward paid to
+	// the operator, between 0 and 100.
+	OperatorReward float64
+
+	// PayoutAddress is the address masternode rewards are paid to.
+	PayoutAddress string
+
+	// FeeSourceAddress optionally selects the address the transaction fee
+	// is paid from.  The payout address is used when it is empty.
+	FeeSourceAddress string
+}
+
+// FutureProTxRegisterPrepareResult is a future promise to deliver the result
+// of a ProTxRegisterPrepareAsync RPC invocation (or an applicable error).
+type FutureProTxRegisterPrepareResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// unsigned registration transaction along with the collateral address and the
+// message to sign.
+func (r FutureProTxRegisterPrepareResult) Receive() (*btcjson.ProTxRegisterPrepareResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a protx register_prepare result object.
+	var prepareResult btcjson.ProTxRegisterPrepareResult
+	err = json.Unmarshal(res, &prepareResult)
+	if err != nil {
+		return nil, err
+	}
+	return &prepareResult, nil
+}
+
+// ProTxRegisterPrepareAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See ProTxRegisterPrepare for the blocking version and more details.
+func (c *Client) ProTxRegisterPrepareAsync(params *ProTxRegisterParams) FutureProTxRegisterPrepareResult {
+	if params == nil || params.CollateralHash == nil {
+		return newFutureError(errors.New("no collateral specified"))
+	}
+	if params.OperatorReward < 0 || params.OperatorReward > 100 {
+		return newFutureError(fmt.Errorf("invalid operator reward %v: "+
+			"must be between 0 and 100", params.OperatorReward))
+	}
+	if err := checkBLSPublicKey(params.OperatorPubKey); err != nil {
+		return newFutureError(err)
+	}
+
+	var feeSourceAddress *string
+	if params.FeeSourceAddress != "" {
+		feeSourceAddress = &params.FeeSourceAddress
+	}
+	cmd := btcjson.NewProTxRegisterPrepareCmd(params.CollateralHash.String(),
+		params.CollateralIndex, params.IPAndPort, params.OwnerAddress,
+		params.OperatorPubKey, params.VotingAddress, params.OperatorReward,
+		params.PayoutAddress, feeSourceAddress)
+	return c.sendCmd(cmd)
+}
+
+// ProTxRegisterPrepare creates an unsigned ProRegTx registering a masternode
+// backed by an existing collateral output.  The returned sign message must be
+// signed with the key of the collateral address, for example with SignMessage,
+// and the signature submitted together with the transaction via
+// ProTxRegisterSubmit.
+//
+// NOTE: This is a dashd wallet extension.
+func (c *Client) ProTxRegisterPrepare(params *ProTxRegisterParams) (*btcjson.ProTxRegisterPrepareResult, error) {
+	return c.ProTxRegisterPrepareAsync(params).Receive()
+}
+
+// FutureProTxRegisterSubmitResult is a future promise to deliver the result
+// of a ProTxRegisterSubmitAsync RPC invocation (or an applicable error).
+type FutureProTxRegisterSubmitResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash
+// of the submitted ProRegTx.
+func (r FutureProTxRegisterSubmitResult) Receive() (*chainhash.Hash, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a string.
+	var txHashStr string
+	err = json.Unmarshal(res, &txHashStr)
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(txHashStr)
+}
+
+// ProTxRegisterSubmitAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See ProTxRegisterSubmit for the blocking version and more details.
+func (c *Client) ProTxRegisterSubmitAsync(tx, sig string) FutureProTxRegisterSubmitResult {
+	cmd := btcjson.NewProTxRegisterSubmitCmd(tx, sig)
+	return c.sendCmd(cmd)
+}
+
+// ProTxRegisterSubmit signs and broadcasts the hex-encoded transaction
+// returned by ProTxRegisterPrepare using the passed base64-encoded signature of
+// its sign message, and returns the hash of the ProRegTx.
+//
+// NOTE: This is a dashd wallet extension.
+func (c *Client) ProTxRegisterSubmit(tx, sig string) (*chainhash.Hash, error) {
+	return c.ProTxRegisterSubmitAsync(tx, sig).Receive()
+}
+
+// checkGovernanceVote ensures the passed vote signal and outcome are ones the
+// server accepts.
+func checkGovernanceVote(signal, outcome string) error {
+	switch signal {
+	case "funding", "valid", "delete", "endorsed":
+	default:
+		return fmt.Errorf("invalid vote signal %q: must be one of "+
+			"funding, valid, delete or endorsed", signal)
+	}
+
+	switch outcome {
+	case "yes", "no", "abstain":
+	default:
+		return fmt.Errorf("invalid vote outcome %q: must be one of yes, "+
+			"no or abstain", outcome)
+	}
+	return nil
+}
+
+// FutureVoteGovernanceObjectResult is a future promise to deliver the result
+// of a VoteGovernanceObjectAsync or VoteGovernanceObjectAliasAsync RPC
+// invocation (or an applicable error).
+type FutureVoteGovernanceObjectResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// overall outcome of the vote along with the outcome for each masternode.
+func (r FutureVoteGovernanceObjectResult) Receive() (*btcjson.GObjectVoteResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a gobject vote result object.
+	var voteResult btcjson.GObjectVoteResult
+	err = json.Unmarshal(res, &voteResult)
+	if err != nil {
+		return nil, err
+	}
+	return &voteResult, nil
+}
+
+// VoteGovernanceObjectAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See VoteGovernanceObject for the blocking version and more details.
+func (c *Client) VoteGovernanceObjectAsync(govHash, signal, outcome string) FutureVoteGovernanceObjectResult {
+	if err := checkGovernanceVote(signal, outcome); err != nil {
+		return newFutureError(err)
+	}
+
+	cmd := btcjson.NewGObjectVoteManyCmd(govHash, signal, outcome)
+	return c.sendCmd(cmd)
+}
+
+// VoteGovernanceObject casts a vote on the governance object with the passed
+// hash with every masternode whose voting key is held by the wallet.  The
+// signal must be one of "funding", "valid", "delete" or "endorsed", and the
+// outcome one of "yes", "no" or "abstain".
+//
+// NOTE: This is a dashd wallet extension.
+func (c *Client) VoteGovernanceObject(govHash, signal, outcome string) (*btcjson.GObjectVoteResult, error) {
+	return c.VoteGovernanceObjectAsync(govHash, signal, outcome).Receive()
+}
+
+// VoteGovernanceObjectAliasAsync returns an instance of a type that can be
+// used to get the result of the RPC at some future time by invoking the
+// Receive function on the returned instance.
+//
+// See VoteGovernanceObjectAlias for the blocking version and more details.
+func (c *Client) VoteGovernanceObjectAliasAsync(govHash, signal, outcome, proTxHash string) FutureVoteGovernanceObjectResult {
+	if err := checkGovernanceVote(signal, outcome); err != nil {
+		return newFutureError(err)
+	}
+
+	cmd := btcjson.NewGObjectVoteAliasCmd(govHash, signal, outcome, proTxHash)
+	return c.sendCmd(cmd)
+}
+
+// VoteGovernanceObjectAlias is the same as VoteGovernanceObject except only the
+// masternode registered by the ProRegTx with the passed hash votes.
+//
+// NOTE: This is a dashd wallet extension.
+func (c *Client) VoteGovernanceObjectAlias(govHash, signal, outcome, proTxHash string) (*btcjson.GObjectVoteResult, error) {
+	return c.VoteGovernanceObjectAliasAsync(govHash, signal, outcome,
+		proTxHash).Receive()
+}
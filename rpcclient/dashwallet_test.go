@@ -0,0 +1,153 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg"
+	"github.com/nargott/godashutil"
+)
+
+// TestGetBalancesReceive ensures the getbalances replies are decoded with exact
+// amounts and with the optional balances left nil when they are not reported.
+func TestGetBalancesReceive(t *testing.T) {
+	t.Parallel()
+
+	coinJoin := DashAmount(110000001)
+	tests := []struct {
+		name     string
+		result   string
+		expected *GetBalancesResult
+	}{
+		{
+			name: "mine only",
+			result: `{"mine":{"trusted":12.34567891,"untrusted_pending":0.29,` +
+				`"immature":0.00000000,"coinjoin":1.10000001}}`,
+			expected: &GetBalancesResult{
+				Mine: BalanceDetails{
+					Trusted:          1234567891,
+					UntrustedPending: 29000000,
+					CoinJoin:         &coinJoin,
+				},
+			},
+		},
+		{
+			name: "with watch-only",
+			result: `{"mine":{"trusted":1.00000000,"untrusted_pending":0.00000000,` +
+				`"immature":0.00000000},"watchonly":{"trusted":2.50000000,` +
+				`"untrusted_pending":0.00000000,"immature":0.10000000}}`,
+			expected: &GetBalancesResult{
+				Mine: BalanceDetails{
+					Trusted: 100000000,
+				},
+				WatchOnly: &BalanceDetails{
+					Trusted:  250000000,
+					Immature: 10000000,
+				},
+			},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		future := make(FutureGetBalancesResult, 1)
+		future <- &response{result: []byte(test.result)}
+		balances, err := future.Receive()
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(balances, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected result - got %s, "+
+				"want %s", i, test.name, spew.Sdump(balances),
+				spew.Sdump(test.expected))
+		}
+	}
+}
+
+// TestListUnspentMinRounds ensures the unspent outputs are requested with the
+// optional address filter and limited to those with enough mixing rounds.
+func TestListUnspentMinRounds(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		params := make([]string, 0, len(req.Params))
+		for _, param := range req.Params {
+			params = append(params, string(param))
+		}
+		requests = append(requests, req.Method+" "+strings.Join(params, " "))
+
+		// Older servers name the rounds ps_rounds and newer ones
+		// coinjoin_rounds, so both are served.
+		w.Write([]byte(`{"result":[` +
+			`{"txid":"aa","vout":0,"amount":1.00001,"confirmations":10,` +
+			`"spendable":true,"solvable":true,"ps_rounds":4},` +
+			`{"txid":"bb","vout":1,"amount":0.10000100,"confirmations":3,` +
+			`"spendable":true,"solvable":true,"coinjoin_rounds":2},` +
+			`{"txid":"cc","vout":2,"amount":5,"confirmations":1,` +
+			`"spendable":true,"solvable":true,"coinjoin_rounds":-2}` +
+			`],"error":null,"id":1}`))
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	unspent, err := client.ListUnspentMinRounds(1, 9999999, nil, 2)
+	if err != nil {
+		t.Fatalf("ListUnspentMinRounds: unexpected error: %v", err)
+	}
+	var txIDs []string
+	for _, output := range unspent {
+		txIDs = append(txIDs, output.TxID)
+	}
+	if want := []string{"aa", "bb"}; !reflect.DeepEqual(txIDs, want) {
+		t.Errorf("ListUnspentMinRounds: got outputs %v, want %v", txIDs,
+			want)
+	}
+	if unspent[0].PSRounds != 4 || unspent[1].PSRounds != 2 ||
+		!unspent[1].Solvable {
+
+		t.Errorf("ListUnspentMinRounds: unexpected outputs %+v", unspent)
+	}
+
+	addr, err := godashutil.NewAddressPubKeyHash(make([]byte, 20),
+		&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewAddressPubKeyHash: unexpected error: %v", err)
+	}
+	unspent, err = client.ListUnspentMinRounds(6, 100,
+		[]godashutil.Address{addr}, 4)
+	if err != nil {
+		t.Fatalf("ListUnspentMinRounds: unexpected error: %v", err)
+	}
+	if len(unspent) != 1 || unspent[0].TxID != "aa" {
+		t.Errorf("ListUnspentMinRounds: unexpected outputs %+v", unspent)
+	}
+
+	want := []string{
+		`listunspent 1 9999999`,
+		`listunspent 6 100 ["` + addr.EncodeAddress() + `"]`,
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("unexpected requests - got %v, want %v", requests, want)
+	}
+}
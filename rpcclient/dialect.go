@@ -0,0 +1,67 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+// coinJoinVersion is the first version of dashd which names the mixing
+// commands coinjoin and getcoinjoininfo instead of privatesend and
+// getprivatesendinfo.
+const coinJoinVersion = 170000
+
+// rpcDialect describes the command names understood by a server as detected
+// from its version.
+type rpcDialect struct {
+	// version is the version reported by the server.
+	version int32
+
+	// privateSend indicates the server only knows the privatesend names
+	// of the mixing commands.
+	privateSend bool
+}
+
+// newRPCDialect returns the dialect spoken by a server running the passed
+// version of dashd.
+func newRPCDialect(version int32) *rpcDialect {
+	return &rpcDialect{
+		version:     version,
+		privateSend: version < coinJoinVersion,
+	}
+}
+
+// detectDialect returns the dialect spoken by the server, querying its
+// version on first use and caching the result.  It returns nil when the
+// AutoDetectDialect option is not set or the version could not be queried, in
+// which case the detection is attempted again on the next call.
+//
+// This function blocks until the version of the server is known.
+func (c *Client) detectDialect() *rpcDialect {
+	if !c.config.AutoDetectDialect {
+		return nil
+	}
+
+	c.dialectMtx.Lock()
+	defer c.dialectMtx.Unlock()
+
+	if c.dialect != nil {
+		return c.dialect
+	}
+
+	info, err := c.GetNetworkInfo()
+	if err != nil {
+		log.Warnf("Unable to detect the RPC dialect of the server: %v",
+			err)
+		return nil
+	}
+	c.dialect = newRPCDialect(info.Version)
+	log.Debugf("Detected RPC dialect of server version %d", info.Version)
+	return c.dialect
+}
+
+// usePrivateSend returns whether the mixing commands must be sent with their
+// privatesend names.  The current names are used unless the dialect of the
+// server was detected and predates them.
+func (c *Client) usePrivateSend() bool {
+	dialect := c.detectDialect()
+	return dialect != nil && dialect.privateSend
+}
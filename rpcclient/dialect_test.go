@@ -0,0 +1,99 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestAutoDetectDialect ensures the mixing commands are sent with the names
+// understood by the detected server version and the version is only queried
+// once.
+func TestAutoDetectDialect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version int32
+		methods []string
+	}{
+		{
+			name:    "privatesend",
+			version: 160100,
+			methods: []string{"getnetworkinfo", "privatesend",
+				"getprivatesendinfo"},
+		},
+		{
+			name:    "coinjoin",
+			version: 180200,
+			methods: []string{"getnetworkinfo", "coinjoin",
+				"getcoinjoininfo"},
+		},
+	}
+
+	for _, test := range tests {
+		var mtx sync.Mutex
+		var methods []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Method string `json:"method"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("%s: unable to decode request: %v",
+					test.name, err)
+			}
+			mtx.Lock()
+			methods = append(methods, req.Method)
+			mtx.Unlock()
+
+			switch req.Method {
+			case "getnetworkinfo":
+				fmt.Fprintf(w, `{"result":{"version":%d},"error":null,"id":1}`,
+					test.version)
+			case "getcoinjoininfo", "getprivatesendinfo":
+				w.Write([]byte(`{"result":{"enabled":true},"error":null,"id":1}`))
+			default:
+				w.Write([]byte(`{"result":"Mixing started successfully","error":null,"id":1}`))
+			}
+		}))
+
+		client, err := New(&ConnConfig{
+			Host:              strings.TrimPrefix(srv.URL, "http://"),
+			User:              "user",
+			Pass:              "pass",
+			HTTPPostMode:      true,
+			DisableTLS:        true,
+			AutoDetectDialect: true,
+		}, nil)
+		if err != nil {
+			t.Fatalf("%s: New: unexpected error: %v", test.name, err)
+		}
+
+		if err := client.CoinJoinStart(); err != nil {
+			t.Errorf("%s: CoinJoinStart: unexpected error: %v",
+				test.name, err)
+		}
+		if _, err := client.GetCoinJoinInfo(); err != nil {
+			t.Errorf("%s: GetCoinJoinInfo: unexpected error: %v",
+				test.name, err)
+		}
+		client.Shutdown()
+		srv.Close()
+
+		mtx.Lock()
+		if !reflect.DeepEqual(methods, test.methods) {
+			t.Errorf("%s: unexpected methods - got %v, want %v",
+				test.name, methods, test.methods)
+		}
+		mtx.Unlock()
+	}
+}
@@ -0,0 +1,1018 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godash/wire"
+	"github.com/nargott/godashutil/base58"
+)
+
+const (
+	// blsSecretKeySize is the size in bytes of a serialized BLS secret
+	// key.
+	blsSecretKeySize = 32
+
+	// blsPublicKeySize is the size in bytes of a serialized BLS public
+	// key.
+	blsPublicKeySize = 48
+
+	// blsSignatureSize is the size in bytes of a serialized BLS
+	// signature.
+	blsSignatureSize = 96
+)
+
+// checkBLSSignature ensures the passed string is the hex encoding of a
+// serialized BLS signature.
+func checkBLSSignature(signature string) error {
+	if len(signature) != blsSignatureSize*2 {
+		return fmt.Errorf("invalid BLS signature length: got %d hex "+
+			"characters, want %d", len(signature), blsSignatureSize*2)
+	}
+	if _, err := hex.DecodeString(signature); err != nil {
+		return fmt.Errorf("invalid BLS signature: %v", err)
+	}
+	return nil
+}
+
+// checkBLSPublicKey ensures the passed string is the hex encoding of a
+// serialized BLS public key.
+func checkBLSPublicKey(pubKey string) error {
+	if len(pubKey) != blsPublicKeySize*2 {
+		return fmt.Errorf("invalid BLS public key length: got %d hex "+
+			"characters, want %d", len(pubKey), blsPublicKeySize*2)
+	}
+	if _, err := hex.DecodeString(pubKey); err != nil {
+		return fmt.Errorf("invalid BLS public key: %v", err)
+	}
+	return nil
+}
+
+// FutureGetProTxListResult is a future promise to deliver the result of a
+// GetProTxListAsync RPC invocation (or an applicable error).
+type FutureGetProTxListResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// registered deterministic masternodes.
+func (r FutureGetProTxListResult) Receive() ([]btcjson.ProTxInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of protx info results.
+	var proTxList []btcjson.ProTxInfoResult
+	err = json.Unmarshal(res, &proTxList)
+	if err != nil {
+		return nil, err
+	}
+	return proTxList, nil
+}
+
+// GetProTxListAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetProTxList for the blocking version and more details.
+func (c *Client) GetProTxListAsync(detailed bool, height int32) FutureGetProTxListResult {
+	var heightParam *int32
+	if height > 0 {
+		heightParam = &height
+	}
+
+	cmd := btcjson.NewProTxListCmd(btcjson.String("registered"), &detailed,
+		heightParam)
+	return c.sendCmd(cmd)
+}
+
+// GetProTxList returns the deterministic masternodes registered as of the
+// provided height, or the current best block when the height is not positive.
+// Only the ProTxHash field of the returned entries is populated unless detailed
+// is set.
+func (c *Client) GetProTxList(detailed bool, height int32) ([]btcjson.ProTxInfoResult, error) {
+	return c.GetProTxListAsync(detailed, height).Receive()
+}
+
+// FutureGetProTxInfoResult is a future promise to deliver the result of a
+// GetProTxInfoAsync RPC invocation (or an applicable error).
+type FutureGetProTxInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// information about the requested deterministic masternode.
+func (r FutureGetProTxInfoResult) Receive() (*btcjson.ProTxInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a protx info result object.
+	var infoResult btcjson.ProTxInfoResult
+	err = json.Unmarshal(res, &infoResult)
+	if err != nil {
+		return nil, err
+	}
+	return &infoResult, nil
+}
+
+// GetProTxInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetProTxInfo for the blocking version and more details.
+func (c *Client) GetProTxInfoAsync(proTxHash *chainhash.Hash) FutureGetProTxInfoResult {
+	hash := ""
+	if proTxHash != nil {
+		hash = proTxHash.String()
+	}
+
+	cmd := btcjson.NewProTxInfoCmd(hash)
+	return c.sendCmd(cmd)
+}
+
+// GetProTxInfo returns the registration details and current state of the
+// deterministic masternode registered by the ProRegTx with the given hash.
+func (c *Client) GetProTxInfo(proTxHash *chainhash.Hash) (*btcjson.ProTxInfoResult, error) {
+	return c.GetProTxInfoAsync(proTxHash).Receive()
+}
+
+// FutureGetProTxDiffResult is a future promise to deliver the result of a
+// GetProTxDiffAsync RPC invocation (or an applicable error).
+type FutureGetProTxDiffResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// masternode list diff between the requested blocks.
+func (r FutureGetProTxDiffResult) Receive() (*btcjson.MnListDiffResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a protx diff result object.
+	var diff btcjson.MnListDiffResult
+	err = json.Unmarshal(res, &diff)
+	if err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// GetProTxDiffAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetProTxDiff for the blocking version and more details.
+func (c *Client) GetProTxDiffAsync(baseHeight, height int32) FutureGetProTxDiffResult {
+	cmd := btcjson.NewProTxDiffCmd(btcjson.HashOrHeight{Value: baseHeight},
+		btcjson.HashOrHeight{Value: height})
+	return c.sendCmd(cmd)
+}
+
+// GetProTxDiff returns the changes of the simplified masternode list and the
+// active quorums between the blocks at the given heights.  It holds the same
+// data as a mnlistdiff message, so clients which trust the server can sync the
+// masternode list without connecting to the peer-to-peer network.  See
+// DecodeSMLEntries to verify the returned list against the CbTx.
+func (c *Client) GetProTxDiff(baseHeight, height int32) (*btcjson.MnListDiffResult, error) {
+	return c.GetProTxDiffAsync(baseHeight, height).Receive()
+}
+
+// DecodeSMLEntries converts the masternode list entries of a masternode list
+// diff returned by the server into their wire representation, the same one
+// used by mnlistdiff messages.  The full list obtained by applying the diffs can
+// then be verified against the CbTx with wire.VerifySMLEntryProof.
+func DecodeSMLEntries(entries []btcjson.SMLEntryResult) ([]wire.SimplifiedMNListEntry, error) {
+	decoded := make([]wire.SimplifiedMNListEntry, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		proRegTxHash, err := chainhash.NewHashFromStr(entry.ProRegTxHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proRegTxHash: %v", err)
+		}
+		confirmedHash, err := chainhash.NewHashFromStr(entry.ConfirmedHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid confirmedHash: %v", err)
+		}
+
+		host, portStr, err := net.SplitHostPort(entry.Service)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service: %v", err)
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid service IP address %q", host)
+		}
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service port: %v", err)
+		}
+
+		if err := checkBLSPublicKey(entry.PubKeyOperator); err != nil {
+			return nil, err
+		}
+		pubKeyOperator, _ := hex.DecodeString(entry.PubKeyOperator)
+
+		keyIDVoting, _, err := base58.CheckDecode(entry.VotingAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid votingAddress: %v", err)
+		}
+		if len(keyIDVoting) != wire.KeyIDSize {
+			return nil, fmt.Errorf("invalid votingAddress length: "+
+				"got %d bytes, want %d", len(keyIDVoting),
+				wire.KeyIDSize)
+		}
+
+		d := &decoded[i]
+		d.Version = entry.Version
+		if d.Version == 0 {
+			// Servers older than Dash Core 19 only know legacy
+			// BLS entries and don't report the version.
+			d.Version = wire.ProTxVersionLegacyBLS
+		}
+		d.ProRegTxHash = *proRegTxHash
+		d.ConfirmedHash = *confirmedHash
+		d.IPAddress = ip
+		d.Port = uint16(port)
+		copy(d.PubKeyOperator[:], pubKeyOperator)
+		copy(d.KeyIDVoting[:], keyIDVoting)
+		d.IsValid = entry.IsValid
+		d.Type = entry.Type
+
+		if d.Type == wire.MasternodeTypeEvo {
+			nodeID, err := hex.DecodeString(entry.PlatformNodeID)
+			if err != nil || len(nodeID) != wire.PlatformNodeIDSize {
+				return nil, fmt.Errorf("invalid platformNodeID %q",
+					entry.PlatformNodeID)
+			}
+
+			// The node id is reported byte-reversed like the
+			// hashes.
+			for j := range nodeID {
+				d.PlatformNodeID[j] = nodeID[len(nodeID)-1-j]
+			}
+			d.PlatformHTTPPort = entry.PlatformHTTPPort
+		}
+	}
+	return decoded, nil
+}
+
+// FutureGetQuorumListResult is a future promise to deliver the result of a
+// GetQuorumListAsync RPC invocation (or an applicable error).
+type FutureGetQuorumListResult chan *response
+
+// Receive waits for the response promised by the future and returns the hashes
+// of the active quorums keyed by the name of their LLMQ type.
+func (r FutureGetQuorumListResult) Receive() (map[string][]string, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a map of quorum hashes.
+	var quorums map[string][]string
+	err = json.Unmarshal(res, &quorums)
+	if err != nil {
+		return nil, err
+	}
+	return quorums, nil
+}
+
+// GetQuorumListAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetQuorumList for the blocking version and more details.
+func (c *Client) GetQuorumListAsync(count int) FutureGetQuorumListResult {
+	var countParam *int
+	if count > 0 {
+		countParam = &count
+	}
+
+	cmd := btcjson.NewQuorumListCmd(countParam)
+	return c.sendCmd(cmd)
+}
+
+// GetQuorumList returns the hashes of the most recent quorums keyed by the name
+// of their LLMQ type, such as "llmq_50_60".  The count limits the number of
+// quorums returned for each type and uses the server default when it is not
+// positive.
+func (c *Client) GetQuorumList(count int) (map[string][]string, error) {
+	return c.GetQuorumListAsync(count).Receive()
+}
+
+// FutureGetQuorumInfoResult is a future promise to deliver the result of a
+// GetQuorumInfoAsync RPC invocation (or an applicable error).
+type FutureGetQuorumInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// information about the requested quorum.
+func (r FutureGetQuorumInfoResult) Receive() (*btcjson.QuorumInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a quorum info result object.
+	var infoResult btcjson.QuorumInfoResult
+	err = json.Unmarshal(res, &infoResult)
+	if err != nil {
+		return nil, err
+	}
+	return &infoResult, nil
+}
+
+// GetQuorumInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetQuorumInfo for the blocking version and more details.
+func (c *Client) GetQuorumInfoAsync(llmqType int, quorumHash *chainhash.Hash, includeSkShare bool) FutureGetQuorumInfoResult {
+	hash := ""
+	if quorumHash != nil {
+		hash = quorumHash.String()
+	}
+
+	cmd := btcjson.NewQuorumInfoCmd(llmqType, hash, &includeSkShare)
+	return c.sendCmd(cmd)
+}
+
+// GetQuorumInfo returns the members and public key of the quorum of the given
+// LLMQ type and hash.  The secret key share of the server is only included
+// when includeSkShare is set and the server is a member of the quorum.
+func (c *Client) GetQuorumInfo(llmqType int, quorumHash *chainhash.Hash, includeSkShare bool) (*btcjson.QuorumInfoResult, error) {
+	return c.GetQuorumInfoAsync(llmqType, quorumHash, includeSkShare).Receive()
+}
+
+// hashParam returns the string encoding of the passed hash, or an empty string
+// when it is nil.
+func hashParam(hash *chainhash.Hash) string {
+	if hash == nil {
+		return ""
+	}
+	return hash.String()
+}
+
+// FutureQuorumMemberOfResult is a future promise to deliver the result of a
+// QuorumMemberOfAsync RPC invocation (or an applicable error).
+type FutureQuorumMemberOfResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// quorums the masternode is a member of.
+func (r FutureQuorumMemberOfResult) Receive() ([]btcjson.QuorumMemberOfResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of quorum memberof result objects.
+	var quorums []btcjson.QuorumMemberOfResult
+	err = json.Unmarshal(res, &quorums)
+	if err != nil {
+		return nil, err
+	}
+	return quorums, nil
+}
+
+// QuorumMemberOfAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See QuorumMemberOf for the blocking version and more details.
+func (c *Client) QuorumMemberOfAsync(proTxHash *chainhash.Hash, scanQuorumsCount int) FutureQuorumMemberOfResult {
+	var countParam *int
+	if scanQuorumsCount > 0 {
+		countParam = &scanQuorumsCount
+	}
+
+	cmd := btcjson.NewQuorumMemberOfCmd(hashParam(proTxHash), countParam)
+	return c.sendCmd(cmd)
+}
+
+// QuorumMemberOf returns the quorums the masternode with the given ProTx hash
+// is a member of, along with whether it is a valid member and its index in
+// each of them.  Only the most recent scanQuorumsCount quorums of each LLMQ
+// type are scanned, or the number of active quorums when not positive.
+func (c *Client) QuorumMemberOf(proTxHash *chainhash.Hash, scanQuorumsCount int) ([]btcjson.QuorumMemberOfResult, error) {
+	return c.QuorumMemberOfAsync(proTxHash, scanQuorumsCount).Receive()
+}
+
+// FutureQuorumSignResult is a future promise to deliver the result of a
+// QuorumSignAsync RPC invocation (or an applicable error).
+type FutureQuorumSignResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// result of the signing request.
+func (r FutureQuorumSignResult) Receive() (*btcjson.QuorumSignResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a quorum sign result object.
+	var signResult btcjson.QuorumSignResult
+	err = json.Unmarshal(res, &signResult)
+	if err != nil {
+		return nil, err
+	}
+	return &signResult, nil
+}
+
+// QuorumSignAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See QuorumSign for the blocking version and more details.
+func (c *Client) QuorumSignAsync(llmqType int, id, msgHash *chainhash.Hash,
+	quorumHash *chainhash.Hash, submit bool) FutureQuorumSignResult {
+
+	// The server selects the quorum itself when the quorum hash is empty.
+	quorumHashParam := hashParam(quorumHash)
+	cmd := btcjson.NewQuorumSignCmd(llmqType, hashParam(id),
+		hashParam(msgHash), &quorumHashParam, &submit)
+	return c.sendCmd(cmd)
+}
+
+// QuorumSign asks the server to sign the message hash for the given request ID
+// with its share of a quorum of the given LLMQ type.  The quorum is selected
+// by the server unless quorumHash is non-nil.
+//
+// When submit is set the signature share is propagated to the other quorum
+// members so the recovered signature can later be fetched and verified, and
+// only the Submitted field of the returned result is set.  Otherwise the
+// signature share of the server is returned along with the request ID, the
+// quorum hash and the sign hash without being propagated.
+func (c *Client) QuorumSign(llmqType int, id, msgHash *chainhash.Hash,
+	quorumHash *chainhash.Hash, submit bool) (*btcjson.QuorumSignResult, error) {
+
+	return c.QuorumSignAsync(llmqType, id, msgHash, quorumHash, submit).Receive()
+}
+
+// FutureQuorumVerifyResult is a future promise to deliver the result of a
+// QuorumVerifyAsync RPC invocation (or an applicable error).
+type FutureQuorumVerifyResult chan *response
+
+// Receive waits for the response promised by the future and returns whether or
+// not the quorum signature is valid.
+func (r FutureQuorumVerifyResult) Receive() (bool, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return false, err
+	}
+
+	// Unmarshal result as a boolean.
+	var verified bool
+	err = json.Unmarshal(res, &verified)
+	if err != nil {
+		return false, err
+	}
+	return verified, nil
+}
+
+// QuorumVerifyAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See QuorumVerify for the blocking version and more details.
+func (c *Client) QuorumVerifyAsync(llmqType int, id, msgHash *chainhash.Hash,
+	signature string, quorumHash *chainhash.Hash, signHeight int32) FutureQuorumVerifyResult {
+
+	if err := checkBLSSignature(signature); err != nil {
+		return newFutureError(err)
+	}
+
+	quorumHashParam := hashParam(quorumHash)
+	var heightParam *int32
+	if signHeight > 0 {
+		heightParam = &signHeight
+	}
+
+	cmd := btcjson.NewQuorumVerifyCmd(llmqType, hashParam(id),
+		hashParam(msgHash), signature, &quorumHashParam, heightParam)
+	return c.sendCmd(cmd)
+}
+
+// QuorumVerify returns whether the hex-encoded BLS signature is a valid
+// recovered signature of a quorum of the given LLMQ type for the message hash
+// and request ID.  The signature is checked against the quorum with the given
+// hash when quorumHash is non-nil, and otherwise against the quorum that was
+// active at signHeight, which defaults to the tip when not positive.
+//
+// An error is returned without contacting the server when the signature is
+// not a hex-encoded 96-byte BLS signature.
+func (c *Client) QuorumVerify(llmqType int, id, msgHash *chainhash.Hash,
+	signature string, quorumHash *chainhash.Hash, signHeight int32) (bool, error) {
+
+	return c.QuorumVerifyAsync(llmqType, id, msgHash, signature, quorumHash,
+		signHeight).Receive()
+}
+
+// FutureGetQuorumRotationInfoResult is a future promise to deliver the result
+// of a GetQuorumRotationInfoAsync RPC invocation (or an applicable error).
+type FutureGetQuorumRotationInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// rotation information of the quorums.
+func (r FutureGetQuorumRotationInfoResult) Receive() (*btcjson.QuorumRotationInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a quorum rotationinfo result object.
+	var info btcjson.QuorumRotationInfoResult
+	err = json.Unmarshal(res, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetQuorumRotationInfoAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetQuorumRotationInfo for the blocking version and more details.
+func (c *Client) GetQuorumRotationInfoAsync(blockRequestHash *chainhash.Hash,
+	extraShare bool, baseBlockHashes []*chainhash.Hash) FutureGetQuorumRotationInfoResult {
+
+	// The base block hashes are trailing positional parameters of the
+	// command, which registered btcjson commands can't express, so the
+	// request is built by hand.
+	params := make([]interface{}, 0, 3+len(baseBlockHashes))
+	params = append(params, "rotationinfo", hashParam(blockRequestHash),
+		extraShare)
+	for _, hash := range baseBlockHashes {
+		params = append(params, hashParam(hash))
+	}
+
+	rawParams := make([]json.RawMessage, 0, len(params))
+	for _, param := range params {
+		marshalled, err := json.Marshal(param)
+		if err != nil {
+			return newFutureError(err)
+		}
+		rawParams = append(rawParams, marshalled)
+	}
+
+	return FutureGetQuorumRotationInfoResult(c.rawRequestCtx(
+		context.Background(), "quorum", rawParams))
+}
+
+// GetQuorumRotationInfo returns the information needed to compute the members
+// of the rotating quorums as defined by DIP0024 for the cycle of the block with
+// the given hash.  The masternode list diffs are relative to the most recent of
+// the passed base blocks known to the server, or to the genesis block when none
+// are given.  The snapshots and diffs of a fourth previous cycle are included
+// when extraShare is set.
+func (c *Client) GetQuorumRotationInfo(blockRequestHash *chainhash.Hash,
+	extraShare bool, baseBlockHashes []*chainhash.Hash) (*btcjson.QuorumRotationInfoResult, error) {
+
+	return c.GetQuorumRotationInfoAsync(blockRequestHash, extraShare,
+		baseBlockHashes).Receive()
+}
+
+// FutureGetISLockResult is a future promise to deliver the result of a
+// GetISLockAsync RPC invocation (or an applicable error).
+type FutureGetISLockResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// InstantSend lock of the requested transaction, or nil when the transaction
+// is not locked.
+func (r FutureGetISLockResult) Receive() (*btcjson.ISLockResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of raw locks.
+	var locks []json.RawMessage
+	err = json.Unmarshal(res, &locks)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server replies with the string "None" in place of the lock for
+	// transactions which are not locked.
+	if len(locks) == 0 {
+		return nil, nil
+	}
+	var none string
+	if json.Unmarshal(locks[0], &none) == nil {
+		return nil, nil
+	}
+
+	var lock btcjson.ISLockResult
+	err = json.Unmarshal(locks[0], &lock)
+	if err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// GetISLockAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetISLock for the blocking version and more details.
+func (c *Client) GetISLockAsync(txHash *chainhash.Hash) FutureGetISLockResult {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+
+	cmd := btcjson.NewGetISLocksCmd([]string{hash})
+	return c.sendCmd(cmd)
+}
+
+// GetISLock returns the InstantSend lock for the transaction with the given
+// hash, or nil when the transaction has not been locked (yet).
+func (c *Client) GetISLock(txHash *chainhash.Hash) (*btcjson.ISLockResult, error) {
+	return c.GetISLockAsync(txHash).Receive()
+}
+
+// defaultISLockPollInterval is the interval WaitForInstantLock polls the
+// server at when no interval is given.
+const defaultISLockPollInterval = time.Second
+
+// WaitForInstantLock blocks until the transaction with the given hash is
+// locked by InstantSend or the passed context is done, in which case the
+// error of the context is returned.
+//
+// When the client runs in websocket mode with notification handlers, it
+// registers for instantsend lock notifications and returns as soon as the
+// lock is announced.  The registration is only made once per client and is
+// shared by all calls.  The server is polled with getislocks at the given
+// interval either way, which covers HTTP POST mode as well as notifications
+// missed while reconnecting.  An interval which is not positive polls once a
+// second.
+func (c *Client) WaitForInstantLock(ctx context.Context, txHash *chainhash.Hash, poll time.Duration) error {
+	if txHash == nil {
+		return errors.New("no transaction hash specified")
+	}
+	if poll <= 0 {
+		poll = defaultISLockPollInterval
+	}
+
+	// Prefer being notified of the lock when the connection allows it.
+	// The waiter is added before registering so a lock announced in
+	// between is not missed.  A nil channel never fires, leaving polling
+	// as the only way out of the loop below.
+	var locked chan struct{}
+	if !c.config.HTTPPostMode && c.ntfnHandlers != nil {
+		waiter := c.addISLockWaiter(*txHash)
+		defer c.removeISLockWaiter(*txHash, waiter)
+
+		if err := c.registerISLockNtfns(); err != nil {
+			log.Debugf("Unable to register for instantsend lock "+
+				"notifications, polling instead: %v", err)
+		} else {
+			locked = waiter
+		}
+	}
+
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		cmd := btcjson.NewGetISLocksCmd([]string{txHash.String()})
+		lock, err := FutureGetISLockResult(c.sendCmdCtx(ctx, cmd)).Receive()
+		if err != nil {
+			return err
+		}
+		if lock != nil {
+			return nil
+		}
+
+		select {
+		case <-locked:
+			return nil
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// FutureVerifyChainLockResult is a future promise to deliver the result of a
+// VerifyChainLockAsync RPC invocation (or an applicable error).
+type FutureVerifyChainLockResult chan *response
+
+// Receive waits for the response promised by the future and returns whether or
+// not the chainlock signature is valid.
+func (r FutureVerifyChainLockResult) Receive() (bool, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return false, err
+	}
+
+	// Unmarshal result as a boolean.
+	var verified bool
+	err = json.Unmarshal(res, &verified)
+	if err != nil {
+		return false, err
+	}
+	return verified, nil
+}
+
+// VerifyChainLockAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See VerifyChainLock for the blocking version and more details.
+func (c *Client) VerifyChainLockAsync(blockHash *chainhash.Hash, signature string, blockHeight int32) FutureVerifyChainLockResult {
+	if err := checkBLSSignature(signature); err != nil {
+		return newFutureError(err)
+	}
+
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+
+	var heightParam *int32
+	if blockHeight > 0 {
+		heightParam = &blockHeight
+	}
+
+	cmd := btcjson.NewVerifyChainLockCmd(hash, signature, heightParam)
+	return c.sendCmd(cmd)
+}
+
+// VerifyChainLock returns whether the hex-encoded BLS signature is a valid
+// chainlock for the block with the given hash.  The block height is only
+// required when the server does not know the block and is otherwise ignored
+// when not positive.
+//
+// An error is returned without contacting the server when the signature is
+// not a hex-encoded 96-byte BLS signature.
+func (c *Client) VerifyChainLock(blockHash *chainhash.Hash, signature string, blockHeight int32) (bool, error) {
+	return c.VerifyChainLockAsync(blockHash, signature, blockHeight).Receive()
+}
+
+// FutureVerifyISLockResult is a future promise to deliver the result of a
+// VerifyISLockAsync RPC invocation (or an applicable error).
+type FutureVerifyISLockResult chan *response
+
+// Receive waits for the response promised by the future and returns whether or
+// not the InstantSend lock signature is valid.
+func (r FutureVerifyISLockResult) Receive() (bool, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return false, err
+	}
+
+	// Unmarshal result as a boolean.
+	var verified bool
+	err = json.Unmarshal(res, &verified)
+	if err != nil {
+		return false, err
+	}
+	return verified, nil
+}
+
+// VerifyISLockAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See VerifyISLock for the blocking version and more details.
+func (c *Client) VerifyISLockAsync(requestID, txHash, signature string, maxHeight int32) FutureVerifyISLockResult {
+	if err := checkBLSSignature(signature); err != nil {
+		return newFutureError(err)
+	}
+
+	var heightParam *int32
+	if maxHeight > 0 {
+		heightParam = &maxHeight
+	}
+
+	cmd := btcjson.NewVerifyISLockCmd(requestID, txHash, signature,
+		heightParam)
+	return c.sendCmd(cmd)
+}
+
+// VerifyISLock returns whether the hex-encoded BLS signature is a valid
+// InstantSend lock with the given request ID for the transaction with the
+// given hash.  The max height limits the quorums considered for the
+// verification and is ignored when not positive.
+//
+// An error is returned without contacting the server when the signature is
+// not a hex-encoded 96-byte BLS signature.
+func (c *Client) VerifyISLock(requestID, txHash, signature string, maxHeight int32) (bool, error) {
+	return c.VerifyISLockAsync(requestID, txHash, signature, maxHeight).Receive()
+}
+
+// FutureGetBestChainLockResult is a future promise to deliver the result of a
+// GetBestChainLockAsync RPC invocation (or an applicable error).
+type FutureGetBestChainLockResult chan *response
+
+// Receive waits for the response promised by the future and returns the best
+// chainlock known to the server.  The Known field of the returned result is
+// false when the server does not know about any chainlock yet.
+func (r FutureGetBestChainLockResult) Receive() (*btcjson.GetBestChainLockResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		// The server replies with an internal error rather than an
+		// empty result when it does not know about any chainlock.
+		if jerr, ok := err.(*btcjson.RPCError); ok &&
+			jerr.Code == btcjson.ErrRPCInternal.Code &&
+			strings.Contains(strings.ToLower(jerr.Message),
+				"unable to find any chainlock") {
+
+			return &btcjson.GetBestChainLockResult{}, nil
+		}
+		return nil, err
+	}
+
+	// Unmarshal result as a getbestchainlock result object.
+	var chainLock btcjson.GetBestChainLockResult
+	err = json.Unmarshal(res, &chainLock)
+	if err != nil {
+		return nil, err
+	}
+	chainLock.Known = true
+	return &chainLock, nil
+}
+
+// GetBestChainLockAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetBestChainLock for the blocking version and more details.
+func (c *Client) GetBestChainLockAsync() FutureGetBestChainLockResult {
+	cmd := btcjson.NewGetBestChainLockCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetBestChainLock returns the hash, height and signature of the most recent
+// chainlock known to the server.  The Known field of the returned result is
+// false when no chainlock exists yet.
+func (c *Client) GetBestChainLock() (*btcjson.GetBestChainLockResult, error) {
+	return c.GetBestChainLockAsync().Receive()
+}
+
+// IsChainLocked returns whether the block with the given hash is final
+// because it, or one of its descendants in the main chain, is locked by a
+// chainlock.
+//
+// The best chainlock is queried first so a block which is itself the best
+// chainlock is answered without fetching its header.  Otherwise the header is
+// fetched and the block is locked when it is part of the main chain at or
+// below the height of the best chainlock.
+func (c *Client) IsChainLocked(blockHash *chainhash.Hash) (bool, error) {
+	if blockHash == nil {
+		return false, errors.New("no block hash specified")
+	}
+
+	bestLock, err := c.GetBestChainLock()
+	if err != nil {
+		return false, err
+	}
+	if !bestLock.Known {
+		return false, nil
+	}
+	if bestLock.BlockHash == blockHash.String() {
+		return true, nil
+	}
+
+	header, err := c.GetBlockHeaderVerbose(blockHash)
+	if err != nil {
+		return false, err
+	}
+	if header.ChainLock {
+		return true, nil
+	}
+
+	// Blocks which are not part of the main chain are reported with -1
+	// confirmations and can never be locked.
+	if header.Confirmations < 0 {
+		return false, nil
+	}
+	return header.Height <= bestLock.Height, nil
+}
+
+// FutureGetSpecialTxesResult is a future promise to deliver the result of a
+// GetSpecialTxesAsync RPC invocation (or an applicable error).
+type FutureGetSpecialTxesResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// special transactions of the requested block.
+func (r FutureGetSpecialTxesResult) Receive() ([]btcjson.SpecialTxResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of special transactions.
+	var txns []btcjson.SpecialTxResult
+	err = json.Unmarshal(res, &txns)
+	if err != nil {
+		return nil, err
+	}
+	return txns, nil
+}
+
+// GetSpecialTxesAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetSpecialTxes for the blocking version and more details.
+func (c *Client) GetSpecialTxesAsync(blockHash *chainhash.Hash, txType, count, skip, verbosity int) FutureGetSpecialTxesResult {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+
+	cmd := btcjson.NewGetSpecialTxesCmd(hash, &txType, &count, &skip,
+		&verbosity)
+	return c.sendCmd(cmd)
+}
+
+// GetSpecialTxes returns up to count special transactions of the given type,
+// or of any type when it is -1, contained in the block with the given hash
+// after skipping the first skip of them.
+//
+// With a verbosity of zero only the Hex field of the returned transactions is
+// populated.  Higher verbosities return the decoded transactions, including
+// their type and extra payload.
+func (c *Client) GetSpecialTxes(blockHash *chainhash.Hash, txType, count, skip, verbosity int) ([]btcjson.SpecialTxResult, error) {
+	return c.GetSpecialTxesAsync(blockHash, txType, count, skip,
+		verbosity).Receive()
+}
+
+// FutureBLSKeyResult is a future promise to deliver the result of a
+// BLSGenerateAsync or BLSFromSecretAsync RPC invocation (or an applicable
+// error).
+type FutureBLSKeyResult chan *response
+
+// Receive waits for the response promised by the future and returns the BLS
+// key pair.  An error is returned when the server replies with keys which are
+// not hex-encoded keys of the expected sizes.
+func (r FutureBLSKeyResult) Receive() (*btcjson.BLSKeyResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a bls key result object.
+	var keyResult btcjson.BLSKeyResult
+	err = json.Unmarshal(res, &keyResult)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyResult.Secret) != blsSecretKeySize*2 {
+		return nil, fmt.Errorf("invalid BLS secret key length: got %d "+
+			"hex characters, want %d", len(keyResult.Secret),
+			blsSecretKeySize*2)
+	}
+	if len(keyResult.Public) != blsPublicKeySize*2 {
+		return nil, fmt.Errorf("invalid BLS public key length: got %d "+
+			"hex characters, want %d", len(keyResult.Public),
+			blsPublicKeySize*2)
+	}
+	return &keyResult, nil
+}
+
+// BLSGenerateAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See BLSGenerate for the blocking version and more details.
+func (c *Client) BLSGenerateAsync() FutureBLSKeyResult {
+	cmd := btcjson.NewBLSGenerateCmd()
+	return c.sendCmd(cmd)
+}
+
+// BLSGenerate has the server generate a new BLS key pair, such as the operator
+// key of a masternode, and returns the hex-encoded secret and public keys.
+func (c *Client) BLSGenerate() (*btcjson.BLSKeyResult, error) {
+	return c.BLSGenerateAsync().Receive()
+}
+
+// BLSFromSecretAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See BLSFromSecret for the blocking version and more details.
+func (c *Client) BLSFromSecretAsync(secret string) FutureBLSKeyResult {
+	cmd := btcjson.NewBLSFromSecretCmd(secret)
+	return c.sendCmd(cmd)
+}
+
+// BLSFromSecret returns the BLS key pair of the provided hex-encoded secret
+// key.
+func (c *Client) BLSFromSecret(secret string) (*btcjson.BLSKeyResult, error) {
+	return c.BLSFromSecretAsync(secret).Receive()
+}
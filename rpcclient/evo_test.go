@@ -0,0 +1,553 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godash/wire"
+)
+
+// TestGetBestChainLockReceive ensures the getbestchainlock replies, including
+// the error returned by servers which don't know about any chainlock yet, are
+// handled as expected.
+func TestGetBestChainLockReceive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resp     *response
+		expected *btcjson.GetBestChainLockResult
+		wantErr  bool
+	}{
+		{
+			name: "known chainlock",
+			resp: &response{
+				result: []byte(`{"blockhash":"00000000000000112e41e4b3afda8b233b8cc07c532d2eac5de097b68358c43e",` +
+					`"height":1001230,"signature":"8b0d9b2e","known_block":true}`),
+			},
+			expected: &btcjson.GetBestChainLockResult{
+				BlockHash:  "00000000000000112e41e4b3afda8b233b8cc07c532d2eac5de097b68358c43e",
+				Height:     1001230,
+				Signature:  "8b0d9b2e",
+				KnownBlock: true,
+				Known:      true,
+			},
+		},
+		{
+			name: "no chainlock known",
+			resp: &response{
+				err: &btcjson.RPCError{
+					Code:    btcjson.ErrRPCInternal.Code,
+					Message: "Unable to find any ChainLock",
+				},
+			},
+			expected: &btcjson.GetBestChainLockResult{},
+		},
+		{
+			name: "other error",
+			resp: &response{
+				err: &btcjson.RPCError{
+					Code:    btcjson.ErrRPCInternal.Code,
+					Message: "Internal error",
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		future := make(FutureGetBestChainLockResult, 1)
+		future <- test.resp
+		result, err := future.Receive()
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Test #%d (%s) expected error", i, test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Test #%d (%s) unexpected result - got %+v, "+
+				"want %+v", i, test.name, result, test.expected)
+			continue
+		}
+	}
+}
+
+// TestBLSKeyReceive ensures the replies to the bls generate and bls fromsecret
+// commands are only accepted with keys of the expected sizes.
+func TestBLSKeyReceive(t *testing.T) {
+	t.Parallel()
+
+	const (
+		secret = "52f35cd3d977a505485f2474e7e71ef3f60f859603d72ad6b0fa7f7bd163e144"
+		public = "885d01d1c5a4ed6e8a4b8e48b0af1a4a0c5b2a6a0a2f1e6a9d6eb64c51b4b7c8" +
+			"e4e2bd6c2cf1a1f9b5b01f367f7a1d6e"
+	)
+
+	tests := []struct {
+		name    string
+		result  string
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			result: `{"secret":"` + secret + `","public":"` + public + `"}`,
+		},
+		{
+			name:    "short secret",
+			result:  `{"secret":"` + secret[2:] + `","public":"` + public + `"}`,
+			wantErr: true,
+		},
+		{
+			name:    "short public",
+			result:  `{"secret":"` + secret + `","public":"` + public[2:] + `"}`,
+			wantErr: true,
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		future := make(FutureBLSKeyResult, 1)
+		future <- &response{result: []byte(test.result)}
+		key, err := future.Receive()
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Test #%d (%s) expected error", i, test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if len(key.Secret) != 64 || len(key.Public) != 96 {
+			t.Errorf("Test #%d (%s) unexpected key lengths - got "+
+				"%d/%d, want 64/96", i, test.name,
+				len(key.Secret), len(key.Public))
+			continue
+		}
+	}
+}
+
+// TestIsChainLocked ensures blocks are reported as locked when they are the
+// best chainlock or part of the main chain below it, and that the header is
+// only fetched when needed.
+func TestIsChainLocked(t *testing.T) {
+	t.Parallel()
+
+	const (
+		lockedHash  = "000000000000000e12c6e3f7a7a6b2e0b9452a2ec9e561a2dda6d6d0ce7a9d04"
+		belowHash   = "0000000000000005f6a97e4c8c3dbf84fd0b31d17e0d6ed110bfb3e751d2e9a1"
+		aboveHash   = "000000000000001463cbc8b69e1e11ddb1f77a2218e845a5701a6ba2b01b3e41"
+		orphanHash  = "00000000000000047e5b6c2eecd8ac8e0d9a7bfcddf9d36d5fa7bdfc48e2f0c3"
+		lockedBlock = 1520000
+	)
+	headers := map[string]string{
+		belowHash:  `{"hash":"` + belowHash + `","confirmations":11,"height":1519990,"chainlock":false}`,
+		aboveHash:  `{"hash":"` + aboveHash + `","confirmations":1,"height":1520001,"chainlock":false}`,
+		orphanHash: `{"hash":"` + orphanHash + `","confirmations":-1,"height":1519990,"chainlock":false}`,
+	}
+
+	var mtx sync.Mutex
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		mtx.Lock()
+		methods = append(methods, req.Method)
+		mtx.Unlock()
+
+		switch req.Method {
+		case "getbestchainlock":
+			fmt.Fprintf(w, `{"result":{"blockhash":"%s","height":%d,`+
+				`"signature":"","known_block":true},"error":null,"id":1}`,
+				lockedHash, lockedBlock)
+		case "getblockheader":
+			var hash string
+			json.Unmarshal(req.Params[0], &hash)
+			fmt.Fprintf(w, `{"result":%s,"error":null,"id":1}`,
+				headers[hash])
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	tests := []struct {
+		name    string
+		hash    string
+		locked  bool
+		methods []string
+	}{
+		{
+			name:    "best chainlock",
+			hash:    lockedHash,
+			locked:  true,
+			methods: []string{"getbestchainlock"},
+		},
+		{
+			name:    "below best chainlock",
+			hash:    belowHash,
+			locked:  true,
+			methods: []string{"getbestchainlock", "getblockheader"},
+		},
+		{
+			name:    "above best chainlock",
+			hash:    aboveHash,
+			methods: []string{"getbestchainlock", "getblockheader"},
+		},
+		{
+			name:    "not in main chain",
+			hash:    orphanHash,
+			methods: []string{"getbestchainlock", "getblockheader"},
+		},
+	}
+
+	for _, test := range tests {
+		mtx.Lock()
+		methods = nil
+		mtx.Unlock()
+
+		hash, err := chainhash.NewHashFromStr(test.hash)
+		if err != nil {
+			t.Fatalf("%s: NewHashFromStr: unexpected error: %v",
+				test.name, err)
+		}
+		locked, err := client.IsChainLocked(hash)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if locked != test.locked {
+			t.Errorf("%s: got locked %v, want %v", test.name, locked,
+				test.locked)
+		}
+
+		mtx.Lock()
+		if strings.Join(methods, ",") != strings.Join(test.methods, ",") {
+			t.Errorf("%s: unexpected methods - got %v, want %v",
+				test.name, methods, test.methods)
+		}
+		mtx.Unlock()
+	}
+}
+
+// TestGetProTxDiff ensures the protx diff request identifies the blocks by
+// height and the returned entries decode into the wire representation of the
+// simplified masternode list.
+func TestGetProTxDiff(t *testing.T) {
+	t.Parallel()
+
+	var params []json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		if req.Method != "protx" {
+			t.Errorf("unexpected method %q", req.Method)
+		}
+		params = req.Params
+
+		fmt.Fprintf(w, `{"result":{"baseBlockHash":"00","blockHash":"01",`+
+			`"cbTxMerkleTree":"0100","cbTx":"0300050001","deletedMNs":["02"],`+
+			`"mnList":[{"proRegTxHash":"%064x","confirmedHash":"%064x",`+
+			`"service":"10.0.0.1:9999","pubKeyOperator":"%s",`+
+			`"votingAddress":"XfAwsaqGbWag9Gz4fZLS3r9Fa4bWryXaoh","isValid":true}],`+
+			`"deletedQuorums":[],"newQuorums":[],`+
+			`"merkleRootMNList":"f0dd3e3dc4c297ed9ebb590fb80724f8b0dc49ddc278d59b6bbb6ea4e44a9732"},`+
+			`"error":null,"id":1}`, 0x01, 0x11, strings.Repeat("21", 48))
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	diff, err := client.GetProTxDiff(1000000, 1000100)
+	if err != nil {
+		t.Fatalf("GetProTxDiff: unexpected error: %v", err)
+	}
+	wantParams := []string{`"diff"`, `1000000`, `1000100`}
+	if len(params) != len(wantParams) {
+		t.Fatalf("got %d params, want %d", len(params), len(wantParams))
+	}
+	for i, param := range params {
+		if string(param) != wantParams[i] {
+			t.Errorf("param #%d: got %s, want %s", i, param,
+				wantParams[i])
+		}
+	}
+	if !reflect.DeepEqual(diff.DeletedMNs, []string{"02"}) || len(diff.MNList) != 1 {
+		t.Fatalf("unexpected diff %+v", diff)
+	}
+
+	entries, err := DecodeSMLEntries(diff.MNList)
+	if err != nil {
+		t.Fatalf("DecodeSMLEntries: unexpected error: %v", err)
+	}
+	root, err := chainhash.NewHashFromStr(diff.MerkleRootMNList)
+	if err != nil {
+		t.Fatalf("NewHashFromStr: unexpected error: %v", err)
+	}
+	if err := wire.VerifySMLEntryProof(entries, *root); err != nil {
+		t.Errorf("VerifySMLEntryProof: %v", err)
+	}
+
+	// Entries of servers which don't report the version are legacy BLS
+	// entries.
+	if entries[0].Version != wire.ProTxVersionLegacyBLS {
+		t.Errorf("DecodeSMLEntries: got version %d, want %d",
+			entries[0].Version, wire.ProTxVersionLegacyBLS)
+	}
+
+	// The platform fields of Evo masternodes are decoded with the node id
+	// in wire byte order.
+	evo := diff.MNList[0]
+	evo.Version = wire.ProTxVersionBasicBLS
+	evo.Type = wire.MasternodeTypeEvo
+	evo.PlatformHTTPPort = 443
+	evo.PlatformNodeID = "00000000000000000000000000000000000000aa"
+	evoEntries, err := DecodeSMLEntries([]btcjson.SMLEntryResult{evo})
+	if err != nil {
+		t.Fatalf("DecodeSMLEntries evo: unexpected error: %v", err)
+	}
+	got := evoEntries[0]
+	if got.Version != wire.ProTxVersionBasicBLS ||
+		got.Type != wire.MasternodeTypeEvo ||
+		got.PlatformHTTPPort != 443 || got.PlatformNodeID[0] != 0xaa {
+
+		t.Errorf("DecodeSMLEntries evo: unexpected entry %+v", got)
+	}
+
+	// Entries with malformed fields must be rejected.
+	invalid := []func(*btcjson.SMLEntryResult){
+		func(e *btcjson.SMLEntryResult) { e.ProRegTxHash = "zz" },
+		func(e *btcjson.SMLEntryResult) { e.Service = "10.0.0.1" },
+		func(e *btcjson.SMLEntryResult) { e.Service = "10.0.0.1:99999" },
+		func(e *btcjson.SMLEntryResult) { e.PubKeyOperator = "21" },
+		func(e *btcjson.SMLEntryResult) { e.VotingAddress = "Xbad" },
+		func(e *btcjson.SMLEntryResult) {
+			e.Type = wire.MasternodeTypeEvo
+			e.PlatformNodeID = "0102"
+		},
+	}
+	for i, modify := range invalid {
+		entry := diff.MNList[0]
+		modify(&entry)
+		if _, err := DecodeSMLEntries([]btcjson.SMLEntryResult{entry}); err == nil {
+			t.Errorf("#%d: DecodeSMLEntries: expected error", i)
+		}
+	}
+}
+
+// TestGetQuorumRotationInfo ensures the quorum rotationinfo request passes the
+// base block hashes as trailing parameters and the reply is decoded into the
+// snapshot and masternode list diff types.
+func TestGetQuorumRotationInfo(t *testing.T) {
+	t.Parallel()
+
+	const (
+		requestHash = "0000000000000010a7bcd3a8b3e5bba4c5b0d6c3f4bd1e209a1d1eb3fd1c97a4"
+		baseHash    = "00000ffd590b1485b3caadc19b22e6379c733355108f107a430458cdf3407ab6"
+	)
+	diff := `{"baseBlockHash":"` + baseHash + `","blockHash":"` + requestHash + `",` +
+		`"cbTxMerkleTree":"0100","cbTx":"0300050001","deletedMNs":[],` +
+		`"mnList":[{"proRegTxHash":"1a2b","confirmedHash":"3c4d",` +
+		`"service":"1.2.3.4:9999","pubKeyOperator":"8e5b","votingAddress":"XbzWHkEWreorjSwo4A1JvoxgWEXzJBPdTy",` +
+		`"isValid":true}],"deletedQuorums":[{"llmqType":5,"quorumHash":"5e6f"}],` +
+		`"newQuorums":[],"merkleRootMNList":"7a8b","merkleRootQuorums":"9c0d"}`
+	snapshot := `{"activeQuorumMembers":[true,false,true],"mnSkipListMode":1,"mnSkipList":[2,5]}`
+
+	var params []json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		if req.Method != "quorum" {
+			t.Errorf("unexpected method %q", req.Method)
+		}
+		params = req.Params
+
+		fmt.Fprintf(w, `{"result":{"extraShare":false,`+
+			`"quorumSnapshotAtHMinusC":%[1]s,"quorumSnapshotAtHMinus2C":%[1]s,`+
+			`"quorumSnapshotAtHMinus3C":%[1]s,"mnListDiffTip":%[2]s,`+
+			`"mnListDiffH":%[2]s,"mnListDiffAtHMinusC":%[2]s,`+
+			`"mnListDiffAtHMinus2C":%[2]s,"mnListDiffAtHMinus3C":%[2]s,`+
+			`"lastCommitmentPerIndex":[{"version":2,"llmqType":5,`+
+			`"quorumHash":"5e6f","quorumIndex":3,"signersCount":50,`+
+			`"signers":"ff","validMembersCount":50,"validMembers":"ff",`+
+			`"quorumPublicKey":"aa","quorumVvecHash":"bb","quorumSig":"cc",`+
+			`"membersSig":"dd"}],"quorumSnapshotList":[],"mnListDiffList":[]},`+
+			`"error":null,"id":1}`, snapshot, diff)
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	blockRequestHash, err := chainhash.NewHashFromStr(requestHash)
+	if err != nil {
+		t.Fatalf("NewHashFromStr: unexpected error: %v", err)
+	}
+	baseBlockHash, err := chainhash.NewHashFromStr(baseHash)
+	if err != nil {
+		t.Fatalf("NewHashFromStr: unexpected error: %v", err)
+	}
+	info, err := client.GetQuorumRotationInfo(blockRequestHash, false,
+		[]*chainhash.Hash{baseBlockHash, baseBlockHash})
+	if err != nil {
+		t.Fatalf("GetQuorumRotationInfo: unexpected error: %v", err)
+	}
+
+	wantParams := []string{`"rotationinfo"`, `"` + requestHash + `"`, `false`,
+		`"` + baseHash + `"`, `"` + baseHash + `"`}
+	if len(params) != len(wantParams) {
+		t.Fatalf("got %d params, want %d", len(params), len(wantParams))
+	}
+	for i, param := range params {
+		if string(param) != wantParams[i] {
+			t.Errorf("param #%d: got %s, want %s", i, param,
+				wantParams[i])
+		}
+	}
+
+	wantSnapshot := btcjson.QuorumSnapshotResult{
+		ActiveQuorumMembers: []bool{true, false, true},
+		MnSkipListMode:      1,
+		MnSkipList:          []int32{2, 5},
+	}
+	if !reflect.DeepEqual(info.QuorumSnapshotAtHMinus3C, wantSnapshot) {
+		t.Errorf("unexpected snapshot - got %+v, want %+v",
+			info.QuorumSnapshotAtHMinus3C, wantSnapshot)
+	}
+	if info.QuorumSnapshotAtHMinus4C != nil || info.MnListDiffAtHMinus4C != nil {
+		t.Error("unexpected H-4C data without extra share")
+	}
+
+	wantDiff := btcjson.MnListDiffResult{
+		BaseBlockHash:  baseHash,
+		BlockHash:      requestHash,
+		CbTxMerkleTree: "0100",
+		CbTx:           "0300050001",
+		DeletedMNs:     []string{},
+		MNList: []btcjson.SMLEntryResult{{
+			ProRegTxHash:   "1a2b",
+			ConfirmedHash:  "3c4d",
+			Service:        "1.2.3.4:9999",
+			PubKeyOperator: "8e5b",
+			VotingAddress:  "XbzWHkEWreorjSwo4A1JvoxgWEXzJBPdTy",
+			IsValid:        true,
+		}},
+		DeletedQuorums: []btcjson.DeletedQuorumResult{{
+			LLMQType:   5,
+			QuorumHash: "5e6f",
+		}},
+		NewQuorums:        []btcjson.QuorumCommitmentResult{},
+		MerkleRootMNList:  "7a8b",
+		MerkleRootQuorums: "9c0d",
+	}
+	if !reflect.DeepEqual(info.MnListDiffTip, wantDiff) {
+		t.Errorf("unexpected diff - got %+v, want %+v",
+			info.MnListDiffTip, wantDiff)
+	}
+	if len(info.LastCommitmentPerIndex) != 1 ||
+		info.LastCommitmentPerIndex[0].QuorumIndex != 3 {
+		t.Errorf("unexpected commitments %+v", info.LastCommitmentPerIndex)
+	}
+}
+
+// TestWaitForInstantLock ensures the server is polled until the transaction is
+// locked and that waiting stops once the context is done.
+func TestWaitForInstantLock(t *testing.T) {
+	t.Parallel()
+
+	const txid = "6d1d4c5e2e3bd0b4cd8785e0cbe3f6bbd5c5977ec3fd2d28b3b8b76c2682d0df"
+	var mtx sync.Mutex
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), txid) {
+			w.Write([]byte(`{"result":["None"],"error":null,"id":1}`))
+			return
+		}
+
+		mtx.Lock()
+		polls++
+		locked := polls >= 3
+		mtx.Unlock()
+
+		if !locked {
+			w.Write([]byte(`{"result":["None"],"error":null,"id":1}`))
+			return
+		}
+		fmt.Fprintf(w, `{"result":[{"txid":"%s","inputs":[],`+
+			`"signature":""}],"error":null,"id":1}`, txid)
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	hash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		t.Fatalf("NewHashFromStr: unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.WaitForInstantLock(ctx, hash, 10*time.Millisecond); err != nil {
+		t.Fatalf("WaitForInstantLock: unexpected error: %v", err)
+	}
+	mtx.Lock()
+	if polls != 3 {
+		t.Errorf("WaitForInstantLock: got %d polls, want 3", polls)
+	}
+	mtx.Unlock()
+
+	// Ensure waiting for a transaction which is never locked ends with
+	// the context error.
+	otherHash := chainhash.Hash{0x01}
+	ctx, cancel = context.WithTimeout(context.Background(),
+		50*time.Millisecond)
+	defer cancel()
+	err = client.WaitForInstantLock(ctx, &otherHash, 10*time.Millisecond)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("WaitForInstantLock: got error %v, want %v", err,
+			context.DeadlineExceeded)
+	}
+}
@@ -32,9 +32,17 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	hash,_:=client.GetBestBlockHash()
-	//a,_:=client.GetBlockStats(hash)
-	a,_:=client.GetBlockVerbose(hash)
-	log.Printf("block: %v",a)
 	log.Printf("Block count: %d", blockCount)
+
+	// Get the statistics of the best block.
+	hash, err := client.GetBestBlockHash()
+	if err != nil {
+		log.Fatal(err)
+	}
+	stats, err := client.GetBlockStats(hash)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Block %d: %d transactions, average fee rate %d duffs/byte",
+		stats.Height, stats.Txs, stats.AvgFeeRate)
 }
@@ -7,6 +7,7 @@ package rpcclient
 import (
 	"bytes"
 	"container/list"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
@@ -26,6 +27,7 @@ import (
 	"github.com/jiangjinyuan/godash/btcjson"
 	"github.com/btcsuite/go-socks/socks"
 	"github.com/btcsuite/websocket"
+	"github.com/nargott/godash/chaincfg/chainhash"
 )
 
 var (
@@ -69,6 +71,12 @@ var (
 	// client having already connected to the RPC server.
 	ErrClientAlreadyConnected = errors.New("websocket client has already " +
 		"connected")
+
+	// ErrNotHTTPPostClient is an error to describe the condition of
+	// calling a Client method that requires HTTP POST mode when the client
+	// has been configured to use websockets instead.
+	ErrNotHTTPPostClient = errors.New("client is not configured for " +
+		"HTTP POST mode")
 )
 
 const (
@@ -101,6 +109,10 @@ type jsonRequest struct {
 	cmd            interface{}
 	marshalledJSON []byte
 	responseChan   chan *response
+
+	// ctx is the context the request was issued with, if any.  It is
+	// attached to the underlying HTTP request in HTTP POST mode.
+	ctx context.Context
 }
 
 // Client represents a Bitcoin RPC client which allows easy access to the
@@ -129,6 +141,12 @@ type Client struct {
 	// POST mode.
 	httpClient *http.Client
 
+	// cookieMtx protects the credentials cached from the cookie file when
+	// the CookiePath option is set.
+	cookieMtx  sync.Mutex
+	cookieUser string
+	cookiePass string
+
 	// mtx is a mutex to protect access to connection related fields.
 	mtx sync.Mutex
 
@@ -139,6 +157,11 @@ type Client struct {
 	// reconnect to the RPC server.
 	retryCount int64
 
+	// dialectMtx protects the RPC dialect detected when the
+	// AutoDetectDialect option is set.
+	dialectMtx sync.Mutex
+	dialect    *rpcDialect
+
 	// Track command and their response channels by ID.
 	requestLock sync.Mutex
 	requestMap  map[uint64]*list.Element
@@ -149,6 +172,15 @@ type Client struct {
 	ntfnStateLock sync.Mutex
 	ntfnState     *notificationState
 
+	// islockWaiters tracks the channels of WaitForInstantLock calls by the
+	// hash of the transaction they wait for.
+	islockMtx     sync.Mutex
+	islockWaiters map[chainhash.Hash][]chan struct{}
+
+	// islockNtfnMtx serializes the registrations for instantsend lock
+	// notifications made by WaitForInstantLock calls.
+	islockNtfnMtx sync.Mutex
+
 	// Networking infrastructure.
 	sendChan        chan []byte
 	sendPostChan    chan *sendPostDetails
@@ -241,6 +273,12 @@ func (c *Client) trackRegisteredNtfns(cmd interface{}) {
 	case *btcjson.NotifyBlocksCmd:
 		c.ntfnState.notifyBlocks = true
 
+	case *btcjson.NotifyChainLocksCmd:
+		c.ntfnState.notifyChainLocks = true
+
+	case *btcjson.NotifyInstantSendLocksCmd:
+		c.ntfnState.notifyISLocks = true
+
 	case *btcjson.NotifyNewTransactionsCmd:
 		if bcmd.Verbose != nil && *bcmd.Verbose {
 			c.ntfnState.notifyNewTxVerbose = true
@@ -511,6 +549,22 @@ func (c *Client) reregisterNtfns() error {
 		}
 	}
 
+	// Reregister notifychainlocks if needed.
+	if stateCopy.notifyChainLocks {
+		log.Debugf("Reregistering [notifychainlocks]")
+		if err := c.NotifyChainLocks(); err != nil {
+			return err
+		}
+	}
+
+	// Reregister notifyinstantsendlocks if needed.
+	if stateCopy.notifyISLocks {
+		log.Debugf("Reregistering [notifyinstantsendlocks]")
+		if err := c.NotifyInstantSendLocks(); err != nil {
+			return err
+		}
+	}
+
 	// Reregister notifynewtransactions if needed.
 	if stateCopy.notifyNewTx || stateCopy.notifyNewTxVerbose {
 		log.Debugf("Reregistering [notifynewtransactions] (verbose=%v)",
@@ -684,13 +738,38 @@ out:
 	log.Tracef("RPC client reconnect handler done for %s", c.config.Host)
 }
 
+// doPost performs the passed HTTP POST request carrying the passed body.  When
+// the credentials were read from a cookie file and the server rejects them, the
+// cookie file is read again and the request is retried once since dashd
+// rotates the cookie on every restart.
+func (c *Client) doPost(httpReq *http.Request, body []byte) (*http.Response, error) {
+	httpResponse, err := c.httpClient.Do(httpReq)
+	if err != nil || c.config.CookiePath == "" ||
+		httpResponse.StatusCode != http.StatusUnauthorized {
+
+		return httpResponse, err
+	}
+	httpResponse.Body.Close()
+
+	log.Debugf("Server rejected cookie credentials, reloading %s",
+		c.config.CookiePath)
+	c.invalidateCookie()
+	retryReq, err := c.newPostRequest(body)
+	if err != nil {
+		return nil, err
+	}
+	retryReq = retryReq.WithContext(httpReq.Context())
+	return c.httpClient.Do(retryReq)
+}
+
 // handleSendPostMessage handles performing the passed HTTP request, reading the
 // result, unmarshalling it, and delivering the unmarshalled result to the
 // provided response channel.
 func (c *Client) handleSendPostMessage(details *sendPostDetails) {
 	jReq := details.jsonRequest
 	log.Tracef("Sending command [%s] with id %d", jReq.method, jReq.id)
-	httpResponse, err := c.httpClient.Do(details.httpRequest)
+	httpResponse, err := c.doPostRetry(details.httpRequest,
+		jReq.marshalledJSON, isRetryable(jReq))
 	if err != nil {
 		jReq.responseChan <- &response{err: err}
 		return
@@ -796,29 +875,43 @@ func receiveFuture(f chan *response) ([]byte, error) {
 	return r.result, r.err
 }
 
-// sendPost sends the passed request to the server by issuing an HTTP POST
-// request using the provided response channel for the reply.  Typically a new
-// connection is opened and closed for each command when using this method,
-// however, the underlying HTTP client might coalesce multiple commands
-// depending on several factors including the remote server configuration.
-func (c *Client) sendPost(jReq *jsonRequest) {
-	// Generate a request to the configured RPC server.
+// newPostRequest generates an HTTP POST request to the configured RPC server
+// carrying the passed marshalled JSON body.
+func (c *Client) newPostRequest(body []byte) (*http.Request, error) {
 	protocol := "http"
 	if !c.config.DisableTLS {
 		protocol = "https"
 	}
 	url := protocol + "://" + c.config.Host
-	bodyReader := bytes.NewReader(jReq.marshalledJSON)
-	httpReq, err := http.NewRequest("POST", url, bodyReader)
+	httpReq, err := http.NewRequest("POST", url, bytes.NewReader(body))
 	if err != nil {
-		jReq.responseChan <- &response{result: nil, err: err}
-		return
+		return nil, err
 	}
-	httpReq.Close = true
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	// Configure basic access authorization.
-	httpReq.SetBasicAuth(c.config.User, c.config.Pass)
+	user, pass, err := c.postAuth()
+	if err != nil {
+		return nil, err
+	}
+	httpReq.SetBasicAuth(user, pass)
+
+	return httpReq, nil
+}
+
+// sendPost sends the passed request to the server by issuing an HTTP POST
+// request using the provided response channel for the reply.  Connections to
+// the server are kept alive and reused for subsequent commands, subject to the
+// idle connection limits in the connection configuration.
+func (c *Client) sendPost(jReq *jsonRequest) {
+	httpReq, err := c.newPostRequest(jReq.marshalledJSON)
+	if err != nil {
+		jReq.responseChan <- &response{result: nil, err: err}
+		return
+	}
+	if jReq.ctx != nil {
+		httpReq = httpReq.WithContext(jReq.ctx)
+	}
 
 	log.Tracef("Sending command [%s] with id %d", jReq.method, jReq.id)
 	c.sendPostRequest(httpReq, jReq)
@@ -858,11 +951,80 @@ func (c *Client) sendRequest(jReq *jsonRequest) {
 	c.sendMessage(jReq.marshalledJSON)
 }
 
+// sendRequestCtx sends the passed json request to the associated server and
+// returns a channel on which the reply will be delivered.  When the context is
+// canceled or its deadline expires before the reply arrives, the request is
+// abandoned and the context error is delivered on the channel instead.
+func (c *Client) sendRequestCtx(ctx context.Context, jReq *jsonRequest) chan *response {
+	jReq.ctx = ctx
+	responseChan := jReq.responseChan
+
+	// There is nothing to watch for contexts that can never be canceled.
+	if ctx.Done() == nil {
+		c.sendRequest(jReq)
+		return responseChan
+	}
+	if err := ctx.Err(); err != nil {
+		return newFutureError(err)
+	}
+
+	// Route the reply through an intermediate channel so the caller can be
+	// released as soon as the context is done, even when the request is
+	// still queued or in flight.
+	replyChan := make(chan *response, 1)
+	jReq.responseChan = replyChan
+	c.sendRequest(jReq)
+
+	go func() {
+		select {
+		case reply := <-replyChan:
+			responseChan <- reply
+
+		case <-ctx.Done():
+			// Stop tracking websocket requests so a late reply
+			// from the server is ignored.
+			c.removeRequest(jReq.id)
+			responseChan <- &response{err: ctx.Err()}
+		}
+	}()
+
+	return responseChan
+}
+
+// sendObservedRequestCtx is the same as sendRequestCtx except the request and
+// its reply are reported to the OnRequest and OnResponse hooks of the
+// connection configuration when they are set.
+func (c *Client) sendObservedRequestCtx(ctx context.Context, jReq *jsonRequest) chan *response {
+	if c.config.OnRequest != nil {
+		c.config.OnRequest(jReq.method)
+	}
+	if c.config.OnResponse == nil {
+		return c.sendRequestCtx(ctx, jReq)
+	}
+
+	start := time.Now()
+	replyChan := c.sendRequestCtx(ctx, jReq)
+	responseChan := make(chan *response, 1)
+	go func() {
+		reply := <-replyChan
+		c.config.OnResponse(jReq.method, time.Since(start), reply.err)
+		responseChan <- reply
+	}()
+	return responseChan
+}
+
 // sendCmd sends the passed command to the associated server and returns a
 // response channel on which the reply will be delivered at some point in the
 // future.  It handles both websocket and HTTP POST mode depending on the
 // configuration of the client.
 func (c *Client) sendCmd(cmd interface{}) chan *response {
+	return c.sendCmdCtx(context.Background(), cmd)
+}
+
+// sendCmdCtx is the same as sendCmd except the request is bound to the passed
+// context.  Cancelling the context aborts the request and delivers the context
+// error on the returned channel.
+func (c *Client) sendCmdCtx(ctx context.Context, cmd interface{}) chan *response {
 	// Get the method associated with the command.
 	method, err := btcjson.CmdMethod(cmd)
 	if err != nil {
@@ -885,9 +1047,8 @@ func (c *Client) sendCmd(cmd interface{}) chan *response {
 		marshalledJSON: marshalledJSON,
 		responseChan:   responseChan,
 	}
-	c.sendRequest(jReq)
 
-	return responseChan
+	return c.sendObservedRequestCtx(ctx, jReq)
 }
 
 // sendCmdAndWait sends the passed command to the associated server, waits
@@ -1063,6 +1224,13 @@ type ConnConfig struct {
 	// Pass is the passphrase to use to authenticate to the RPC server.
 	Pass string
 
+	// CookiePath is the path to the authentication cookie file dashd
+	// writes when no rpcuser is configured.  When set, the credentials are
+	// read from the file instead of User and Pass, and the file is read
+	// again whenever the server rejects them since dashd rotates the cookie
+	// on every restart.
+	CookiePath string
+
 	// DisableTLS specifies whether transport layer security should be
 	// disabled.  It is recommended to always use TLS if the RPC server
 	// supports it as otherwise your username and password is sent across
@@ -1106,13 +1274,78 @@ type ConnConfig struct {
 	// flag can be set to true to use basic HTTP POST requests instead.
 	HTTPPostMode bool
 
+	// MaxRetries is the number of times a request is retried in HTTP POST
+	// mode when the server can't be reached or replies with 503 Service
+	// Unavailable, such as while the node is restarting.  Only read-only
+	// commands such as getblockcount are retried; commands which change
+	// server, chain, or wallet state, like sendrawtransaction, are never
+	// retried.  The default of zero disables retries.
+	MaxRetries int
+
+	// RetryBackoff is the delay before the first retry.  It is doubled for
+	// every subsequent retry up to a maximum of one minute.  It defaults
+	// to one second when MaxRetries is set.
+	RetryBackoff time.Duration
+
+	// MaxIdleConns is the maximum number of idle connections to the server
+	// kept open for reuse in HTTP POST mode.  It defaults to 100.  Raising
+	// it avoids the churn of opening a new connection for every request
+	// when many requests are issued concurrently.
+	MaxIdleConns int
+
+	// MaxConnsPerHost limits the total number of connections to the server
+	// in HTTP POST mode, including those in use.  Requests wait for a
+	// connection to become available once the limit is reached.  The
+	// default of zero means no limit.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open for reuse
+	// in HTTP POST mode.  It defaults to 90 seconds.
+	IdleConnTimeout time.Duration
+
+	// AutoDetectDialect queries the version of the server with
+	// getnetworkinfo on the first use of a command whose name differs
+	// between dashd versions, such as coinjoin which older versions call
+	// privatesend, and sends the name the server understands from then on.
+	// The detected dialect is cached for the lifetime of the client.
+	//
+	// When it is not set, or the version could not be queried, the current
+	// names are sent first and the older names are only tried when the
+	// server does not know the method.
+	AutoDetectDialect bool
+
+	// OnRequest is an optional callback invoked with the method name of
+	// every request just before it is sent to the server.
+	OnRequest func(method string)
+
+	// OnResponse is an optional callback invoked with the method name of
+	// every request, the time it took for its reply to arrive and the
+	// error of the reply, which is nil on success, before the reply is
+	// delivered to the caller.  It is also invoked for requests which fail
+	// without reaching the server, such as when the client is not
+	// connected.  Callbacks must not block since they run on the path of
+	// the reply.
+	//
+	// Both callbacks apply to HTTP POST as well as websocket mode and may
+	// be invoked concurrently from multiple goroutines.  Commands sent
+	// through a BatchClient are not reported.
+	OnResponse func(method string, duration time.Duration, err error)
+
 	// EnableBCInfoHacks is an option provided to enable compatiblity hacks
 	// when connecting to blockchain.info RPC server
 	EnableBCInfoHacks bool
 }
 
+// These constants define the default connection pooling settings of the
+// transport used in HTTP POST mode.
+const (
+	defaultMaxIdleConns    = 100
+	defaultIdleConnTimeout = 90 * time.Second
+)
+
 // newHTTPClient returns a new http client that is configured according to the
-// proxy and TLS settings in the associated connection configuration.
+// proxy, TLS and connection pooling settings in the associated connection
+// configuration.
 func newHTTPClient(config *ConnConfig) (*http.Client, error) {
 	// Set proxy function if there is a proxy configured.
 	var proxyFunc func(*http.Request) (*url.URL, error)
@@ -1136,10 +1369,25 @@ func newHTTPClient(config *ConnConfig) (*http.Client, error) {
 		}
 	}
 
+	maxIdleConns := config.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = defaultMaxIdleConns
+	}
+	idleConnTimeout := config.IdleConnTimeout
+	if idleConnTimeout <= 0 {
+		idleConnTimeout = defaultIdleConnTimeout
+	}
+
+	// All requests go to the same host, so the per host idle limit, which
+	// otherwise defaults to a mere two connections, is the overall limit.
 	client := http.Client{
 		Transport: &http.Transport{
-			Proxy:           proxyFunc,
-			TLSClientConfig: tlsConfig,
+			Proxy:               proxyFunc,
+			TLSClientConfig:     tlsConfig,
+			MaxIdleConns:        maxIdleConns,
+			MaxIdleConnsPerHost: maxIdleConns,
+			MaxConnsPerHost:     config.MaxConnsPerHost,
+			IdleConnTimeout:     idleConnTimeout,
 		},
 	}
 
@@ -1180,7 +1428,11 @@ func dial(config *ConnConfig) (*websocket.Conn, error) {
 
 	// The RPC server requires basic authorization, so create a custom
 	// request header with the Authorization header set.
-	login := config.User + ":" + config.Pass
+	user, pass, err := config.getAuth()
+	if err != nil {
+		return nil, err
+	}
+	login := user + ":" + pass
 	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
 	requestHeader := make(http.Header)
 	requestHeader.Add("Authorization", auth)
@@ -1234,6 +1486,14 @@ func New(config *ConnConfig, ntfnHandlers *NotificationHandlers) (*Client, error
 		if err != nil {
 			return nil, err
 		}
+
+		// Ensure the cookie file can be read up front so a bad path
+		// is reported when the client is created.
+		if config.CookiePath != "" {
+			if _, _, err := config.getAuth(); err != nil {
+				return nil, err
+			}
+		}
 	} else {
 		if !config.DisableConnectOnNew {
 			var err error
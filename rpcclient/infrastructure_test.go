@@ -0,0 +1,237 @@
+// Copyright (c) 2014-2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestPostClient returns an HTTP POST mode client connected to the passed
+// test server.
+func newTestPostClient(t *testing.T, srv *httptest.Server) *Client {
+	client, err := New(&ConnConfig{
+		Host:         strings.TrimPrefix(srv.URL, "http://"),
+		User:         "user",
+		Pass:         "pass",
+		HTTPPostMode: true,
+		DisableTLS:   true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("New: unexpected error: %v", err)
+	}
+	return client
+}
+
+// TestSendCmdCtx ensures requests bound to a context are delivered normally
+// and are aborted with the context error once the context is done.
+func TestSendCmdCtx(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":1234,"error":null,"id":1}`))
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	count, err := client.GetBlockCountCtx(context.Background())
+	if err != nil {
+		t.Fatalf("GetBlockCountCtx: unexpected error: %v", err)
+	}
+	if count != 1234 {
+		t.Fatalf("GetBlockCountCtx: got %d, want 1234", count)
+	}
+
+	// A context that is already canceled must fail without a round trip.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetBlockCountCtx(ctx); err != context.Canceled {
+		t.Fatalf("GetBlockCountCtx: got error %v, want %v", err,
+			context.Canceled)
+	}
+
+	// A request the server never answers must be aborted once the
+	// deadline expires.
+	release := make(chan struct{})
+	blockingSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer blockingSrv.Close()
+	defer close(release)
+
+	blockingClient := newTestPostClient(t, blockingSrv)
+	defer blockingClient.Shutdown()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := blockingClient.GetBlockCountCtx(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("GetBlockCountCtx: got error %v, want %v", err,
+			context.DeadlineExceeded)
+	}
+}
+
+// TestPostConnReuse ensures sequential requests in HTTP POST mode reuse a
+// single kept alive connection instead of opening one per request.
+func TestPostConnReuse(t *testing.T) {
+	t.Parallel()
+
+	var newConns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":1234,"error":null,"id":1}`))
+	}))
+	srv.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&newConns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetBlockCount(); err != nil {
+			t.Fatalf("GetBlockCount #%d: unexpected error: %v", i, err)
+		}
+	}
+	if n := atomic.LoadInt32(&newConns); n != 1 {
+		t.Fatalf("unexpected number of connections - got %d, want 1", n)
+	}
+}
+
+// TestNewHTTPClientPooling ensures the connection pooling settings of the
+// connection configuration, or their defaults, are applied to the transport.
+func TestNewHTTPClientPooling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		config          ConnConfig
+		maxIdleConns    int
+		maxConnsPerHost int
+		idleConnTimeout time.Duration
+	}{
+		{
+			name:            "defaults",
+			maxIdleConns:    defaultMaxIdleConns,
+			idleConnTimeout: defaultIdleConnTimeout,
+		},
+		{
+			name: "configured",
+			config: ConnConfig{
+				MaxIdleConns:    500,
+				MaxConnsPerHost: 600,
+				IdleConnTimeout: time.Minute,
+			},
+			maxIdleConns:    500,
+			maxConnsPerHost: 600,
+			idleConnTimeout: time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		test.config.DisableTLS = true
+		client, err := newHTTPClient(&test.config)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		transport := client.Transport.(*http.Transport)
+		if transport.DisableKeepAlives {
+			t.Errorf("%s: keep-alives are disabled", test.name)
+		}
+		if transport.MaxIdleConns != test.maxIdleConns ||
+			transport.MaxIdleConnsPerHost != test.maxIdleConns {
+
+			t.Errorf("%s: unexpected idle connection limits - got "+
+				"%d/%d, want %d", test.name, transport.MaxIdleConns,
+				transport.MaxIdleConnsPerHost, test.maxIdleConns)
+		}
+		if transport.MaxConnsPerHost != test.maxConnsPerHost {
+			t.Errorf("%s: unexpected connection limit - got %d, "+
+				"want %d", test.name, transport.MaxConnsPerHost,
+				test.maxConnsPerHost)
+		}
+		if transport.IdleConnTimeout != test.idleConnTimeout {
+			t.Errorf("%s: unexpected idle timeout - got %v, want %v",
+				test.name, transport.IdleConnTimeout,
+				test.idleConnTimeout)
+		}
+	}
+}
+
+// TestRequestHooks ensures the OnRequest and OnResponse hooks are invoked with
+// the method name and the outcome of every request.
+func TestRequestHooks(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(body), `"getblockcount"`) {
+			w.Write([]byte(`{"result":1234,"error":null,"id":1}`))
+			return
+		}
+		w.Write([]byte(`{"result":null,"error":{"code":-32601,` +
+			`"message":"Method not found"},"id":1}`))
+	}))
+	defer srv.Close()
+
+	var requests, responses []string
+	var errs []error
+	client, err := New(&ConnConfig{
+		Host:         strings.TrimPrefix(srv.URL, "http://"),
+		User:         "user",
+		Pass:         "pass",
+		HTTPPostMode: true,
+		DisableTLS:   true,
+		OnRequest: func(method string) {
+			requests = append(requests, method)
+		},
+		OnResponse: func(method string, duration time.Duration, err error) {
+			if duration <= 0 {
+				t.Errorf("OnResponse: unexpected duration %v for %s",
+					duration, method)
+			}
+			responses = append(responses, method)
+			errs = append(errs, err)
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("New: unexpected error: %v", err)
+	}
+	defer client.Shutdown()
+
+	if _, err := client.GetBlockCount(); err != nil {
+		t.Fatalf("GetBlockCount: unexpected error: %v", err)
+	}
+	if _, err := client.RawRequest("getfoo", nil); err == nil {
+		t.Fatal("RawRequest: expected error")
+	}
+
+	want := []string{"getblockcount", "getfoo"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("OnRequest: got methods %v, want %v", requests, want)
+	}
+	if !reflect.DeepEqual(responses, want) {
+		t.Errorf("OnResponse: got methods %v, want %v", responses, want)
+	}
+	if len(errs) != 2 || errs[0] != nil || errs[1] == nil {
+		t.Errorf("OnResponse: unexpected errors %v", errs)
+	}
+}
@@ -414,4 +414,41 @@ func (c *Client) SubmitBlock(block *godashutil.Block, options *btcjson.SubmitBlo
 	return c.SubmitBlockAsync(block, options).Receive()
 }
 
-// TODO(davec): Implement GetBlockTemplate
+// FutureGetBlockTemplateResult is a future promise to deliver the result of a
+// GetBlockTemplateAsync RPC invocation (or an applicable error).
+type FutureGetBlockTemplateResult chan *response
+
+// Receive waits for the response promised by the future and returns the block
+// template, including the Dash masternode and superblock payments the coinbase
+// transaction must pay.
+func (r FutureGetBlockTemplateResult) Receive() (*btcjson.GetBlockTemplateResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getblocktemplate result object.
+	var template btcjson.GetBlockTemplateResult
+	err = json.Unmarshal(res, &template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// GetBlockTemplateAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetBlockTemplate for the blocking version and more details.
+func (c *Client) GetBlockTemplateAsync(request *btcjson.TemplateRequest) FutureGetBlockTemplateResult {
+	cmd := btcjson.NewGetBlockTemplateCmd(request)
+	return c.sendCmd(cmd)
+}
+
+// GetBlockTemplate returns a block template to mine on.  The request may be
+// nil to request a template using the server defaults.
+func (c *Client) GetBlockTemplate(request *btcjson.TemplateRequest) (*btcjson.GetBlockTemplateResult, error) {
+	return c.GetBlockTemplateAsync(request).Receive()
+}
@@ -6,6 +6,8 @@ package rpcclient
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/jiangjinyuan/godash/btcjson"
 )
@@ -50,6 +52,13 @@ func (r FutureAddNodeResult) Receive() error {
 //
 // See AddNode for the blocking version and more details.
 func (c *Client) AddNodeAsync(host string, command AddNodeCommand) FutureAddNodeResult {
+	switch command {
+	case ANAdd, ANRemove, ANOneTry:
+	default:
+		return newFutureError(fmt.Errorf("invalid addnode command %q",
+			command))
+	}
+
 	cmd := btcjson.NewAddNodeCmd(host, btcjson.AddNodeSubCmd(command))
 	return c.sendCmd(cmd)
 }
@@ -58,11 +67,50 @@ func (c *Client) AddNodeAsync(host string, command AddNodeCommand) FutureAddNode
 // For example, it can be used to add or a remove a persistent peer, or to do
 // a one time connection to a peer.
 //
-// It may not be used to remove non-persistent peers.
+// It may not be used to remove non-persistent peers.  Commands other than
+// ANAdd, ANRemove and ANOneTry are rejected without contacting the server.
 func (c *Client) AddNode(host string, command AddNodeCommand) error {
 	return c.AddNodeAsync(host, command).Receive()
 }
 
+// FutureDisconnectNodeResult is a future promise to deliver the result of a
+// DisconnectNodeAsync RPC invocation (or an applicable error).
+type FutureDisconnectNodeResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if
+// the peer could not be disconnected.
+func (r FutureDisconnectNodeResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// DisconnectNodeAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See DisconnectNode for the blocking version and more details.
+func (c *Client) DisconnectNodeAsync(addr string, nodeID int) FutureDisconnectNodeResult {
+	var cmd *btcjson.DisconnectNodeCmd
+	switch {
+	case addr != "":
+		cmd = btcjson.NewDisconnectNodeCmd(&addr, nil)
+	case nodeID >= 0:
+		cmd = btcjson.NewDisconnectNodeCmd(btcjson.String(""), &nodeID)
+	default:
+		return newFutureError(errors.New("disconnectnode requires an " +
+			"address or a node id"))
+	}
+	return c.sendCmd(cmd)
+}
+
+// DisconnectNode immediately disconnects the peer with the passed address.
+// When the address is empty, the peer is identified by the passed node id
+// instead, which is the ID field of the peer in the GetPeerInfo results.  An
+// error is returned when there is no such peer.
+func (c *Client) DisconnectNode(addr string, nodeID int) error {
+	return c.DisconnectNodeAsync(addr, nodeID).Receive()
+}
+
 // FutureGetAddedNodeInfoResult is a future promise to deliver the result of a
 // GetAddedNodeInfoAsync RPC invocation (or an applicable error).
 type FutureGetAddedNodeInfoResult chan *response
@@ -242,7 +290,9 @@ func (c *Client) GetPeerInfoAsync() FutureGetPeerInfoResult {
 	return c.sendCmd(cmd)
 }
 
-// GetPeerInfo returns data about each connected network peer.
+// GetPeerInfo returns data about each connected network peer.  Connections
+// between masternodes are reported by dashd with the Masternode field set and
+// the identity of the authenticated masternode.
 func (c *Client) GetPeerInfo() ([]btcjson.GetPeerInfoResult, error) {
 	return c.GetPeerInfoAsync().Receive()
 }
@@ -283,3 +333,45 @@ func (c *Client) GetNetTotalsAsync() FutureGetNetTotalsResult {
 func (c *Client) GetNetTotals() (*btcjson.GetNetTotalsResult, error) {
 	return c.GetNetTotalsAsync().Receive()
 }
+
+// FutureGetNetworkInfoResult is a future promise to deliver the result of a
+// GetNetworkInfoAsync RPC invocation (or an applicable error).
+type FutureGetNetworkInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns data about
+// the current network state of the server.
+func (r FutureGetNetworkInfoResult) Receive() (*btcjson.GetNetworkInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getnetworkinfo result object.
+	var networkInfo btcjson.GetNetworkInfoResult
+	err = json.Unmarshal(res, &networkInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &networkInfo, nil
+}
+
+// GetNetworkInfoAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetNetworkInfo for the blocking version and more details.
+func (c *Client) GetNetworkInfoAsync() FutureGetNetworkInfoResult {
+	cmd := btcjson.NewGetNetworkInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetNetworkInfo returns data about the current network state of the server,
+// such as its version, subversion, protocol version, connection count,
+// networks, relay fee, local addresses and warnings.
+//
+// The version is the numeric node version, for example 180000 for dashd
+// v0.18.0, and may be used to decide which RPC dialect the server speaks.
+func (c *Client) GetNetworkInfo() (*btcjson.GetNetworkInfoResult, error) {
+	return c.GetNetworkInfoAsync().Receive()
+}
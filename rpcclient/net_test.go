@@ -0,0 +1,189 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/jiangjinyuan/godash/btcjson"
+)
+
+// TestGetNetworkInfoReceive ensures a getnetworkinfo reply from dashd is
+// decoded as expected.
+func TestGetNetworkInfoReceive(t *testing.T) {
+	t.Parallel()
+
+	future := make(FutureGetNetworkInfoResult, 1)
+	future <- &response{
+		result: []byte(`{"version":180200,"buildversion":"v18.2.0",` +
+			`"subversion":"/Dash Core:18.2.0/","protocolversion":70220,` +
+			`"localservices":"0000000000000405","localrelay":true,` +
+			`"timeoffset":0,"networkactive":true,"connections":8,` +
+			`"socketevents":"epoll","networks":[{"name":"ipv4",` +
+			`"limited":false,"reachable":true,"proxy":"",` +
+			`"proxy_randomize_credentials":false}],"relayfee":0.00001000,` +
+			`"incrementalfee":0.00001000,"localaddresses":[{"address":` +
+			`"203.0.113.5","port":9999,"score":4}],"warnings":""}`),
+	}
+
+	expected := &btcjson.GetNetworkInfoResult{
+		Version:         180200,
+		SubVersion:      "/Dash Core:18.2.0/",
+		ProtocolVersion: 70220,
+		LocalServices:   "0000000000000405",
+		LocalRelay:      true,
+		Connections:     8,
+		NetworkActive:   true,
+		Networks: []btcjson.NetworksResult{
+			{Name: "ipv4", Reachable: true},
+		},
+		RelayFee:       0.00001,
+		IncrementalFee: 0.00001,
+		LocalAddresses: []btcjson.LocalAddressesResult{
+			{Address: "203.0.113.5", Port: 9999, Score: 4},
+		},
+	}
+
+	info, err := future.Receive()
+	if err != nil {
+		t.Fatalf("Receive: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(info, expected) {
+		t.Fatalf("Receive: mismatched result - got %v, want %v",
+			spew.Sdump(info), spew.Sdump(expected))
+	}
+}
+
+// TestGetPeerInfoReceive ensures a getpeerinfo reply from dashd is decoded
+// with the masternode connection fields.
+func TestGetPeerInfoReceive(t *testing.T) {
+	t.Parallel()
+
+	future := make(FutureGetPeerInfoResult, 1)
+	future <- &response{
+		result: []byte(`[{"id":3,"addr":"203.0.113.7:9999",` +
+			`"services":"0000000000000405","relaytxes":true,` +
+			`"lastsend":1700000100,"lastrecv":1700000101,"bytessent":1024,` +
+			`"bytesrecv":2048,"conntime":1700000000,"timeoffset":0,` +
+			`"pingtime":0.052,"version":70220,"subver":"/Dash Core:18.2.0/",` +
+			`"inbound":false,"masternode":true,` +
+			`"verified_proregtx_hash":"2d8f5d9b34d36b874e5a0ac2d6c8e5dd31c69a1cd0d2cbb6a1fe9a9d1f6a3c4b",` +
+			`"verified_pubkey_hash":"5f1c3e2d8b7a6d4c9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d",` +
+			`"startingheight":1900000,"banscore":0},` +
+			`{"id":4,"addr":"198.51.100.2:51234","services":"0000000000000405",` +
+			`"conntime":1700000050,"pingtime":0.1,"version":70220,` +
+			`"subver":"/Dash Core:18.2.0/","inbound":true,"masternode":false}]`),
+	}
+
+	expected := []btcjson.GetPeerInfoResult{
+		{
+			ID:                   3,
+			Addr:                 "203.0.113.7:9999",
+			Services:             "0000000000000405",
+			RelayTxes:            true,
+			LastSend:             1700000100,
+			LastRecv:             1700000101,
+			BytesSent:            1024,
+			BytesRecv:            2048,
+			ConnTime:             1700000000,
+			PingTime:             0.052,
+			Version:              70220,
+			SubVer:               "/Dash Core:18.2.0/",
+			StartingHeight:       1900000,
+			Masternode:           true,
+			VerifiedProRegTxHash: "2d8f5d9b34d36b874e5a0ac2d6c8e5dd31c69a1cd0d2cbb6a1fe9a9d1f6a3c4b",
+			VerifiedPubKeyHash:   "5f1c3e2d8b7a6d4c9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d",
+		},
+		{
+			ID:       4,
+			Addr:     "198.51.100.2:51234",
+			Services: "0000000000000405",
+			ConnTime: 1700000050,
+			PingTime: 0.1,
+			Version:  70220,
+			SubVer:   "/Dash Core:18.2.0/",
+			Inbound:  true,
+		},
+	}
+
+	peers, err := future.Receive()
+	if err != nil {
+		t.Fatalf("Receive: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(peers, expected) {
+		t.Fatalf("Receive: mismatched result - got %v, want %v",
+			spew.Sdump(peers), spew.Sdump(expected))
+	}
+}
+
+// TestPeerControl ensures the addnode and disconnectnode requests carry the
+// expected parameters, invalid arguments are rejected without contacting the
+// server and RPC errors are returned.
+func TestPeerControl(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		params := make([]string, 0, len(req.Params))
+		for _, param := range req.Params {
+			params = append(params, string(param))
+		}
+		requests = append(requests, req.Method+" "+strings.Join(params, " "))
+
+		if req.Method == "disconnectnode" && string(req.Params[0]) == `"198.51.100.9:9999"` {
+			w.Write([]byte(`{"result":null,"error":{"code":-29,` +
+				`"message":"Node not found in connected nodes"},"id":1}`))
+			return
+		}
+		w.Write([]byte(`{"result":null,"error":null,"id":1}`))
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	if err := client.AddNode("203.0.113.7:9999", ANOneTry); err != nil {
+		t.Errorf("AddNode: unexpected error: %v", err)
+	}
+	if err := client.AddNode("203.0.113.7:9999", AddNodeCommand("connect")); err == nil {
+		t.Error("AddNode: expected error for invalid command")
+	}
+	if err := client.DisconnectNode("203.0.113.7:9999", -1); err != nil {
+		t.Errorf("DisconnectNode by address: unexpected error: %v", err)
+	}
+	if err := client.DisconnectNode("", 12); err != nil {
+		t.Errorf("DisconnectNode by node id: unexpected error: %v", err)
+	}
+	if err := client.DisconnectNode("", -1); err == nil {
+		t.Error("DisconnectNode: expected error without address or node id")
+	}
+	err := client.DisconnectNode("198.51.100.9:9999", -1)
+	if jerr, ok := err.(*btcjson.RPCError); !ok || jerr.Code != -29 {
+		t.Errorf("DisconnectNode: got error %v, want RPC error -29", err)
+	}
+
+	want := []string{
+		`addnode "203.0.113.7:9999" "onetry"`,
+		`disconnectnode "203.0.113.7:9999"`,
+		`disconnectnode "" 12`,
+		`disconnectnode "198.51.100.9:9999"`,
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("unexpected requests - got %v, want %v", requests, want)
+	}
+}
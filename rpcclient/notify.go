@@ -35,6 +35,8 @@ type notificationState struct {
 	notifyBlocks       bool
 	notifyNewTx        bool
 	notifyNewTxVerbose bool
+	notifyChainLocks   bool
+	notifyISLocks      bool
 	notifyReceived     map[string]struct{}
 	notifySpent        map[btcjson.OutPoint]struct{}
 }
@@ -45,6 +47,8 @@ func (s *notificationState) Copy() *notificationState {
 	stateCopy.notifyBlocks = s.notifyBlocks
 	stateCopy.notifyNewTx = s.notifyNewTx
 	stateCopy.notifyNewTxVerbose = s.notifyNewTxVerbose
+	stateCopy.notifyChainLocks = s.notifyChainLocks
+	stateCopy.notifyISLocks = s.notifyISLocks
 	stateCopy.notifyReceived = make(map[string]struct{})
 	for addr := range s.notifyReceived {
 		stateCopy.notifyReceived[addr] = struct{}{}
@@ -179,6 +183,17 @@ type NotificationHandlers struct {
 	// made to register for the notification and the function is non-nil.
 	OnTxAcceptedVerbose func(txDetails *btcjson.TxRawResult)
 
+	// OnChainLock is invoked when a block is chainlocked by an LLMQ.  It
+	// will only be invoked if a preceding call to NotifyChainLocks has been
+	// made to register for the notification and the function is non-nil.
+	OnChainLock func(blockHash *chainhash.Hash, height int32)
+
+	// OnInstantSendLock is invoked when a transaction is locked by
+	// InstantSend.  It will only be invoked if a preceding call to
+	// NotifyInstantSendLocks has been made to register for the
+	// notification and the function is non-nil.
+	OnInstantSendLock func(tx *wire.MsgTx)
+
 	// OnBtcdConnected is invoked when a wallet connects or disconnects from
 	// btcd.
 	//
@@ -462,6 +477,45 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 
 		c.ntfnHandlers.OnWalletLockState(locked)
 
+	// OnChainLock
+	case btcjson.ChainLockNtfnMethod:
+		// Ignore the notification if the client is not interested in
+		// it.
+		if c.ntfnHandlers.OnChainLock == nil {
+			return
+		}
+
+		blockHash, height, err := parseChainLockNtfnParams(ntfn.Params)
+		if err != nil {
+			log.Warnf("Received invalid chainlock notification: %v",
+				err)
+			return
+		}
+
+		c.ntfnHandlers.OnChainLock(blockHash, height)
+
+	// OnInstantSendLock
+	case btcjson.InstantSendLockNtfnMethod:
+		// Ignore the notification if neither the client nor a
+		// WaitForInstantLock call is interested in it.
+		if c.ntfnHandlers.OnInstantSendLock == nil &&
+			!c.hasISLockWaiters() {
+
+			return
+		}
+
+		tx, err := parseInstantSendLockNtfnParams(ntfn.Params)
+		if err != nil {
+			log.Warnf("Received invalid instantsend lock "+
+				"notification: %v", err)
+			return
+		}
+
+		c.notifyISLockWaiters(tx.TxHash())
+		if c.ntfnHandlers.OnInstantSendLock != nil {
+			c.ntfnHandlers.OnInstantSendLock(tx)
+		}
+
 	// OnUnknownNotification
 	default:
 		if c.ntfnHandlers.OnUnknownNotification == nil {
@@ -731,15 +785,9 @@ func parseTxAcceptedNtfnParams(params []json.RawMessage) (*chainhash.Hash,
 		return nil, 0, err
 	}
 
-	// Unmarshal second parameter as a floating point number.
-	var famt float64
-	err = json.Unmarshal(params[1], &famt)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	// Bounds check amount.
-	amt, err := godashutil.NewAmount(famt)
+	// Unmarshal second parameter as an exact amount.
+	var amt DashAmount
+	err = json.Unmarshal(params[1], &amt)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -750,7 +798,7 @@ func parseTxAcceptedNtfnParams(params []json.RawMessage) (*chainhash.Hash,
 		return nil, 0, err
 	}
 
-	return txHash, amt, nil
+	return txHash, amt.Amount(), nil
 }
 
 // parseTxAcceptedVerboseNtfnParams parses out details about a raw transaction
@@ -808,9 +856,9 @@ func parseAccountBalanceNtfnParams(params []json.RawMessage) (account string,
 		return "", 0, false, err
 	}
 
-	// Unmarshal second parameter as a floating point number.
-	var fbal float64
-	err = json.Unmarshal(params[1], &fbal)
+	// Unmarshal second parameter as an exact amount.
+	var bal DashAmount
+	err = json.Unmarshal(params[1], &bal)
 	if err != nil {
 		return "", 0, false, err
 	}
@@ -821,13 +869,7 @@ func parseAccountBalanceNtfnParams(params []json.RawMessage) (account string,
 		return "", 0, false, err
 	}
 
-	// Bounds check amount.
-	bal, err := godashutil.NewAmount(fbal)
-	if err != nil {
-		return "", 0, false, err
-	}
-
-	return account, bal, confirmed, nil
+	return account, bal.Amount(), confirmed, nil
 }
 
 // parseWalletLockStateNtfnParams parses out the account name and locked
@@ -854,6 +896,133 @@ func parseWalletLockStateNtfnParams(params []json.RawMessage) (account string,
 	return account, locked, nil
 }
 
+// parseChainLockNtfnParams parses out the block hash and height from the
+// parameters of a chainlock notification.
+func parseChainLockNtfnParams(params []json.RawMessage) (*chainhash.Hash,
+	int32, error) {
+
+	if len(params) != 2 {
+		return nil, 0, wrongNumParams(len(params))
+	}
+
+	// Unmarshal first parameter as a string.
+	var blockHashStr string
+	err := json.Unmarshal(params[0], &blockHashStr)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Unmarshal second parameter as an integer.
+	var height int32
+	err = json.Unmarshal(params[1], &height)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Create hash from block hash string.
+	blockHash, err := chainhash.NewHashFromStr(blockHashStr)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return blockHash, height, nil
+}
+
+// parseInstantSendLockNtfnParams parses out the locked transaction from the
+// parameters of an instantsendlock notification.
+func parseInstantSendLockNtfnParams(params []json.RawMessage) (*wire.MsgTx, error) {
+	if len(params) != 1 {
+		return nil, wrongNumParams(len(params))
+	}
+
+	// Hex decode and deserialize the transaction.
+	serializedTx, err := parseHexParam(params[0])
+	if err != nil {
+		return nil, err
+	}
+	var msgTx wire.MsgTx
+	err = msgTx.Deserialize(bytes.NewReader(serializedTx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &msgTx, nil
+}
+
+// addISLockWaiter returns a channel which is closed once an instantsend lock
+// notification for the transaction with the passed hash is received.
+func (c *Client) addISLockWaiter(txHash chainhash.Hash) chan struct{} {
+	c.islockMtx.Lock()
+	defer c.islockMtx.Unlock()
+
+	if c.islockWaiters == nil {
+		c.islockWaiters = make(map[chainhash.Hash][]chan struct{})
+	}
+	waiter := make(chan struct{})
+	c.islockWaiters[txHash] = append(c.islockWaiters[txHash], waiter)
+	return waiter
+}
+
+// removeISLockWaiter stops tracking the passed channel returned by
+// addISLockWaiter.  It is a no-op when the channel was already closed by a
+// notification.
+func (c *Client) removeISLockWaiter(txHash chainhash.Hash, waiter chan struct{}) {
+	c.islockMtx.Lock()
+	defer c.islockMtx.Unlock()
+
+	waiters := c.islockWaiters[txHash]
+	for i, w := range waiters {
+		if w != waiter {
+			continue
+		}
+		waiters = append(waiters[:i], waiters[i+1:]...)
+		if len(waiters) == 0 {
+			delete(c.islockWaiters, txHash)
+		} else {
+			c.islockWaiters[txHash] = waiters
+		}
+		return
+	}
+}
+
+// registerISLockNtfns registers the client for instantsend lock notifications
+// unless it already is.  The registration is restored after reconnects, so all
+// WaitForInstantLock calls of a client share a single one.
+func (c *Client) registerISLockNtfns() error {
+	c.islockNtfnMtx.Lock()
+	defer c.islockNtfnMtx.Unlock()
+
+	c.ntfnStateLock.Lock()
+	registered := c.ntfnState.notifyISLocks
+	c.ntfnStateLock.Unlock()
+	if registered {
+		return nil
+	}
+
+	return c.NotifyInstantSendLocks()
+}
+
+// hasISLockWaiters returns whether any WaitForInstantLock call is waiting for
+// an instantsend lock notification.
+func (c *Client) hasISLockWaiters() bool {
+	c.islockMtx.Lock()
+	defer c.islockMtx.Unlock()
+
+	return len(c.islockWaiters) > 0
+}
+
+// notifyISLockWaiters closes and stops tracking the channels waiting for the
+// transaction with the passed hash to be locked.
+func (c *Client) notifyISLockWaiters(txHash chainhash.Hash) {
+	c.islockMtx.Lock()
+	defer c.islockMtx.Unlock()
+
+	for _, waiter := range c.islockWaiters[txHash] {
+		close(waiter)
+	}
+	delete(c.islockWaiters, txHash)
+}
+
 // FutureNotifyBlocksResult is a future promise to deliver the result of a
 // NotifyBlocksAsync RPC invocation (or an applicable error).
 type FutureNotifyBlocksResult chan *response
@@ -1355,3 +1524,99 @@ func (c *Client) LoadTxFilterAsync(reload bool, addresses []godashutil.Address,
 func (c *Client) LoadTxFilter(reload bool, addresses []godashutil.Address, outPoints []wire.OutPoint) error {
 	return c.LoadTxFilterAsync(reload, addresses, outPoints).Receive()
 }
+
+// FutureNotifyChainLocksResult is a future promise to deliver the result of a
+// NotifyChainLocksAsync RPC invocation (or an applicable error).
+type FutureNotifyChainLocksResult chan *response
+
+// Receive waits for the response promised by the future and returns an error
+// if the registration was not successful.
+func (r FutureNotifyChainLocksResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// NotifyChainLocksAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See NotifyChainLocks for the blocking version and more details.
+//
+// NOTE: This requires a websocket connection.
+func (c *Client) NotifyChainLocksAsync() FutureNotifyChainLocksResult {
+	// Not supported in HTTP POST mode.
+	if c.config.HTTPPostMode {
+		return newFutureError(ErrWebsocketsRequired)
+	}
+
+	// Ignore the notification if the client is not interested in
+	// notifications.
+	if c.ntfnHandlers == nil {
+		return newNilFutureResult()
+	}
+
+	cmd := btcjson.NewNotifyChainLocksCmd()
+	return c.sendCmd(cmd)
+}
+
+// NotifyChainLocks registers the client to receive notifications when blocks
+// are chainlocked.  The notifications are delivered to the notification
+// handlers associated with the client.  Calling this function has no effect if
+// there are no notification handlers and will result in an error if the client
+// is configured to run in HTTP POST mode.
+//
+// The notifications delivered as a result of this call will be via
+// OnChainLock.
+//
+// NOTE: This requires a websocket connection.
+func (c *Client) NotifyChainLocks() error {
+	return c.NotifyChainLocksAsync().Receive()
+}
+
+// FutureNotifyInstantSendLocksResult is a future promise to deliver the result
+// of a NotifyInstantSendLocksAsync RPC invocation (or an applicable error).
+type FutureNotifyInstantSendLocksResult chan *response
+
+// Receive waits for the response promised by the future and returns an error
+// if the registration was not successful.
+func (r FutureNotifyInstantSendLocksResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// NotifyInstantSendLocksAsync returns an instance of a type that can be used
+// to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See NotifyInstantSendLocks for the blocking version and more details.
+//
+// NOTE: This requires a websocket connection.
+func (c *Client) NotifyInstantSendLocksAsync() FutureNotifyInstantSendLocksResult {
+	// Not supported in HTTP POST mode.
+	if c.config.HTTPPostMode {
+		return newFutureError(ErrWebsocketsRequired)
+	}
+
+	// Ignore the notification if the client is not interested in
+	// notifications.
+	if c.ntfnHandlers == nil {
+		return newNilFutureResult()
+	}
+
+	cmd := btcjson.NewNotifyInstantSendLocksCmd()
+	return c.sendCmd(cmd)
+}
+
+// NotifyInstantSendLocks registers the client to receive notifications when
+// transactions are locked by InstantSend.  The notifications are delivered to
+// the notification handlers associated with the client.  Calling this function
+// has no effect if there are no notification handlers and will result in an
+// error if the client is configured to run in HTTP POST mode.
+//
+// The notifications delivered as a result of this call will be via
+// OnInstantSendLock.
+//
+// NOTE: This requires a websocket connection.
+func (c *Client) NotifyInstantSendLocks() error {
+	return c.NotifyInstantSendLocksAsync().Receive()
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2014-2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godash/wire"
+)
+
+// TestDashNotifications ensures chainlock and instantsend lock notifications
+// are parsed and dispatched to their handlers.
+func TestDashNotifications(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gotHash   *chainhash.Hash
+		gotHeight int32
+		gotTx     *wire.MsgTx
+	)
+	c := &Client{ntfnHandlers: &NotificationHandlers{
+		OnChainLock: func(blockHash *chainhash.Hash, height int32) {
+			gotHash, gotHeight = blockHash, height
+		},
+		OnInstantSendLock: func(tx *wire.MsgTx) {
+			gotTx = tx
+		},
+	}}
+
+	dispatch := func(ntfn interface{}) {
+		marshalled, err := btcjson.MarshalCmd(nil, ntfn)
+		if err != nil {
+			t.Fatalf("MarshalCmd: unexpected error: %v", err)
+		}
+		var raw rawNotification
+		if err := json.Unmarshal(marshalled, &raw); err != nil {
+			t.Fatalf("Unmarshal: unexpected error: %v", err)
+		}
+		c.handleNotification(&raw)
+	}
+
+	hashStr := "000000000000001d8d1f49ee0e1b7bc8abf8b9e4bb1a06fcfacdf2fd5a90a1e2"
+	dispatch(btcjson.NewChainLockNtfn(hashStr, 1000000))
+	if gotHash == nil || gotHash.String() != hashStr {
+		t.Fatalf("OnChainLock: got hash %v, want %s", gotHash, hashStr)
+	}
+	if gotHeight != 1000000 {
+		t.Fatalf("OnChainLock: got height %d, want 1000000", gotHeight)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(5000, []byte{0x51}))
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatalf("Serialize: unexpected error: %v", err)
+	}
+	dispatch(btcjson.NewInstantSendLockNtfn(hex.EncodeToString(buf.Bytes())))
+	if gotTx == nil || gotTx.TxHash() != tx.TxHash() {
+		t.Fatalf("OnInstantSendLock: got tx %v, want %v", gotTx,
+			tx.TxHash())
+	}
+}
+
+// TestISLockWaiters ensures instantsend lock notifications wake up only the
+// waiters of the locked transaction, even without an OnInstantSendLock
+// handler.
+func TestISLockWaiters(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{ntfnHandlers: &NotificationHandlers{}}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(5000, []byte{0x51}))
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatalf("Serialize: unexpected error: %v", err)
+	}
+
+	locked := c.addISLockWaiter(tx.TxHash())
+	other := c.addISLockWaiter(chainhash.Hash{0x01})
+
+	marshalled, err := btcjson.MarshalCmd(nil,
+		btcjson.NewInstantSendLockNtfn(hex.EncodeToString(buf.Bytes())))
+	if err != nil {
+		t.Fatalf("MarshalCmd: unexpected error: %v", err)
+	}
+	var raw rawNotification
+	if err := json.Unmarshal(marshalled, &raw); err != nil {
+		t.Fatalf("Unmarshal: unexpected error: %v", err)
+	}
+	c.handleNotification(&raw)
+
+	select {
+	case <-locked:
+	default:
+		t.Fatal("waiter of the locked transaction was not notified")
+	}
+	select {
+	case <-other:
+		t.Fatal("waiter of another transaction was notified")
+	default:
+	}
+
+	c.removeISLockWaiter(chainhash.Hash{0x01}, other)
+	if c.hasISLockWaiters() {
+		t.Fatal("waiters remain after all were notified or removed")
+	}
+}
+
+// TestRegisterISLockNtfns ensures WaitForInstantLock calls do not register for
+// instantsend lock notifications again once the client is registered.
+func TestRegisterISLockNtfns(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{
+		config:       &ConnConfig{},
+		ntfnHandlers: &NotificationHandlers{},
+		ntfnState:    newNotificationState(),
+	}
+	c.ntfnState.notifyISLocks = true
+
+	// The client has no connection, so sending the registration would
+	// never complete.
+	done := make(chan error, 1)
+	go func() {
+		done <- c.registerISLockNtfns()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("registerISLockNtfns: unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("registerISLockNtfns registered again")
+	}
+}
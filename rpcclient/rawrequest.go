@@ -5,6 +5,7 @@
 package rpcclient
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 
@@ -27,6 +28,12 @@ func (r FutureRawResult) Receive() (json.RawMessage, error) {
 //
 // See RawRequest for the blocking version and more details.
 func (c *Client) RawRequestAsync(method string, params []json.RawMessage) FutureRawResult {
+	return c.rawRequestCtx(context.Background(), method, params)
+}
+
+// rawRequestCtx creates and sends a raw JSON-RPC request bound to the passed
+// context.
+func (c *Client) rawRequestCtx(ctx context.Context, method string, params []json.RawMessage) FutureRawResult {
 	// Method may not be empty.
 	if method == "" {
 		return newFutureError(errors.New("no method"))
@@ -63,9 +70,8 @@ func (c *Client) RawRequestAsync(method string, params []json.RawMessage) Future
 		marshalledJSON: marshalledJSON,
 		responseChan:   responseChan,
 	}
-	c.sendRequest(jReq)
 
-	return responseChan
+	return c.sendObservedRequestCtx(ctx, jReq)
 }
 
 // RawRequest allows the caller to send a raw or custom request to the server.
@@ -76,3 +82,10 @@ func (c *Client) RawRequestAsync(method string, params []json.RawMessage) Future
 func (c *Client) RawRequest(method string, params []json.RawMessage) (json.RawMessage, error) {
 	return c.RawRequestAsync(method, params).Receive()
 }
+
+// RawRequestCtx is the same as RawRequest except the request is bound to the
+// passed context.  The request is aborted and the context error returned when
+// the context is canceled or its deadline expires before a reply is received.
+func (c *Client) RawRequestCtx(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	return c.rawRequestCtx(ctx, method, params).Receive()
+}
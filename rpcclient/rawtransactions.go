@@ -6,6 +6,7 @@ package rpcclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 
@@ -114,6 +115,18 @@ func (c *Client) GetRawTransaction(txHash *chainhash.Hash) (*godashutil.Tx, erro
 	return c.GetRawTransactionAsync(txHash).Receive()
 }
 
+// GetRawTransactionCtx is the same as GetRawTransaction except the request is
+// bound to the passed context.
+func (c *Client) GetRawTransactionCtx(ctx context.Context, txHash *chainhash.Hash) (*godashutil.Tx, error) {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+
+	cmd := btcjson.NewGetRawTransactionCmd(hash, btcjson.Int(0))
+	return FutureGetRawTransactionResult(c.sendCmdCtx(ctx, cmd)).Receive()
+}
+
 // FutureGetRawTransactionVerboseResult is a future promise to deliver the
 // result of a GetRawTransactionVerboseAsync RPC invocation (or an applicable
 // error).
@@ -160,6 +173,18 @@ func (c *Client) GetRawTransactionVerbose(txHash *chainhash.Hash) (*btcjson.TxRa
 	return c.GetRawTransactionVerboseAsync(txHash).Receive()
 }
 
+// GetRawTransactionVerboseCtx is the same as GetRawTransactionVerbose except
+// the request is bound to the passed context.
+func (c *Client) GetRawTransactionVerboseCtx(ctx context.Context, txHash *chainhash.Hash) (*btcjson.TxRawResult, error) {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+
+	cmd := btcjson.NewGetRawTransactionCmd(hash, btcjson.Int(1))
+	return FutureGetRawTransactionVerboseResult(c.sendCmdCtx(ctx, cmd)).Receive()
+}
+
 // FutureDecodeRawTransactionResult is a future promise to deliver the result
 // of a DecodeRawTransactionAsync RPC invocation (or an applicable error).
 type FutureDecodeRawTransactionResult chan *response
@@ -187,16 +212,44 @@ func (r FutureDecodeRawTransactionResult) Receive() (*btcjson.TxRawResult, error
 // function on the returned instance.
 //
 // See DecodeRawTransaction for the blocking version and more details.
-func (c *Client) DecodeRawTransactionAsync(serializedTx []byte) FutureDecodeRawTransactionResult {
-	txHex := hex.EncodeToString(serializedTx)
+func (c *Client) DecodeRawTransactionAsync(tx *wire.MsgTx) FutureDecodeRawTransactionResult {
+	txHex := ""
+	if tx != nil {
+		// Serialize the transaction and convert to hex string.
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if err := tx.Serialize(buf); err != nil {
+			return newFutureError(err)
+		}
+		txHex = hex.EncodeToString(buf.Bytes())
+	}
+
+	return c.DecodeRawTransactionHexAsync(txHex)
+}
+
+// DecodeRawTransaction returns information about the passed transaction as
+// decoded by the server.  For Dash special transactions the result includes
+// the transaction type along with the decoded extra payload, such as the
+// proRegTx or cbTx object, matching the type.
+func (c *Client) DecodeRawTransaction(tx *wire.MsgTx) (*btcjson.TxRawResult, error) {
+	return c.DecodeRawTransactionAsync(tx).Receive()
+}
+
+// DecodeRawTransactionHexAsync returns an instance of a type that can be used
+// to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See DecodeRawTransactionHex for the blocking version and more details.
+func (c *Client) DecodeRawTransactionHexAsync(txHex string) FutureDecodeRawTransactionResult {
 	cmd := btcjson.NewDecodeRawTransactionCmd(txHex)
 	return c.sendCmd(cmd)
 }
 
-// DecodeRawTransaction returns information about a transaction given its
-// serialized bytes.
-func (c *Client) DecodeRawTransaction(serializedTx []byte) (*btcjson.TxRawResult, error) {
-	return c.DecodeRawTransactionAsync(serializedTx).Receive()
+// DecodeRawTransactionHex returns information about a transaction given its
+// hex-encoded serialization.
+//
+// See DecodeRawTransaction for more details.
+func (c *Client) DecodeRawTransactionHex(txHex string) (*btcjson.TxRawResult, error) {
+	return c.DecodeRawTransactionHexAsync(txHex).Receive()
 }
 
 // FutureCreateRawTransactionResult is a future promise to deliver the result
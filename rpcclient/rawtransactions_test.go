@@ -0,0 +1,93 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godash/wire"
+)
+
+// TestDecodeRawTransactionSpecial ensures special transactions are sent to the
+// server with their extra payload and that the decoded payload in the reply is
+// returned to the caller.
+func TestDecodeRawTransactionSpecial(t *testing.T) {
+	t.Parallel()
+
+	tx := wire.NewMsgTx(wire.SpecialTxVersion)
+	tx.Type = wire.TxTypeProUpdateService
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{0x01}, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(100000, []byte{0x51}))
+	tx.ExtraPayload = []byte{0x01, 0x00, 0x02}
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatalf("Serialize: unexpected error: %v", err)
+	}
+	wantHex := hex.EncodeToString(buf.Bytes())
+
+	reply := `{"txid":"` + tx.TxHash().String() + `","version":3,"type":2,` +
+		`"locktime":0,"vin":[],"vout":[],"extraPayloadSize":3,"extraPayload":"010002",` +
+		`"proUpServTx":{"version":1,` +
+		`"proTxHash":"4781bdd6da7f3ec9a8c83dc15c847bcd0d2a59c1aba2b7e4b5d9e0fd9af7d8f3",` +
+		`"service":"1.2.3.4:9999",` +
+		`"inputsHash":"f7b7bd9c3a1d4ff8d0d2d0fbd1c1bdf2ea3ed6e6c1c3d3a3f2b1a1e3d2c1b0a9"}}`
+
+	var gotHex string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err == nil &&
+			len(req.Params) == 1 {
+
+			json.Unmarshal(req.Params[0], &gotHex)
+		}
+		w.Write([]byte(`{"result":` + reply + `,"error":null,"id":1}`))
+	}))
+	defer srv.Close()
+
+	client := newTestPostClient(t, srv)
+	defer client.Shutdown()
+
+	result, err := client.DecodeRawTransaction(tx)
+	if err != nil {
+		t.Fatalf("DecodeRawTransaction: unexpected error: %v", err)
+	}
+	if gotHex != wantHex {
+		t.Fatalf("DecodeRawTransaction: unexpected hex sent - got %s, "+
+			"want %s", gotHex, wantHex)
+	}
+	if result.Type != int32(wire.TxTypeProUpdateService) {
+		t.Fatalf("DecodeRawTransaction: unexpected type - got %d, want %d",
+			result.Type, wire.TxTypeProUpdateService)
+	}
+	want := &btcjson.ProUpServTxPayload{
+		Version:    1,
+		ProTxHash:  "4781bdd6da7f3ec9a8c83dc15c847bcd0d2a59c1aba2b7e4b5d9e0fd9af7d8f3",
+		Service:    "1.2.3.4:9999",
+		InputsHash: "f7b7bd9c3a1d4ff8d0d2d0fbd1c1bdf2ea3ed6e6c1c3d3a3f2b1a1e3d2c1b0a9",
+	}
+	if result.ProUpServTx == nil || *result.ProUpServTx != *want {
+		t.Fatalf("DecodeRawTransaction: unexpected payload - got %+v, "+
+			"want %+v", result.ProUpServTx, want)
+	}
+
+	// The hex variant must send the passed string unchanged.
+	if _, err := client.DecodeRawTransactionHex(wantHex); err != nil {
+		t.Fatalf("DecodeRawTransactionHex: unexpected error: %v", err)
+	}
+	if gotHex != wantHex {
+		t.Fatalf("DecodeRawTransactionHex: unexpected hex sent - got %s, "+
+			"want %s", gotHex, wantHex)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2014-2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// defaultRetryBackoff is the delay before the first retry of a failed
+	// HTTP POST request when the RetryBackoff option is not set.
+	defaultRetryBackoff = time.Second
+
+	// maxRetryBackoff is the upper bound of the delay in between retries of
+	// failed HTTP POST requests.
+	maxRetryBackoff = time.Minute
+)
+
+// readOnlyMethods houses the methods which do not change the state of the
+// server, the chain, or the wallet.  Only requests for these methods are
+// retried, so newly registered methods are never sent twice unless they are
+// added here.
+var readOnlyMethods = map[string]struct{}{
+	"bls fromsecret":          {},
+	"bls generate":            {},
+	"createmultisig":          {},
+	"createrawtransaction":    {},
+	"decoderawtransaction":    {},
+	"decodescript":            {},
+	"dumpprivkey":             {},
+	"estimatefee":             {},
+	"estimatepriority":        {},
+	"estimatesmartfee":        {},
+	"getaccount":              {},
+	"getaddednodeinfo":        {},
+	"getaddressbalance":       {},
+	"getaddressdeltas":        {},
+	"getaddressesbyaccount":   {},
+	"getaddresstxids":         {},
+	"getaddressutxos":         {},
+	"getbalance":              {},
+	"getbalances":             {},
+	"getbestblock":            {},
+	"getbestblockhash":        {},
+	"getbestchainlock":        {},
+	"getblock":                {},
+	"getblockchaininfo":       {},
+	"getblockcount":           {},
+	"getblockhash":            {},
+	"getblockhashes":          {},
+	"getblockheader":          {},
+	"getblockstats":           {},
+	"getblocktemplate":        {},
+	"getchaintips":            {},
+	"getcoinjoininfo":         {},
+	"getconnectioncount":      {},
+	"getcurrentnet":           {},
+	"getdifficulty":           {},
+	"getgenerate":             {},
+	"getgovernanceinfo":       {},
+	"gethashespersec":         {},
+	"getheaders":              {},
+	"getinfo":                 {},
+	"getislocks":              {},
+	"getmempoolentry":         {},
+	"getmempoolinfo":          {},
+	"getmininginfo":           {},
+	"getnettotals":            {},
+	"getnetworkhashps":        {},
+	"getnetworkinfo":          {},
+	"getpeerinfo":             {},
+	"getprivatesendinfo":      {},
+	"getrawmempool":           {},
+	"getrawtransaction":       {},
+	"getreceivedbyaccount":    {},
+	"getreceivedbyaddress":    {},
+	"getspecialtxes":          {},
+	"getspentinfo":            {},
+	"getsuperblockbudget":     {},
+	"gettransaction":          {},
+	"gettxout":                {},
+	"gettxoutproof":           {},
+	"gettxoutsetinfo":         {},
+	"getwalletinfo":           {},
+	"getzmqnotifications":     {},
+	"gobject check":           {},
+	"gobject get":             {},
+	"gobject getcurrentvotes": {},
+	"gobject getvotes":        {},
+	"gobject list":            {},
+	"help":                    {},
+	"listaccounts":            {},
+	"listaddressgroupings":    {},
+	"listlockunspent":         {},
+	"listreceivedbyaccount":   {},
+	"listreceivedbyaddress":   {},
+	"listsinceblock":          {},
+	"listtransactions":        {},
+	"listunspent":             {},
+	"masternode count":        {},
+	"masternode payments":     {},
+	"masternode winners":      {},
+	"masternodelist":          {},
+	"mnsync status":           {},
+	"protx diff":              {},
+	"protx info":              {},
+	"protx list":              {},
+	"quorum info":             {},
+	"quorum list":             {},
+	"quorum memberof":         {},
+	"quorum verify":           {},
+	"searchrawtransactions":   {},
+	"signmessage":             {},
+	"signrawtransaction":      {},
+	"spork active":            {},
+	"spork show":              {},
+	"uptime":                  {},
+	"validateaddress":         {},
+	"verifychain":             {},
+	"verifychainlock":         {},
+	"verifyislock":            {},
+	"verifymessage":           {},
+	"verifytxoutproof":        {},
+	"version":                 {},
+}
+
+// isRetryable returns whether the passed request may be sent again after a
+// transient failure.  Raw requests are never retried since nothing is known
+// about the effects of their method.
+func isRetryable(jReq *jsonRequest) bool {
+	if jReq.cmd == nil {
+		return false
+	}
+	_, readOnly := readOnlyMethods[jReq.method]
+	return readOnly
+}
+
+// isTransientPostError returns whether the passed HTTP POST outcome describes
+// a temporary failure of the server.  Only failures to connect and 503 Service
+// Unavailable replies qualify, since in both cases the server did not process
+// the request.
+func isTransientPostError(httpResponse *http.Response, err error) bool {
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		opErr, ok := err.(*net.OpError)
+		return ok && opErr.Op == "dial"
+	}
+
+	return httpResponse.StatusCode == http.StatusServiceUnavailable
+}
+
+// retryBackoff returns the delay before the passed zero-based retry attempt.
+// The configured RetryBackoff is doubled for every attempt up to a maximum of
+// one minute.
+func (c *Client) retryBackoff(attempt int) time.Duration {
+	backoff := c.config.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	for i := 0; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
+
+// doPostRetry performs the passed HTTP POST request carrying the passed body.
+// When retry is set, transient failures are retried with exponential backoff
+// up to the configured MaxRetries before the last outcome is returned.
+func (c *Client) doPostRetry(httpReq *http.Request, body []byte, retry bool) (*http.Response, error) {
+	httpResponse, err := c.doPost(httpReq, body)
+	if !retry {
+		return httpResponse, err
+	}
+
+	ctx := httpReq.Context()
+	for attempt := 0; attempt < c.config.MaxRetries; attempt++ {
+		if !isTransientPostError(httpResponse, err) {
+			break
+		}
+		if httpResponse != nil {
+			httpResponse.Body.Close()
+		}
+
+		backoff := c.retryBackoff(attempt)
+		log.Debugf("Request to %s failed, retrying in %s (attempt %d "+
+			"of %d)", c.config.Host, backoff, attempt+1,
+			c.config.MaxRetries)
+		if err := c.waitRetry(ctx, backoff); err != nil {
+			return nil, err
+		}
+
+		retryReq, reqErr := c.newPostRequest(body)
+		if reqErr != nil {
+			return nil, reqErr
+		}
+		retryReq = retryReq.WithContext(ctx)
+		httpResponse, err = c.doPost(retryReq, body)
+	}
+
+	return httpResponse, err
+}
+
+// waitRetry blocks for the passed duration.  It returns early with an error
+// when the context is done or the client is shut down.
+func (c *Client) waitRetry(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.shutdown:
+		return ErrClientShutdown
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2014-2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jiangjinyuan/godash/btcjson"
+	"github.com/nargott/godash/wire"
+)
+
+// TestPostRetry ensures transient failures are retried for read-only commands
+// and never for mutating ones.
+func TestPostRetry(t *testing.T) {
+	t.Parallel()
+
+	var posts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first two attempts of every request.
+		if atomic.AddInt32(&posts, 1)%3 != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"result":1234,"error":null,"id":1}`))
+	}))
+	defer srv.Close()
+
+	client, err := New(&ConnConfig{
+		Host:         strings.TrimPrefix(srv.URL, "http://"),
+		HTTPPostMode: true,
+		DisableTLS:   true,
+		MaxRetries:   3,
+		RetryBackoff: time.Millisecond,
+	}, nil)
+	if err != nil {
+		t.Fatalf("New: unexpected error: %v", err)
+	}
+	defer client.Shutdown()
+
+	count, err := client.GetBlockCount()
+	if err != nil {
+		t.Fatalf("GetBlockCount: unexpected error: %v", err)
+	}
+	if count != 1234 {
+		t.Fatalf("GetBlockCount: got %d, want 1234", count)
+	}
+	if n := atomic.LoadInt32(&posts); n != 3 {
+		t.Fatalf("GetBlockCount: got %d attempts, want 3", n)
+	}
+
+	atomic.StoreInt32(&posts, 0)
+	_, err = client.SendRawTransaction(wire.NewMsgTx(wire.TxVersion), false)
+	if err == nil {
+		t.Fatal("SendRawTransaction: expected error")
+	}
+	if n := atomic.LoadInt32(&posts); n != 1 {
+		t.Fatalf("SendRawTransaction: got %d attempts, want 1", n)
+	}
+}
+
+// TestIsRetryable ensures requests are only retried for commands which do not
+// change the state of the server and that other commands default to not being
+// retried.
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	address := "198.51.100.9:9999"
+	tests := []struct {
+		name string
+		cmd  interface{}
+		want bool
+	}{
+		{"getblockcount", btcjson.NewGetBlockCountCmd(), true},
+		{"disconnectnode", btcjson.NewDisconnectNodeCmd(&address, nil), false},
+		{"quorum sign", btcjson.NewQuorumSignCmd(1, "id", "msgHash", nil, nil), false},
+		{"node", btcjson.NewNodeCmd(btcjson.NConnect, address, nil), false},
+		{"debuglevel", btcjson.NewDebugLevelCmd("debug"), false},
+		{"raw request", nil, false},
+	}
+	for _, test := range tests {
+		jReq := &jsonRequest{cmd: test.cmd}
+		if test.cmd != nil {
+			method, err := btcjson.CmdMethod(test.cmd)
+			if err != nil {
+				t.Fatalf("%s: CmdMethod: %v", test.name, err)
+			}
+			jReq.method = method
+		}
+		if got := isRetryable(jReq); got != test.want {
+			t.Errorf("%s: isRetryable got %v, want %v", test.name,
+				got, test.want)
+		}
+	}
+}
+
+// TestRetryBackoff ensures the retry delay doubles up to the maximum.
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{config: &ConnConfig{RetryBackoff: 10 * time.Second}}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 20 * time.Second},
+		{2, 40 * time.Second},
+		{3, time.Minute},
+		{100, time.Minute},
+	}
+	for _, test := range tests {
+		if got := c.retryBackoff(test.attempt); got != test.want {
+			t.Errorf("retryBackoff(%d): got %s, want %s",
+				test.attempt, got, test.want)
+		}
+	}
+
+	c.config.RetryBackoff = 0
+	if got := c.retryBackoff(0); got != defaultRetryBackoff {
+		t.Errorf("retryBackoff default: got %s, want %s", got,
+			defaultRetryBackoff)
+	}
+}
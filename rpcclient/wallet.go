@@ -6,7 +6,6 @@ package rpcclient
 
 import (
 	"encoding/json"
-	"strconv"
 
 	"github.com/jiangjinyuan/godash/btcjson"
 	"github.com/nargott/godash/chaincfg"
@@ -1311,7 +1310,7 @@ func (r FutureListAccountsResult) Receive() (map[string]godashutil.Amount, error
 	}
 
 	// Unmarshal result as a json object.
-	var accounts map[string]float64
+	var accounts map[string]DashAmount
 	err = json.Unmarshal(res, &accounts)
 	if err != nil {
 		return nil, err
@@ -1319,12 +1318,7 @@ func (r FutureListAccountsResult) Receive() (map[string]godashutil.Amount, error
 
 	accountsMap := make(map[string]godashutil.Amount)
 	for k, v := range accounts {
-		amount, err := godashutil.NewAmount(v)
-		if err != nil {
-			return nil, err
-		}
-
-		accountsMap[k] = amount
+		accountsMap[k] = v.Amount()
 	}
 
 	return accountsMap, nil
@@ -1379,24 +1373,19 @@ func (r FutureGetBalanceResult) Receive() (godashutil.Amount, error) {
 		return 0, err
 	}
 
-	// Unmarshal result as a floating point number.
-	var balance float64
+	// Unmarshal result as an exact amount.
+	var balance DashAmount
 	err = json.Unmarshal(res, &balance)
 	if err != nil {
 		return 0, err
 	}
 
-	amount, err := godashutil.NewAmount(balance)
-	if err != nil {
-		return 0, err
-	}
-
-	return amount, nil
+	return balance.Amount(), nil
 }
 
 // FutureGetBalanceParseResult is same as FutureGetBalanceResult except
 // that the result is expected to be a string which is then parsed into
-// a float64 value
+// an exact amount
 // This is required for compatiblity with servers like blockchain.info
 type FutureGetBalanceParseResult chan *response
 
@@ -1408,23 +1397,14 @@ func (r FutureGetBalanceParseResult) Receive() (godashutil.Amount, error) {
 		return 0, err
 	}
 
-	// Unmarshal result as a string
-	var balanceString string
-	err = json.Unmarshal(res, &balanceString)
-	if err != nil {
-		return 0, err
-	}
-
-	balance, err := strconv.ParseFloat(balanceString, 64)
-	if err != nil {
-		return 0, err
-	}
-	amount, err := godashutil.NewAmount(balance)
+	// Unmarshal result as a string holding an exact amount.
+	var balance DashAmount
+	err = json.Unmarshal(res, &balance)
 	if err != nil {
 		return 0, err
 	}
 
-	return amount, nil
+	return balance.Amount(), nil
 }
 
 // GetBalanceAsync returns an instance of a type that can be used to get the
@@ -1482,19 +1462,14 @@ func (r FutureGetReceivedByAccountResult) Receive() (godashutil.Amount, error) {
 		return 0, err
 	}
 
-	// Unmarshal result as a floating point number.
-	var balance float64
+	// Unmarshal result as an exact amount.
+	var balance DashAmount
 	err = json.Unmarshal(res, &balance)
 	if err != nil {
 		return 0, err
 	}
 
-	amount, err := godashutil.NewAmount(balance)
-	if err != nil {
-		return 0, err
-	}
-
-	return amount, nil
+	return balance.Amount(), nil
 }
 
 // GetReceivedByAccountAsync returns an instance of a type that can be used to
@@ -1547,19 +1522,14 @@ func (r FutureGetUnconfirmedBalanceResult) Receive() (godashutil.Amount, error)
 		return 0, err
 	}
 
-	// Unmarshal result as a floating point number.
-	var balance float64
+	// Unmarshal result as an exact amount.
+	var balance DashAmount
 	err = json.Unmarshal(res, &balance)
 	if err != nil {
 		return 0, err
 	}
 
-	amount, err := godashutil.NewAmount(balance)
-	if err != nil {
-		return 0, err
-	}
-
-	return amount, nil
+	return balance.Amount(), nil
 }
 
 // GetUnconfirmedBalanceAsync returns an instance of a type that can be used to
@@ -1591,19 +1561,14 @@ func (r FutureGetReceivedByAddressResult) Receive() (godashutil.Amount, error) {
 		return 0, err
 	}
 
-	// Unmarshal result as a floating point number.
-	var balance float64
+	// Unmarshal result as an exact amount.
+	var balance DashAmount
 	err = json.Unmarshal(res, &balance)
 	if err != nil {
 		return 0, err
 	}
 
-	amount, err := godashutil.NewAmount(balance)
-	if err != nil {
-		return 0, err
-	}
-
-	return amount, nil
+	return balance.Amount(), nil
 }
 
 // GetReceivedByAddressAsync returns an instance of a type that can be used to
@@ -1928,6 +1893,9 @@ func (c *Client) SignMessageAsync(address godashutil.Address, message string) Fu
 }
 
 // SignMessage signs a message with the private key of the specified address.
+// The returned base64-encoded compact signature commits to the message with
+// the Dash message prefix, so it can be verified offline against the hash
+// returned by chaincfg.MagicMessageHash.
 //
 // NOTE: This function requires to the wallet to be unlocked.  See the
 // WalletPassphrase function for more details.
@@ -170,6 +170,7 @@ var helpDescsEnUS = map[string]string{
 	"getblockchaininforesult-difficulty":            "The current chain difficulty",
 	"getblockchaininforesult-mediantime":            "The median time from the PoV of the best block in the chain",
 	"getblockchaininforesult-verificationprogress":  "An estimate for how much of the best chain we've verified",
+	"getblockchaininforesult-size_on_disk":          "The estimated size of the block and undo files on disk",
 	"getblockchaininforesult-pruned":                "A bool that indicates if the node is pruned or not",
 	"getblockchaininforesult-pruneheight":           "The lowest block retained in the current pruned chain",
 	"getblockchaininforesult-chainwork":             "The total cumulative work in the best chain",
@@ -200,6 +201,76 @@ var helpDescsEnUS = map[string]string{
 	"txrawresult-vsize":         "The virtual size of the transaction in bytes",
 	"txrawresult-hash":          "The wtxid of the transaction",
 
+	// Dash special transaction fields of TxRawResult.
+	"txrawresult-type":                 "The Dash special transaction type, omitted for classical transactions",
+	"txrawresult-extraPayloadSize":     "The size of the special transaction payload in bytes",
+	"txrawresult-extraPayload":         "The hex-encoded special transaction payload",
+	"txrawresult-proRegTx":             "The decoded provider registration payload",
+	"txrawresult-proUpServTx":          "The decoded provider update service payload",
+	"txrawresult-proUpRegTx":           "The decoded provider update registrar payload",
+	"txrawresult-proUpRevTx":           "The decoded provider update revocation payload",
+	"txrawresult-cbTx":                 "The decoded coinbase special transaction payload",
+	"txrawresult-qcTx":                 "The decoded quorum commitment payload",
+	"txrawresult-instantlock":          "Whether the transaction is locked by InstantSend or its block by a ChainLock",
+	"txrawresult-instantlock_internal": "Whether the server holds an InstantSend lock for the transaction",
+
+	// ProRegTxPayload help.
+	"proregtxpayload-version":         "The payload version",
+	"proregtxpayload-collateralHash":  "The hash of the collateral transaction",
+	"proregtxpayload-collateralIndex": "The output index of the collateral",
+	"proregtxpayload-service":         "The IP address and port of the masternode",
+	"proregtxpayload-ownerAddress":    "The address of the owner key",
+	"proregtxpayload-votingAddress":   "The address of the voting key",
+	"proregtxpayload-payoutAddress":   "The address masternode rewards are paid to",
+	"proregtxpayload-pubKeyOperator":  "The BLS public key of the operator",
+	"proregtxpayload-operatorReward":  "The percentage of the reward paid to the operator",
+	"proregtxpayload-inputsHash":      "The hash of all inputs of the transaction",
+
+	// ProUpServTxPayload help.
+	"proupservtxpayload-version":               "The payload version",
+	"proupservtxpayload-proTxHash":             "The hash of the provider registration transaction",
+	"proupservtxpayload-service":               "The IP address and port of the masternode",
+	"proupservtxpayload-operatorPayoutAddress": "The address operator rewards are paid to",
+	"proupservtxpayload-inputsHash":            "The hash of all inputs of the transaction",
+
+	// ProUpRegTxPayload help.
+	"proupregtxpayload-version":        "The payload version",
+	"proupregtxpayload-proTxHash":      "The hash of the provider registration transaction",
+	"proupregtxpayload-votingAddress":  "The address of the voting key",
+	"proupregtxpayload-payoutAddress":  "The address masternode rewards are paid to",
+	"proupregtxpayload-pubKeyOperator": "The BLS public key of the operator",
+	"proupregtxpayload-inputsHash":     "The hash of all inputs of the transaction",
+
+	// ProUpRevTxPayload help.
+	"prouprevtxpayload-version":    "The payload version",
+	"prouprevtxpayload-proTxHash":  "The hash of the provider registration transaction",
+	"prouprevtxpayload-reason":     "The reason for the revocation",
+	"prouprevtxpayload-inputsHash": "The hash of all inputs of the transaction",
+
+	// CbTxPayload help.
+	"cbtxpayload-version":           "The payload version",
+	"cbtxpayload-height":            "The height of the block",
+	"cbtxpayload-merkleRootMNList":  "The merkle root of the masternode list",
+	"cbtxpayload-merkleRootQuorums": "The merkle root of the active quorums",
+
+	// QcTxPayload help.
+	"qctxpayload-version":    "The payload version",
+	"qctxpayload-height":     "The height of the block",
+	"qctxpayload-commitment": "The final quorum commitment",
+
+	// QuorumCommitmentResult help.
+	"quorumcommitmentresult-version":           "The commitment version",
+	"quorumcommitmentresult-llmqType":          "The LLMQ type of the quorum",
+	"quorumcommitmentresult-quorumHash":        "The hash of the block the quorum was formed at",
+	"quorumcommitmentresult-signersCount":      "The number of members that signed the commitment",
+	"quorumcommitmentresult-signers":           "The bitset of the members that signed the commitment",
+	"quorumcommitmentresult-validMembersCount": "The number of valid members",
+	"quorumcommitmentresult-validMembers":      "The bitset of the valid members",
+	"quorumcommitmentresult-quorumPublicKey":   "The BLS public key of the quorum",
+	"quorumcommitmentresult-quorumVvecHash":    "The hash of the quorum verification vector",
+	"quorumcommitmentresult-quorumSig":         "The recovered threshold signature of the quorum",
+	"quorumcommitmentresult-membersSig":        "The aggregated signature of the signing members",
+
 	// SearchRawTransactionsResult help.
 	"searchrawtransactionsresult-hex":           "Hex-encoded transaction",
 	"searchrawtransactionsresult-txid":          "The hash of the transaction",
@@ -233,6 +304,8 @@ var helpDescsEnUS = map[string]string{
 	"getblockverboseresult-nextblockhash":     "The hash of the next block (only if there is one)",
 	"getblockverboseresult-strippedsize":      "The size of the block without witness data",
 	"getblockverboseresult-weight":            "The weight of the block",
+	"getblockverboseresult-cbTx":              "The decoded coinbase special transaction payload",
+	"getblockverboseresult-chainlock":         "Whether the block is locked by a ChainLock",
 
 	// GetBlockCountCmd help.
 	"getblockcount--synopsis": "Returns the number of blocks in the longest block chain.",
@@ -259,11 +332,14 @@ var helpDescsEnUS = map[string]string{
 	"getblockheaderverboseresult-versionHex":        "The block version in hexidecimal",
 	"getblockheaderverboseresult-merkleroot":        "Root hash of the merkle tree",
 	"getblockheaderverboseresult-time":              "The block time in seconds since 1 Jan 1970 GMT",
+	"getblockheaderverboseresult-mediantime":        "The median block time of the past 11 blocks in seconds since 1 Jan 1970 GMT",
 	"getblockheaderverboseresult-nonce":             "The block nonce",
 	"getblockheaderverboseresult-bits":              "The bits which represent the block difficulty",
 	"getblockheaderverboseresult-difficulty":        "The proof-of-work difficulty as a multiple of the minimum difficulty",
+	"getblockheaderverboseresult-chainwork":         "The total amount of work in the chain up to the block in hex",
 	"getblockheaderverboseresult-previousblockhash": "The hash of the previous block",
 	"getblockheaderverboseresult-nextblockhash":     "The hash of the next block (only if there is one)",
+	"getblockheaderverboseresult-chainlock":         "Whether the block is locked by a ChainLock",
 
 	// TemplateRequest help.
 	"templaterequest-mode":         "This is 'template', 'proposal', or omitted",
@@ -288,31 +364,43 @@ var helpDescsEnUS = map[string]string{
 	"getblocktemplateresultaux-flags": "Hex-encoded byte-for-byte data to include in the coinbase signature script",
 
 	// GetBlockTemplateResult help.
-	"getblocktemplateresult-bits":                       "Hex-encoded compressed difficulty",
-	"getblocktemplateresult-curtime":                    "Current time as seen by the server (recommended for block time); must fall within mintime/maxtime rules",
-	"getblocktemplateresult-height":                     "Height of the block to be solved",
-	"getblocktemplateresult-previousblockhash":          "Hex-encoded big-endian hash of the previous block",
-	"getblocktemplateresult-sigoplimit":                 "Number of sigops allowed in blocks ",
-	"getblocktemplateresult-sizelimit":                  "Number of bytes allowed in blocks",
-	"getblocktemplateresult-transactions":               "Array of transactions as JSON objects",
-	"getblocktemplateresult-version":                    "The block version",
-	"getblocktemplateresult-coinbaseaux":                "Data that should be included in the coinbase signature script",
-	"getblocktemplateresult-coinbasetxn":                "Information about the coinbase transaction",
-	"getblocktemplateresult-coinbasevalue":              "Total amount available for the coinbase in Satoshi",
-	"getblocktemplateresult-workid":                     "This value must be returned with result if provided (not provided)",
-	"getblocktemplateresult-longpollid":                 "Identifier for long poll request which allows monitoring for expiration",
-	"getblocktemplateresult-longpolluri":                "An alternate URI to use for long poll requests if provided (not provided)",
-	"getblocktemplateresult-submitold":                  "Not applicable",
-	"getblocktemplateresult-target":                     "Hex-encoded big-endian number which valid results must be less than",
-	"getblocktemplateresult-expires":                    "Maximum number of seconds (starting from when the server sent the response) this work is valid for",
-	"getblocktemplateresult-maxtime":                    "Maximum allowed time",
-	"getblocktemplateresult-mintime":                    "Minimum allowed time",
-	"getblocktemplateresult-mutable":                    "List of mutations the server explicitly allows",
-	"getblocktemplateresult-noncerange":                 "Two concatenated hex-encoded big-endian 32-bit integers which represent the valid ranges of nonces the miner may scan",
-	"getblocktemplateresult-capabilities":               "List of server capabilities including 'proposal' to indicate support for block proposals",
-	"getblocktemplateresult-reject-reason":              "Reason the proposal was invalid as-is (only applies to proposal responses)",
-	"getblocktemplateresult-default_witness_commitment": "The witness commitment itself. Will be populated if the block has witness data",
-	"getblocktemplateresult-weightlimit":                "The current limit on the max allowed weight of a block",
+	"getblocktemplateresult-bits":                         "Hex-encoded compressed difficulty",
+	"getblocktemplateresult-curtime":                      "Current time as seen by the server (recommended for block time); must fall within mintime/maxtime rules",
+	"getblocktemplateresult-height":                       "Height of the block to be solved",
+	"getblocktemplateresult-previousblockhash":            "Hex-encoded big-endian hash of the previous block",
+	"getblocktemplateresult-sigoplimit":                   "Number of sigops allowed in blocks ",
+	"getblocktemplateresult-sizelimit":                    "Number of bytes allowed in blocks",
+	"getblocktemplateresult-transactions":                 "Array of transactions as JSON objects",
+	"getblocktemplateresult-version":                      "The block version",
+	"getblocktemplateresult-coinbaseaux":                  "Data that should be included in the coinbase signature script",
+	"getblocktemplateresult-coinbasetxn":                  "Information about the coinbase transaction",
+	"getblocktemplateresult-coinbasevalue":                "Total amount available for the coinbase in Satoshi",
+	"getblocktemplateresult-workid":                       "This value must be returned with result if provided (not provided)",
+	"getblocktemplateresult-longpollid":                   "Identifier for long poll request which allows monitoring for expiration",
+	"getblocktemplateresult-longpolluri":                  "An alternate URI to use for long poll requests if provided (not provided)",
+	"getblocktemplateresult-submitold":                    "Not applicable",
+	"getblocktemplateresult-target":                       "Hex-encoded big-endian number which valid results must be less than",
+	"getblocktemplateresult-expires":                      "Maximum number of seconds (starting from when the server sent the response) this work is valid for",
+	"getblocktemplateresult-maxtime":                      "Maximum allowed time",
+	"getblocktemplateresult-mintime":                      "Minimum allowed time",
+	"getblocktemplateresult-mutable":                      "List of mutations the server explicitly allows",
+	"getblocktemplateresult-noncerange":                   "Two concatenated hex-encoded big-endian 32-bit integers which represent the valid ranges of nonces the miner may scan",
+	"getblocktemplateresult-capabilities":                 "List of server capabilities including 'proposal' to indicate support for block proposals",
+	"getblocktemplateresult-reject-reason":                "Reason the proposal was invalid as-is (only applies to proposal responses)",
+	"getblocktemplateresult-default_witness_commitment":   "The witness commitment itself. Will be populated if the block has witness data",
+	"getblocktemplateresult-weightlimit":                  "The current limit on the max allowed weight of a block",
+	"getblocktemplateresult-masternode":                   "The masternode payments the coinbase transaction must include",
+	"getblocktemplateresult-masternode_payments_started":  "Whether masternode payments have started",
+	"getblocktemplateresult-masternode_payments_enforced": "Whether masternode payments are enforced",
+	"getblocktemplateresult-superblock":                   "The superblock payments the coinbase transaction must include",
+	"getblocktemplateresult-superblocks_started":          "Whether superblocks have started",
+	"getblocktemplateresult-superblocks_enabled":          "Whether superblocks are enabled",
+	"getblocktemplateresult-coinbase_payload":             "The hex-encoded payload of the coinbase special transaction",
+
+	// GetBlockTemplateResultPayee help.
+	"getblocktemplateresultpayee-payee":  "The address of the payee",
+	"getblocktemplateresultpayee-script": "The hex-encoded output script of the payment",
+	"getblocktemplateresultpayee-amount": "The amount of the payment in duffs",
 
 	// GetBlockTemplateCmd help.
 	"getblocktemplate--synopsis": "Returns a JSON object with information necessary to construct a block to mine or accepts a proposal to validate.\n" +
@@ -386,8 +474,13 @@ var helpDescsEnUS = map[string]string{
 	"getmempoolinfo--synopsis": "Returns memory pool information",
 
 	// GetMempoolInfoResult help.
-	"getmempoolinforesult-bytes": "Size in bytes of the mempool",
-	"getmempoolinforesult-size":  "Number of transactions in the mempool",
+	"getmempoolinforesult-bytes":            "Size in bytes of the mempool",
+	"getmempoolinforesult-size":             "Number of transactions in the mempool",
+	"getmempoolinforesult-usage":            "Total memory usage of the mempool in bytes",
+	"getmempoolinforesult-maxmempool":       "Maximum memory usage of the mempool in bytes",
+	"getmempoolinforesult-mempoolminfee":    "Minimum fee rate in DASH/kB for a transaction to be accepted",
+	"getmempoolinforesult-minrelaytxfee":    "The minimum relay fee for non-free transactions in DASH/kB",
+	"getmempoolinforesult-instantsendlocks": "Number of unconfirmed transactions with an InstantSend lock",
 
 	// GetMiningInfoResult help.
 	"getmininginforesult-blocks":             "Height of the latest best block",
@@ -443,6 +536,11 @@ var helpDescsEnUS = map[string]string{
 	"getpeerinforesult-feefilter":      "The requested minimum fee a transaction must have to be announced to the peer",
 	"getpeerinforesult-syncnode":       "Whether or not the peer is the sync peer",
 
+	// Dash specific GetPeerInfoResult fields.
+	"getpeerinforesult-masternode":             "Whether or not the connection is a masternode connection",
+	"getpeerinforesult-verified_proregtx_hash": "The ProRegTx hash of the masternode authenticated on a masternode connection",
+	"getpeerinforesult-verified_pubkey_hash":   "The hash of the operator public key of the masternode authenticated on a masternode connection",
+
 	// GetPeerInfoCmd help.
 	"getpeerinfo--synopsis": "Returns data about each connected network peer as an array of json objects.",
 
@@ -455,6 +553,11 @@ var helpDescsEnUS = map[string]string{
 	"getrawmempoolverboseresult-currentpriority":  "Current priority",
 	"getrawmempoolverboseresult-depends":          "Unconfirmed transactions used as inputs for this transaction",
 	"getrawmempoolverboseresult-vsize":            "The virtual size of a transaction",
+	"getrawmempoolverboseresult-descendantcount":  "Number of in-mempool descendant transactions, including this one",
+	"getrawmempoolverboseresult-descendantsize":   "Size of in-mempool descendants, including this one",
+	"getrawmempoolverboseresult-ancestorcount":    "Number of in-mempool ancestor transactions, including this one",
+	"getrawmempoolverboseresult-ancestorsize":     "Size of in-mempool ancestors, including this one",
+	"getrawmempoolverboseresult-instantlock":      "Whether the transaction is locked by InstantSend",
 
 	// GetRawMempoolCmd help.
 	"getrawmempool--synopsis":   "Returns information about all of the transactions currently in the memory pool.",
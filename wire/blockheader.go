@@ -45,8 +45,10 @@ type BlockHeader struct {
 const blockHeaderLen = 80
 
 // BlockHash computes the block identifier hash for the given block header.
+// Dash identifies blocks by the X11 hash of the 80-byte serialized header,
+// which is also the proof of work hash.
 func (h *BlockHeader) BlockHash() chainhash.Hash {
-	// Encode the header and double sha256 everything prior to the number of
+	// Encode the header and X11 hash everything prior to the number of
 	// transactions.  Ignore the error returns since there is no way the
 	// encode could fail except being out of memory which would cause a
 	// run-time panic.
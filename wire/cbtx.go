@@ -0,0 +1,128 @@
+// Copyright (c) 2018 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+const (
+	// CbTxVersionMerkleRootQuorums is the first coinbase payload version
+	// which commits to the merkle root of the active quorums.
+	CbTxVersionMerkleRootQuorums = 2
+
+	// CbTxVersionCLSigAndBalance is the first coinbase payload version
+	// which carries the best known chainlock and the balance of the credit
+	// pool.  It is used since Dash Core 20.
+	CbTxVersionCLSigAndBalance = 3
+)
+
+// CbTx represents the extra payload of a coinbase special transaction
+// (TxTypeCoinbase) as defined by DIP0004.  It commits to the merkle root of the
+// deterministic masternode list and, starting with version 2, the merkle root
+// of the active LLMQ quorum commitments.  Version 3 adds the chainlock of the
+// most recent chainlocked block, whose height is BestCLHeightDiff + 1 blocks
+// below the coinbase, and the balance of the platform credit pool in duffs.
+type CbTx struct {
+	Version           uint16
+	Height            uint32
+	MerkleRootMNList  chainhash.Hash
+	MerkleRootQuorums chainhash.Hash
+	BestCLHeightDiff  uint32
+	BestCLSignature   [BLSSignatureSize]byte
+	CreditPoolBalance int64
+}
+
+// BtcDecode decodes r using the Dash serialization of the payload into the
+// receiver.
+func (p *CbTx) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElements(r, &p.Version, &p.Height, &p.MerkleRootMNList)
+	if err != nil {
+		return err
+	}
+
+	p.MerkleRootQuorums = chainhash.Hash{}
+	p.BestCLHeightDiff = 0
+	p.BestCLSignature = [BLSSignatureSize]byte{}
+	p.CreditPoolBalance = 0
+	if p.Version < CbTxVersionMerkleRootQuorums {
+		return nil
+	}
+	if err := readElement(r, &p.MerkleRootQuorums); err != nil {
+		return err
+	}
+
+	if p.Version < CbTxVersionCLSigAndBalance {
+		return nil
+	}
+	heightDiff, err := ReadVarInt(r, pver)
+	if err != nil {
+		return err
+	}
+	if heightDiff > math.MaxUint32 {
+		str := fmt.Sprintf("best chainlock height difference %d is "+
+			"out of range", heightDiff)
+		return messageError("CbTx.BtcDecode", str)
+	}
+	p.BestCLHeightDiff = uint32(heightDiff)
+	return readElements(r, &p.BestCLSignature, &p.CreditPoolBalance)
+}
+
+// BtcEncode encodes the receiver to w using the Dash serialization of the
+// payload.
+func (p *CbTx) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElements(w, p.Version, p.Height, &p.MerkleRootMNList)
+	if err != nil {
+		return err
+	}
+
+	if p.Version < CbTxVersionMerkleRootQuorums {
+		return nil
+	}
+	if err := writeElement(w, &p.MerkleRootQuorums); err != nil {
+		return err
+	}
+
+	if p.Version < CbTxVersionCLSigAndBalance {
+		return nil
+	}
+	err = WriteVarInt(w, pver, uint64(p.BestCLHeightDiff))
+	if err != nil {
+		return err
+	}
+	return writeElements(w, &p.BestCLSignature, p.CreditPoolBalance)
+}
+
+// SerializeSize returns the number of bytes it would take to serialize the
+// payload.
+func (p *CbTx) SerializeSize() int {
+	// Version 2 bytes + Height 4 bytes + MerkleRootMNList 32 bytes.
+	n := 6 + chainhash.HashSize
+	if p.Version >= CbTxVersionMerkleRootQuorums {
+		n += chainhash.HashSize
+	}
+	if p.Version >= CbTxVersionCLSigAndBalance {
+		// BestCLHeightDiff varint + BestCLSignature 96 bytes +
+		// CreditPoolBalance 8 bytes.
+		n += VarIntSerializeSize(uint64(p.BestCLHeightDiff)) +
+			BLSSignatureSize + 8
+	}
+	return n
+}
+
+// CbTx decodes the extra payload of a coinbase special transaction.  An error
+// is returned when the transaction is of another type or the payload is
+// malformed.
+func (msg *MsgTx) CbTx() (*CbTx, error) {
+	var payload CbTx
+	if err := msg.decodePayload(TxTypeCoinbase, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2018 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestCbTxWire tests the CbTx payload encode and decode for the payload
+// versions with and without the quorum merkle root.
+func TestCbTxWire(t *testing.T) {
+	mnListRoot := chainhash.Hash{0x01, 0x02, 0x03}
+	quorumsRoot := chainhash.Hash{0x04, 0x05, 0x06}
+	clSig := [BLSSignatureSize]byte{0x8a, 0x1b, 0x2c}
+
+	tests := []struct {
+		in  *CbTx
+		buf []byte
+	}{
+		// Version 1 without the quorum merkle root.
+		{
+			&CbTx{
+				Version:          1,
+				Height:           1028160,
+				MerkleRootMNList: mnListRoot,
+			},
+			append([]byte{
+				0x01, 0x00, // Version
+				0x40, 0xb0, 0x0f, 0x00, // Height
+			}, mnListRoot[:]...),
+		},
+
+		// Version 2 with the quorum merkle root.
+		{
+			&CbTx{
+				Version:           2,
+				Height:            1028160,
+				MerkleRootMNList:  mnListRoot,
+				MerkleRootQuorums: quorumsRoot,
+			},
+			append(append([]byte{
+				0x02, 0x00, // Version
+				0x40, 0xb0, 0x0f, 0x00, // Height
+			}, mnListRoot[:]...), quorumsRoot[:]...),
+		},
+
+		// Version 3 with the best chainlock and the credit pool balance.
+		{
+			&CbTx{
+				Version:           3,
+				Height:            1937823,
+				MerkleRootMNList:  mnListRoot,
+				MerkleRootQuorums: quorumsRoot,
+				BestCLHeightDiff:  0,
+				BestCLSignature:   clSig,
+				CreditPoolBalance: 1234567890,
+			},
+			append(append(append(append(append([]byte{
+				0x03, 0x00, // Version
+				0x9f, 0x91, 0x1d, 0x00, // Height
+			}, mnListRoot[:]...), quorumsRoot[:]...),
+				0x00), // BestCLHeightDiff
+				clSig[:]...),
+				0xd2, 0x02, 0x96, 0x49, 0x00, 0x00, 0x00, 0x00, // CreditPoolBalance
+			),
+		},
+
+		// Version 3 with a height difference which takes a multi-byte
+		// varint.
+		{
+			&CbTx{
+				Version:           3,
+				Height:            1937823,
+				MerkleRootMNList:  mnListRoot,
+				MerkleRootQuorums: quorumsRoot,
+				BestCLHeightDiff:  300,
+				BestCLSignature:   clSig,
+			},
+			append(append(append(append(append([]byte{
+				0x03, 0x00, // Version
+				0x9f, 0x91, 0x1d, 0x00, // Height
+			}, mnListRoot[:]...), quorumsRoot[:]...),
+				0xfd, 0x2c, 0x01), // BestCLHeightDiff
+				clSig[:]...),
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // CreditPoolBalance
+			),
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		var buf bytes.Buffer
+		err := test.in.BtcEncode(&buf, ProtocolVersion, BaseEncoding)
+		if err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("BtcEncode #%d\n got: %s want: %s", i,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+		if size := test.in.SerializeSize(); size != len(test.buf) {
+			t.Errorf("SerializeSize #%d got %d, want %d", i, size,
+				len(test.buf))
+		}
+
+		var payload CbTx
+		rbuf := bytes.NewReader(test.buf)
+		err = payload.BtcDecode(rbuf, ProtocolVersion, BaseEncoding)
+		if err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(&payload, test.in) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i,
+				spew.Sdump(&payload), spew.Sdump(test.in))
+			continue
+		}
+
+		// The payload must also be available from a coinbase special
+		// transaction carrying it.
+		tx := NewMsgTx(SpecialTxVersion)
+		tx.Type = TxTypeCoinbase
+		tx.ExtraPayload = test.buf
+		cbTx, err := tx.CbTx()
+		if err != nil {
+			t.Errorf("CbTx #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(cbTx, test.in) {
+			t.Errorf("CbTx #%d\n got: %s want: %s", i,
+				spew.Sdump(cbTx), spew.Sdump(test.in))
+		}
+	}
+}
@@ -87,7 +87,7 @@ the following constants:
 	wire.MainNet
 	wire.TestNet  (Regression test network)
 	wire.TestNet3 (Test network version 3)
-	wire.SimNet   (Simulation test network)
+	wire.DevNet   (Development networks)
 
 Determining Message Type
 
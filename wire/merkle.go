@@ -0,0 +1,50 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// CalcMerkleRoot calculates the merkle root of the passed transactions as
+// committed to by the MerkleRoot field of a block header.  The leaves of the
+// tree are the transaction hashes and each parent is the double sha256 of the
+// concatenation of its two children.  An odd number of hashes at any level is
+// handled by duplicating the last one.  The zero hash is returned when there
+// are no transactions.
+func CalcMerkleRoot(txns []*MsgTx) chainhash.Hash {
+	if len(txns) == 0 {
+		return chainhash.Hash{}
+	}
+
+	hashes := make([]chainhash.Hash, 0, len(txns))
+	for _, tx := range txns {
+		hashes = append(hashes, tx.TxHash())
+	}
+	return merkleRoot(hashes)
+}
+
+// merkleRoot calculates the merkle root of the tree with the passed leaves the
+// way CalcMerkleRoot describes.  The passed slice is modified.
+func merkleRoot(hashes []chainhash.Hash) chainhash.Hash {
+	if len(hashes) == 0 {
+		return chainhash.Hash{}
+	}
+
+	for len(hashes) > 1 {
+		if len(hashes)%2 != 0 {
+			hashes = append(hashes, hashes[len(hashes)-1])
+		}
+		next := make([]chainhash.Hash, 0, len(hashes)/2)
+		for i := 0; i < len(hashes); i += 2 {
+			var buf [chainhash.HashSize * 2]byte
+			copy(buf[:chainhash.HashSize], hashes[i][:])
+			copy(buf[chainhash.HashSize:], hashes[i+1][:])
+			next = append(next, chainhash.DoubleHashH(buf[:]))
+		}
+		hashes = next
+	}
+	return hashes[0]
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// genesisCoinbaseTxHex is the serialized coinbase transaction of the genesis
+// block shared by the Dash main, test and regression test networks.
+const genesisCoinbaseTxHex = "" +
+	"0100000001000000000000000000000000000000000000000000000000000000" +
+	"0000000000ffffffff6204ffff001d01044c5957697265642030392f4a616e2f" +
+	"3230313420546865204772616e64204578706572696d656e7420476f6573204c" +
+	"6976653a204f76657273746f636b2e636f6d204973204e6f7720416363657074" +
+	"696e6720426974636f696e73ffffffff0100f2052a010000004341040184710f" +
+	"a689ad5023690c80f3a49c8f13f8d45b8c857fbcbc8bc4a8e4d3eb4b10f4d460" +
+	"4fa08dce601aaf0f470216fe1b51850b4acf21b179c45070ac7b03a9ac000000" +
+	"00"
+
+// TestCalcMerkleRoot ensures CalcMerkleRoot produces the expected merkle roots,
+// including the one committed to by the Dash genesis block, for trees which
+// need the last hash duplicated at one or more levels.
+func TestCalcMerkleRoot(t *testing.T) {
+	t.Parallel()
+
+	serializedTx, err := hex.DecodeString(genesisCoinbaseTxHex)
+	if err != nil {
+		t.Fatalf("DecodeString: unexpected error: %v", err)
+	}
+
+	// Derive distinct transactions from the genesis coinbase by changing
+	// the lock time.
+	txns := make([]*MsgTx, 0, 5)
+	for i := 0; i < 5; i++ {
+		var tx MsgTx
+		err := tx.Deserialize(bytes.NewReader(serializedTx))
+		if err != nil {
+			t.Fatalf("Deserialize: unexpected error: %v", err)
+		}
+		tx.LockTime = uint32(i)
+		txns = append(txns, &tx)
+	}
+
+	tests := []struct {
+		name string
+		txns []*MsgTx
+		want string
+	}{
+		{
+			name: "no transactions",
+			want: "0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "genesis coinbase",
+			txns: txns[:1],
+			want: "e0028eb9648db56b1ac77cf090b99048a8007e2bb64b68f092c03c7f56a662c7",
+		},
+		{
+			name: "two transactions",
+			txns: txns[:2],
+			want: "b1f9c2db5d32d7b81987d2464195d925c2ea81e0d65ebd1c38db96f944f06e3c",
+		},
+		{
+			name: "three transactions",
+			txns: txns[:3],
+			want: "4f8e638d6df9f19c85fa403dea8cb52ed4ee2b6e3dac4a4155e8a0d1ae9dd7f1",
+		},
+		{
+			name: "three transactions with last duplicated",
+			txns: []*MsgTx{txns[0], txns[1], txns[2], txns[2]},
+			want: "4f8e638d6df9f19c85fa403dea8cb52ed4ee2b6e3dac4a4155e8a0d1ae9dd7f1",
+		},
+		{
+			name: "four transactions",
+			txns: txns[:4],
+			want: "777245b092671e66c626832ca6f465c9f227fa9c4fed476cccd56192bb7ee3c5",
+		},
+		{
+			name: "five transactions",
+			txns: txns,
+			want: "49e0aa11224fb2ea0b2660fa436843b65e9891926aebc61dec1a86a58d7464a2",
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		want, err := chainhash.NewHashFromStr(test.want)
+		if err != nil {
+			t.Errorf("NewHashFromStr #%d (%s): unexpected error: %v",
+				i, test.name, err)
+			continue
+		}
+		got := CalcMerkleRoot(test.txns)
+		if !got.IsEqual(want) {
+			t.Errorf("CalcMerkleRoot #%d (%s): got %v, want %v", i,
+				test.name, got, want)
+		}
+	}
+}
@@ -52,6 +52,19 @@ const (
 	CmdReject      = "reject"
 	CmdSendHeaders = "sendheaders"
 	CmdFeeFilter   = "feefilter"
+	CmdCLSig       = "clsig"
+	CmdISLock      = "islock"
+	CmdISDLock     = "isdlock"
+
+	// CmdGetMnListDiff is truncated to fit the 12 byte command size.
+	CmdGetMnListDiff = "getmnlistd"
+	CmdMnListDiff    = "mnlistdiff"
+	CmdSpork         = "spork"
+	CmdGovObject     = "govobj"
+	CmdGovObjectVote = "govobjvote"
+	CmdGovSync       = "govsync"
+	CmdDSQ           = "dsq"
+	CmdDSTX          = "dstx"
 )
 
 // MessageEncoding represents the wire message encoding format to be used.
@@ -157,6 +170,39 @@ func makeEmptyMessage(command string) (Message, error) {
 	case CmdFeeFilter:
 		msg = &MsgFeeFilter{}
 
+	case CmdCLSig:
+		msg = &MsgCLSig{}
+
+	case CmdISLock:
+		msg = &MsgISLock{}
+
+	case CmdISDLock:
+		msg = &MsgISDLock{}
+
+	case CmdGetMnListDiff:
+		msg = &MsgGetMnListDiff{}
+
+	case CmdMnListDiff:
+		msg = &MsgMnListDiff{}
+
+	case CmdSpork:
+		msg = &MsgSpork{}
+
+	case CmdGovObject:
+		msg = &MsgGovObject{}
+
+	case CmdGovObjectVote:
+		msg = &MsgGovObjectVote{}
+
+	case CmdGovSync:
+		msg = &MsgGovSync{}
+
+	case CmdDSQ:
+		msg = &MsgDSQ{}
+
+	case CmdDSTX:
+		msg = &MsgDSTX{}
+
 	default:
 		return nil, fmt.Errorf("unhandled command [%s]", command)
 	}
@@ -190,7 +236,7 @@ func readMessageHeader(r io.Reader) (int, *messageHeader, error) {
 	readElements(hr, &hdr.magic, &command, &hdr.length, &hdr.checksum)
 
 	// Strip trailing zeros from command string.
-	hdr.command = string(bytes.TrimRight(command[:], string(0)))
+	hdr.command = string(bytes.TrimRight(command[:], "\x00"))
 
 	return n, &hdr, nil
 }
@@ -69,6 +69,9 @@ func TestMessage(t *testing.T) {
 	bh := NewBlockHeader(1, &chainhash.Hash{}, &chainhash.Hash{}, 0, 0)
 	msgMerkleBlock := NewMsgMerkleBlock(bh)
 	msgReject := NewMsgReject("block", RejectDuplicate, "duplicate block")
+	msgCLSig := NewMsgCLSig(1, &chainhash.Hash{}, [BLSSignatureSize]byte{})
+	msgGetMnListDiff := NewMsgGetMnListDiff(&chainhash.Hash{}, &chainhash.Hash{})
+	msgSpork := NewMsgSpork(10001, 0, 1546300800, []byte{0x01, 0x02})
 
 	tests := []struct {
 		in     Message    // Value to encode
@@ -77,7 +80,7 @@ func TestMessage(t *testing.T) {
 		btcnet DASHNet // Network to use for wire encoding
 		bytes  int        // Expected num bytes read/written
 	}{
-		{msgVersion, msgVersion, pver, MainNet, 125},
+		{msgVersion, msgVersion, pver, MainNet, 158},
 		{msgVerack, msgVerack, pver, MainNet, 24},
 		{msgGetAddr, msgGetAddr, pver, MainNet, 24},
 		{msgAddr, msgAddr, pver, MainNet, 25},
@@ -98,6 +101,9 @@ func TestMessage(t *testing.T) {
 		{msgFilterLoad, msgFilterLoad, pver, MainNet, 35},
 		{msgMerkleBlock, msgMerkleBlock, pver, MainNet, 110},
 		{msgReject, msgReject, pver, MainNet, 79},
+		{msgCLSig, msgCLSig, pver, MainNet, 156},
+		{msgGetMnListDiff, msgGetMnListDiff, pver, MainNet, 88},
+		{msgSpork, msgSpork, pver, MainNet, 47},
 	}
 
 	t.Logf("Running %d tests", len(tests))
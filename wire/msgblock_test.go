@@ -6,6 +6,7 @@ package wire
 
 import (
 	"bytes"
+	"encoding/hex"
 	"io"
 	"reflect"
 	"testing"
@@ -89,20 +90,129 @@ func TestBlockTxHashes(t *testing.T) {
 	}
 }
 
-// TestBlockHash tests the ability to generate the hash of a block accurately.
+// TestBlockHash tests the ability to generate the X11 hash of a block
+// accurately using the genesis blocks of the Dash networks.
 func TestBlockHash(t *testing.T) {
-	// Block 1 hash.
-	hashStr := "839a8e6886ab5951d76f411475428afc90947ee320161bbf18eb6048"
-	wantHash, err := chainhash.NewHashFromStr(hashStr)
+	merkleRoot, err := chainhash.NewHashFromStr("e0028eb9648db56b1ac77cf090" +
+		"b99048a8007e2bb64b68f092c03c7f56a662c7")
 	if err != nil {
-		t.Errorf("NewHashFromStr: %v", err)
+		t.Fatalf("NewHashFromStr: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		timestamp int64
+		bits      uint32
+		nonce     uint32
+		want      string
+	}{
+		{
+			name:      "mainnet genesis",
+			timestamp: 1390095618,
+			bits:      0x1e0ffff0,
+			nonce:     28917698,
+			want:      "00000ffd590b1485b3caadc19b22e6379c733355108f107a430458cdf3407ab6",
+		},
+		{
+			name:      "testnet genesis",
+			timestamp: 1390666206,
+			bits:      0x1e0ffff0,
+			nonce:     3861367235,
+			want:      "00000bafbc94add76cb75e2ec92894837288a481e5c005f6563d91623bf8bc2c",
+		},
+		{
+			name:      "regtest genesis",
+			timestamp: 1417713337,
+			bits:      0x207fffff,
+			nonce:     1096447,
+			want:      "000008ca1832a4baf228eb1553c03d3a2c8e02399550dd6ea8d65cec3ef23d2e",
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		wantHash, err := chainhash.NewHashFromStr(test.want)
+		if err != nil {
+			t.Errorf("NewHashFromStr #%d (%s): %v", i, test.name, err)
+			continue
+		}
+
+		header := NewBlockHeader(1, &chainhash.Hash{}, merkleRoot,
+			test.bits, test.nonce)
+		header.Timestamp = time.Unix(test.timestamp, 0)
+
+		// Ensure the hash produced is expected for both the header and
+		// the block.
+		headerHash := header.BlockHash()
+		if !headerHash.IsEqual(wantHash) {
+			t.Errorf("BlockHeader.BlockHash #%d (%s): wrong hash - "+
+				"got %v, want %v", i, test.name,
+				spew.Sprint(headerHash), spew.Sprint(wantHash))
+		}
+		block := NewMsgBlock(header)
+		blockHash := block.BlockHash()
+		if !blockHash.IsEqual(wantHash) {
+			t.Errorf("MsgBlock.BlockHash #%d (%s): wrong hash - "+
+				"got %v, want %v", i, test.name,
+				spew.Sprint(blockHash), spew.Sprint(wantHash))
+		}
+	}
+}
+
+// mainNetGenesisBlockHex is the serialized genesis block of the Dash main
+// network.
+var mainNetGenesisBlockHex = "01000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000c762a6567f3cc092f0684bb62b7e00a84890b990f07cc71a" +
+	"6bb58d64b98e02e0022ddb52f0ff0f1ec23fb901010100000001000000000000" +
+	"0000000000000000000000000000000000000000000000000000ffffffff6204" +
+	"ffff001d01044c5957697265642030392f4a616e2f3230313420546865204772" +
+	"616e64204578706572696d656e7420476f6573204c6976653a204f7665727374" +
+	"6f636b2e636f6d204973204e6f7720416363657074696e6720426974636f696e" +
+	"73ffffffff0100f2052a010000004341040184710fa689ad5023690c80f3a49c" +
+	"8f13f8d45b8c857fbcbc8bc4a8e4d3eb4b10f4d4604fa08dce601aaf0f470216" +
+	"fe1b51850b4acf21b179c45070ac7b03a9ac00000000"
+
+// TestMainNetGenesisBlock ensures the serialized genesis block of the Dash main
+// network decodes to a block with the published hash and merkle root and
+// round trips.
+func TestMainNetGenesisBlock(t *testing.T) {
+	const (
+		wantHash       = "00000ffd590b1485b3caadc19b22e6379c733355108f107a430458cdf3407ab6"
+		wantMerkleRoot = "e0028eb9648db56b1ac77cf090b99048a8007e2bb64b68f092c03c7f56a662c7"
+	)
+
+	serialized, err := hex.DecodeString(mainNetGenesisBlockHex)
+	if err != nil {
+		t.Fatalf("DecodeString: %v", err)
 	}
 
-	// Ensure the hash produced is expected.
-	blockHash := blockOne.BlockHash()
-	if !blockHash.IsEqual(wantHash) {
-		t.Errorf("BlockHash: wrong hash - got %v, want %v",
-			spew.Sprint(blockHash), spew.Sprint(wantHash))
+	var block MsgBlock
+	if err := block.Deserialize(bytes.NewReader(serialized)); err != nil {
+		t.Fatalf("Deserialize: %v", err)
+	}
+	if hash := block.BlockHash().String(); hash != wantHash {
+		t.Errorf("BlockHash: got %s, want %s", hash, wantHash)
+	}
+	if len(block.Transactions) != 1 {
+		t.Fatalf("got %d transactions, want 1", len(block.Transactions))
+	}
+
+	// The merkle root of a block with a single transaction is the hash of
+	// that transaction.
+	if root := block.Header.MerkleRoot.String(); root != wantMerkleRoot {
+		t.Errorf("MerkleRoot: got %s, want %s", root, wantMerkleRoot)
+	}
+	if txHash := block.Transactions[0].TxHash().String(); txHash != wantMerkleRoot {
+		t.Errorf("TxHash: got %s, want %s", txHash, wantMerkleRoot)
+	}
+
+	var buf bytes.Buffer
+	if err := block.Serialize(&buf); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), serialized) {
+		t.Errorf("Serialize: round trip mismatch\n got: %x\n want: %x",
+			buf.Bytes(), serialized)
 	}
 }
 
@@ -0,0 +1,116 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// clsigRequestIDPrefix is the prefix of the data hashed into the request ID of
+// a ChainLock.
+const clsigRequestIDPrefix = "clsig"
+
+// LLMQSignHash returns the hash an LLMQ of the given type actually signs when
+// the quorum with the given hash recovers a signature for the request ID and
+// message hash.  It is the double sha256 of the serialized LLMQ type, quorum
+// hash, request ID and message hash, and is the message the recovered BLS
+// signature of a ChainLock or InstantSend lock is verified against.
+func LLMQSignHash(llmqType uint8, quorumHash, requestID,
+	msgHash *chainhash.Hash) chainhash.Hash {
+
+	var buf [1 + chainhash.HashSize*3]byte
+	buf[0] = llmqType
+	copy(buf[1:], quorumHash[:])
+	copy(buf[1+chainhash.HashSize:], requestID[:])
+	copy(buf[1+chainhash.HashSize*2:], msgHash[:])
+	return chainhash.DoubleHashH(buf[:])
+}
+
+// ChainLockRequestID returns the request ID the LLMQ signs when locking the
+// block at the given height as defined by DIP0008.  It is the double sha256 of
+// the serialized "clsig" prefix followed by the height.
+func ChainLockRequestID(height int32) chainhash.Hash {
+	var buf bytes.Buffer
+	buf.Grow(1 + len(clsigRequestIDPrefix) + 4)
+
+	// Writing to a bytes.Buffer never fails, so the errors are ignored.
+	_ = WriteVarString(&buf, 0, clsigRequestIDPrefix)
+	_ = binary.Write(&buf, littleEndian, height)
+	return chainhash.DoubleHashH(buf.Bytes())
+}
+
+// ChainLockSignID returns the hash signed by the quorum with the given LLMQ
+// type and hash for a ChainLock of the block with the given hash and height.
+// The recovered signature of a clsig message is valid when it verifies
+// against this hash and the public key of the quorum.  The request ID, which
+// only depends on the height, is returned by ChainLockRequestID.
+func ChainLockSignID(llmqType uint8, quorumHash *chainhash.Hash, height int32,
+	blockHash *chainhash.Hash) chainhash.Hash {
+
+	requestID := ChainLockRequestID(height)
+	return LLMQSignHash(llmqType, quorumHash, &requestID, blockHash)
+}
+
+// MsgCLSig implements the Message interface and represents a Dash clsig
+// message.  It is used to announce a ChainLock, the LLMQ signature which locks
+// the block with the given hash at the given height as defined by DIP0008.
+type MsgCLSig struct {
+	Height    int32
+	BlockHash chainhash.Hash
+	Signature [BLSSignatureSize]byte
+}
+
+// RequestID returns the request ID signed by the LLMQ for the height of the
+// ChainLock.  See ChainLockRequestID.
+func (msg *MsgCLSig) RequestID() chainhash.Hash {
+	return ChainLockRequestID(msg.Height)
+}
+
+// SignHash returns the hash signed by the quorum with the given LLMQ type and
+// hash for the ChainLock.  See ChainLockSignID.
+func (msg *MsgCLSig) SignHash(llmqType uint8, quorumHash *chainhash.Hash) chainhash.Hash {
+	return ChainLockSignID(llmqType, quorumHash, msg.Height, &msg.BlockHash)
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgCLSig) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	return readElements(r, &msg.Height, &msg.BlockHash, &msg.Signature)
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgCLSig) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	return writeElements(w, msg.Height, &msg.BlockHash, msg.Signature)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgCLSig) Command() string {
+	return CmdCLSig
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgCLSig) MaxPayloadLength(pver uint32) uint32 {
+	// Height 4 bytes + BlockHash 32 bytes + Signature 96 bytes.
+	return 4 + chainhash.HashSize + BLSSignatureSize
+}
+
+// NewMsgCLSig returns a new Dash clsig message that conforms to the Message
+// interface.  See MsgCLSig for details.
+func NewMsgCLSig(height int32, blockHash *chainhash.Hash,
+	signature [BLSSignatureSize]byte) *MsgCLSig {
+
+	return &MsgCLSig{
+		Height:    height,
+		BlockHash: *blockHash,
+		Signature: signature,
+	}
+}
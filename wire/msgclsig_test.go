@@ -0,0 +1,152 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestCLSig tests the MsgCLSig API and its wire encoding.
+func TestCLSig(t *testing.T) {
+	pver := ProtocolVersion
+
+	blockHash := chainhash.Hash{0x01, 0x02, 0x03}
+	var sig [BLSSignatureSize]byte
+	sig[0], sig[BLSSignatureSize-1] = 0xaa, 0xbb
+	msg := NewMsgCLSig(1028160, &blockHash, sig)
+
+	// Ensure the command is expected value.
+	wantCmd := "clsig"
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgCLSig: wrong command - got %v want %v",
+			cmd, wantCmd)
+	}
+
+	// Ensure max payload is expected value for latest protocol version.
+	wantPayload := uint32(132)
+	maxPayload := msg.MaxPayloadLength(pver)
+	if maxPayload != wantPayload {
+		t.Errorf("MaxPayloadLength: wrong max payload length for "+
+			"protocol version %d - got %v, want %v", pver,
+			maxPayload, wantPayload)
+	}
+
+	// Ensure the encoding matches the expected bytes.
+	want := []byte{0x40, 0xb0, 0x0f, 0x00} // Height
+	want = append(want, blockHash[:]...)
+	want = append(want, sig[:]...)
+
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, pver, BaseEncoding); err != nil {
+		t.Fatalf("encode of MsgCLSig failed %v err <%v>", msg, err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("BtcEncode\n got: %s want: %s",
+			spew.Sdump(buf.Bytes()), spew.Sdump(want))
+	}
+
+	// Ensure decoding produces the same message.
+	var readmsg MsgCLSig
+	if err := readmsg.BtcDecode(&buf, pver, BaseEncoding); err != nil {
+		t.Fatalf("decode of MsgCLSig failed [%v] err <%v>", buf, err)
+	}
+	if !reflect.DeepEqual(&readmsg, msg) {
+		t.Errorf("BtcDecode\n got: %s want: %s", spew.Sdump(&readmsg),
+			spew.Sdump(msg))
+	}
+
+	// Ensure a truncated message fails to decode.
+	r := newFixedReader(len(want)-1, want)
+	if err := readmsg.BtcDecode(r, pver, BaseEncoding); err != io.ErrUnexpectedEOF {
+		t.Errorf("BtcDecode: wrong error for truncated message - got "+
+			"%v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+// TestLLMQSignHash tests the hash an LLMQ signs against the sign hash dashd
+// returned from the quorum sign command on a regression test network.
+func TestLLMQSignHash(t *testing.T) {
+	hash := func(s string) *chainhash.Hash {
+		h, err := chainhash.NewHashFromStr(s)
+		if err != nil {
+			t.Fatalf("NewHashFromStr: %v", err)
+		}
+		return h
+	}
+
+	quorumHash := hash("53d959f609a654cf4e5e3c083fd6c47b7ec6cb73af4ac7329149688337b8ef9a")
+	requestID := hash("0000000000000000000000000000000000000000000000000000000000000001")
+	msgHash := hash("0000000000000000000000000000000000000000000000000000000000000002")
+	want := "39458221939396a45a2e348caada646eabd52849990827d40e33eb1399097b3c"
+
+	// llmq_test, which is LLMQ type 100.
+	got := LLMQSignHash(100, quorumHash, requestID, msgHash)
+	if got.String() != want {
+		t.Errorf("LLMQSignHash: got %v, want %v", got, want)
+	}
+}
+
+// TestChainLockSignID tests the request ID and sign hash of a ChainLock.
+func TestChainLockSignID(t *testing.T) {
+	blockHash, err := chainhash.NewHashFromStr("000000000000001767c0a1e7d4a0b2ce55c5d64da8ba6c0c4e7c56e9e0ba9c33")
+	if err != nil {
+		t.Fatalf("NewHashFromStr: %v", err)
+	}
+	quorumHash, err := chainhash.NewHashFromStr("0000000000000010c1a5a0bbd14b5d9d2cbfa7d4b5f1b1a6e3b8d5f3b4e2a1c0")
+	if err != nil {
+		t.Fatalf("NewHashFromStr: %v", err)
+	}
+
+	// The request ID is the hash of the pair of the "clsig" prefix and the
+	// height as serialized by dashd, a compact size prefixed string
+	// followed by the little endian height.
+	tests := []struct {
+		height   int32
+		preimage []byte
+		want     string
+	}{
+		{
+			0,
+			[]byte{0x05, 'c', 'l', 's', 'i', 'g', 0x00, 0x00, 0x00, 0x00},
+			"7be1e84ae07d85a993b328fd76a5a2f8e18bf55d72026a3298aea7476a17a34e",
+		},
+		{
+			1000000,
+			[]byte{0x05, 'c', 'l', 's', 'i', 'g', 0x40, 0x42, 0x0f, 0x00},
+			"b3bf8849c16a0f05a4635d7cd0cf03c72d2024c11aa783b6ac716c1817fd4f14",
+		},
+	}
+	for _, test := range tests {
+		got := ChainLockRequestID(test.height)
+		if got.String() != test.want {
+			t.Errorf("ChainLockRequestID(%d): got %v, want %v",
+				test.height, got, test.want)
+		}
+		if want := chainhash.DoubleHashH(test.preimage); got != want {
+			t.Errorf("ChainLockRequestID(%d): got %v, want hash "+
+				"of %x", test.height, got, test.preimage)
+		}
+	}
+
+	// The sign hash of a ChainLock by a llmq_400_60 quorum.
+	msg := NewMsgCLSig(1000000, blockHash, [BLSSignatureSize]byte{})
+	if got := msg.RequestID(); got != ChainLockRequestID(1000000) {
+		t.Errorf("RequestID: got %v", got)
+	}
+	want := "b2a3792e54782ead3cfd23c994e11559eb75029356a8438975782ec80ce34e26"
+	signHash := ChainLockSignID(2, quorumHash, msg.Height, &msg.BlockHash)
+	if signHash.String() != want {
+		t.Errorf("ChainLockSignID: got %v, want %v", signHash, want)
+	}
+	if got := msg.SignHash(2, quorumHash); got != signHash {
+		t.Errorf("SignHash: got %v, want %v", got, signHash)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"io"
+)
+
+// maxCoinJoinSignatureSize is the maximum size of the masternode signature of
+// a CoinJoin message.  It is large enough to hold both compact ECDSA and BLS
+// signatures.
+const maxCoinJoinSignatureSize = BLSSignatureSize
+
+// MsgDSQ implements the Message interface and represents a Dash dsq message.
+// It is used by a masternode to announce a CoinJoin mixing queue for the
+// given denomination and to signal once the queue is ready to mix.
+//
+// The masternode is identified by the outpoint of its collateral and the
+// denomination is given in its bit representation, see the coinjoin package.
+type MsgDSQ struct {
+	Denomination       int32
+	MasternodeOutpoint OutPoint
+	Time               int64
+	Ready              bool
+	Signature          []byte
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgDSQ) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElement(r, &msg.Denomination)
+	if err != nil {
+		return err
+	}
+
+	err = readOutPoint(r, pver, 0, &msg.MasternodeOutpoint)
+	if err != nil {
+		return err
+	}
+
+	err = readElements(r, &msg.Time, &msg.Ready)
+	if err != nil {
+		return err
+	}
+
+	msg.Signature, err = ReadVarBytes(r, pver, maxCoinJoinSignatureSize,
+		"dsq signature")
+	return err
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgDSQ) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElement(w, msg.Denomination)
+	if err != nil {
+		return err
+	}
+
+	err = writeOutPoint(w, pver, 0, &msg.MasternodeOutpoint)
+	if err != nil {
+		return err
+	}
+
+	err = writeElements(w, msg.Time, msg.Ready)
+	if err != nil {
+		return err
+	}
+
+	return WriteVarBytes(w, pver, msg.Signature)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgDSQ) Command() string {
+	return CmdDSQ
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgDSQ) MaxPayloadLength(pver uint32) uint32 {
+	// Denomination 4 bytes + MasternodeOutpoint 36 bytes + Time 8 bytes +
+	// Ready 1 byte + signature length varint 1 byte + Signature.
+	return 4 + outPointSize + 10 + maxCoinJoinSignatureSize
+}
+
+// NewMsgDSQ returns a new Dash dsq message that conforms to the Message
+// interface.  See MsgDSQ for details.
+func NewMsgDSQ(denomination int32, outpoint *OutPoint, time int64,
+	ready bool, signature []byte) *MsgDSQ {
+
+	return &MsgDSQ{
+		Denomination:       denomination,
+		MasternodeOutpoint: *outpoint,
+		Time:               time,
+		Ready:              ready,
+		Signature:          signature,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestCoinJoinMessages tests the MsgDSQ and MsgDSTX API and wire encoding.
+func TestCoinJoinMessages(t *testing.T) {
+	pver := ProtocolVersion
+
+	outpoint := OutPoint{Hash: chainhash.Hash{0x01}, Index: 1}
+	sig := bytes.Repeat([]byte{0xaa}, 65)
+
+	dsq := NewMsgDSQ(2, &outpoint, 1546300800, true, sig)
+	dsqEncoded := []byte{0x02, 0x00, 0x00, 0x00} // Denomination
+	dsqEncoded = append(dsqEncoded, outpoint.Hash[:]...)
+	dsqEncoded = append(dsqEncoded,
+		0x01, 0x00, 0x00, 0x00, // Index
+		0x80, 0xad, 0x2a, 0x5c, 0x00, 0x00, 0x00, 0x00, // Time
+		0x01, // Ready
+		byte(len(sig)),
+	)
+	dsqEncoded = append(dsqEncoded, sig...)
+
+	tx := NewMsgTx(2)
+	tx.AddTxIn(&TxIn{
+		PreviousOutPoint: OutPoint{Hash: chainhash.Hash{0x02}, Index: 0},
+		SignatureScript:  []byte{0x51},
+		Sequence:         MaxTxInSequenceNum,
+	})
+	tx.AddTxOut(&TxOut{Value: 100001, PkScript: []byte{0x51}})
+	var txBuf bytes.Buffer
+	if err := tx.Serialize(&txBuf); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	dstx := NewMsgDSTX(tx, &outpoint, sig, 1546300800)
+	dstxEncoded := append([]byte{}, txBuf.Bytes()...)
+	dstxEncoded = append(dstxEncoded, outpoint.Hash[:]...)
+	dstxEncoded = append(dstxEncoded, 0x01, 0x00, 0x00, 0x00, byte(len(sig)))
+	dstxEncoded = append(dstxEncoded, sig...)
+	dstxEncoded = append(dstxEncoded,
+		0x80, 0xad, 0x2a, 0x5c, 0x00, 0x00, 0x00, 0x00, // SigTime
+	)
+
+	tests := []struct {
+		in  Message // Message to encode
+		out Message // Empty message to decode into
+		cmd string  // Expected command
+		buf []byte  // Wire encoding
+	}{
+		{dsq, &MsgDSQ{}, "dsq", dsqEncoded},
+		{dstx, &MsgDSTX{}, "dstx", dstxEncoded},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		if cmd := test.in.Command(); cmd != test.cmd {
+			t.Errorf("Command #%d: wrong command - got %v want %v",
+				i, cmd, test.cmd)
+		}
+		if max := test.in.MaxPayloadLength(pver); max > MaxMessagePayload {
+			t.Errorf("MaxPayloadLength #%d: %d exceeds max message "+
+				"payload %d", i, max, MaxMessagePayload)
+		}
+
+		var buf bytes.Buffer
+		if err := test.in.BtcEncode(&buf, pver, BaseEncoding); err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("BtcEncode #%d\n got: %s want: %s", i,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+
+		rbuf := bytes.NewReader(test.buf)
+		if err := test.out.BtcDecode(rbuf, pver, BaseEncoding); err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.out, test.in) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i,
+				spew.Sdump(test.out), spew.Sdump(test.in))
+		}
+	}
+}
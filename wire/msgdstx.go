@@ -0,0 +1,91 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"io"
+)
+
+// MsgDSTX implements the Message interface and represents a Dash dstx
+// message.  It is used to broadcast the final transaction of a CoinJoin
+// mixing session together with the signature of the masternode, identified by
+// the outpoint of its collateral, which created it.
+type MsgDSTX struct {
+	Tx                 MsgTx
+	MasternodeOutpoint OutPoint
+	Signature          []byte
+	SigTime            int64
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgDSTX) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := msg.Tx.BtcDecode(r, pver, enc)
+	if err != nil {
+		return err
+	}
+
+	err = readOutPoint(r, pver, 0, &msg.MasternodeOutpoint)
+	if err != nil {
+		return err
+	}
+
+	msg.Signature, err = ReadVarBytes(r, pver, maxCoinJoinSignatureSize,
+		"dstx signature")
+	if err != nil {
+		return err
+	}
+
+	return readElement(r, &msg.SigTime)
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgDSTX) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := msg.Tx.BtcEncode(w, pver, enc)
+	if err != nil {
+		return err
+	}
+
+	err = writeOutPoint(w, pver, 0, &msg.MasternodeOutpoint)
+	if err != nil {
+		return err
+	}
+
+	err = WriteVarBytes(w, pver, msg.Signature)
+	if err != nil {
+		return err
+	}
+
+	return writeElement(w, msg.SigTime)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgDSTX) Command() string {
+	return CmdDSTX
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgDSTX) MaxPayloadLength(pver uint32) uint32 {
+	// Tx + MasternodeOutpoint 36 bytes + signature length varint 1 byte +
+	// Signature + SigTime 8 bytes.
+	return msg.Tx.MaxPayloadLength(pver) + outPointSize + 9 +
+		maxCoinJoinSignatureSize
+}
+
+// NewMsgDSTX returns a new Dash dstx message that conforms to the Message
+// interface.  See MsgDSTX for details.
+func NewMsgDSTX(tx *MsgTx, outpoint *OutPoint, signature []byte,
+	sigTime int64) *MsgDSTX {
+
+	return &MsgDSTX{
+		Tx:                 *tx,
+		MasternodeOutpoint: *outpoint,
+		Signature:          signature,
+		SigTime:            sigTime,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// MsgGetMnListDiff implements the Message interface and represents a Dash
+// getmnlistd message.  It is used to request the difference of the simplified
+// masternode list between the two blocks with the given hashes.  A zero base
+// block hash requests the full list at the block.
+//
+// The peer responds with a mnlistdiff message (MsgMnListDiff).
+type MsgGetMnListDiff struct {
+	BaseBlockHash chainhash.Hash
+	BlockHash     chainhash.Hash
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgGetMnListDiff) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	return readElements(r, &msg.BaseBlockHash, &msg.BlockHash)
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgGetMnListDiff) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	return writeElements(w, &msg.BaseBlockHash, &msg.BlockHash)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgGetMnListDiff) Command() string {
+	return CmdGetMnListDiff
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgGetMnListDiff) MaxPayloadLength(pver uint32) uint32 {
+	// BaseBlockHash 32 bytes + BlockHash 32 bytes.
+	return chainhash.HashSize * 2
+}
+
+// NewMsgGetMnListDiff returns a new Dash getmnlistd message that conforms to
+// the Message interface.  See MsgGetMnListDiff for details.
+func NewMsgGetMnListDiff(baseBlockHash, blockHash *chainhash.Hash) *MsgGetMnListDiff {
+	return &MsgGetMnListDiff{
+		BaseBlockHash: *baseBlockHash,
+		BlockHash:     *blockHash,
+	}
+}
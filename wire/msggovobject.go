@@ -0,0 +1,144 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+const (
+	// MaxGovObjectDataSize is the maximum size in bytes of the data of a
+	// governance object.
+	MaxGovObjectDataSize = 16 * 1024
+
+	// maxGovSignatureSize is the maximum size of the signature of a
+	// governance object or vote.  It is large enough to hold both compact
+	// ECDSA and BLS signatures.
+	maxGovSignatureSize = BLSSignatureSize
+)
+
+// GovObjectType identifies the kind of a governance object.
+type GovObjectType int32
+
+// Governance object types as defined by Dash Core.
+const (
+	GovObjectTypeUnknown  GovObjectType = 0
+	GovObjectTypeProposal GovObjectType = 1
+	GovObjectTypeTrigger  GovObjectType = 2
+	GovObjectTypeWatchdog GovObjectType = 3
+)
+
+// MsgGovObject implements the Message interface and represents a Dash govobj
+// message.  It is used to relay a governance object such as a budget proposal
+// or a superblock trigger.
+//
+// Data holds the hex encoded JSON description of the object.  Proposals are
+// paid for by the transaction with hash CollateralHash while triggers are
+// signed by the masternode identified by MasternodeOutpoint.
+type MsgGovObject struct {
+	HashParent         chainhash.Hash
+	Revision           int32
+	Time               int64
+	CollateralHash     chainhash.Hash
+	Data               []byte
+	ObjectType         GovObjectType
+	MasternodeOutpoint OutPoint
+	Signature          []byte
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgGovObject) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElements(r, &msg.HashParent, &msg.Revision, &msg.Time,
+		&msg.CollateralHash)
+	if err != nil {
+		return err
+	}
+
+	msg.Data, err = ReadVarBytes(r, pver, MaxGovObjectDataSize,
+		"governance object data")
+	if err != nil {
+		return err
+	}
+
+	err = readElement(r, (*int32)(&msg.ObjectType))
+	if err != nil {
+		return err
+	}
+
+	err = readOutPoint(r, pver, 0, &msg.MasternodeOutpoint)
+	if err != nil {
+		return err
+	}
+
+	msg.Signature, err = ReadVarBytes(r, pver, maxGovSignatureSize,
+		"governance object signature")
+	return err
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgGovObject) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElements(w, &msg.HashParent, msg.Revision, msg.Time,
+		&msg.CollateralHash)
+	if err != nil {
+		return err
+	}
+
+	err = WriteVarBytes(w, pver, msg.Data)
+	if err != nil {
+		return err
+	}
+
+	err = writeElement(w, int32(msg.ObjectType))
+	if err != nil {
+		return err
+	}
+
+	err = writeOutPoint(w, pver, 0, &msg.MasternodeOutpoint)
+	if err != nil {
+		return err
+	}
+
+	return WriteVarBytes(w, pver, msg.Signature)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgGovObject) Command() string {
+	return CmdGovObject
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgGovObject) MaxPayloadLength(pver uint32) uint32 {
+	// HashParent 32 bytes + Revision 4 bytes + Time 8 bytes +
+	// CollateralHash 32 bytes + data length varint + Data + ObjectType
+	// 4 bytes + MasternodeOutpoint 36 bytes + signature length varint 1
+	// byte + Signature.
+	return chainhash.HashSize*2 + 12 +
+		uint32(VarIntSerializeSize(MaxGovObjectDataSize)) +
+		MaxGovObjectDataSize + 4 + outPointSize + 1 + maxGovSignatureSize
+}
+
+// NewMsgGovObject returns a new Dash govobj message that conforms to the
+// Message interface.  See MsgGovObject for details.
+func NewMsgGovObject(hashParent *chainhash.Hash, revision int32, time int64,
+	collateralHash *chainhash.Hash, data []byte,
+	objectType GovObjectType) *MsgGovObject {
+
+	return &MsgGovObject{
+		HashParent:         *hashParent,
+		Revision:           revision,
+		Time:               time,
+		CollateralHash:     *collateralHash,
+		Data:               data,
+		ObjectType:         objectType,
+		MasternodeOutpoint: OutPoint{Index: MaxPrevOutIndex},
+		Signature:          make([]byte, 0),
+	}
+}
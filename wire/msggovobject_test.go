@@ -0,0 +1,126 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestGovernanceMessages tests the MsgGovObject, MsgGovObjectVote and
+// MsgGovSync API and wire encoding.
+func TestGovernanceMessages(t *testing.T) {
+	pver := ProtocolVersion
+
+	parentHash := chainhash.Hash{0x01}
+	collateralHash := chainhash.Hash{0x02}
+	objectHash := chainhash.Hash{0x03}
+	outpoint := OutPoint{Hash: chainhash.Hash{0x04}, Index: 1}
+	data := []byte("7b7d") // Hex encoded "{}"
+	sig := bytes.Repeat([]byte{0xaa}, 65)
+
+	govObj := NewMsgGovObject(&parentHash, 1, 1546300800, &collateralHash,
+		data, GovObjectTypeProposal)
+	govObjEncoded := append([]byte{}, parentHash[:]...)
+	govObjEncoded = append(govObjEncoded,
+		0x01, 0x00, 0x00, 0x00, // Revision
+		0x80, 0xad, 0x2a, 0x5c, 0x00, 0x00, 0x00, 0x00, // Time
+	)
+	govObjEncoded = append(govObjEncoded, collateralHash[:]...)
+	govObjEncoded = append(govObjEncoded, 0x04, '7', 'b', '7', 'd') // Data
+	govObjEncoded = append(govObjEncoded, 0x01, 0x00, 0x00, 0x00)   // ObjectType
+	govObjEncoded = append(govObjEncoded, make([]byte, chainhash.HashSize)...)
+	govObjEncoded = append(govObjEncoded,
+		0xff, 0xff, 0xff, 0xff, // MasternodeOutpoint index
+		0x00, // Signature length
+	)
+
+	vote := NewMsgGovObjectVote(&outpoint, &parentHash, VoteOutcomeYes,
+		VoteSignalFunding, 1546300800, sig)
+	voteEncoded := append([]byte{}, outpoint.Hash[:]...)
+	voteEncoded = append(voteEncoded, 0x01, 0x00, 0x00, 0x00) // Index
+	voteEncoded = append(voteEncoded, parentHash[:]...)
+	voteEncoded = append(voteEncoded,
+		0x01, 0x00, 0x00, 0x00, // Outcome
+		0x01, 0x00, 0x00, 0x00, // Signal
+		0x80, 0xad, 0x2a, 0x5c, 0x00, 0x00, 0x00, 0x00, // Time
+		byte(len(sig)),
+	)
+	voteEncoded = append(voteEncoded, sig...)
+
+	govSync := NewMsgGovSync(&objectHash,
+		NewMsgFilterLoad([]byte{0x00}, 10, 0, BloomUpdateNone))
+	govSyncEncoded := append([]byte{}, objectHash[:]...)
+	govSyncEncoded = append(govSyncEncoded,
+		0x01, 0x00, // Filter
+		0x0a, 0x00, 0x00, 0x00, // HashFuncs
+		0x00, 0x00, 0x00, 0x00, // Tweak
+		0x00, // Flags
+	)
+
+	tests := []struct {
+		in  Message // Message to encode
+		out Message // Empty message to decode into
+		cmd string  // Expected command
+		buf []byte  // Wire encoding
+	}{
+		{govObj, &MsgGovObject{}, "govobj", govObjEncoded},
+		{vote, &MsgGovObjectVote{}, "govobjvote", voteEncoded},
+		{govSync, &MsgGovSync{}, "govsync", govSyncEncoded},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		if cmd := test.in.Command(); cmd != test.cmd {
+			t.Errorf("Command #%d: wrong command - got %v want %v",
+				i, cmd, test.cmd)
+		}
+		if max := test.in.MaxPayloadLength(pver); max > MaxMessagePayload {
+			t.Errorf("MaxPayloadLength #%d: %d exceeds max message "+
+				"payload %d", i, max, MaxMessagePayload)
+		}
+
+		var buf bytes.Buffer
+		if err := test.in.BtcEncode(&buf, pver, BaseEncoding); err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("BtcEncode #%d\n got: %s want: %s", i,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+
+		rbuf := bytes.NewReader(test.buf)
+		if err := test.out.BtcDecode(rbuf, pver, BaseEncoding); err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.out, test.in) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i,
+				spew.Sdump(test.out), spew.Sdump(test.in))
+		}
+	}
+}
+
+// TestGovObjectDataTooLarge ensures a governance object with more data than
+// allowed is rejected before allocating it.
+func TestGovObjectDataTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	writeElements(&buf, &chainhash.Hash{}, int32(1), int64(0),
+		&chainhash.Hash{})
+	WriteVarInt(&buf, ProtocolVersion, MaxGovObjectDataSize+1)
+
+	var msg MsgGovObject
+	err := msg.BtcDecode(&buf, ProtocolVersion, BaseEncoding)
+	if _, ok := err.(*MessageError); !ok {
+		t.Errorf("BtcDecode: wrong error - got %v, want %T", err,
+			&MessageError{})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// VoteOutcome is the outcome of a governance vote.
+type VoteOutcome int32
+
+// Governance vote outcomes as defined by Dash Core.
+const (
+	VoteOutcomeNone    VoteOutcome = 0
+	VoteOutcomeYes     VoteOutcome = 1
+	VoteOutcomeNo      VoteOutcome = 2
+	VoteOutcomeAbstain VoteOutcome = 3
+)
+
+// VoteSignal identifies what a governance vote is cast on.
+type VoteSignal int32
+
+// Governance vote signals as defined by Dash Core.
+const (
+	VoteSignalNone     VoteSignal = 0
+	VoteSignalFunding  VoteSignal = 1
+	VoteSignalValid    VoteSignal = 2
+	VoteSignalDelete   VoteSignal = 3
+	VoteSignalEndorsed VoteSignal = 4
+)
+
+// MsgGovObjectVote implements the Message interface and represents a Dash
+// govobjvote message.  It is used to relay the vote of the masternode
+// identified by MasternodeOutpoint on the governance object with hash
+// ParentHash.
+type MsgGovObjectVote struct {
+	MasternodeOutpoint OutPoint
+	ParentHash         chainhash.Hash
+	Outcome            VoteOutcome
+	Signal             VoteSignal
+	Time               int64
+	Signature          []byte
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgGovObjectVote) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readOutPoint(r, pver, 0, &msg.MasternodeOutpoint)
+	if err != nil {
+		return err
+	}
+
+	err = readElements(r, &msg.ParentHash, (*int32)(&msg.Outcome),
+		(*int32)(&msg.Signal), &msg.Time)
+	if err != nil {
+		return err
+	}
+
+	msg.Signature, err = ReadVarBytes(r, pver, maxGovSignatureSize,
+		"governance vote signature")
+	return err
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgGovObjectVote) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeOutPoint(w, pver, 0, &msg.MasternodeOutpoint)
+	if err != nil {
+		return err
+	}
+
+	err = writeElements(w, &msg.ParentHash, int32(msg.Outcome),
+		int32(msg.Signal), msg.Time)
+	if err != nil {
+		return err
+	}
+
+	return WriteVarBytes(w, pver, msg.Signature)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgGovObjectVote) Command() string {
+	return CmdGovObjectVote
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgGovObjectVote) MaxPayloadLength(pver uint32) uint32 {
+	// MasternodeOutpoint 36 bytes + ParentHash 32 bytes + Outcome 4 bytes +
+	// Signal 4 bytes + Time 8 bytes + signature length varint 1 byte +
+	// Signature.
+	return outPointSize + chainhash.HashSize + 17 + maxGovSignatureSize
+}
+
+// NewMsgGovObjectVote returns a new Dash govobjvote message that conforms to
+// the Message interface.  See MsgGovObjectVote for details.
+func NewMsgGovObjectVote(outpoint *OutPoint, parentHash *chainhash.Hash,
+	outcome VoteOutcome, signal VoteSignal, time int64,
+	signature []byte) *MsgGovObjectVote {
+
+	return &MsgGovObjectVote{
+		MasternodeOutpoint: *outpoint,
+		ParentHash:         *parentHash,
+		Outcome:            outcome,
+		Signal:             signal,
+		Time:               time,
+		Signature:          signature,
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// MsgGovSync implements the Message interface and represents a Dash govsync
+// message.  It is used to request governance objects from a peer.  A zero
+// ObjectHash requests all objects while any other hash requests the votes on
+// the object with that hash.  Votes matching Filter, encoded the same way as
+// in a filterload message, are not sent.
+type MsgGovSync struct {
+	ObjectHash chainhash.Hash
+	Filter     MsgFilterLoad
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgGovSync) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElement(r, &msg.ObjectHash)
+	if err != nil {
+		return err
+	}
+
+	return msg.Filter.BtcDecode(r, pver, enc)
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgGovSync) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElement(w, &msg.ObjectHash)
+	if err != nil {
+		return err
+	}
+
+	return msg.Filter.BtcEncode(w, pver, enc)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgGovSync) Command() string {
+	return CmdGovSync
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgGovSync) MaxPayloadLength(pver uint32) uint32 {
+	// ObjectHash 32 bytes + filter.
+	return chainhash.HashSize + msg.Filter.MaxPayloadLength(pver)
+}
+
+// NewMsgGovSync returns a new Dash govsync message that conforms to the
+// Message interface.  See MsgGovSync for details.
+func NewMsgGovSync(objectHash *chainhash.Hash, filter *MsgFilterLoad) *MsgGovSync {
+	return &MsgGovSync{
+		ObjectHash: *objectHash,
+		Filter:     *filter,
+	}
+}
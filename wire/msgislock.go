@@ -0,0 +1,236 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+const (
+	// MaxISLockInputs is the maximum number of inputs an InstantSend lock
+	// may hold.  It is the number of inputs of the largest transaction
+	// which could possibly fit into a block.
+	MaxISLockInputs = MaxBlockPayload / minTxInPayload
+
+	// outPointSize is the serialized size of an OutPoint.
+	// Hash 32 bytes + Index 4 bytes.
+	outPointSize = chainhash.HashSize + 4
+
+	// islockRequestIDPrefix is the prefix of the data hashed into the
+	// request ID of an InstantSend lock.
+	islockRequestIDPrefix = "islock"
+)
+
+// InstantSendRequestID returns the request ID the LLMQ signs when locking the
+// passed inputs as defined by DIP0010.  It is the double sha256 of the
+// serialized "islock" prefix followed by the serialized inputs, and is needed
+// along with the txid to verify the signature of an islock or isdlock.
+func InstantSendRequestID(inputs []OutPoint) chainhash.Hash {
+	var buf bytes.Buffer
+	buf.Grow(1 + len(islockRequestIDPrefix) + MaxVarIntPayload +
+		len(inputs)*outPointSize)
+
+	// Writing to a bytes.Buffer never fails, so the errors are ignored.
+	_ = WriteVarString(&buf, 0, islockRequestIDPrefix)
+	_ = WriteVarInt(&buf, 0, uint64(len(inputs)))
+	for i := range inputs {
+		_ = writeOutPoint(&buf, 0, 0, &inputs[i])
+	}
+	return chainhash.DoubleHashH(buf.Bytes())
+}
+
+// readISLockInputs reads the inputs locked by an InstantSend lock.
+func readISLockInputs(r io.Reader, pver uint32, command string) ([]OutPoint, error) {
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit to max inputs per message.
+	if count > MaxISLockInputs {
+		str := fmt.Sprintf("too many inputs for message "+
+			"[count %v, max %v]", count, MaxISLockInputs)
+		return nil, messageError(command+".BtcDecode", str)
+	}
+
+	inputs := make([]OutPoint, count)
+	for i := range inputs {
+		err := readOutPoint(r, pver, 0, &inputs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return inputs, nil
+}
+
+// writeISLockInputs writes the inputs locked by an InstantSend lock.
+func writeISLockInputs(w io.Writer, pver uint32, command string, inputs []OutPoint) error {
+	count := len(inputs)
+	if count > MaxISLockInputs {
+		str := fmt.Sprintf("too many inputs for message "+
+			"[count %v, max %v]", count, MaxISLockInputs)
+		return messageError(command+".BtcEncode", str)
+	}
+
+	err := WriteVarInt(w, pver, uint64(count))
+	if err != nil {
+		return err
+	}
+	for i := range inputs {
+		err := writeOutPoint(w, pver, 0, &inputs[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MsgISLock implements the Message interface and represents a Dash islock
+// message.  It is used to announce the LLMQ signature which locks the inputs
+// of the transaction with the given id as defined by DIP0010.
+type MsgISLock struct {
+	Inputs    []OutPoint
+	TxID      chainhash.Hash
+	Signature [BLSSignatureSize]byte
+}
+
+// RequestID returns the request ID signed by the LLMQ for the inputs of the
+// lock.  See InstantSendRequestID.
+func (msg *MsgISLock) RequestID() chainhash.Hash {
+	return InstantSendRequestID(msg.Inputs)
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgISLock) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	inputs, err := readISLockInputs(r, pver, "MsgISLock")
+	if err != nil {
+		return err
+	}
+	msg.Inputs = inputs
+
+	return readElements(r, &msg.TxID, &msg.Signature)
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgISLock) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeISLockInputs(w, pver, "MsgISLock", msg.Inputs)
+	if err != nil {
+		return err
+	}
+
+	return writeElements(w, &msg.TxID, msg.Signature)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgISLock) Command() string {
+	return CmdISLock
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgISLock) MaxPayloadLength(pver uint32) uint32 {
+	// Num inputs (varInt) + max allowed inputs + TxID 32 bytes +
+	// Signature 96 bytes.
+	return MaxVarIntPayload + MaxISLockInputs*outPointSize +
+		chainhash.HashSize + BLSSignatureSize
+}
+
+// NewMsgISLock returns a new Dash islock message that conforms to the Message
+// interface.  See MsgISLock for details.
+func NewMsgISLock(inputs []OutPoint, txID *chainhash.Hash,
+	signature [BLSSignatureSize]byte) *MsgISLock {
+
+	return &MsgISLock{
+		Inputs:    inputs,
+		TxID:      *txID,
+		Signature: signature,
+	}
+}
+
+// MsgISDLock implements the Message interface and represents a Dash isdlock
+// message.  It is the deterministic variant of the islock message which is
+// signed by the rotating quorum of the cycle identified by CycleHash.
+type MsgISDLock struct {
+	Version   uint8
+	Inputs    []OutPoint
+	TxID      chainhash.Hash
+	CycleHash chainhash.Hash
+	Signature [BLSSignatureSize]byte
+}
+
+// RequestID returns the request ID signed by the LLMQ for the inputs of the
+// lock.  See InstantSendRequestID.
+func (msg *MsgISDLock) RequestID() chainhash.Hash {
+	return InstantSendRequestID(msg.Inputs)
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgISDLock) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElement(r, &msg.Version)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := readISLockInputs(r, pver, "MsgISDLock")
+	if err != nil {
+		return err
+	}
+	msg.Inputs = inputs
+
+	return readElements(r, &msg.TxID, &msg.CycleHash, &msg.Signature)
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgISDLock) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElement(w, msg.Version)
+	if err != nil {
+		return err
+	}
+
+	err = writeISLockInputs(w, pver, "MsgISDLock", msg.Inputs)
+	if err != nil {
+		return err
+	}
+
+	return writeElements(w, &msg.TxID, &msg.CycleHash, msg.Signature)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgISDLock) Command() string {
+	return CmdISDLock
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgISDLock) MaxPayloadLength(pver uint32) uint32 {
+	// Version 1 byte + Num inputs (varInt) + max allowed inputs + TxID 32
+	// bytes + CycleHash 32 bytes + Signature 96 bytes.
+	return 1 + MaxVarIntPayload + MaxISLockInputs*outPointSize +
+		chainhash.HashSize*2 + BLSSignatureSize
+}
+
+// NewMsgISDLock returns a new Dash isdlock message that conforms to the
+// Message interface.  See MsgISDLock for details.
+func NewMsgISDLock(version uint8, inputs []OutPoint, txID,
+	cycleHash *chainhash.Hash, signature [BLSSignatureSize]byte) *MsgISDLock {
+
+	return &MsgISDLock{
+		Version:   version,
+		Inputs:    inputs,
+		TxID:      *txID,
+		CycleHash: *cycleHash,
+		Signature: signature,
+	}
+}
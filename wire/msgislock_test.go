@@ -0,0 +1,170 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestISLock tests the MsgISLock and MsgISDLock API and wire encoding.
+func TestISLock(t *testing.T) {
+	pver := ProtocolVersion
+
+	inputs := []OutPoint{
+		{Hash: chainhash.Hash{0x01}, Index: 0},
+		{Hash: chainhash.Hash{0x02}, Index: 3},
+	}
+	txID := chainhash.Hash{0x03}
+	cycleHash := chainhash.Hash{0x04}
+	var sig [BLSSignatureSize]byte
+	sig[0] = 0xaa
+
+	islock := NewMsgISLock(inputs, &txID, sig)
+	isdlock := NewMsgISDLock(1, inputs, &txID, &cycleHash, sig)
+
+	// Expected encoding of the inputs shared by both messages.
+	encodedInputs := []byte{0x02}
+	encodedInputs = append(encodedInputs, inputs[0].Hash[:]...)
+	encodedInputs = append(encodedInputs, 0x00, 0x00, 0x00, 0x00)
+	encodedInputs = append(encodedInputs, inputs[1].Hash[:]...)
+	encodedInputs = append(encodedInputs, 0x03, 0x00, 0x00, 0x00)
+
+	islockEncoded := append([]byte{}, encodedInputs...)
+	islockEncoded = append(islockEncoded, txID[:]...)
+	islockEncoded = append(islockEncoded, sig[:]...)
+
+	isdlockEncoded := append([]byte{0x01}, encodedInputs...)
+	isdlockEncoded = append(isdlockEncoded, txID[:]...)
+	isdlockEncoded = append(isdlockEncoded, cycleHash[:]...)
+	isdlockEncoded = append(isdlockEncoded, sig[:]...)
+
+	tests := []struct {
+		in  Message // Message to encode
+		out Message // Empty message to decode into
+		cmd string  // Expected command
+		buf []byte  // Wire encoding
+	}{
+		{islock, &MsgISLock{}, "islock", islockEncoded},
+		{isdlock, &MsgISDLock{}, "isdlock", isdlockEncoded},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		if cmd := test.in.Command(); cmd != test.cmd {
+			t.Errorf("Command #%d: wrong command - got %v want %v",
+				i, cmd, test.cmd)
+		}
+		if max := test.in.MaxPayloadLength(pver); max > MaxMessagePayload {
+			t.Errorf("MaxPayloadLength #%d: %d exceeds max message "+
+				"payload %d", i, max, MaxMessagePayload)
+		}
+
+		var buf bytes.Buffer
+		if err := test.in.BtcEncode(&buf, pver, BaseEncoding); err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("BtcEncode #%d\n got: %s want: %s", i,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+
+		rbuf := bytes.NewReader(test.buf)
+		if err := test.out.BtcDecode(rbuf, pver, BaseEncoding); err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.out, test.in) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i,
+				spew.Sdump(test.out), spew.Sdump(test.in))
+		}
+	}
+}
+
+// TestISLockTooManyInputs ensures an islock claiming more inputs than allowed
+// is rejected before allocating them.
+func TestISLockTooManyInputs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteVarInt(&buf, ProtocolVersion, MaxISLockInputs+1); err != nil {
+		t.Fatalf("WriteVarInt: %v", err)
+	}
+
+	var msg MsgISLock
+	err := msg.BtcDecode(&buf, ProtocolVersion, BaseEncoding)
+	if _, ok := err.(*MessageError); !ok {
+		t.Errorf("BtcDecode: wrong error - got %v, want %T", err,
+			&MessageError{})
+	}
+}
+
+// TestInstantSendRequestID tests the request ID computed for the inputs of an
+// InstantSend lock.
+func TestInstantSendRequestID(t *testing.T) {
+	mustHash := func(s string) chainhash.Hash {
+		h, err := chainhash.NewHashFromStr(s)
+		if err != nil {
+			t.Fatalf("NewHashFromStr: %v", err)
+		}
+		return *h
+	}
+
+	hash1 := mustHash("f3b81d4d6f5e0b37d4a1b19f0f8e1a8a3a0f3c4b1f9d7e2c5b8a6d4e3f2a1b0c")
+	hash2 := mustHash("29e1c5a78f5d6a2b3c4d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b")
+
+	// The request ID is the hash of the pair of the "islock" prefix and
+	// the inputs as serialized by dashd, a compact size prefixed string
+	// followed by the compact size prefixed outpoints.
+	prefix := []byte{0x06, 'i', 's', 'l', 'o', 'c', 'k'}
+	twoInputs := append(append([]byte{}, prefix...), 0x02)
+	twoInputs = append(append(twoInputs, hash1[:]...), 0x01, 0x00, 0x00, 0x00)
+	twoInputs = append(append(twoInputs, hash2[:]...), 0x00, 0x00, 0x00, 0x00)
+
+	tests := []struct {
+		name     string
+		inputs   []OutPoint
+		preimage []byte
+		want     string
+	}{
+		{
+			name: "two inputs",
+			inputs: []OutPoint{
+				{Hash: hash1, Index: 1},
+				{Hash: hash2, Index: 0},
+			},
+			preimage: twoInputs,
+			want:     "deec0b7ab06e98626724153f57c9effad8d60e1675745e722c4430aad946452d",
+		},
+		{
+			name:     "no inputs",
+			inputs:   nil,
+			preimage: append(append([]byte{}, prefix...), 0x00),
+			want:     "9646b89cce83774e7c9ea5cefee68a6da32171f380810babd36cac76a686ab7b",
+		},
+	}
+
+	for _, test := range tests {
+		got := InstantSendRequestID(test.inputs)
+		if got.String() != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+			continue
+		}
+		if want := chainhash.DoubleHashH(test.preimage); got != want {
+			t.Errorf("%s: got %v, want hash of %x", test.name, got,
+				test.preimage)
+		}
+
+		islock := NewMsgISLock(test.inputs, &chainhash.Hash{},
+			[BLSSignatureSize]byte{})
+		if islock.RequestID() != got {
+			t.Errorf("%s: MsgISLock.RequestID mismatch", test.name)
+		}
+	}
+}
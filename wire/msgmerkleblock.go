@@ -20,6 +20,10 @@ const maxFlagsPerMerkleBlock = maxTxPerBlock / 8
 // MsgMerkleBlock implements the Message interface and represents a bitcoin
 // merkleblock message which is used to reset a Bloom filter.
 //
+// The transactions of the block which match the filter are identified by the
+// partial merkle tree described by Transactions, Hashes and Flags, which
+// ExtractMatches decodes.
+//
 // This message was not added until protocol version BIP0037Version.
 type MsgMerkleBlock struct {
 	Header       BlockHeader
@@ -157,3 +161,143 @@ func NewMsgMerkleBlock(bh *BlockHeader) *MsgMerkleBlock {
 		Flags:        make([]byte, 0),
 	}
 }
+
+// partialMerkleTree houses the state used to traverse the partial merkle tree
+// of a merkleblock message as defined by BIP0037.
+type partialMerkleTree struct {
+	msg      *MsgMerkleBlock
+	bitsUsed uint32
+	hashUsed uint32
+	matches  []*chainhash.Hash
+	indices  []uint32
+}
+
+// treeWidth returns the number of nodes at the passed height of the tree,
+// where the leaves are at height zero.
+func (t *partialMerkleTree) treeWidth(height uint32) uint32 {
+	return (t.msg.Transactions + (1 << height) - 1) >> height
+}
+
+// traverse returns the hash of the node at the passed height and position
+// while consuming the flag bits and hashes of the message in depth-first order
+// and recording the matched transactions.
+func (t *partialMerkleTree) traverse(height, pos uint32) (*chainhash.Hash, error) {
+	const fn = "MsgMerkleBlock.ExtractMatches"
+
+	if t.bitsUsed >= uint32(len(t.msg.Flags))*8 {
+		return nil, messageError(fn, "merkle block flags exhausted")
+	}
+	bit := t.bitsUsed
+	parentOfMatch := t.msg.Flags[bit/8]&(1<<(bit%8)) != 0
+	t.bitsUsed++
+
+	// The hash of a leaf or of a node which has no matches below it is
+	// included directly.
+	if height == 0 || !parentOfMatch {
+		if t.hashUsed >= uint32(len(t.msg.Hashes)) {
+			return nil, messageError(fn, "merkle block hashes "+
+				"exhausted")
+		}
+		hash := t.msg.Hashes[t.hashUsed]
+		t.hashUsed++
+		if height == 0 && parentOfMatch {
+			t.matches = append(t.matches, hash)
+			t.indices = append(t.indices, pos)
+		}
+		return hash, nil
+	}
+
+	// Otherwise the hash is calculated from its children, where the last
+	// node of a level with an odd width is paired with itself.
+	left, err := t.traverse(height-1, pos*2)
+	if err != nil {
+		return nil, err
+	}
+	right := left
+	if pos*2+1 < t.treeWidth(height-1) {
+		right, err = t.traverse(height-1, pos*2+1)
+		if err != nil {
+			return nil, err
+		}
+
+		// Identical children only occur when the last node is paired
+		// with itself.  Allowing them otherwise would permit the same
+		// tree to be described with different transactions as in
+		// CVE-2012-2459.
+		if *right == *left {
+			return nil, messageError(fn, "merkle block has "+
+				"identical sibling hashes")
+		}
+	}
+
+	var buf [chainhash.HashSize * 2]byte
+	copy(buf[:chainhash.HashSize], left[:])
+	copy(buf[chainhash.HashSize:], right[:])
+	hash := chainhash.DoubleHashH(buf[:])
+	return &hash, nil
+}
+
+// ExtractMatches decodes the partial merkle tree of the message and returns the
+// hashes of the transactions it matches along with their positions in the
+// block.  An error is returned when the tree is malformed, when it does not use
+// all of the hashes and flags of the message, or when its root does not equal
+// the merkle root of the block header, in which case the matches can't be
+// trusted to be part of the block.
+func (msg *MsgMerkleBlock) ExtractMatches() ([]*chainhash.Hash, []uint32, error) {
+	const fn = "MsgMerkleBlock.ExtractMatches"
+
+	// Reject trees which can't be valid based on their sizes alone.
+	if msg.Transactions == 0 {
+		return nil, nil, messageError(fn, "merkle block has no "+
+			"transactions")
+	}
+	if msg.Transactions > maxTxPerBlock {
+		str := fmt.Sprintf("merkle block has too many transactions "+
+			"[count %v, max %v]", msg.Transactions, maxTxPerBlock)
+		return nil, nil, messageError(fn, str)
+	}
+	if uint32(len(msg.Hashes)) > msg.Transactions {
+		str := fmt.Sprintf("merkle block has more hashes than "+
+			"transactions [hashes %v, transactions %v]",
+			len(msg.Hashes), msg.Transactions)
+		return nil, nil, messageError(fn, str)
+	}
+	if len(msg.Flags)*8 < len(msg.Hashes) {
+		str := fmt.Sprintf("merkle block has fewer flag bits than "+
+			"hashes [bits %v, hashes %v]", len(msg.Flags)*8,
+			len(msg.Hashes))
+		return nil, nil, messageError(fn, str)
+	}
+
+	t := partialMerkleTree{msg: msg}
+	var height uint32
+	for t.treeWidth(height) > 1 {
+		height++
+	}
+	root, err := t.traverse(height, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// All of the hashes and all but the padding bits of the last flag byte
+	// must have been used.
+	if (t.bitsUsed+7)/8 != uint32(len(msg.Flags)) {
+		str := fmt.Sprintf("merkle block has unused flag bytes [used "+
+			"%v, total %v]", (t.bitsUsed+7)/8, len(msg.Flags))
+		return nil, nil, messageError(fn, str)
+	}
+	if t.hashUsed != uint32(len(msg.Hashes)) {
+		str := fmt.Sprintf("merkle block has unused hashes [used %v, "+
+			"total %v]", t.hashUsed, len(msg.Hashes))
+		return nil, nil, messageError(fn, str)
+	}
+
+	if *root != msg.Header.MerkleRoot {
+		str := fmt.Sprintf("merkle block root %v does not match the "+
+			"merkle root %v of the header", root,
+			msg.Header.MerkleRoot)
+		return nil, nil, messageError(fn, str)
+	}
+
+	return t.matches, t.indices, nil
+}
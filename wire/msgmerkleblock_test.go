@@ -428,3 +428,199 @@ var merkleBlockOneBytes = []byte{
 	0x01, // Num flag bytes
 	0x80, // Flags
 }
+
+// buildPartialMerkleTree returns a merkle block for a block with the passed
+// transaction hashes which matches the transactions whose match flag is set.
+// It builds the partial merkle tree the way BIP0037 describes.
+func buildPartialMerkleTree(txHashes []chainhash.Hash, match []bool) *MsgMerkleBlock {
+	numTx := uint32(len(txHashes))
+	width := func(height uint32) uint32 {
+		return (numTx + (1 << height) - 1) >> height
+	}
+
+	// hash returns the hash of the node at the passed height and position.
+	var hash func(height, pos uint32) chainhash.Hash
+	hash = func(height, pos uint32) chainhash.Hash {
+		if height == 0 {
+			return txHashes[pos]
+		}
+		left := hash(height-1, pos*2)
+		right := left
+		if pos*2+1 < width(height-1) {
+			right = hash(height-1, pos*2+1)
+		}
+		var buf [chainhash.HashSize * 2]byte
+		copy(buf[:chainhash.HashSize], left[:])
+		copy(buf[chainhash.HashSize:], right[:])
+		return chainhash.DoubleHashH(buf[:])
+	}
+
+	var bits []bool
+	var hashes []*chainhash.Hash
+	var build func(height, pos uint32)
+	build = func(height, pos uint32) {
+		parentOfMatch := false
+		for p := pos << height; p < (pos+1)<<height && p < numTx; p++ {
+			parentOfMatch = parentOfMatch || match[p]
+		}
+		bits = append(bits, parentOfMatch)
+		if height == 0 || !parentOfMatch {
+			h := hash(height, pos)
+			hashes = append(hashes, &h)
+			return
+		}
+		build(height-1, pos*2)
+		if pos*2+1 < width(height-1) {
+			build(height-1, pos*2+1)
+		}
+	}
+
+	var height uint32
+	for width(height) > 1 {
+		height++
+	}
+	build(height, 0)
+
+	flags := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		if bit {
+			flags[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return &MsgMerkleBlock{
+		Header:       BlockHeader{MerkleRoot: hash(height, 0)},
+		Transactions: numTx,
+		Hashes:       hashes,
+		Flags:        flags,
+	}
+}
+
+// TestMerkleBlockExtractMatches ensures the matches are extracted from partial
+// merkle trees of various shapes and that their root is verified.
+func TestMerkleBlockExtractMatches(t *testing.T) {
+	tests := []struct {
+		numTx   int
+		matches []uint32
+	}{
+		{1, nil},
+		{1, []uint32{0}},
+		{2, []uint32{1}},
+		{3, []uint32{2}},
+		{5, []uint32{0, 4}},
+		{7, []uint32{1, 2, 3, 4, 5, 6}},
+		{16, []uint32{15}},
+		{17, []uint32{3, 16}},
+		{100, []uint32{0, 33, 64, 99}},
+		{257, nil},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		txHashes := make([]chainhash.Hash, test.numTx)
+		for j := range txHashes {
+			txHashes[j] = chainhash.DoubleHashH([]byte{byte(j),
+				byte(j >> 8)})
+		}
+		match := make([]bool, test.numTx)
+		for _, index := range test.matches {
+			match[index] = true
+		}
+
+		msg := buildPartialMerkleTree(txHashes, match)
+		hashes, indices, err := msg.ExtractMatches()
+		if err != nil {
+			t.Errorf("ExtractMatches #%d unexpected error: %v", i, err)
+			continue
+		}
+		if len(indices) != len(test.matches) {
+			t.Errorf("ExtractMatches #%d got %d matches, want %d", i,
+				len(indices), len(test.matches))
+			continue
+		}
+		for j, index := range test.matches {
+			if indices[j] != index || *hashes[j] != txHashes[index] {
+				t.Errorf("ExtractMatches #%d match %d got %v at %d, "+
+					"want %v at %d", i, j, hashes[j],
+					indices[j], txHashes[index], index)
+			}
+		}
+	}
+
+	// The merkle block of block one has a single transaction which is
+	// included as the root without being flagged as a match.
+	hashes, _, err := merkleBlockOne.ExtractMatches()
+	if err != nil || len(hashes) != 0 {
+		t.Errorf("ExtractMatches of block one got %v, %v, want no "+
+			"matches", hashes, err)
+	}
+}
+
+// TestMerkleBlockExtractMatchesErrors ensures malformed partial merkle trees
+// and trees which don't commit to the merkle root of the header are rejected.
+func TestMerkleBlockExtractMatchesErrors(t *testing.T) {
+	txHashes := make([]chainhash.Hash, 6)
+	for i := range txHashes {
+		txHashes[i] = chainhash.DoubleHashH([]byte{byte(i)})
+	}
+	match := []bool{false, true, false, false, true, false}
+
+	// The duplicated transaction yields the same root as the block with an
+	// odd number of transactions, which must not be accepted.
+	duplicated := append(txHashes[:5:5], txHashes[4])
+
+	tests := []struct {
+		name   string
+		modify func(*MsgMerkleBlock)
+	}{
+		{"no transactions", func(msg *MsgMerkleBlock) {
+			msg.Transactions = 0
+		}},
+		{"too many transactions", func(msg *MsgMerkleBlock) {
+			msg.Transactions = maxTxPerBlock + 1
+		}},
+		{"more hashes than transactions", func(msg *MsgMerkleBlock) {
+			msg.Transactions = 2
+		}},
+		{"fewer flag bits than hashes", func(msg *MsgMerkleBlock) {
+			msg.Flags = nil
+		}},
+		{"flags exhausted", func(msg *MsgMerkleBlock) {
+			msg.Flags = []byte{0xff}
+		}},
+		{"hashes exhausted", func(msg *MsgMerkleBlock) {
+			msg.Hashes = msg.Hashes[:len(msg.Hashes)-1]
+		}},
+		{"unused hash", func(msg *MsgMerkleBlock) {
+			msg.Hashes = append(msg.Hashes, msg.Hashes[0])
+		}},
+		{"unused flag byte", func(msg *MsgMerkleBlock) {
+			msg.Flags = append(msg.Flags, 0x00)
+		}},
+		{"root mismatch", func(msg *MsgMerkleBlock) {
+			msg.Header.MerkleRoot[0] ^= 0x01
+		}},
+		{"changed hash", func(msg *MsgMerkleBlock) {
+			changed := *msg.Hashes[0]
+			changed[0] ^= 0x01
+			msg.Hashes[0] = &changed
+		}},
+		{"identical siblings", func(msg *MsgMerkleBlock) {
+			*msg = *buildPartialMerkleTree(duplicated, match)
+		}},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		msg := buildPartialMerkleTree(txHashes, match)
+		if _, _, err := msg.ExtractMatches(); err != nil {
+			t.Fatalf("ExtractMatches unexpected error: %v", err)
+		}
+
+		test.modify(msg)
+		_, _, err := msg.ExtractMatches()
+		if _, ok := err.(*MessageError); !ok {
+			t.Errorf("ExtractMatches #%d (%s) got %v, want a "+
+				"MessageError", i, test.name, err)
+		}
+	}
+}
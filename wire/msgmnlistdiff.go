@@ -0,0 +1,432 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+const (
+	// deletedQuorumSize is the serialized size of a DeletedQuorum.
+	// LLMQType 1 byte + QuorumHash 32 bytes.
+	deletedQuorumSize = 1 + chainhash.HashSize
+
+	// minQuorumCommitmentSize is the serialized size of a QuorumCommitment
+	// with empty Signers and ValidMembers bitsets.
+	minQuorumCommitmentSize = 3 + chainhash.HashSize*2 + BLSPublicKeySize +
+		BLSSignatureSize*2 + 2
+
+	// maxMnListDiffHashes is the maximum number of merkle hashes or deleted
+	// masternode hashes that could possibly fit into a mnlistdiff message.
+	maxMnListDiffHashes = MaxMessagePayload / chainhash.HashSize
+
+	// maxMnListDiffEntries is the maximum number of masternode list entries
+	// that could possibly fit into a mnlistdiff message.
+	maxMnListDiffEntries = MaxMessagePayload / SMLEntrySize
+
+	// maxMnListDiffVersionedEntries is the maximum number of masternode list
+	// entries that could possibly fit into a mnlistdiff message as of
+	// protocol version SMNLEVersionedVersion, which added the 2-byte version
+	// to each entry.
+	maxMnListDiffVersionedEntries = MaxMessagePayload / (SMLEntrySize + 2)
+
+	// maxMnListDiffDeletedQuorums is the maximum number of deleted quorums
+	// that could possibly fit into a mnlistdiff message.
+	maxMnListDiffDeletedQuorums = MaxMessagePayload / deletedQuorumSize
+
+	// maxMnListDiffNewQuorums is the maximum number of quorum commitments
+	// that could possibly fit into a mnlistdiff message.
+	maxMnListDiffNewQuorums = MaxMessagePayload / minQuorumCommitmentSize
+
+	// maxMnListDiffQuorumCLSigs is the maximum number of quorum chainlock
+	// signatures that could possibly fit into a mnlistdiff message.  Each
+	// one takes at least its signature and the count of its indexes.
+	maxMnListDiffQuorumCLSigs = MaxMessagePayload / (BLSSignatureSize + 1)
+)
+
+// DeletedQuorum identifies a quorum which was removed from the active quorum
+// set by a mnlistdiff message.
+type DeletedQuorum struct {
+	LLMQType   uint8
+	QuorumHash chainhash.Hash
+}
+
+// QuorumCLSig is a chainlock signature of a mnlistdiff message along with the
+// indexes into the NewQuorums of the message of the quorums whose commitments
+// it is the chainlock of.
+type QuorumCLSig struct {
+	Signature     [BLSSignatureSize]byte
+	QuorumIndexes []uint16
+}
+
+// MsgMnListDiff implements the Message interface and represents a Dash
+// mnlistdiff message.  It is sent in response to a getmnlistd message
+// (MsgGetMnListDiff) and holds the changes of the simplified masternode list
+// and the active quorums between two blocks as defined by DIP0004.
+//
+// CbTx is the coinbase transaction of the block identified by BlockHash.  Its
+// inclusion in the block is proven by the partial merkle tree described by
+// TotalTransactions, MerkleHashes and MerkleFlags, which is encoded the same
+// way as in a merkleblock message.
+//
+// The fields of the message depend on the protocol version:
+//   - DeletedQuorums and NewQuorums are part of the message as of LLMQVersion
+//   - Version, the BLS scheme version of the entries of MNList, follows CbTx
+//     as of BLSSchemeVersion and precedes BaseBlockHash as of
+//     MnListDiffVersionOrderVersion.  It is ProTxVersionLegacyBLS before
+//     BLSSchemeVersion and it is copied to the entries of MNList until they
+//     carry their own version as of SMNLEVersionedVersion
+//   - QuorumsCLSigs is part of the message as of MnListDiffCLSigsVersion
+type MsgMnListDiff struct {
+	Version           uint16
+	BaseBlockHash     chainhash.Hash
+	BlockHash         chainhash.Hash
+	TotalTransactions uint32
+	MerkleHashes      []chainhash.Hash
+	MerkleFlags       []byte
+	CbTx              MsgTx
+	DeletedMNs        []chainhash.Hash
+	MNList            []SimplifiedMNListEntry
+	DeletedQuorums    []DeletedQuorum
+	NewQuorums        []QuorumCommitment
+	QuorumsCLSigs     []QuorumCLSig
+}
+
+// maxEntries returns the maximum number of masternode list entries that could
+// possibly fit into a mnlistdiff message at the passed protocol version.
+func (msg *MsgMnListDiff) maxEntries(pver uint32) int {
+	if pver >= SMNLEVersionedVersion {
+		return maxMnListDiffVersionedEntries
+	}
+	return maxMnListDiffEntries
+}
+
+// readCount reads a varint element count and makes sure it does not exceed
+// max.
+func (msg *MsgMnListDiff) readCount(r io.Reader, pver uint32, max uint64, what string) (uint64, error) {
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return 0, err
+	}
+
+	// Prevent counts larger than the max message size.  It would be
+	// possible to cause memory exhaustion and panics without a sane upper
+	// bound on these counts.
+	if count > max {
+		str := fmt.Sprintf("too many %s for message [count %v, max %v]",
+			what, count, max)
+		return 0, messageError("MsgMnListDiff.BtcDecode", str)
+	}
+	return count, nil
+}
+
+// writeCount writes a varint element count after making sure it does not
+// exceed max.
+func (msg *MsgMnListDiff) writeCount(w io.Writer, pver uint32, count int, max int, what string) error {
+	if count > max {
+		str := fmt.Sprintf("too many %s for message [count %v, max %v]",
+			what, count, max)
+		return messageError("MsgMnListDiff.BtcEncode", str)
+	}
+	return WriteVarInt(w, pver, uint64(count))
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgMnListDiff) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	msg.Version = ProTxVersionLegacyBLS
+	if pver >= MnListDiffVersionOrderVersion {
+		if err := readElement(r, &msg.Version); err != nil {
+			return err
+		}
+	}
+
+	err := readElements(r, &msg.BaseBlockHash, &msg.BlockHash,
+		&msg.TotalTransactions)
+	if err != nil {
+		return err
+	}
+
+	count, err := msg.readCount(r, pver, maxMnListDiffHashes, "merkle hashes")
+	if err != nil {
+		return err
+	}
+	msg.MerkleHashes = make([]chainhash.Hash, count)
+	for i := range msg.MerkleHashes {
+		err := readElement(r, &msg.MerkleHashes[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	msg.MerkleFlags, err = ReadVarBytes(r, pver, maxFlagsPerMerkleBlock,
+		"mnlistdiff merkle flags size")
+	if err != nil {
+		return err
+	}
+
+	err = msg.CbTx.Deserialize(r)
+	if err != nil {
+		return err
+	}
+
+	if pver >= BLSSchemeVersion && pver < MnListDiffVersionOrderVersion {
+		if err := readElement(r, &msg.Version); err != nil {
+			return err
+		}
+	}
+
+	count, err = msg.readCount(r, pver, maxMnListDiffHashes,
+		"deleted masternodes")
+	if err != nil {
+		return err
+	}
+	msg.DeletedMNs = make([]chainhash.Hash, count)
+	for i := range msg.DeletedMNs {
+		err := readElement(r, &msg.DeletedMNs[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	count, err = msg.readCount(r, pver, uint64(msg.maxEntries(pver)),
+		"masternode list entries")
+	if err != nil {
+		return err
+	}
+	msg.MNList = make([]SimplifiedMNListEntry, count)
+	for i := range msg.MNList {
+		// Entries only carry their own version as of
+		// SMNLEVersionedVersion.
+		msg.MNList[i].Version = msg.Version
+		err := msg.MNList[i].BtcDecode(r, pver, enc)
+		if err != nil {
+			return err
+		}
+	}
+
+	msg.DeletedQuorums = nil
+	msg.NewQuorums = nil
+	msg.QuorumsCLSigs = nil
+	if pver < LLMQVersion {
+		return nil
+	}
+
+	count, err = msg.readCount(r, pver, maxMnListDiffDeletedQuorums,
+		"deleted quorums")
+	if err != nil {
+		return err
+	}
+	msg.DeletedQuorums = make([]DeletedQuorum, count)
+	for i := range msg.DeletedQuorums {
+		q := &msg.DeletedQuorums[i]
+		err := readElements(r, &q.LLMQType, &q.QuorumHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	count, err = msg.readCount(r, pver, maxMnListDiffNewQuorums,
+		"new quorums")
+	if err != nil {
+		return err
+	}
+	msg.NewQuorums = make([]QuorumCommitment, count)
+	for i := range msg.NewQuorums {
+		err := msg.NewQuorums[i].BtcDecode(r, pver, enc)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pver < MnListDiffCLSigsVersion {
+		return nil
+	}
+
+	count, err = msg.readCount(r, pver, maxMnListDiffQuorumCLSigs,
+		"quorum chainlock signatures")
+	if err != nil {
+		return err
+	}
+	msg.QuorumsCLSigs = make([]QuorumCLSig, count)
+	for i := range msg.QuorumsCLSigs {
+		clSig := &msg.QuorumsCLSigs[i]
+		if err := readElement(r, &clSig.Signature); err != nil {
+			return err
+		}
+
+		count, err := msg.readCount(r, pver, maxMnListDiffNewQuorums,
+			"quorum chainlock signature indexes")
+		if err != nil {
+			return err
+		}
+		clSig.QuorumIndexes = make([]uint16, count)
+		for j := range clSig.QuorumIndexes {
+			err := readElement(r, &clSig.QuorumIndexes[j])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgMnListDiff) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	numFlagBytes := len(msg.MerkleFlags)
+	if numFlagBytes > maxFlagsPerMerkleBlock {
+		str := fmt.Sprintf("too many merkle flag bytes for message "+
+			"[count %v, max %v]", numFlagBytes, maxFlagsPerMerkleBlock)
+		return messageError("MsgMnListDiff.BtcEncode", str)
+	}
+
+	if pver >= MnListDiffVersionOrderVersion {
+		if err := writeElement(w, msg.Version); err != nil {
+			return err
+		}
+	}
+
+	err := writeElements(w, &msg.BaseBlockHash, &msg.BlockHash,
+		msg.TotalTransactions)
+	if err != nil {
+		return err
+	}
+
+	err = msg.writeCount(w, pver, len(msg.MerkleHashes),
+		maxMnListDiffHashes, "merkle hashes")
+	if err != nil {
+		return err
+	}
+	for i := range msg.MerkleHashes {
+		err := writeElement(w, &msg.MerkleHashes[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	err = WriteVarBytes(w, pver, msg.MerkleFlags)
+	if err != nil {
+		return err
+	}
+
+	err = msg.CbTx.Serialize(w)
+	if err != nil {
+		return err
+	}
+
+	if pver >= BLSSchemeVersion && pver < MnListDiffVersionOrderVersion {
+		if err := writeElement(w, msg.Version); err != nil {
+			return err
+		}
+	}
+
+	err = msg.writeCount(w, pver, len(msg.DeletedMNs),
+		maxMnListDiffHashes, "deleted masternodes")
+	if err != nil {
+		return err
+	}
+	for i := range msg.DeletedMNs {
+		err := writeElement(w, &msg.DeletedMNs[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	err = msg.writeCount(w, pver, len(msg.MNList), msg.maxEntries(pver),
+		"masternode list entries")
+	if err != nil {
+		return err
+	}
+	for i := range msg.MNList {
+		err := msg.MNList[i].BtcEncode(w, pver, enc)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pver < LLMQVersion {
+		return nil
+	}
+
+	err = msg.writeCount(w, pver, len(msg.DeletedQuorums),
+		maxMnListDiffDeletedQuorums, "deleted quorums")
+	if err != nil {
+		return err
+	}
+	for i := range msg.DeletedQuorums {
+		q := &msg.DeletedQuorums[i]
+		err := writeElements(w, q.LLMQType, &q.QuorumHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = msg.writeCount(w, pver, len(msg.NewQuorums),
+		maxMnListDiffNewQuorums, "new quorums")
+	if err != nil {
+		return err
+	}
+	for i := range msg.NewQuorums {
+		err := msg.NewQuorums[i].BtcEncode(w, pver, enc)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pver < MnListDiffCLSigsVersion {
+		return nil
+	}
+
+	err = msg.writeCount(w, pver, len(msg.QuorumsCLSigs),
+		maxMnListDiffQuorumCLSigs, "quorum chainlock signatures")
+	if err != nil {
+		return err
+	}
+	for i := range msg.QuorumsCLSigs {
+		clSig := &msg.QuorumsCLSigs[i]
+		if err := writeElement(w, clSig.Signature); err != nil {
+			return err
+		}
+
+		err := msg.writeCount(w, pver, len(clSig.QuorumIndexes),
+			maxMnListDiffNewQuorums, "quorum chainlock signature indexes")
+		if err != nil {
+			return err
+		}
+		for _, index := range clSig.QuorumIndexes {
+			if err := writeElement(w, index); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgMnListDiff) Command() string {
+	return CmdMnListDiff
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgMnListDiff) MaxPayloadLength(pver uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// NewMsgMnListDiff returns a new Dash mnlistdiff message that conforms to the
+// Message interface.  See MsgMnListDiff for details.
+func NewMsgMnListDiff(baseBlockHash, blockHash *chainhash.Hash) *MsgMnListDiff {
+	return &MsgMnListDiff{
+		BaseBlockHash: *baseBlockHash,
+		BlockHash:     *blockHash,
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestGetMnListDiff tests the MsgGetMnListDiff API and wire encoding.
+func TestGetMnListDiff(t *testing.T) {
+	pver := ProtocolVersion
+
+	baseHash := chainhash.Hash{0x01}
+	blockHash := chainhash.Hash{0x02}
+	msg := NewMsgGetMnListDiff(&baseHash, &blockHash)
+
+	if cmd := msg.Command(); cmd != "getmnlistd" {
+		t.Errorf("Command: wrong command - got %v want getmnlistd", cmd)
+	}
+	if max := msg.MaxPayloadLength(pver); max != 64 {
+		t.Errorf("MaxPayloadLength: wrong max payload length - got %v, "+
+			"want 64", max)
+	}
+
+	want := append(append([]byte{}, baseHash[:]...), blockHash[:]...)
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, pver, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("BtcEncode\n got: %s want: %s", spew.Sdump(buf.Bytes()),
+			spew.Sdump(want))
+	}
+
+	var readMsg MsgGetMnListDiff
+	if err := readMsg.BtcDecode(&buf, pver, BaseEncoding); err != nil {
+		t.Fatalf("BtcDecode: %v", err)
+	}
+	if !reflect.DeepEqual(&readMsg, msg) {
+		t.Fatalf("BtcDecode\n got: %s want: %s", spew.Sdump(&readMsg),
+			spew.Sdump(msg))
+	}
+}
+
+// TestMnListDiff tests the MsgMnListDiff API and wire encoding round trip at
+// the protocol versions which changed the layout of the message.
+func TestMnListDiff(t *testing.T) {
+	pver := ProtocolVersion
+
+	msg := newTestMnListDiff(t)
+	if cmd := msg.Command(); cmd != "mnlistdiff" {
+		t.Errorf("Command: wrong command - got %v want mnlistdiff", cmd)
+	}
+	if max := msg.MaxPayloadLength(pver); max != MaxMessagePayload {
+		t.Errorf("MaxPayloadLength: wrong max payload length - got %v, "+
+			"want %v", max, MaxMessagePayload)
+	}
+
+	tests := []struct {
+		name string
+		pver uint32
+	}{
+		{"before quorums", LLMQVersion - 1},
+		{"quorums", LLMQVersion},
+		{"bls scheme", BLSSchemeVersion},
+		{"masternode type", DMNTypeVersion},
+		{"versioned entries", SMNLEVersionedVersion},
+		{"version first", MnListDiffVersionOrderVersion},
+		{"chainlock signatures", MnListDiffCLSigsVersion},
+		{"latest", ProtocolVersion},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for _, test := range tests {
+		msg := newTestMnListDiff(t)
+
+		var buf bytes.Buffer
+		if err := msg.BtcEncode(&buf, test.pver, BaseEncoding); err != nil {
+			t.Errorf("%s: BtcEncode: %v", test.name, err)
+			continue
+		}
+
+		// Base and block hashes, total transactions, one merkle hash,
+		// one flag byte, the coinbase transaction, one deleted
+		// masternode and one entry.  Then one deleted quorum and one
+		// new quorum, and one chainlock signature of the new quorum.
+		wantSize := 2*chainhash.HashSize + 4 + 1 + chainhash.HashSize +
+			2 + msg.CbTx.SerializeSize() + 1 + chainhash.HashSize +
+			1 + msg.MNList[0].SerializeSize(test.pver)
+		if test.pver >= BLSSchemeVersion {
+			wantSize += 2
+		}
+		if test.pver >= LLMQVersion {
+			wantSize += 1 + deletedQuorumSize + 1 +
+				msg.NewQuorums[0].SerializeSize()
+		}
+		if test.pver >= MnListDiffCLSigsVersion {
+			wantSize += 1 + BLSSignatureSize + 1 + 2
+		}
+		if buf.Len() != wantSize {
+			t.Errorf("%s: BtcEncode: wrong size - got %d, want %d",
+				test.name, buf.Len(), wantSize)
+		}
+
+		// The version of the message is serialized first as of
+		// MnListDiffVersionOrderVersion.
+		encoded := buf.Bytes()
+		if test.pver >= MnListDiffVersionOrderVersion &&
+			!bytes.Equal(encoded[:2], []byte{0x02, 0x00}) {
+
+			t.Errorf("%s: BtcEncode: got version bytes %x, want 0200",
+				test.name, encoded[:2])
+		}
+
+		var readMsg MsgMnListDiff
+		if err := readMsg.BtcDecode(&buf, test.pver, BaseEncoding); err != nil {
+			t.Errorf("%s: BtcDecode: %v", test.name, err)
+			continue
+		}
+
+		// Fields which are not serialized at the protocol version are
+		// decoded to their defaults.
+		want := msg
+		if test.pver < BLSSchemeVersion {
+			want.Version = ProTxVersionLegacyBLS
+			want.MNList[0].Version = ProTxVersionLegacyBLS
+		}
+		if test.pver < DMNTypeVersion || want.MNList[0].Version != ProTxVersionBasicBLS {
+			want.MNList[0].Type = MasternodeTypeRegular
+			want.MNList[0].PlatformHTTPPort = 0
+			want.MNList[0].PlatformNodeID = [PlatformNodeIDSize]byte{}
+		}
+		if test.pver < LLMQVersion {
+			want.DeletedQuorums = nil
+			want.NewQuorums = nil
+		}
+		if test.pver < MnListDiffCLSigsVersion {
+			want.QuorumsCLSigs = nil
+		}
+		if !reflect.DeepEqual(&readMsg, want) {
+			t.Errorf("%s: BtcDecode\n got: %s want: %s", test.name,
+				spew.Sdump(&readMsg), spew.Sdump(want))
+			continue
+		}
+
+		cbTx, err := readMsg.CbTx.CbTx()
+		if err != nil {
+			t.Errorf("%s: CbTx: %v", test.name, err)
+			continue
+		}
+		if cbTx.Height != 1028160 {
+			t.Errorf("%s: CbTx: wrong height - got %d, want 1028160",
+				test.name, cbTx.Height)
+		}
+	}
+}
+
+// TestMnListDiffTooMany ensures a mnlistdiff claiming more elements than could
+// fit into a message is rejected before allocating them.
+func TestMnListDiffTooMany(t *testing.T) {
+	pver := ProtocolVersion
+
+	var buf bytes.Buffer
+	writeElements(&buf, ProTxVersionBasicBLS, &chainhash.Hash{},
+		&chainhash.Hash{}, uint32(1))
+	WriteVarInt(&buf, pver, maxMnListDiffHashes+1)
+
+	var msg MsgMnListDiff
+	err := msg.BtcDecode(&buf, pver, BaseEncoding)
+	if _, ok := err.(*MessageError); !ok {
+		t.Errorf("BtcDecode: wrong error - got %v, want %T", err,
+			&MessageError{})
+	}
+
+	tooMany := newTestMnListDiff(t)
+	tooMany.MNList = make([]SimplifiedMNListEntry, maxMnListDiffVersionedEntries+1)
+	err = tooMany.BtcEncode(&bytes.Buffer{}, pver, BaseEncoding)
+	if _, ok := err.(*MessageError); !ok {
+		t.Errorf("BtcEncode: wrong error - got %v, want %T", err,
+			&MessageError{})
+	}
+}
+
+// newTestMnListDiff returns a mnlistdiff message of basic BLS entries with one
+// element of each kind.
+func newTestMnListDiff(t *testing.T) *MsgMnListDiff {
+	var payload bytes.Buffer
+	cbTx := &CbTx{
+		Version:          1,
+		Height:           1028160,
+		MerkleRootMNList: chainhash.Hash{0x07},
+	}
+	if err := cbTx.BtcEncode(&payload, ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("CbTx.BtcEncode: %v", err)
+	}
+
+	baseHash := chainhash.Hash{0x01}
+	blockHash := chainhash.Hash{0x02}
+	msg := NewMsgMnListDiff(&baseHash, &blockHash)
+	msg.TotalTransactions = 1
+	msg.MerkleHashes = []chainhash.Hash{{0x03}}
+	msg.MerkleFlags = []byte{0x01}
+
+	msg.CbTx = *NewMsgTx(SpecialTxVersion)
+	msg.CbTx.Type = TxTypeCoinbase
+	msg.CbTx.AddTxIn(&TxIn{
+		PreviousOutPoint: OutPoint{Index: MaxPrevOutIndex},
+		SignatureScript:  []byte{0x03, 0x40, 0xb0, 0x0f},
+		Sequence:         MaxTxInSequenceNum,
+	})
+	msg.CbTx.AddTxOut(&TxOut{Value: 1000, PkScript: []byte{0x51}})
+	msg.CbTx.ExtraPayload = payload.Bytes()
+
+	msg.Version = ProTxVersionBasicBLS
+	msg.DeletedMNs = []chainhash.Hash{{0x04}}
+	entry := newTestSMLEntry()
+	entry.Version = ProTxVersionBasicBLS
+	entry.Type = MasternodeTypeEvo
+	entry.PlatformHTTPPort = 443
+	entry.PlatformNodeID[0] = 0x0a
+	msg.MNList = []SimplifiedMNListEntry{*entry}
+	msg.DeletedQuorums = []DeletedQuorum{
+		{LLMQType: 1, QuorumHash: chainhash.Hash{0x05}},
+	}
+
+	commitment := QuorumCommitment{
+		Version:        1,
+		LLMQType:       1,
+		QuorumHash:     chainhash.Hash{0x06},
+		Signers:        []bool{true, false, true},
+		ValidMembers:   []bool{true, true, true},
+		QuorumVvecHash: chainhash.Hash{0x08},
+	}
+	commitment.QuorumPublicKey[0] = 0x09
+	msg.NewQuorums = []QuorumCommitment{commitment}
+
+	clSig := QuorumCLSig{QuorumIndexes: []uint16{0}}
+	clSig.Signature[0] = 0x0b
+	msg.QuorumsCLSigs = []QuorumCLSig{clSig}
+	return msg
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// maxSporkSignatureSize is the maximum size of a spork signature.  Sporks are
+// signed with compact ECDSA signatures which are 65 bytes long.
+const maxSporkSignatureSize = 65
+
+// MsgSpork implements the Message interface and represents a Dash spork
+// message.  It is used to relay the value of a network wide feature switch
+// (spork) signed by the spork key.
+type MsgSpork struct {
+	SporkID    int32
+	Value      int64
+	TimeSigned int64
+	Signature  []byte
+}
+
+// SignatureHash returns the hash of the spork which is signed by the spork
+// key.  It commits to the spork id, value and signing time but not to the
+// signature itself.
+func (msg *MsgSpork) SignatureHash() chainhash.Hash {
+	// Spork id 4 bytes + Value 8 bytes + TimeSigned 8 bytes.
+	buf := bytes.NewBuffer(make([]byte, 0, 20))
+	_ = writeElements(buf, msg.SporkID, msg.Value, msg.TimeSigned)
+	return chainhash.DoubleHashH(buf.Bytes())
+}
+
+// Hash returns the hash of the fully serialized spork message including the
+// signature.  It identifies the spork in inventory vectors.
+func (msg *MsgSpork) Hash() chainhash.Hash {
+	buf := bytes.NewBuffer(make([]byte, 0, msg.MaxPayloadLength(0)))
+	_ = msg.BtcEncode(buf, 0, BaseEncoding)
+	return chainhash.DoubleHashH(buf.Bytes())
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgSpork) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElements(r, &msg.SporkID, &msg.Value, &msg.TimeSigned)
+	if err != nil {
+		return err
+	}
+
+	msg.Signature, err = ReadVarBytes(r, pver, maxSporkSignatureSize,
+		"spork signature")
+	return err
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgSpork) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElements(w, msg.SporkID, msg.Value, msg.TimeSigned)
+	if err != nil {
+		return err
+	}
+
+	return WriteVarBytes(w, pver, msg.Signature)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgSpork) Command() string {
+	return CmdSpork
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgSpork) MaxPayloadLength(pver uint32) uint32 {
+	// SporkID 4 bytes + Value 8 bytes + TimeSigned 8 bytes + signature
+	// length varint 1 byte + Signature.
+	return 21 + maxSporkSignatureSize
+}
+
+// NewMsgSpork returns a new Dash spork message that conforms to the Message
+// interface.  See MsgSpork for details.
+func NewMsgSpork(sporkID int32, value, timeSigned int64, signature []byte) *MsgSpork {
+	return &MsgSpork{
+		SporkID:    sporkID,
+		Value:      value,
+		TimeSigned: timeSigned,
+		Signature:  signature,
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestSpork tests the MsgSpork API and wire encoding.
+func TestSpork(t *testing.T) {
+	pver := ProtocolVersion
+
+	sig := bytes.Repeat([]byte{0xaa}, maxSporkSignatureSize)
+	msg := NewMsgSpork(10001, 4070908800, 1546300800, sig)
+
+	if cmd := msg.Command(); cmd != "spork" {
+		t.Errorf("Command: wrong command - got %v want spork", cmd)
+	}
+	if max := msg.MaxPayloadLength(pver); max != 86 {
+		t.Errorf("MaxPayloadLength: wrong max payload length - got %v, "+
+			"want 86", max)
+	}
+
+	header := []byte{
+		0x11, 0x27, 0x00, 0x00, // SporkID 10001
+		0x80, 0x23, 0xa5, 0xf2, 0x00, 0x00, 0x00, 0x00, // Value
+		0x80, 0xad, 0x2a, 0x5c, 0x00, 0x00, 0x00, 0x00, // TimeSigned
+	}
+	encoded := append(append(append([]byte{}, header...),
+		byte(len(sig))), sig...)
+
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, pver, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), encoded) {
+		t.Fatalf("BtcEncode\n got: %s want: %s", spew.Sdump(buf.Bytes()),
+			spew.Sdump(encoded))
+	}
+
+	var readMsg MsgSpork
+	if err := readMsg.BtcDecode(bytes.NewReader(encoded), pver, BaseEncoding); err != nil {
+		t.Fatalf("BtcDecode: %v", err)
+	}
+	if !reflect.DeepEqual(&readMsg, msg) {
+		t.Fatalf("BtcDecode\n got: %s want: %s", spew.Sdump(&readMsg),
+			spew.Sdump(msg))
+	}
+
+	// The signature hash commits to everything but the signature while the
+	// message hash commits to the full serialization.
+	if got, want := msg.SignatureHash(), chainhash.DoubleHashH(header); got != want {
+		t.Errorf("SignatureHash: got %v, want %v", got, want)
+	}
+	if got, want := msg.Hash(), chainhash.DoubleHashH(encoded); got != want {
+		t.Errorf("Hash: got %v, want %v", got, want)
+	}
+
+	// A signature longer than the max allowed size must be rejected.
+	tooLong := append(append([]byte{}, header...), maxSporkSignatureSize+1)
+	tooLong = append(tooLong, bytes.Repeat([]byte{0xaa}, maxSporkSignatureSize+1)...)
+	err := readMsg.BtcDecode(bytes.NewReader(tooLong), pver, BaseEncoding)
+	if _, ok := err.(*MessageError); !ok {
+		t.Errorf("BtcDecode: wrong error - got %v, want %T", err,
+			&MessageError{})
+	}
+}
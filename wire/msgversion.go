@@ -11,6 +11,8 @@ import (
 	"io"
 	"strings"
 	"time"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
 )
 
 // MaxUserAgentLen is the maximum allowed length for the user agent field in a
@@ -56,6 +58,17 @@ type MsgVersion struct {
 
 	// Don't announce transactions to peer.
 	DisableRelayTx bool
+
+	// MNAuthChallenge is the random challenge a masternode must sign in
+	// its mnauth message to authenticate itself to the generator of the
+	// version message.
+	MNAuthChallenge chainhash.Hash
+
+	// MasternodeConnection indicates that the generator of the version
+	// message is a masternode connecting to another masternode.  Such
+	// connections are used for quorum communication and don't relay
+	// inventory.
+	MasternodeConnection bool
 }
 
 // HasService returns whether the specified service is supported by the peer
@@ -145,6 +158,23 @@ func (msg *MsgVersion) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding)
 		msg.DisableRelayTx = !relayTx
 	}
 
+	// Dash protocol versions >= MNAuthVersion added the masternode
+	// authentication challenge and later the masternode connection flag.
+	// They are only considered present if there are bytes remaining in
+	// the message.
+	if buf.Len() > 0 {
+		err = readElement(buf, &msg.MNAuthChallenge)
+		if err != nil {
+			return err
+		}
+	}
+	if buf.Len() > 0 {
+		err = readElement(buf, &msg.MasternodeConnection)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -196,6 +226,17 @@ func (msg *MsgVersion) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding)
 			return err
 		}
 	}
+
+	// There were no masternode authentication fields before MNAuthVersion.
+	// Dash Core always sends the masternode connection flag along with the
+	// challenge and ignores it when it predates the flag.
+	if pver >= MNAuthVersion {
+		err = writeElements(w, &msg.MNAuthChallenge,
+			msg.MasternodeConnection)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -214,8 +255,15 @@ func (msg *MsgVersion) MaxPayloadLength(pver uint32) uint32 {
 	// remote and local net addresses + nonce 8 bytes + length of user
 	// agent (varInt) + max allowed useragent length + last block 4 bytes +
 	// relay transactions flag 1 byte.
-	return 33 + (maxNetAddressPayload(pver) * 2) + MaxVarIntPayload +
+	plen := 33 + (maxNetAddressPayload(pver) * 2) + MaxVarIntPayload +
 		MaxUserAgentLen
+
+	// Masternode authentication challenge 32 bytes + masternode
+	// connection flag 1 byte.
+	if pver >= MNAuthVersion {
+		plen += chainhash.HashSize + 1
+	}
+	return plen
 }
 
 // NewMsgVersion returns a new bitcoin version message that conforms to the
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
 )
 
 // TestVersion tests the MsgVersion API.
@@ -106,8 +107,9 @@ func TestVersion(t *testing.T) {
 	// Protocol version 4 bytes + services 8 bytes + timestamp 8 bytes +
 	// remote and local net addresses + nonce 8 bytes + length of user agent
 	// (varInt) + max allowed user agent length + last block 4 bytes +
-	// relay transactions flag 1 byte.
-	wantPayload := uint32(358)
+	// relay transactions flag 1 byte + masternode authentication challenge
+	// 32 bytes + masternode connection flag 1 byte.
+	wantPayload := uint32(391)
 	maxPayload := msg.MaxPayloadLength(pver)
 	if maxPayload != wantPayload {
 		t.Errorf("MaxPayloadLength: wrong max payload length for "+
@@ -146,11 +148,29 @@ func TestVersionWire(t *testing.T) {
 		enc  MessageEncoding // Message encoding format
 	}{
 		// Latest protocol version.
+		{
+			baseVersionMNAuth,
+			baseVersionMNAuth,
+			baseVersionMNAuthEncoded,
+			ProtocolVersion,
+			BaseEncoding,
+		},
+
+		// Protocol version MNAuthVersion.
+		{
+			baseVersionMNAuth,
+			baseVersionMNAuth,
+			baseVersionMNAuthEncoded,
+			MNAuthVersion,
+			BaseEncoding,
+		},
+
+		// Protocol version before MNAuthVersion.
 		{
 			baseVersionBIP0037,
 			baseVersionBIP0037,
 			baseVersionBIP0037Encoded,
-			ProtocolVersion,
+			MNAuthVersion - 1,
 			BaseEncoding,
 		},
 
@@ -562,3 +582,62 @@ var baseVersionBIP0037Encoded = []byte{
 	0xfa, 0x92, 0x03, 0x00, // Last block
 	0x01, // Relay tx
 }
+
+// baseVersionMNAuth is used in the various tests as a baseline MsgVersion for
+// MNAuthVersion.
+var baseVersionMNAuth = &MsgVersion{
+	ProtocolVersion: 70214,
+	Services:        SFNodeNetwork,
+	Timestamp:       time.Unix(0x495fab29, 0), // 2009-01-03 12:15:05 -0600 CST)
+	AddrYou: NetAddress{
+		Timestamp: time.Time{}, // Zero value -- no timestamp in version
+		Services:  SFNodeNetwork,
+		IP:        net.ParseIP("192.168.0.1"),
+		Port:      8333,
+	},
+	AddrMe: NetAddress{
+		Timestamp: time.Time{}, // Zero value -- no timestamp in version
+		Services:  SFNodeNetwork,
+		IP:        net.ParseIP("127.0.0.1"),
+		Port:      8333,
+	},
+	Nonce:     123123, // 0x1e0f3
+	UserAgent: "/btcdtest:0.0.1/",
+	LastBlock: 234234, // 0x392fa
+	MNAuthChallenge: chainhash.Hash{
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+		0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+		0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+	},
+	MasternodeConnection: true,
+}
+
+// baseVersionMNAuthEncoded is the wire encoded bytes for baseVersionMNAuth
+// using protocol version MNAuthVersion and is used in the various tests.
+var baseVersionMNAuthEncoded = []byte{
+	0x46, 0x12, 0x01, 0x00, // Protocol version 70214
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // SFNodeNetwork
+	0x29, 0xab, 0x5f, 0x49, 0x00, 0x00, 0x00, 0x00, // 64-bit Timestamp
+	// AddrYou -- No timestamp for NetAddress in version message
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // SFNodeNetwork
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0xff, 0xff, 0xc0, 0xa8, 0x00, 0x01, // IP 192.168.0.1
+	0x20, 0x8d, // Port 8333 in big-endian
+	// AddrMe -- No timestamp for NetAddress in version message
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // SFNodeNetwork
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0xff, 0xff, 0x7f, 0x00, 0x00, 0x01, // IP 127.0.0.1
+	0x20, 0x8d, // Port 8333 in big-endian
+	0xf3, 0xe0, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, // Nonce
+	0x10, // Varint for user agent length
+	0x2f, 0x62, 0x74, 0x63, 0x64, 0x74, 0x65, 0x73,
+	0x74, 0x3a, 0x30, 0x2e, 0x30, 0x2e, 0x31, 0x2f, // User agent
+	0xfa, 0x92, 0x03, 0x00, // Last block
+	0x01, // Relay tx
+	0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+	0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+	0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+	0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20, // MNAuth challenge
+	0x01, // Masternode connection
+}
@@ -13,7 +13,9 @@ import (
 
 const (
 	// ProtocolVersion is the latest protocol version this package supports.
-	ProtocolVersion uint32 = 70013
+	// Dash Core peers disconnect peers announcing versions far below their
+	// own, so it follows the Dash protocol version.
+	ProtocolVersion uint32 = DashProtocolVersion
 
 	// MultipleAddressVersion is the protocol version which added multiple
 	// addresses per message (pver >= MultipleAddressVersion).
@@ -51,6 +53,47 @@ const (
 	// FeeFilterVersion is the protocol version which added a new
 	// feefilter message.
 	FeeFilterVersion uint32 = 70013
+
+	// MNAuthVersion is the Dash protocol version which extended the version
+	// message with the challenge signed by masternodes to authenticate
+	// themselves with the mnauth message (pver >= MNAuthVersion).
+	MNAuthVersion uint32 = 70214
+
+	// LLMQVersion is the Dash protocol version which added the deleted and
+	// new quorums to the mnlistdiff message (pver >= LLMQVersion).
+	LLMQVersion uint32 = 70214
+
+	// BLSSchemeVersion is the Dash protocol version which added the BLS
+	// scheme version of the masternode list entries to the mnlistdiff
+	// message (pver >= BLSSchemeVersion).
+	BLSSchemeVersion uint32 = 70225
+
+	// DMNTypeVersion is the Dash protocol version which added the masternode
+	// type and the platform fields of Evo masternodes to the simplified
+	// masternode list entries (pver >= DMNTypeVersion).
+	DMNTypeVersion uint32 = 70227
+
+	// SMNLEVersionedVersion is the Dash protocol version which added the
+	// BLS scheme version to each simplified masternode list entry
+	// (pver >= SMNLEVersionedVersion).
+	SMNLEVersionedVersion uint32 = 70228
+
+	// MnListDiffVersionOrderVersion is the Dash protocol version which moved
+	// the version of the mnlistdiff message in front of its base block hash
+	// (pver >= MnListDiffVersionOrderVersion).
+	MnListDiffVersionOrderVersion uint32 = 70229
+
+	// MnListDiffCLSigsVersion is the Dash protocol version which added the
+	// chainlock signatures of the new quorums to the mnlistdiff message
+	// (pver >= MnListDiffCLSigsVersion).
+	MnListDiffCLSigsVersion uint32 = 70230
+
+	// DashProtocolVersion is the latest Dash protocol version this package
+	// supports.  The messages of the package implement the formats of all
+	// Dash protocol versions up to it, the latest change being the
+	// chainlock signatures added to the mnlistdiff message by
+	// MnListDiffCLSigsVersion.
+	DashProtocolVersion uint32 = MnListDiffCLSigsVersion
 )
 
 // ServiceFlag identifies services supported by a bitcoin peer.
@@ -71,14 +114,41 @@ const (
 	// SFNodeWitness is a flag used to indicate a peer supports blocks
 	// and transactions including witness data (BIP0144).
 	SFNodeWitness
+
+	// SFNodeXthin is a flag used to indicate a peer supports xthin blocks.
+	// Dash Core dropped xthin support but the bit is still reserved.
+	SFNodeXthin
+)
+
+// Service flags assigned by Dash Core which are not part of the contiguous
+// bitcoin flags above.  Dash Core does not assign service bits for masternode,
+// CoinJoin, InstantSend or ChainLocks support.  Those features are signaled by
+// the protocol version of the peer instead, so a full node is able to relay
+// them.
+const (
+	// SFNodeCompactFilters is a flag used to indicate a peer serves
+	// compact block filters (BIP0157).  It uses bit 6.
+	SFNodeCompactFilters ServiceFlag = 1 << 6
+
+	// SFNodeNetworkLimited is a flag used to indicate a peer only serves
+	// the last 288 blocks (BIP0159).  It uses bit 10.
+	SFNodeNetworkLimited ServiceFlag = 1 << 10
 )
 
+// SFDNSSeedFilterable is the set of service flags which DNS seeders that
+// support filtering are able to filter peers by.
+const SFDNSSeedFilterable = SFNodeNetwork | SFNodeBloom | SFNodeXthin |
+	SFNodeCompactFilters | SFNodeNetworkLimited
+
 // Map of service flags back to their constant names for pretty printing.
 var sfStrings = map[ServiceFlag]string{
-	SFNodeNetwork: "SFNodeNetwork",
-	SFNodeGetUTXO: "SFNodeGetUTXO",
-	SFNodeBloom:   "SFNodeBloom",
-	SFNodeWitness: "SFNodeWitness",
+	SFNodeNetwork:        "SFNodeNetwork",
+	SFNodeGetUTXO:        "SFNodeGetUTXO",
+	SFNodeBloom:          "SFNodeBloom",
+	SFNodeWitness:        "SFNodeWitness",
+	SFNodeXthin:          "SFNodeXthin",
+	SFNodeCompactFilters: "SFNodeCompactFilters",
+	SFNodeNetworkLimited: "SFNodeNetworkLimited",
 }
 
 // orderedSFStrings is an ordered list of service flags from highest to
@@ -88,6 +158,9 @@ var orderedSFStrings = []ServiceFlag{
 	SFNodeGetUTXO,
 	SFNodeBloom,
 	SFNodeWitness,
+	SFNodeXthin,
+	SFNodeCompactFilters,
+	SFNodeNetworkLimited,
 }
 
 // String returns the ServiceFlag in human-readable form.
@@ -131,6 +204,10 @@ const (
 
 	// TestNet3 represents the test network (version 3).
 	TestNet3 DASHNet = 0x0709110b
+
+	// DevNet represents the development networks.  All devnets share the
+	// same magic and are told apart by their devnet genesis block.
+	DevNet DASHNet = 0xceffcae2
 )
 
 // bnStrings is a map of bitcoin networks back to their constant names for
@@ -139,6 +216,7 @@ var bnStrings = map[DASHNet]string{
 	MainNet:  "MainNet",
 	TestNet:  "TestNet",
 	TestNet3: "TestNet3",
+	DevNet:   "DevNet",
 }
 
 // String returns the DASHNet in human-readable form.
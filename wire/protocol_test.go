@@ -17,7 +17,10 @@ func TestServiceFlagStringer(t *testing.T) {
 		{SFNodeGetUTXO, "SFNodeGetUTXO"},
 		{SFNodeBloom, "SFNodeBloom"},
 		{SFNodeWitness, "SFNodeWitness"},
-		{0xffffffff, "SFNodeNetwork|SFNodeGetUTXO|SFNodeBloom|SFNodeWitness|0xfffffff0"},
+		{SFNodeXthin, "SFNodeXthin"},
+		{SFNodeCompactFilters, "SFNodeCompactFilters"},
+		{SFNodeNetworkLimited, "SFNodeNetworkLimited"},
+		{0xffffffff, "SFNodeNetwork|SFNodeGetUTXO|SFNodeBloom|SFNodeWitness|SFNodeXthin|SFNodeCompactFilters|SFNodeNetworkLimited|0xfffffba0"},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -40,7 +43,7 @@ func TestDASHNetStringer(t *testing.T) {
 		{MainNet, "MainNet"},
 		{TestNet, "TestNet"},
 		{TestNet3, "TestNet3"},
-		{SimNet, "SimNet"},
+		{DevNet, "DevNet"},
 		{0xffffffff, "Unknown DASHNet (4294967295)"},
 	}
 
@@ -0,0 +1,467 @@
+// Copyright (c) 2018 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+const (
+	// maxProTxScriptSize is the maximum allowed size of the scripts and
+	// signatures carried by provider transactions.
+	maxProTxScriptSize = maxTxExtraPayload
+
+	// BLSPublicKeySize is the size of a serialized BLS public key.
+	BLSPublicKeySize = 48
+
+	// BLSSignatureSize is the size of a serialized BLS signature.
+	BLSSignatureSize = 96
+
+	// KeyIDSize is the size of a key id, the hash160 of a public key.
+	KeyIDSize = 20
+
+	// PlatformNodeIDSize is the size of the Dash Platform node id of an Evo
+	// masternode, the hash160 of its Tenderdash node key.
+	PlatformNodeIDSize = 20
+)
+
+// These constants define the versions of the provider transaction payloads.
+// The version tells the BLS serialization scheme of the operator key and
+// signature carried by a payload: the legacy scheme for ProTxVersionLegacyBLS
+// and the basic scheme of the v19 hard fork for ProTxVersionBasicBLS.  See
+// chaincfg.Params.BLSSchemeAt for the scheme in effect at a height.
+const (
+	ProTxVersionLegacyBLS uint16 = 1
+	ProTxVersionBasicBLS  uint16 = 2
+)
+
+// These constants define the types of masternodes provider transactions
+// register and update.  Evo masternodes also serve Dash Platform, so their
+// payloads carry the platform fields.
+const (
+	MasternodeTypeRegular uint16 = 0
+	MasternodeTypeEvo     uint16 = 1
+)
+
+// ProRegTx represents the extra payload of a provider registration special
+// transaction (TxTypeProRegister) which registers a masternode as defined by
+// DIP0003.
+//
+// The platform fields are only serialized for masternodes of type
+// MasternodeTypeEvo.
+type ProRegTx struct {
+	Version            uint16
+	Type               uint16
+	Mode               uint16
+	CollateralOutpoint OutPoint
+	IPAddress          net.IP
+	Port               uint16
+	KeyIDOwner         [KeyIDSize]byte
+	PubKeyOperator     [BLSPublicKeySize]byte
+	KeyIDVoting        [KeyIDSize]byte
+	OperatorReward     uint16
+	ScriptPayout       []byte
+	InputsHash         chainhash.Hash
+	PlatformNodeID     [PlatformNodeIDSize]byte
+	PlatformP2PPort    uint16
+	PlatformHTTPPort   uint16
+	Signature          []byte
+}
+
+// BtcDecode decodes r using the Dash serialization of the payload into the
+// receiver.
+func (p *ProRegTx) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElements(r, &p.Version, &p.Type, &p.Mode)
+	if err != nil {
+		return err
+	}
+
+	err = readOutPoint(r, pver, 0, &p.CollateralOutpoint)
+	if err != nil {
+		return err
+	}
+
+	p.IPAddress, p.Port, err = readServiceAddress(r)
+	if err != nil {
+		return err
+	}
+
+	err = readElements(r, &p.KeyIDOwner, &p.PubKeyOperator, &p.KeyIDVoting,
+		&p.OperatorReward)
+	if err != nil {
+		return err
+	}
+
+	p.ScriptPayout, err = ReadVarBytes(r, pver, maxProTxScriptSize,
+		"ScriptPayout")
+	if err != nil {
+		return err
+	}
+
+	err = readElement(r, &p.InputsHash)
+	if err != nil {
+		return err
+	}
+
+	if p.Type == MasternodeTypeEvo {
+		err = readElements(r, &p.PlatformNodeID, &p.PlatformP2PPort,
+			&p.PlatformHTTPPort)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.Signature, err = ReadVarBytes(r, pver, maxProTxScriptSize,
+		"Signature")
+	return err
+}
+
+// BtcEncode encodes the receiver to w using the Dash serialization of the
+// payload.
+func (p *ProRegTx) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElements(w, p.Version, p.Type, p.Mode)
+	if err != nil {
+		return err
+	}
+
+	err = writeOutPoint(w, pver, 0, &p.CollateralOutpoint)
+	if err != nil {
+		return err
+	}
+
+	err = writeServiceAddress(w, p.IPAddress, p.Port)
+	if err != nil {
+		return err
+	}
+
+	err = writeElements(w, p.KeyIDOwner, p.PubKeyOperator, p.KeyIDVoting,
+		p.OperatorReward)
+	if err != nil {
+		return err
+	}
+
+	err = WriteVarBytes(w, pver, p.ScriptPayout)
+	if err != nil {
+		return err
+	}
+
+	err = writeElement(w, &p.InputsHash)
+	if err != nil {
+		return err
+	}
+
+	if p.Type == MasternodeTypeEvo {
+		err = writeElements(w, p.PlatformNodeID, p.PlatformP2PPort,
+			p.PlatformHTTPPort)
+		if err != nil {
+			return err
+		}
+	}
+
+	return WriteVarBytes(w, pver, p.Signature)
+}
+
+// ProRegTx decodes the extra payload of a provider registration transaction.
+// An error is returned when the transaction is of another type or the payload
+// is malformed.
+func (msg *MsgTx) ProRegTx() (*ProRegTx, error) {
+	var payload ProRegTx
+	if err := msg.decodePayload(TxTypeProRegister, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// These constants define the reasons a ProUpRevTx may give for revoking the
+// operator of a masternode.
+const (
+	// ProUpRevReasonNotSpecified is used when no reason is given.
+	ProUpRevReasonNotSpecified uint16 = 0
+
+	// ProUpRevReasonTerminationOfService is used when the operator stops
+	// providing service.
+	ProUpRevReasonTerminationOfService uint16 = 1
+
+	// ProUpRevReasonCompromisedKeys is used when the keys of the operator
+	// were compromised.
+	ProUpRevReasonCompromisedKeys uint16 = 2
+
+	// ProUpRevReasonChangeOfKeys is used when the operator is about to
+	// change its keys.
+	ProUpRevReasonChangeOfKeys uint16 = 3
+)
+
+// ProUpServTx represents the extra payload of a provider update service
+// special transaction (TxTypeProUpdateService) which updates the service
+// address and operator payout script of a masternode as defined by DIP0003.
+// It is signed with the BLS key of the operator.
+//
+// ProTxHash is the hash of the ProRegTx which registered the masternode, the
+// same as for the other provider update transactions.  The masternode Type is
+// only serialized from ProTxVersionBasicBLS on, and the platform fields only
+// for masternodes of type MasternodeTypeEvo.
+type ProUpServTx struct {
+	Version              uint16
+	Type                 uint16
+	ProTxHash            chainhash.Hash
+	IPAddress            net.IP
+	Port                 uint16
+	ScriptOperatorPayout []byte
+	InputsHash           chainhash.Hash
+	PlatformNodeID       [PlatformNodeIDSize]byte
+	PlatformP2PPort      uint16
+	PlatformHTTPPort     uint16
+	Signature            [BLSSignatureSize]byte
+}
+
+// BtcDecode decodes r using the Dash serialization of the payload into the
+// receiver.
+func (p *ProUpServTx) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElement(r, &p.Version)
+	if err != nil {
+		return err
+	}
+
+	if p.Version == ProTxVersionBasicBLS {
+		err = readElement(r, &p.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = readElement(r, &p.ProTxHash)
+	if err != nil {
+		return err
+	}
+
+	p.IPAddress, p.Port, err = readServiceAddress(r)
+	if err != nil {
+		return err
+	}
+
+	p.ScriptOperatorPayout, err = ReadVarBytes(r, pver,
+		maxProTxScriptSize, "ScriptOperatorPayout")
+	if err != nil {
+		return err
+	}
+
+	err = readElement(r, &p.InputsHash)
+	if err != nil {
+		return err
+	}
+
+	if p.Type == MasternodeTypeEvo {
+		err = readElements(r, &p.PlatformNodeID, &p.PlatformP2PPort,
+			&p.PlatformHTTPPort)
+		if err != nil {
+			return err
+		}
+	}
+
+	return readElement(r, &p.Signature)
+}
+
+// BtcEncode encodes the receiver to w using the Dash serialization of the
+// payload.
+func (p *ProUpServTx) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElement(w, p.Version)
+	if err != nil {
+		return err
+	}
+
+	if p.Version == ProTxVersionBasicBLS {
+		err = writeElement(w, p.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeElement(w, &p.ProTxHash)
+	if err != nil {
+		return err
+	}
+
+	err = writeServiceAddress(w, p.IPAddress, p.Port)
+	if err != nil {
+		return err
+	}
+
+	err = WriteVarBytes(w, pver, p.ScriptOperatorPayout)
+	if err != nil {
+		return err
+	}
+
+	err = writeElement(w, &p.InputsHash)
+	if err != nil {
+		return err
+	}
+
+	if p.Type == MasternodeTypeEvo {
+		err = writeElements(w, p.PlatformNodeID, p.PlatformP2PPort,
+			p.PlatformHTTPPort)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeElement(w, p.Signature)
+}
+
+// ProUpServTx decodes the extra payload of a provider update service
+// transaction.  An error is returned when the transaction is of another type or
+// the payload is malformed.
+func (msg *MsgTx) ProUpServTx() (*ProUpServTx, error) {
+	var payload ProUpServTx
+	if err := msg.decodePayload(TxTypeProUpdateService, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// ProUpRegTx represents the extra payload of a provider update registrar
+// special transaction (TxTypeProUpdateRegistrar) which updates the operator
+// key, voting key and payout script of a masternode as defined by DIP0003.  It
+// is signed with the ECDSA owner key of the masternode.
+type ProUpRegTx struct {
+	Version        uint16
+	ProTxHash      chainhash.Hash
+	Mode           uint16
+	PubKeyOperator [BLSPublicKeySize]byte
+	KeyIDVoting    [KeyIDSize]byte
+	ScriptPayout   []byte
+	InputsHash     chainhash.Hash
+	Signature      []byte
+}
+
+// BtcDecode decodes r using the Dash serialization of the payload into the
+// receiver.
+func (p *ProUpRegTx) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElements(r, &p.Version, &p.ProTxHash, &p.Mode,
+		&p.PubKeyOperator, &p.KeyIDVoting)
+	if err != nil {
+		return err
+	}
+
+	p.ScriptPayout, err = ReadVarBytes(r, pver, maxProTxScriptSize,
+		"ScriptPayout")
+	if err != nil {
+		return err
+	}
+
+	err = readElement(r, &p.InputsHash)
+	if err != nil {
+		return err
+	}
+
+	p.Signature, err = ReadVarBytes(r, pver, maxProTxScriptSize,
+		"Signature")
+	return err
+}
+
+// BtcEncode encodes the receiver to w using the Dash serialization of the
+// payload.
+func (p *ProUpRegTx) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElements(w, p.Version, &p.ProTxHash, p.Mode,
+		p.PubKeyOperator, p.KeyIDVoting)
+	if err != nil {
+		return err
+	}
+
+	err = WriteVarBytes(w, pver, p.ScriptPayout)
+	if err != nil {
+		return err
+	}
+
+	err = writeElement(w, &p.InputsHash)
+	if err != nil {
+		return err
+	}
+
+	return WriteVarBytes(w, pver, p.Signature)
+}
+
+// ProUpRegTx decodes the extra payload of a provider update registrar
+// transaction.  An error is returned when the transaction is of another type or
+// the payload is malformed.
+func (msg *MsgTx) ProUpRegTx() (*ProUpRegTx, error) {
+	var payload ProUpRegTx
+	if err := msg.decodePayload(TxTypeProUpdateRegistrar, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// ProUpRevTx represents the extra payload of a provider update revocation
+// special transaction (TxTypeProUpdateRevoke) which revokes the operator of a
+// masternode as defined by DIP0003.  Reason is one of the ProUpRevReason
+// constants.  It is signed with the BLS key of the operator.
+type ProUpRevTx struct {
+	Version    uint16
+	ProTxHash  chainhash.Hash
+	Reason     uint16
+	InputsHash chainhash.Hash
+	Signature  [BLSSignatureSize]byte
+}
+
+// BtcDecode decodes r using the Dash serialization of the payload into the
+// receiver.
+func (p *ProUpRevTx) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	return readElements(r, &p.Version, &p.ProTxHash, &p.Reason,
+		&p.InputsHash, &p.Signature)
+}
+
+// BtcEncode encodes the receiver to w using the Dash serialization of the
+// payload.
+func (p *ProUpRevTx) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	return writeElements(w, p.Version, &p.ProTxHash, p.Reason,
+		&p.InputsHash, p.Signature)
+}
+
+// ProUpRevTx decodes the extra payload of a provider update revocation
+// transaction.  An error is returned when the transaction is of another type or
+// the payload is malformed.
+func (msg *MsgTx) ProUpRevTx() (*ProUpRevTx, error) {
+	var payload ProUpRevTx
+	if err := msg.decodePayload(TxTypeProUpdateRevoke, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// readServiceAddress reads an IP address and port as serialized by the CService
+// type of Dash Core, which is the same as the address part of a NetAddress.
+func readServiceAddress(r io.Reader) (net.IP, uint16, error) {
+	var ip [16]byte
+	if err := readElement(r, &ip); err != nil {
+		return nil, 0, err
+	}
+
+	// Sigh.  Bitcoin protocol mixes little and big endian.
+	port, err := binarySerializer.Uint16(r, bigEndian)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return net.IP(ip[:]), port, nil
+}
+
+// writeServiceAddress writes an IP address and port as serialized by the
+// CService type of Dash Core.
+func writeServiceAddress(w io.Writer, ip net.IP, port uint16) error {
+	var ipBytes [16]byte
+	if ip != nil {
+		copy(ipBytes[:], ip.To16())
+	}
+	if err := writeElement(w, ipBytes); err != nil {
+		return err
+	}
+
+	// Sigh.  Bitcoin protocol mixes little and big endian.
+	return binary.Write(w, bigEndian, port)
+}
@@ -0,0 +1,435 @@
+// Copyright (c) 2018 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestProRegTxWire tests the ProRegTx payload encode and decode against its
+// expected serialization.
+func TestProRegTxWire(t *testing.T) {
+	proRegTx := newTestProRegTx()
+
+	var buf bytes.Buffer
+	if err := proRegTx.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), proRegTxEncoded) {
+		t.Fatalf("BtcEncode\n got: %s want: %s",
+			spew.Sdump(buf.Bytes()), spew.Sdump(proRegTxEncoded))
+	}
+
+	var payload ProRegTx
+	rbuf := bytes.NewReader(proRegTxEncoded)
+	if err := payload.BtcDecode(rbuf, ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcDecode: %v", err)
+	}
+	if !reflect.DeepEqual(&payload, proRegTx) {
+		t.Fatalf("BtcDecode\n got: %s want: %s", spew.Sdump(&payload),
+			spew.Sdump(proRegTx))
+	}
+
+	// Decoding a truncated payload must fail.
+	truncated := proRegTxEncoded[:len(proRegTxEncoded)-1]
+	if err := payload.BtcDecode(bytes.NewReader(truncated),
+		ProtocolVersion, BaseEncoding); err == nil {
+
+		t.Error("BtcDecode: did not fail on truncated payload")
+	}
+}
+
+// TestProRegTxSpecialTx ensures a provider registration transaction round trips
+// through MsgTx with its extra payload intact.
+func TestProRegTxSpecialTx(t *testing.T) {
+	tx := NewMsgTx(SpecialTxVersion)
+	tx.Type = TxTypeProRegister
+	tx.AddTxIn(&TxIn{
+		PreviousOutPoint: OutPoint{Hash: chainhash.Hash{0x01}, Index: 0},
+		SignatureScript:  []byte{0x51},
+		Sequence:         MaxTxInSequenceNum,
+	})
+	tx.AddTxOut(&TxOut{Value: 1000, PkScript: []byte{0x51}})
+	tx.ExtraPayload = proRegTxEncoded
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	if buf.Len() != tx.SerializeSize() {
+		t.Errorf("SerializeSize: got %d, want %d", tx.SerializeSize(),
+			buf.Len())
+	}
+
+	var readTx MsgTx
+	if err := readTx.Deserialize(&buf); err != nil {
+		t.Fatalf("Deserialize: %v", err)
+	}
+	if !reflect.DeepEqual(&readTx, tx) {
+		t.Fatalf("Deserialize\n got: %s want: %s", spew.Sdump(&readTx),
+			spew.Sdump(tx))
+	}
+
+	payload, err := readTx.ProRegTx()
+	if err != nil {
+		t.Fatalf("ProRegTx: %v", err)
+	}
+	want := newTestProRegTx()
+	if !reflect.DeepEqual(payload, want) {
+		t.Fatalf("ProRegTx\n got: %s want: %s", spew.Sdump(payload),
+			spew.Sdump(want))
+	}
+
+	// A classic transaction has no provider registration payload.
+	if _, err := NewMsgTx(1).ProRegTx(); err == nil {
+		t.Error("ProRegTx: did not fail for classic transaction")
+	}
+}
+
+// newTestProRegTx returns the provider registration payload which serializes
+// to proRegTxEncoded.
+func newTestProRegTx() *ProRegTx {
+	payload := &ProRegTx{
+		Version: 1,
+		CollateralOutpoint: OutPoint{
+			Hash: chainhash.Hash{
+				0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+				0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+				0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+				0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+			},
+			Index: 1,
+		},
+		IPAddress:      net.ParseIP("1.2.3.4"),
+		Port:           9999,
+		OperatorReward: 500,
+		ScriptPayout: append(append([]byte{0x76, 0xa9, 0x14},
+			bytes.Repeat([]byte{0x44}, 20)...), 0x88, 0xac),
+		Signature: []byte{},
+	}
+	copy(payload.KeyIDOwner[:], bytes.Repeat([]byte{0x11}, KeyIDSize))
+	copy(payload.PubKeyOperator[:], bytes.Repeat([]byte{0x22}, BLSPublicKeySize))
+	copy(payload.KeyIDVoting[:], bytes.Repeat([]byte{0x33}, KeyIDSize))
+	copy(payload.InputsHash[:], bytes.Repeat([]byte{0x55}, chainhash.HashSize))
+	return payload
+}
+
+// proRegTxEncoded is the serialization of proRegTx.
+var proRegTxEncoded = []byte{
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, // Version, Type, Mode
+	0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, // CollateralOutpoint hash
+	0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+	0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+	0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+	0x01, 0x00, 0x00, 0x00, // CollateralOutpoint index
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // IPAddress
+	0x00, 0x00, 0xff, 0xff, 0x01, 0x02, 0x03, 0x04,
+	0x27, 0x0f, // Port
+	0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, // KeyIDOwner
+	0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11,
+	0x11, 0x11, 0x11, 0x11,
+	0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, // PubKeyOperator
+	0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22,
+	0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22,
+	0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22,
+	0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22,
+	0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22,
+	0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33, // KeyIDVoting
+	0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33,
+	0x33, 0x33, 0x33, 0x33,
+	0xf4, 0x01, // OperatorReward
+	0x19, 0x76, 0xa9, 0x14, 0x44, 0x44, 0x44, 0x44, // ScriptPayout
+	0x44, 0x44, 0x44, 0x44, 0x44, 0x44, 0x44, 0x44,
+	0x44, 0x44, 0x44, 0x44, 0x44, 0x44, 0x44, 0x44,
+	0x88, 0xac,
+	0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, // InputsHash
+	0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55,
+	0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55,
+	0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55,
+	0x00, // Signature
+}
+
+// TestProRegTxEvo ensures the platform fields of an Evo masternode
+// registration are serialized between the inputs hash and the signature, and
+// only for masternodes of that type.
+func TestProRegTxEvo(t *testing.T) {
+	evo := newTestProRegTx()
+	evo.Version = ProTxVersionBasicBLS
+	evo.Type = MasternodeTypeEvo
+	copy(evo.PlatformNodeID[:], bytes.Repeat([]byte{0x66}, PlatformNodeIDSize))
+	evo.PlatformP2PPort = 26656
+	evo.PlatformHTTPPort = 443
+
+	encoded := append([]byte{0x02, 0x00, 0x01, 0x00}, // Version, Type
+		proRegTxEncoded[4:len(proRegTxEncoded)-1]...)
+	encoded = append(encoded, bytes.Repeat([]byte{0x66}, PlatformNodeIDSize)...)
+	encoded = append(encoded,
+		0x20, 0x68, // PlatformP2PPort
+		0xbb, 0x01, // PlatformHTTPPort
+		0x00, // Signature
+	)
+
+	var buf bytes.Buffer
+	if err := evo.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), encoded) {
+		t.Fatalf("BtcEncode\n got: %s want: %s",
+			spew.Sdump(buf.Bytes()), spew.Sdump(encoded))
+	}
+
+	var payload ProRegTx
+	if err := payload.BtcDecode(bytes.NewReader(encoded), ProtocolVersion,
+		BaseEncoding); err != nil {
+
+		t.Fatalf("BtcDecode: %v", err)
+	}
+	if !reflect.DeepEqual(&payload, evo) {
+		t.Fatalf("BtcDecode\n got: %s want: %s", spew.Sdump(&payload),
+			spew.Sdump(evo))
+	}
+
+	// Decoding an Evo registration without the platform fields must
+	// fail.
+	truncated := append([]byte{0x02, 0x00, 0x01, 0x00},
+		proRegTxEncoded[4:]...)
+	if err := payload.BtcDecode(bytes.NewReader(truncated),
+		ProtocolVersion, BaseEncoding); err == nil {
+
+		t.Error("BtcDecode: did not fail without the platform fields")
+	}
+}
+
+// TestProUpTxWire tests the encode and decode of the provider update payloads
+// against their expected serializations, and their decoding from the extra
+// payload of a special transaction of the matching type.
+func TestProUpTxWire(t *testing.T) {
+	proTxHash := chainhash.Hash{0x01, 0x02, 0x03}
+	inputsHash := chainhash.Hash{0x55, 0x55}
+	var blsSig [BLSSignatureSize]byte
+	copy(blsSig[:], bytes.Repeat([]byte{0x66}, BLSSignatureSize))
+	var pubKeyOperator [BLSPublicKeySize]byte
+	copy(pubKeyOperator[:], bytes.Repeat([]byte{0x22}, BLSPublicKeySize))
+	var keyIDVoting [KeyIDSize]byte
+	copy(keyIDVoting[:], bytes.Repeat([]byte{0x33}, KeyIDSize))
+	var platformNodeID [PlatformNodeIDSize]byte
+	copy(platformNodeID[:], bytes.Repeat([]byte{0x44}, PlatformNodeIDSize))
+	script := []byte{0x51, 0x52}
+
+	serialize := func(parts ...[]byte) []byte {
+		return bytes.Join(parts, nil)
+	}
+
+	tests := []struct {
+		name    string
+		txType  uint16
+		payload specialTxPayload
+		empty   func() specialTxPayload
+		decode  func(*MsgTx) (specialTxPayload, error)
+		encoded []byte
+	}{
+		{
+			name:   "ProUpServTx",
+			txType: TxTypeProUpdateService,
+			payload: &ProUpServTx{
+				Version:              1,
+				ProTxHash:            proTxHash,
+				IPAddress:            net.ParseIP("1.2.3.4"),
+				Port:                 9999,
+				ScriptOperatorPayout: script,
+				InputsHash:           inputsHash,
+				Signature:            blsSig,
+			},
+			empty: func() specialTxPayload { return new(ProUpServTx) },
+			decode: func(tx *MsgTx) (specialTxPayload, error) {
+				return tx.ProUpServTx()
+			},
+			encoded: serialize(
+				[]byte{0x01, 0x00}, // Version
+				proTxHash[:],
+				[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0xff, 0xff, 0x01, 0x02, 0x03, 0x04}, // IPAddress
+				[]byte{0x27, 0x0f},       // Port
+				[]byte{0x02, 0x51, 0x52}, // ScriptOperatorPayout
+				inputsHash[:],
+				blsSig[:],
+			),
+		},
+		{
+			name:   "ProUpServTx basic BLS",
+			txType: TxTypeProUpdateService,
+			payload: &ProUpServTx{
+				Version:              ProTxVersionBasicBLS,
+				Type:                 MasternodeTypeRegular,
+				ProTxHash:            proTxHash,
+				IPAddress:            net.ParseIP("1.2.3.4"),
+				Port:                 9999,
+				ScriptOperatorPayout: script,
+				InputsHash:           inputsHash,
+				Signature:            blsSig,
+			},
+			empty: func() specialTxPayload { return new(ProUpServTx) },
+			decode: func(tx *MsgTx) (specialTxPayload, error) {
+				return tx.ProUpServTx()
+			},
+			encoded: serialize(
+				[]byte{0x02, 0x00}, // Version
+				[]byte{0x00, 0x00}, // Type
+				proTxHash[:],
+				[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0xff, 0xff, 0x01, 0x02, 0x03, 0x04}, // IPAddress
+				[]byte{0x27, 0x0f},       // Port
+				[]byte{0x02, 0x51, 0x52}, // ScriptOperatorPayout
+				inputsHash[:],
+				blsSig[:],
+			),
+		},
+		{
+			name:   "ProUpServTx Evo",
+			txType: TxTypeProUpdateService,
+			payload: &ProUpServTx{
+				Version:              ProTxVersionBasicBLS,
+				Type:                 MasternodeTypeEvo,
+				ProTxHash:            proTxHash,
+				IPAddress:            net.ParseIP("1.2.3.4"),
+				Port:                 9999,
+				ScriptOperatorPayout: script,
+				InputsHash:           inputsHash,
+				PlatformNodeID:       platformNodeID,
+				PlatformP2PPort:      26656,
+				PlatformHTTPPort:     443,
+				Signature:            blsSig,
+			},
+			empty: func() specialTxPayload { return new(ProUpServTx) },
+			decode: func(tx *MsgTx) (specialTxPayload, error) {
+				return tx.ProUpServTx()
+			},
+			encoded: serialize(
+				[]byte{0x02, 0x00}, // Version
+				[]byte{0x01, 0x00}, // Type
+				proTxHash[:],
+				[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0xff, 0xff, 0x01, 0x02, 0x03, 0x04}, // IPAddress
+				[]byte{0x27, 0x0f},       // Port
+				[]byte{0x02, 0x51, 0x52}, // ScriptOperatorPayout
+				inputsHash[:],
+				platformNodeID[:],
+				[]byte{0x20, 0x68}, // PlatformP2PPort
+				[]byte{0xbb, 0x01}, // PlatformHTTPPort
+				blsSig[:],
+			),
+		},
+		{
+			name:   "ProUpRegTx",
+			txType: TxTypeProUpdateRegistrar,
+			payload: &ProUpRegTx{
+				Version:        1,
+				ProTxHash:      proTxHash,
+				PubKeyOperator: pubKeyOperator,
+				KeyIDVoting:    keyIDVoting,
+				ScriptPayout:   script,
+				InputsHash:     inputsHash,
+				Signature:      []byte{0x77, 0x77},
+			},
+			empty: func() specialTxPayload { return new(ProUpRegTx) },
+			decode: func(tx *MsgTx) (specialTxPayload, error) {
+				return tx.ProUpRegTx()
+			},
+			encoded: serialize(
+				[]byte{0x01, 0x00}, // Version
+				proTxHash[:],
+				[]byte{0x00, 0x00}, // Mode
+				pubKeyOperator[:],
+				keyIDVoting[:],
+				[]byte{0x02, 0x51, 0x52}, // ScriptPayout
+				inputsHash[:],
+				[]byte{0x02, 0x77, 0x77}, // Signature
+			),
+		},
+		{
+			name:   "ProUpRevTx",
+			txType: TxTypeProUpdateRevoke,
+			payload: &ProUpRevTx{
+				Version:    1,
+				ProTxHash:  proTxHash,
+				Reason:     ProUpRevReasonCompromisedKeys,
+				InputsHash: inputsHash,
+				Signature:  blsSig,
+			},
+			empty: func() specialTxPayload { return new(ProUpRevTx) },
+			decode: func(tx *MsgTx) (specialTxPayload, error) {
+				return tx.ProUpRevTx()
+			},
+			encoded: serialize(
+				[]byte{0x01, 0x00}, // Version
+				proTxHash[:],
+				[]byte{0x02, 0x00}, // Reason
+				inputsHash[:],
+				blsSig[:],
+			),
+		},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := test.payload.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+			t.Errorf("%s: BtcEncode: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.encoded) {
+			t.Errorf("%s: BtcEncode\n got: %s want: %s", test.name,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.encoded))
+			continue
+		}
+
+		payload := test.empty()
+		rbuf := bytes.NewReader(test.encoded)
+		if err := payload.BtcDecode(rbuf, ProtocolVersion, BaseEncoding); err != nil {
+			t.Errorf("%s: BtcDecode: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(payload, test.payload) {
+			t.Errorf("%s: BtcDecode\n got: %s want: %s", test.name,
+				spew.Sdump(payload), spew.Sdump(test.payload))
+			continue
+		}
+
+		// Decoding a truncated payload must fail.
+		truncated := test.encoded[:len(test.encoded)-1]
+		if err := test.empty().BtcDecode(bytes.NewReader(truncated),
+			ProtocolVersion, BaseEncoding); err == nil {
+
+			t.Errorf("%s: BtcDecode: did not fail on truncated "+
+				"payload", test.name)
+		}
+
+		// The payload must only decode from a special transaction of
+		// the matching type.
+		tx := NewMsgTx(SpecialTxVersion)
+		tx.Type = test.txType
+		tx.ExtraPayload = test.encoded
+		payload, err := test.decode(tx)
+		if err != nil {
+			t.Errorf("%s: decode from MsgTx: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(payload, test.payload) {
+			t.Errorf("%s: decode from MsgTx\n got: %s want: %s",
+				test.name, spew.Sdump(payload),
+				spew.Sdump(test.payload))
+		}
+		tx.Type = TxTypeProRegister
+		if _, err := test.decode(tx); err == nil {
+			t.Errorf("%s: decode from MsgTx did not fail for "+
+				"another type", test.name)
+		}
+	}
+}
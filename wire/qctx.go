@@ -0,0 +1,238 @@
+// Copyright (c) 2018 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+const (
+	// MaxLLMQSize is the number of members of the largest LLMQ type, which
+	// are the 400 member quorums of chaincfg.LLMQType400_60 and
+	// chaincfg.LLMQType400_85.
+	MaxLLMQSize = 400
+
+	// maxBitSetSize is the maximum number of bits a variable length bitset
+	// may hold.  The bitsets of a commitment hold one bit per member, so
+	// they are bounded by the size of the largest quorum.
+	maxBitSetSize = MaxLLMQSize
+)
+
+// These constants define the versions of quorum commitments.  The indexed
+// versions are used by the rotating quorums of DIP0024 and carry the index of
+// the quorum in its cycle.  The basic BLS versions are used from the v19 hard
+// fork on, like ProTxVersionBasicBLS for provider transactions.
+const (
+	QcVersionLegacyBLS        uint16 = 1
+	QcVersionLegacyBLSIndexed uint16 = 2
+	QcVersionBasicBLS         uint16 = 3
+	QcVersionBasicBLSIndexed  uint16 = 4
+)
+
+// QuorumCommitment represents the final commitment of an LLMQ quorum as defined
+// by DIP0006.  Signers and ValidMembers hold one bit per quorum member.
+// QuorumIndex is only serialized for the indexed versions.
+type QuorumCommitment struct {
+	Version         uint16
+	LLMQType        uint8
+	QuorumHash      chainhash.Hash
+	QuorumIndex     int16
+	Signers         []bool
+	ValidMembers    []bool
+	QuorumPublicKey [BLSPublicKeySize]byte
+	QuorumVvecHash  chainhash.Hash
+	QuorumSig       [BLSSignatureSize]byte
+	MembersSig      [BLSSignatureSize]byte
+}
+
+// BtcDecode decodes r using the Dash serialization of the commitment into the
+// receiver.
+func (c *QuorumCommitment) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElements(r, &c.Version, &c.LLMQType, &c.QuorumHash)
+	if err != nil {
+		return err
+	}
+
+	if c.IsIndexed() {
+		err = readElement(r, &c.QuorumIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.Signers, err = readBitSet(r, pver)
+	if err != nil {
+		return err
+	}
+	c.ValidMembers, err = readBitSet(r, pver)
+	if err != nil {
+		return err
+	}
+
+	return readElements(r, &c.QuorumPublicKey, &c.QuorumVvecHash,
+		&c.QuorumSig, &c.MembersSig)
+}
+
+// BtcEncode encodes the receiver to w using the Dash serialization of the
+// commitment.
+func (c *QuorumCommitment) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElements(w, c.Version, c.LLMQType, &c.QuorumHash)
+	if err != nil {
+		return err
+	}
+
+	if c.IsIndexed() {
+		err = writeElement(w, c.QuorumIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeBitSet(w, pver, c.Signers)
+	if err != nil {
+		return err
+	}
+	err = writeBitSet(w, pver, c.ValidMembers)
+	if err != nil {
+		return err
+	}
+
+	return writeElements(w, c.QuorumPublicKey, &c.QuorumVvecHash,
+		c.QuorumSig, c.MembersSig)
+}
+
+// IsIndexed returns whether the commitment is of a version which carries the
+// QuorumIndex of a rotating quorum.
+func (c *QuorumCommitment) IsIndexed() bool {
+	return c.Version == QcVersionLegacyBLSIndexed ||
+		c.Version == QcVersionBasicBLSIndexed
+}
+
+// SerializeSize returns the number of bytes it would take to serialize the
+// commitment.
+func (c *QuorumCommitment) SerializeSize() int {
+	// Version 2 bytes + LLMQType 1 byte + QuorumHash + QuorumPublicKey +
+	// QuorumVvecHash + QuorumSig + MembersSig.
+	n := 3 + chainhash.HashSize*2 + BLSPublicKeySize +
+		BLSSignatureSize*2 + bitSetSerializeSize(len(c.Signers)) +
+		bitSetSerializeSize(len(c.ValidMembers))
+
+	// QuorumIndex 2 bytes.
+	if c.IsIndexed() {
+		n += 2
+	}
+	return n
+}
+
+// QcTx represents the extra payload of a quorum commitment special transaction
+// (TxTypeQuorumCommitment) which records the final commitment of an LLMQ
+// quorum on chain.
+type QcTx struct {
+	Version    uint16
+	Height     uint32
+	Commitment QuorumCommitment
+}
+
+// BtcDecode decodes r using the Dash serialization of the payload into the
+// receiver.
+func (p *QcTx) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	err := readElements(r, &p.Version, &p.Height)
+	if err != nil {
+		return err
+	}
+
+	return p.Commitment.BtcDecode(r, pver, enc)
+}
+
+// BtcEncode encodes the receiver to w using the Dash serialization of the
+// payload.
+func (p *QcTx) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	err := writeElements(w, p.Version, p.Height)
+	if err != nil {
+		return err
+	}
+
+	return p.Commitment.BtcEncode(w, pver, enc)
+}
+
+// QcTx decodes the extra payload of a quorum commitment special transaction.
+// An error is returned when the transaction is of another type or the payload
+// is malformed.
+func (msg *MsgTx) QcTx() (*QcTx, error) {
+	var payload QcTx
+	if err := msg.decodePayload(TxTypeQuorumCommitment, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// bitSetByteSize returns the number of bytes needed to hold count bits.
+func bitSetByteSize(count int) int {
+	return (count + 7) / 8
+}
+
+// bitSetSerializeSize returns the number of bytes it would take to serialize a
+// variable length bitset holding count bits.
+func bitSetSerializeSize(count int) int {
+	return VarIntSerializeSize(uint64(count)) + bitSetByteSize(count)
+}
+
+// readBitSet reads a variable length bitset as serialized by Dash Core.  It is
+// encoded as a varint holding the number of bits followed by the bits packed
+// into bytes, least significant bit first.
+func readBitSet(r io.Reader, pver uint32) ([]bool, error) {
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prevent a bitset larger than the largest quorum.  It would be
+	// possible to cause memory exhaustion and panics without a sane upper
+	// bound on this count.
+	if count > maxBitSetSize {
+		str := fmt.Sprintf("bitset is larger than the max allowed size "+
+			"[count %d, max %d]", count, maxBitSetSize)
+		return nil, messageError("readBitSet", str)
+	}
+
+	buf := make([]byte, bitSetByteSize(int(count)))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+
+	// The unused high bits of the last byte must not be set so that every
+	// bitset has a single valid encoding.
+	if rem := count % 8; rem != 0 && buf[len(buf)-1]>>rem != 0 {
+		str := fmt.Sprintf("bitset of %d bits has out of range bits set",
+			count)
+		return nil, messageError("readBitSet", str)
+	}
+
+	bits := make([]bool, count)
+	for i := range bits {
+		bits[i] = buf[i/8]&(1<<uint(i%8)) != 0
+	}
+	return bits, nil
+}
+
+// writeBitSet writes a variable length bitset as serialized by Dash Core.
+func writeBitSet(w io.Writer, pver uint32, bits []bool) error {
+	err := WriteVarInt(w, pver, uint64(len(bits)))
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, bitSetByteSize(len(bits)))
+	for i, bit := range bits {
+		if bit {
+			buf[i/8] |= 1 << uint(i%8)
+		}
+	}
+	_, err = w.Write(buf)
+	return err
+}
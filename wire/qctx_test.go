@@ -0,0 +1,180 @@
+// Copyright (c) 2018 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestBitSet tests the variable length bitset encoding and decoding.
+func TestBitSet(t *testing.T) {
+	tests := []struct {
+		bits []bool
+		buf  []byte
+	}{
+		{[]bool{}, []byte{0x00}},
+		{[]bool{true}, []byte{0x01, 0x01}},
+		{
+			[]bool{true, false, true, false, false, false, false, true},
+			[]byte{0x08, 0x85},
+		},
+		{
+			[]bool{false, false, false, false, false, false, false, false, true},
+			[]byte{0x09, 0x00, 0x01},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		var buf bytes.Buffer
+		if err := writeBitSet(&buf, ProtocolVersion, test.bits); err != nil {
+			t.Errorf("writeBitSet #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("writeBitSet #%d\n got: %x want: %x", i,
+				buf.Bytes(), test.buf)
+			continue
+		}
+		if size := bitSetSerializeSize(len(test.bits)); size != len(test.buf) {
+			t.Errorf("bitSetSerializeSize #%d got %d, want %d", i,
+				size, len(test.buf))
+		}
+
+		bits, err := readBitSet(bytes.NewReader(test.buf), ProtocolVersion)
+		if err != nil {
+			t.Errorf("readBitSet #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(bits, test.bits) {
+			t.Errorf("readBitSet #%d\n got: %v want: %v", i, bits,
+				test.bits)
+		}
+	}
+
+	// Bitsets larger than the largest quorum must be rejected.
+	_, err := readBitSet(bytes.NewReader([]byte{0xfd, 0x91, 0x01}),
+		ProtocolVersion)
+	if _, ok := err.(*MessageError); !ok {
+		t.Errorf("readBitSet: wrong error for oversized bitset - got "+
+			"%v, want %T", err, &MessageError{})
+	}
+
+	// Bits beyond the bitset size must be rejected.
+	_, err = readBitSet(bytes.NewReader([]byte{0x01, 0x03}), ProtocolVersion)
+	if _, ok := err.(*MessageError); !ok {
+		t.Errorf("readBitSet: wrong error for out of range bits - got "+
+			"%v, want %T", err, &MessageError{})
+	}
+}
+
+// TestQcTxWire tests the quorum commitment payload round trips through its
+// serialization and a quorum commitment special transaction.
+func TestQcTxWire(t *testing.T) {
+	payload := &QcTx{
+		Version: 1,
+		Height:  1028160,
+		Commitment: QuorumCommitment{
+			Version:        1,
+			LLMQType:       1,
+			QuorumHash:     chainhash.Hash{0x01},
+			Signers:        []bool{true, true, false, true, true},
+			ValidMembers:   []bool{true, true, true, true, true},
+			QuorumVvecHash: chainhash.Hash{0x02},
+		},
+	}
+	payload.Commitment.QuorumPublicKey[0] = 0x03
+	payload.Commitment.QuorumSig[0] = 0x04
+	payload.Commitment.MembersSig[0] = 0x05
+
+	var buf bytes.Buffer
+	if err := payload.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode: %v", err)
+	}
+
+	// Version 2 + Height 4 bytes and the commitment.
+	wantSize := 6 + payload.Commitment.SerializeSize()
+	if buf.Len() != wantSize {
+		t.Errorf("BtcEncode: wrong size - got %d, want %d", buf.Len(),
+			wantSize)
+	}
+
+	tx := NewMsgTx(SpecialTxVersion)
+	tx.Type = TxTypeQuorumCommitment
+	tx.ExtraPayload = buf.Bytes()
+	decoded, err := tx.QcTx()
+	if err != nil {
+		t.Fatalf("QcTx: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, payload) {
+		t.Fatalf("QcTx\n got: %s want: %s", spew.Sdump(decoded),
+			spew.Sdump(payload))
+	}
+}
+
+// TestQuorumCommitmentIndex ensures the quorum index is only serialized for the
+// indexed commitment versions used by rotating quorums.
+func TestQuorumCommitmentIndex(t *testing.T) {
+	tests := []struct {
+		version uint16
+		indexed bool
+	}{
+		{QcVersionLegacyBLS, false},
+		{QcVersionLegacyBLSIndexed, true},
+		{QcVersionBasicBLS, false},
+		{QcVersionBasicBLSIndexed, true},
+	}
+
+	for _, test := range tests {
+		c := &QuorumCommitment{
+			Version:      test.version,
+			LLMQType:     5,
+			QuorumHash:   chainhash.Hash{0x01},
+			Signers:      []bool{true, false, true},
+			ValidMembers: []bool{true, true, true},
+		}
+		if test.indexed {
+			c.QuorumIndex = 7
+		}
+
+		var buf bytes.Buffer
+		if err := c.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+			t.Errorf("version %d: BtcEncode: %v", test.version, err)
+			continue
+		}
+		if buf.Len() != c.SerializeSize() {
+			t.Errorf("version %d: SerializeSize got %d, want %d",
+				test.version, c.SerializeSize(), buf.Len())
+		}
+
+		// The quorum index follows the quorum hash.
+		b := buf.Bytes()
+		next := b[3+chainhash.HashSize:]
+		if test.indexed && !bytes.Equal(next[:3], []byte{0x07, 0x00, 0x03}) {
+			t.Errorf("version %d: quorum index not serialized - got %x",
+				test.version, next[:3])
+		} else if !test.indexed && next[0] != 0x03 {
+			t.Errorf("version %d: unexpected bytes after quorum hash %x",
+				test.version, next[:3])
+		}
+
+		var decoded QuorumCommitment
+		if err := decoded.BtcDecode(bytes.NewReader(b), ProtocolVersion,
+			BaseEncoding); err != nil {
+
+			t.Errorf("version %d: BtcDecode: %v", test.version, err)
+			continue
+		}
+		if !reflect.DeepEqual(&decoded, c) {
+			t.Errorf("version %d: BtcDecode\n got: %s want: %s",
+				test.version, spew.Sdump(&decoded), spew.Sdump(c))
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// SMLEntrySize is the serialized size of a SimplifiedMNListEntry before
+// protocol version DMNTypeVersion, which is also the smallest serialized size
+// of an entry.  ProRegTxHash 32 bytes + ConfirmedHash 32 bytes + IP address 16
+// bytes + Port 2 bytes + PubKeyOperator 48 bytes + KeyIDVoting 20 bytes +
+// IsValid 1 byte.
+const SMLEntrySize = chainhash.HashSize*2 + 18 + BLSPublicKeySize +
+	KeyIDSize + 1
+
+// SimplifiedMNListEntry represents a single masternode of the simplified
+// masternode list as defined by DIP0004.  It holds the subset of the
+// deterministic masternode state which is needed by light clients.
+//
+// Version is the BLS scheme version of the operator key, ProTxVersionLegacyBLS
+// or ProTxVersionBasicBLS.  It is only serialized as part of the entry as of
+// protocol version SMNLEVersionedVersion.  Before that, mnlistdiff messages
+// set it on their entries from the version of the message, or to
+// ProTxVersionLegacyBLS before protocol version BLSSchemeVersion, and it is
+// left untouched when decoding the entry alone.
+//
+// Type and, for masternodes of type MasternodeTypeEvo, PlatformHTTPPort and
+// PlatformNodeID are part of entries of version ProTxVersionBasicBLS as of
+// protocol version DMNTypeVersion.
+type SimplifiedMNListEntry struct {
+	Version          uint16
+	ProRegTxHash     chainhash.Hash
+	ConfirmedHash    chainhash.Hash
+	IPAddress        net.IP
+	Port             uint16
+	PubKeyOperator   [BLSPublicKeySize]byte
+	KeyIDVoting      [KeyIDSize]byte
+	IsValid          bool
+	Type             uint16
+	PlatformHTTPPort uint16
+	PlatformNodeID   [PlatformNodeIDSize]byte
+}
+
+// hasType returns whether the masternode type is serialized as part of the
+// entry at the passed protocol version.
+func (e *SimplifiedMNListEntry) hasType(pver uint32) bool {
+	return pver >= DMNTypeVersion && e.Version == ProTxVersionBasicBLS
+}
+
+// BtcDecode decodes r using the Dash serialization of the entry into the
+// receiver.
+func (e *SimplifiedMNListEntry) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	if pver >= SMNLEVersionedVersion {
+		if err := readElement(r, &e.Version); err != nil {
+			return err
+		}
+	}
+
+	err := readElements(r, &e.ProRegTxHash, &e.ConfirmedHash)
+	if err != nil {
+		return err
+	}
+
+	e.IPAddress, e.Port, err = readServiceAddress(r)
+	if err != nil {
+		return err
+	}
+
+	err = readElements(r, &e.PubKeyOperator, &e.KeyIDVoting, &e.IsValid)
+	if err != nil {
+		return err
+	}
+
+	e.Type = MasternodeTypeRegular
+	e.PlatformHTTPPort = 0
+	e.PlatformNodeID = [PlatformNodeIDSize]byte{}
+	if !e.hasType(pver) {
+		return nil
+	}
+	if err := readElement(r, &e.Type); err != nil {
+		return err
+	}
+	if e.Type == MasternodeTypeEvo {
+		return readElements(r, &e.PlatformHTTPPort, &e.PlatformNodeID)
+	}
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the Dash serialization of the
+// entry.
+func (e *SimplifiedMNListEntry) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if pver >= SMNLEVersionedVersion {
+		if err := writeElement(w, e.Version); err != nil {
+			return err
+		}
+	}
+	return e.encodeFields(w, e.hasType(pver))
+}
+
+// encodeFields encodes the fields of the entry following its version to w.
+// The masternode type and platform fields are only encoded when withType is
+// set.
+func (e *SimplifiedMNListEntry) encodeFields(w io.Writer, withType bool) error {
+	err := writeElements(w, &e.ProRegTxHash, &e.ConfirmedHash)
+	if err != nil {
+		return err
+	}
+
+	err = writeServiceAddress(w, e.IPAddress, e.Port)
+	if err != nil {
+		return err
+	}
+
+	err = writeElements(w, e.PubKeyOperator, e.KeyIDVoting, e.IsValid)
+	if err != nil {
+		return err
+	}
+
+	if !withType {
+		return nil
+	}
+	if err := writeElement(w, e.Type); err != nil {
+		return err
+	}
+	if e.Type == MasternodeTypeEvo {
+		return writeElements(w, e.PlatformHTTPPort, e.PlatformNodeID)
+	}
+	return nil
+}
+
+// SerializeSize returns the number of bytes it would take to serialize the
+// entry at the passed protocol version.
+func (e *SimplifiedMNListEntry) SerializeSize(pver uint32) int {
+	n := SMLEntrySize
+	if pver >= SMNLEVersionedVersion {
+		// Version 2 bytes.
+		n += 2
+	}
+	if e.hasType(pver) {
+		// Type 2 bytes.
+		n += 2
+		if e.Type == MasternodeTypeEvo {
+			// PlatformHTTPPort 2 bytes + PlatformNodeID 20 bytes.
+			n += 2 + PlatformNodeIDSize
+		}
+	}
+	return n
+}
+
+// Hash returns the double sha256 of the serialized entry, which is the leaf of
+// the entry in the simplified masternode list merkle tree.  Like Dash Core it
+// hashes the entry without its version but, for entries of version
+// ProTxVersionBasicBLS, with the masternode type and platform fields.
+func (e *SimplifiedMNListEntry) Hash() chainhash.Hash {
+	withType := e.Version == ProTxVersionBasicBLS
+
+	var buf bytes.Buffer
+	buf.Grow(SMLEntrySize + 4 + PlatformNodeIDSize)
+
+	// Writing to a bytes.Buffer never fails, so the error is ignored.
+	_ = e.encodeFields(&buf, withType)
+	return chainhash.DoubleHashH(buf.Bytes())
+}
+
+// CalcSMLMerkleRoot calculates the merkle root of the simplified masternode list
+// made of the passed entries as committed to by the MerkleRootMNList field of
+// the coinbase special transaction payload (CbTx) as defined by DIP0004.  The
+// leaves of the tree are the hashes of the entries ordered by ProRegTxHash.
+// The passed slice is not modified.
+func CalcSMLMerkleRoot(entries []SimplifiedMNListEntry) chainhash.Hash {
+	sorted := make([]*SimplifiedMNListEntry, len(entries))
+	for i := range entries {
+		sorted[i] = &entries[i]
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].ProRegTxHash[:],
+			sorted[j].ProRegTxHash[:]) < 0
+	})
+
+	hashes := make([]chainhash.Hash, 0, len(sorted))
+	for _, entry := range sorted {
+		hashes = append(hashes, entry.Hash())
+	}
+	return merkleRoot(hashes)
+}
+
+// VerifySMLEntryProof ensures the passed entries make up the full simplified
+// masternode list committed to by the MerkleRootMNList field of a coinbase
+// special transaction payload (CbTx).  Light clients use it to verify the
+// masternode list obtained by applying a mnlistdiff message against the CbTx
+// the message proves to be part of its block.
+func VerifySMLEntryProof(entries []SimplifiedMNListEntry, cbTxMerkleRoot chainhash.Hash) error {
+	root := CalcSMLMerkleRoot(entries)
+	if root != cbTxMerkleRoot {
+		str := fmt.Sprintf("masternode list merkle root mismatch - "+
+			"calculated %v, want %v", root, cbTxMerkleRoot)
+		return messageError("VerifySMLEntryProof", str)
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2019 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// TestSMLEntryWire tests the SimplifiedMNListEntry encode and decode for the
+// encodings of the entry before and after the masternode type and version were
+// added.
+func TestSMLEntryWire(t *testing.T) {
+	legacy := newTestSMLEntry()
+	legacy.Version = ProTxVersionLegacyBLS
+
+	regular := newTestSMLEntry()
+	regular.Version = ProTxVersionBasicBLS
+	regular.Type = MasternodeTypeRegular
+
+	evo := newTestSMLEntry()
+	evo.Version = ProTxVersionBasicBLS
+	evo.Type = MasternodeTypeEvo
+	evo.PlatformHTTPPort = 443
+	copy(evo.PlatformNodeID[:], bytes.Repeat([]byte{0x44}, PlatformNodeIDSize))
+
+	regularType := []byte{0x00, 0x00} // Type
+	evoType := append([]byte{
+		0x01, 0x00, // Type
+		0xbb, 0x01, // PlatformHTTPPort
+	}, bytes.Repeat([]byte{0x44}, PlatformNodeIDSize)...)
+
+	tests := []struct {
+		name string
+		in   *SimplifiedMNListEntry
+		pver uint32
+		buf  []byte
+	}{
+		{
+			"legacy before type",
+			legacy,
+			BLSSchemeVersion,
+			smlEntryEncoded,
+		},
+		{
+			"basic before type",
+			regular,
+			DMNTypeVersion - 1,
+			smlEntryEncoded,
+		},
+		{
+			"legacy with type",
+			legacy,
+			DMNTypeVersion,
+			smlEntryEncoded,
+		},
+		{
+			"regular with type",
+			regular,
+			DMNTypeVersion,
+			append(append([]byte{}, smlEntryEncoded...), regularType...),
+		},
+		{
+			"evo with type",
+			evo,
+			DMNTypeVersion,
+			append(append([]byte{}, smlEntryEncoded...), evoType...),
+		},
+		{
+			"versioned legacy",
+			legacy,
+			SMNLEVersionedVersion,
+			append([]byte{0x01, 0x00}, smlEntryEncoded...),
+		},
+		{
+			"versioned evo",
+			evo,
+			ProtocolVersion,
+			append(append([]byte{0x02, 0x00}, smlEntryEncoded...),
+				evoType...),
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for _, test := range tests {
+		var buf bytes.Buffer
+		err := test.in.BtcEncode(&buf, test.pver, BaseEncoding)
+		if err != nil {
+			t.Errorf("%s: BtcEncode: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("%s: BtcEncode\n got: %s want: %s", test.name,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+		if size := test.in.SerializeSize(test.pver); size != len(test.buf) {
+			t.Errorf("%s: SerializeSize: got %d, want %d", test.name,
+				size, len(test.buf))
+		}
+
+		// The version is not serialized before SMNLEVersionedVersion,
+		// so it is taken from the receiver in that case.
+		var decoded SimplifiedMNListEntry
+		decoded.Version = test.in.Version
+		rbuf := bytes.NewReader(test.buf)
+		err = decoded.BtcDecode(rbuf, test.pver, BaseEncoding)
+		if err != nil {
+			t.Errorf("%s: BtcDecode: %v", test.name, err)
+			continue
+		}
+		want := *test.in
+		if !want.hasType(test.pver) {
+			want.Type = MasternodeTypeRegular
+			want.PlatformHTTPPort = 0
+			want.PlatformNodeID = [PlatformNodeIDSize]byte{}
+		}
+		if !reflect.DeepEqual(&decoded, &want) {
+			t.Errorf("%s: BtcDecode\n got: %s want: %s", test.name,
+				spew.Sdump(&decoded), spew.Sdump(&want))
+			continue
+		}
+
+		// Every truncation of the entry must fail to decode.
+		for i := 0; i < len(test.buf); i++ {
+			rbuf := bytes.NewReader(test.buf[:i])
+			decoded.Version = test.in.Version
+			err := decoded.BtcDecode(rbuf, test.pver, BaseEncoding)
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				t.Errorf("%s: BtcDecode truncated to %d bytes: "+
+					"wrong error - got %v, want EOF",
+					test.name, i, err)
+			}
+		}
+	}
+
+	if len(smlEntryEncoded) != SMLEntrySize {
+		t.Errorf("SMLEntrySize: got %d, want %d", SMLEntrySize,
+			len(smlEntryEncoded))
+	}
+}
+
+// TestSMLEntryHash ensures the hash of an entry leaves out its version and
+// covers the masternode type and platform fields of basic BLS entries at any
+// protocol version.
+func TestSMLEntryHash(t *testing.T) {
+	legacy := newTestSMLEntry()
+	legacy.Version = ProTxVersionLegacyBLS
+	if got, want := legacy.Hash(), chainhash.DoubleHashH(smlEntryEncoded); got != want {
+		t.Errorf("legacy Hash: got %v, want %v", got, want)
+	}
+
+	evo := newTestSMLEntry()
+	evo.Version = ProTxVersionBasicBLS
+	evo.Type = MasternodeTypeEvo
+	evo.PlatformHTTPPort = 443
+	var buf bytes.Buffer
+	if err := evo.BtcEncode(&buf, DMNTypeVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode: %v", err)
+	}
+	if got, want := evo.Hash(), chainhash.DoubleHashH(buf.Bytes()); got != want {
+		t.Errorf("evo Hash: got %v, want %v", got, want)
+	}
+}
+
+// newTestSMLEntry returns the masternode list entry which serializes to
+// smlEntryEncoded.
+func newTestSMLEntry() *SimplifiedMNListEntry {
+	entry := &SimplifiedMNListEntry{
+		ProRegTxHash:  chainhash.Hash{0x01, 0x02, 0x03},
+		ConfirmedHash: chainhash.Hash{0x04, 0x05, 0x06},
+		IPAddress:     net.ParseIP("1.2.3.4"),
+		Port:          9999,
+		IsValid:       true,
+	}
+	copy(entry.PubKeyOperator[:], bytes.Repeat([]byte{0x22}, BLSPublicKeySize))
+	copy(entry.KeyIDVoting[:], bytes.Repeat([]byte{0x33}, KeyIDSize))
+	return entry
+}
+
+// smlEntryEncoded is the serialization of newTestSMLEntry.
+var smlEntryEncoded = func() []byte {
+	b := []byte{0x01, 0x02, 0x03}
+	b = append(b, make([]byte, chainhash.HashSize-3)...) // ProRegTxHash
+	b = append(b, 0x04, 0x05, 0x06)
+	b = append(b, make([]byte, chainhash.HashSize-3)...) // ConfirmedHash
+	b = append(b, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0xff, 0xff, 0x01, 0x02, 0x03, 0x04) // IP address
+	b = append(b, 0x27, 0x0f) // Port (big endian)
+	b = append(b, bytes.Repeat([]byte{0x22}, BLSPublicKeySize)...)
+	b = append(b, bytes.Repeat([]byte{0x33}, KeyIDSize)...)
+	return append(b, 0x01) // IsValid
+}()
+
+// TestSMLMerkleRoot ensures the simplified masternode list merkle root is
+// calculated over the entries ordered by ProRegTxHash and verified against the
+// root committed to by a CbTx.
+func TestSMLMerkleRoot(t *testing.T) {
+	newEntry := func(id byte, ip string, port uint16, isValid bool) SimplifiedMNListEntry {
+		entry := SimplifiedMNListEntry{
+			ProRegTxHash:  chainhash.Hash{id},
+			ConfirmedHash: chainhash.Hash{0x10 | id},
+			IPAddress:     net.ParseIP(ip),
+			Port:          port,
+			IsValid:       isValid,
+		}
+		copy(entry.PubKeyOperator[:], bytes.Repeat([]byte{0x20 | id}, BLSPublicKeySize))
+		copy(entry.KeyIDVoting[:], bytes.Repeat([]byte{0x30 | id}, KeyIDSize))
+		return entry
+	}
+
+	// The entries are deliberately not ordered by ProRegTxHash.
+	entries := []SimplifiedMNListEntry{
+		newEntry(0x03, "10.0.0.3", 9999, true),
+		newEntry(0x01, "10.0.0.1", 9999, true),
+		newEntry(0x02, "10.0.0.2", 19999, false),
+	}
+
+	tests := []struct {
+		name    string
+		entries []SimplifiedMNListEntry
+		want    string
+	}{
+		{
+			name: "empty list",
+			want: "0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:    "single entry",
+			entries: entries[1:2],
+			want:    "f0dd3e3dc4c297ed9ebb590fb80724f8b0dc49ddc278d59b6bbb6ea4e44a9732",
+		},
+		{
+			name:    "unordered entries",
+			entries: entries,
+			want:    "6db6e33559ccb6633b19409b0655ae79304c10abb75cf0b231b959148dd48dc3",
+		},
+	}
+
+	for _, test := range tests {
+		want, err := chainhash.NewHashFromStr(test.want)
+		if err != nil {
+			t.Fatalf("NewHashFromStr: %v", err)
+		}
+		got := CalcSMLMerkleRoot(test.entries)
+		if got != *want {
+			t.Errorf("%s: got root %v, want %v", test.name, got, want)
+			continue
+		}
+		if err := VerifySMLEntryProof(test.entries, *want); err != nil {
+			t.Errorf("%s: VerifySMLEntryProof: %v", test.name, err)
+		}
+	}
+
+	// The entries must be left untouched.
+	if entries[0].ProRegTxHash[0] != 0x03 {
+		t.Error("CalcSMLMerkleRoot reordered the passed entries")
+	}
+
+	// The root is the merkle root of the entry hashes, with the last hash
+	// of an odd level paired with itself like the block merkle root.
+	pair := func(a, b chainhash.Hash) chainhash.Hash {
+		return chainhash.DoubleHashH(append(a[:], b[:]...))
+	}
+	h1, h2, h3 := entries[1].Hash(), entries[2].Hash(), entries[0].Hash()
+	if got, want := CalcSMLMerkleRoot(entries), pair(pair(h1, h2), pair(h3, h3)); got != want {
+		t.Errorf("CalcSMLMerkleRoot: got %v, want %v", got, want)
+	}
+
+	// Like dashd, the entries are ordered by the bytes of ProRegTxHash as
+	// stored rather than by its byte reversed string form.
+	first, second := newEntry(0x01, "10.0.0.1", 9999, true),
+		newEntry(0x02, "10.0.0.2", 9999, true)
+	first.ProRegTxHash[chainhash.HashSize-1] = 0x02
+	second.ProRegTxHash[chainhash.HashSize-1] = 0x01
+	got := CalcSMLMerkleRoot([]SimplifiedMNListEntry{second, first})
+	if want := pair(first.Hash(), second.Hash()); got != want {
+		t.Errorf("CalcSMLMerkleRoot: got %v, want %v for entries "+
+			"ordered by stored ProRegTxHash", got, want)
+	}
+
+	// A change to any entry or a missing entry must be detected.
+	changed := append([]SimplifiedMNListEntry(nil), entries...)
+	changed[2].IsValid = true
+	root := CalcSMLMerkleRoot(entries)
+	if err := VerifySMLEntryProof(changed, root); err == nil {
+		t.Error("VerifySMLEntryProof: expected error for changed entry")
+	}
+	if err := VerifySMLEntryProof(entries[:2], root); err == nil {
+		t.Error("VerifySMLEntryProof: expected error for missing entry")
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2018 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// These constants define the special transaction types introduced by DIP0002.
+// The type of a transaction is stored in the upper 16 bits of its serialized
+// version and every transaction with a type other than TxTypeNormal carries an
+// extra payload specific to that type.
+const (
+	// TxTypeNormal is the type of classic transactions without an extra
+	// payload.
+	TxTypeNormal uint16 = 0
+
+	// TxTypeProRegister is the type of provider registration transactions
+	// which register a masternode (DIP0003).
+	TxTypeProRegister uint16 = 1
+
+	// TxTypeProUpdateService is the type of provider update service
+	// transactions (DIP0003).
+	TxTypeProUpdateService uint16 = 2
+
+	// TxTypeProUpdateRegistrar is the type of provider update registrar
+	// transactions (DIP0003).
+	TxTypeProUpdateRegistrar uint16 = 3
+
+	// TxTypeProUpdateRevoke is the type of provider update revocation
+	// transactions (DIP0003).
+	TxTypeProUpdateRevoke uint16 = 4
+
+	// TxTypeCoinbase is the type of coinbase transactions carrying a
+	// coinbase payload (DIP0004).
+	TxTypeCoinbase uint16 = 5
+
+	// TxTypeQuorumCommitment is the type of LLMQ quorum commitment
+	// transactions (DIP0006).
+	TxTypeQuorumCommitment uint16 = 6
+)
+
+// SpecialTxVersion is the lowest transaction version which serializes a
+// DIP0002 special transaction type in the upper 16 bits of the version.
+const SpecialTxVersion = 3
+
+// specialTxPayload describes the extra payload of a special transaction.
+type specialTxPayload interface {
+	BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error
+	BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error
+}
+
+// setVersion sets the version and special transaction type of the transaction
+// from its serialized 32-bit version.  The upper 16 bits only hold the type
+// when the lower 16 bits hold a version of SpecialTxVersion or higher.  The
+// full 32 bits are the version otherwise.
+func (msg *MsgTx) setVersion(version uint32) {
+	if version&0xffff >= SpecialTxVersion {
+		msg.Version = int32(version & 0xffff)
+		msg.Type = uint16(version >> 16)
+		return
+	}
+	msg.Version = int32(version)
+	msg.Type = TxTypeNormal
+}
+
+// encodedVersion returns the serialized 32-bit version of the transaction
+// which holds the special transaction type in its upper 16 bits.
+func (msg *MsgTx) encodedVersion() uint32 {
+	if msg.Version >= SpecialTxVersion && msg.Version <= 0xffff {
+		return uint32(msg.Version) | uint32(msg.Type)<<16
+	}
+	return uint32(msg.Version)
+}
+
+// IsSpecial returns whether the transaction is a DIP0002 special transaction
+// which carries an extra payload.
+func (msg *MsgTx) IsSpecial() bool {
+	return msg.Version >= SpecialTxVersion && msg.Type != TxTypeNormal
+}
+
+// decodePayload ensures the transaction is a special transaction of the passed
+// type and decodes its extra payload into the passed payload.
+func (msg *MsgTx) decodePayload(txType uint16, payload specialTxPayload) error {
+	if !msg.IsSpecial() || msg.Type != txType {
+		str := fmt.Sprintf("transaction type %d is not %d", msg.Type,
+			txType)
+		return messageError("MsgTx.decodePayload", str)
+	}
+
+	r := bytes.NewReader(msg.ExtraPayload)
+	if err := payload.BtcDecode(r, 0, BaseEncoding); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		str := fmt.Sprintf("%d trailing bytes after extra payload",
+			r.Len())
+		return messageError("MsgTx.decodePayload", str)
+	}
+	return nil
+}
+
+// CalcInputsHash returns the inputs hash of the transaction as committed to by
+// the InputsHash field of provider special transaction payloads.  It is the
+// double sha256 of the concatenated serialized outpoints spent by the inputs
+// and binds the payload to the transaction, which prevents the payload and its
+// signature from being replayed in another transaction.
+func CalcInputsHash(tx *MsgTx) chainhash.Hash {
+	var buf bytes.Buffer
+	buf.Grow(len(tx.TxIn) * outPointSize)
+
+	// Writing to a bytes.Buffer never fails, so the errors are ignored.
+	for _, txIn := range tx.TxIn {
+		_ = writeOutPoint(&buf, 0, 0, &txIn.PreviousOutPoint)
+	}
+	return chainhash.DoubleHashH(buf.Bytes())
+}
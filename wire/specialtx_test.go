@@ -0,0 +1,332 @@
+// Copyright (c) 2018 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/nargott/godash/chaincfg/chainhash"
+)
+
+// proRegTxHex is a provider registration transaction as prepared by the
+// protx register_prepare command of dashd, before its input is signed.  The
+// payload registers a regular masternode and its inputs hash commits to the
+// outpoint spent by the transaction.
+var proRegTxHex = "0300010001a6970dbf500321a694fb5afb6c2f5269d4dfafe0b0bf70ed4639" +
+	"853de49fe87c0100000000feffffff01f109a503030000001976a9142621b120" +
+	"541dab04072b293f275d7213c4ffb9df88ac00000000d10100000000007ac4fb" +
+	"e1ac562007aa297a40f7e686b29acf9d58c8c06cdd37f26d3d30ad1855010000" +
+	"0000000000000000000000ffff62ca58af4e200c1f2a18885154b6abd1b07364" +
+	"28e47ccddfa743084ceaabfe23865823aa696258245d8f94144fc33fb558528c" +
+	"d1742ef8f033d7b8c701d19cd6a561522c9e8d82bf72831f130b13c2258dd4b2" +
+	"ae2eb77b62a036be7be2a600001976a9142621b120541dab04072b293f275d72" +
+	"13c4ffb9df88ac90f10f99e0a72b561d3f83f0c1294cc0b88931444988eb7e78" +
+	"93fcb92f043f1c00"
+
+// proRegTxHash is the hash of the transaction serialized by proRegTxHex.
+const proRegTxHash = "bb445b8fe89d03655847ebffad75e8958ba6ea94300221022b0dcac073312ff1"
+
+// newPreparedProRegTx returns the provider registration payload of the
+// transaction serialized by proRegTxHex.
+func newPreparedProRegTx() *ProRegTx {
+	decode := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			panic(err)
+		}
+		return b
+	}
+
+	payload := &ProRegTx{
+		Version: ProTxVersionLegacyBLS,
+		Type:    MasternodeTypeRegular,
+		CollateralOutpoint: OutPoint{
+			Hash: chainhash.Hash{
+				0x7a, 0xc4, 0xfb, 0xe1, 0xac, 0x56, 0x20, 0x07,
+				0xaa, 0x29, 0x7a, 0x40, 0xf7, 0xe6, 0x86, 0xb2,
+				0x9a, 0xcf, 0x9d, 0x58, 0xc8, 0xc0, 0x6c, 0xdd,
+				0x37, 0xf2, 0x6d, 0x3d, 0x30, 0xad, 0x18, 0x55,
+			},
+			Index: 1,
+		},
+		IPAddress:    net.ParseIP("98.202.88.175"),
+		Port:         20000,
+		ScriptPayout: decode("76a9142621b120541dab04072b293f275d7213c4ffb9df88ac"),
+		Signature:    []byte{},
+	}
+	copy(payload.KeyIDOwner[:], decode("0c1f2a18885154b6abd1b0736428e47ccddfa743"))
+	copy(payload.PubKeyOperator[:], decode("084ceaabfe23865823aa696258245d8f"+
+		"94144fc33fb558528cd1742ef8f033d7b8c701d19cd6a561522c9e8d82bf7283"))
+	copy(payload.KeyIDVoting[:], decode("1f130b13c2258dd4b2ae2eb77b62a036be7be2a6"))
+	copy(payload.InputsHash[:], decode("90f10f99e0a72b561d3f83f0c1294cc0"+
+		"b88931444988eb7e7893fcb92f043f1c"))
+	return payload
+}
+
+// TestSpecialTxWire ensures a special transaction round trips through both the
+// wire and the storage encoding with its type and extra payload intact.
+func TestSpecialTxWire(t *testing.T) {
+	raw, err := hex.DecodeString(proRegTxHex)
+	if err != nil {
+		t.Fatalf("DecodeString: %v", err)
+	}
+
+	decoders := []struct {
+		name   string
+		decode func(*MsgTx, []byte) error
+		encode func(*MsgTx, *bytes.Buffer) error
+	}{
+		{
+			"wire",
+			func(tx *MsgTx, b []byte) error {
+				return tx.BtcDecode(bytes.NewReader(b),
+					ProtocolVersion, BaseEncoding)
+			},
+			func(tx *MsgTx, w *bytes.Buffer) error {
+				return tx.BtcEncode(w, ProtocolVersion, BaseEncoding)
+			},
+		},
+		{
+			"storage",
+			func(tx *MsgTx, b []byte) error {
+				return tx.Deserialize(bytes.NewReader(b))
+			},
+			func(tx *MsgTx, w *bytes.Buffer) error {
+				return tx.Serialize(w)
+			},
+		},
+		{
+			"deprecated",
+			func(tx *MsgTx, b []byte) error {
+				r := bytes.NewReader(b)
+				version, err := binarySerializer.Uint32(r, littleEndian)
+				if err != nil {
+					return err
+				}
+				tx.Version = int32(version)
+				return tx.DecodeProReg(r, 0)
+			},
+			func(tx *MsgTx, w *bytes.Buffer) error {
+				return tx.Serialize(w)
+			},
+		},
+	}
+
+	for _, test := range decoders {
+		var tx MsgTx
+		if err := test.decode(&tx, raw); err != nil {
+			t.Errorf("%s decode: %v", test.name, err)
+			continue
+		}
+		if tx.Version != 3 || tx.Type != TxTypeProRegister {
+			t.Errorf("%s decode: wrong version and type - got %d/%d, "+
+				"want 3/%d", test.name, tx.Version, tx.Type,
+				TxTypeProRegister)
+		}
+		if hash := tx.TxHash().String(); hash != proRegTxHash {
+			t.Errorf("%s TxHash: got %s, want %s", test.name, hash,
+				proRegTxHash)
+		}
+		payload, err := tx.ProRegTx()
+		if err != nil {
+			t.Errorf("%s ProRegTx: %v", test.name, err)
+		} else if want := newPreparedProRegTx(); !reflect.DeepEqual(payload, want) {
+			t.Errorf("%s ProRegTx\n got: %s want: %s", test.name,
+				spew.Sdump(payload), spew.Sdump(want))
+		}
+
+		var buf bytes.Buffer
+		if err := test.encode(&tx, &buf); err != nil {
+			t.Errorf("%s encode: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), raw) {
+			t.Errorf("%s encode: round trip mismatch\n got: %x\n "+
+				"want: %x", test.name, buf.Bytes(), raw)
+		}
+		if size := tx.SerializeSize(); size != len(raw) {
+			t.Errorf("%s SerializeSize: got %d, want %d", test.name,
+				size, len(raw))
+		}
+	}
+}
+
+// TestDeprecatedSpecialTxDecoders ensures the deprecated type specific
+// decoders reject a special transaction of another type.
+func TestDeprecatedSpecialTxDecoders(t *testing.T) {
+	raw, err := hex.DecodeString(proRegTxHex)
+	if err != nil {
+		t.Fatalf("DecodeString: %v", err)
+	}
+
+	decoders := []struct {
+		name   string
+		decode func(*MsgTx, *bytes.Reader) error
+	}{
+		{"DecodeCoinbase", func(tx *MsgTx, r *bytes.Reader) error {
+			return tx.DecodeCoinbase(r, 0)
+		}},
+		{"DecodeProUpServ", func(tx *MsgTx, r *bytes.Reader) error {
+			return tx.DecodeProUpServ(r, 0)
+		}},
+		{"DecodeProUpReg", func(tx *MsgTx, r *bytes.Reader) error {
+			return tx.DecodeProUpReg(r, 0)
+		}},
+		{"DecodeProUpRev", func(tx *MsgTx, r *bytes.Reader) error {
+			return tx.DecodeProUpRev(r, 0)
+		}},
+		{"DecodeQuorumCommitment", func(tx *MsgTx, r *bytes.Reader) error {
+			return tx.DecodeQuorumCommitment(r, 0)
+		}},
+	}
+
+	for _, test := range decoders {
+		r := bytes.NewReader(raw)
+		version, err := binarySerializer.Uint32(r, littleEndian)
+		if err != nil {
+			t.Fatalf("Uint32: %v", err)
+		}
+		tx := MsgTx{Version: int32(version)}
+		if err := test.decode(&tx, r); err == nil {
+			t.Errorf("%s: decoded a provider registration "+
+				"transaction", test.name)
+		}
+	}
+}
+
+// TestSpecialTxVersionGating ensures the special transaction type and extra
+// payload are only decoded for transaction versions which define them.
+func TestSpecialTxVersionGating(t *testing.T) {
+	// Transactions without inputs and outputs followed by the lock time.
+	// The quorum commitment is decoded using the witness encoding to
+	// ensure a special transaction without inputs is not mistaken for a
+	// witness transaction.
+	tests := []struct {
+		name        string
+		buf         []byte
+		enc         MessageEncoding
+		wantVersion int32
+		wantType    uint16
+		wantPayload []byte
+	}{
+		{
+			"version 2 keeps the upper bits",
+			[]byte{
+				0x02, 0x00, 0x01, 0x00, // Version
+				0x00, 0x00, // TxIn and TxOut counts
+				0x00, 0x00, 0x00, 0x00, // LockTime
+			},
+			BaseEncoding, 0x00010002, TxTypeNormal, nil,
+		},
+		{
+			"version 3 normal transaction",
+			[]byte{
+				0x03, 0x00, 0x00, 0x00, // Version
+				0x00, 0x00, // TxIn and TxOut counts
+				0x00, 0x00, 0x00, 0x00, // LockTime
+			},
+			BaseEncoding, 3, TxTypeNormal, nil,
+		},
+		{
+			"version 3 quorum commitment without inputs",
+			[]byte{
+				0x03, 0x00, 0x06, 0x00, // Version and type
+				0x00, 0x00, // TxIn and TxOut counts
+				0x00, 0x00, 0x00, 0x00, // LockTime
+				0x02, 0xaa, 0xbb, // ExtraPayload
+			},
+			WitnessEncoding, 3, TxTypeQuorumCommitment,
+			[]byte{0xaa, 0xbb},
+		},
+	}
+
+	for _, test := range tests {
+		var tx MsgTx
+		err := tx.BtcDecode(bytes.NewReader(test.buf), ProtocolVersion,
+			test.enc)
+		if err != nil {
+			t.Errorf("%s: BtcDecode: %v", test.name, err)
+			continue
+		}
+		if tx.Version != test.wantVersion || tx.Type != test.wantType {
+			t.Errorf("%s: wrong version and type - got %#x/%d, want "+
+				"%#x/%d", test.name, tx.Version, tx.Type,
+				test.wantVersion, test.wantType)
+		}
+		if !bytes.Equal(tx.ExtraPayload, test.wantPayload) {
+			t.Errorf("%s: wrong extra payload - got %x, want %x",
+				test.name, tx.ExtraPayload, test.wantPayload)
+		}
+
+		var buf bytes.Buffer
+		if err := tx.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+			t.Errorf("%s: BtcEncode: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("%s: round trip mismatch - got %x, want %x",
+				test.name, buf.Bytes(), test.buf)
+		}
+	}
+}
+
+// TestCalcInputsHash ensures the inputs hash of a transaction is the double
+// sha256 of the outpoints spent by its inputs, and matches the inputs hash
+// dashd committed to in the payload of a provider registration.
+func TestCalcInputsHash(t *testing.T) {
+	serialized, err := hex.DecodeString(proRegTxHex)
+	if err != nil {
+		t.Fatalf("DecodeString: unexpected error: %v", err)
+	}
+	var proRegTx MsgTx
+	if err := proRegTx.Deserialize(bytes.NewReader(serialized)); err != nil {
+		t.Fatalf("Deserialize: unexpected error: %v", err)
+	}
+	payload, err := proRegTx.ProRegTx()
+	if err != nil {
+		t.Fatalf("ProRegTx: unexpected error: %v", err)
+	}
+	if got := CalcInputsHash(&proRegTx); got != payload.InputsHash {
+		t.Errorf("provider registration: got %v, want payload inputs "+
+			"hash %v", got, payload.InputsHash)
+	}
+
+	twoInputs := NewMsgTx(SpecialTxVersion)
+	for _, op := range []struct {
+		hash  string
+		index uint32
+	}{
+		{"f3b81d4d6f5e0b37d4a1b19f0f8e1a8a3a0f3c4b1f9d7e2c5b8a6d4e3f2a1b0c", 1},
+		{"29e1c5a78f5d6a2b3c4d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b", 0},
+	} {
+		hash, err := chainhash.NewHashFromStr(op.hash)
+		if err != nil {
+			t.Fatalf("NewHashFromStr: unexpected error: %v", err)
+		}
+		twoInputs.AddTxIn(NewTxIn(NewOutPoint(hash, op.index), nil, nil))
+	}
+
+	tests := []struct {
+		name string
+		tx   *MsgTx
+		want string
+	}{
+		{"provider registration", &proRegTx, "1c3f042fb9fc93787eeb8849443189b8c04c29c1f0833f1d562ba7e0990ff190"},
+		{"two inputs", twoInputs, "db55ead2c9e8ac70845ff48b2ac3a3500bde9b6a4e418b1e463f8dd8f7262dd8"},
+		{"no inputs", NewMsgTx(SpecialTxVersion), "56944c5d3f98413ef45cf54545538103cc9f298e0575820ad3591376e2e0f65d"},
+	}
+
+	for _, test := range tests {
+		got := CalcInputsHash(test.tx)
+		if got.String() != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
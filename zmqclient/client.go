@@ -0,0 +1,458 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zmqclient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godash/wire"
+)
+
+// The topics of the notifications published by dashd.
+const (
+	TopicHashBlock       = "hashblock"
+	TopicHashTx          = "hashtx"
+	TopicRawBlock        = "rawblock"
+	TopicRawChainLock    = "rawchainlock"
+	TopicRawChainLockSig = "rawchainlocksig"
+	TopicRawTxLock       = "rawtxlock"
+	TopicRawTxLockSig    = "rawtxlocksig"
+)
+
+// handshakeTimeout is the time allowed for the publisher to complete the ZMTP
+// handshake.
+const handshakeTimeout = 10 * time.Second
+
+// ErrClientShutdown is an error to describe the condition where the client is
+// either already shutdown, or in the process of shutting down.
+var ErrClientShutdown = errors.New("the client has been shutdown")
+
+// Handlers defines callback function pointers to invoke with notifications.
+// Only the topics which have a handler are subscribed to.  The handlers are
+// invoked from a single goroutine in the order the notifications are received,
+// so a handler which blocks delays the notifications that follow it.
+//
+// NOTE: Unless otherwise documented, the handlers must not retain the values
+// they are passed beyond their invocation unless they take ownership of them.
+type Handlers struct {
+	// OnHashBlock is invoked with the hash of each block connected to the
+	// main chain.  It requires -zmqpubhashblock.
+	OnHashBlock func(hash *chainhash.Hash)
+
+	// OnHashTx is invoked with the hash of each transaction accepted to the
+	// mempool or included in a connected block.  It requires -zmqpubhashtx.
+	OnHashTx func(hash *chainhash.Hash)
+
+	// OnRawBlock is invoked with each block connected to the main chain.  It
+	// requires -zmqpubrawblock.
+	OnRawBlock func(block *wire.MsgBlock)
+
+	// OnRawChainLock is invoked with each block locked by a ChainLock.  It
+	// requires -zmqpubrawchainlock.
+	OnRawChainLock func(block *wire.MsgBlock)
+
+	// OnRawChainLockSig is invoked with each block locked by a ChainLock
+	// along with the ChainLock itself.  It requires -zmqpubrawchainlocksig.
+	OnRawChainLockSig func(block *wire.MsgBlock, clsig *wire.MsgCLSig)
+
+	// OnRawTxLock is invoked with each transaction locked by InstantSend.
+	// It requires -zmqpubrawtxlock.
+	OnRawTxLock func(tx *wire.MsgTx)
+
+	// OnRawTxLockSig is invoked with each transaction locked by InstantSend
+	// along with the lock itself, which is either a *wire.MsgISDLock or,
+	// for nodes predating deterministic InstantSend, a *wire.MsgISLock.  It
+	// requires -zmqpubrawtxlocksig.
+	OnRawTxLockSig func(tx *wire.MsgTx, islock wire.Message)
+
+	// OnError is invoked when a notification can't be decoded.  The
+	// notification is skipped and the client keeps running.
+	OnError func(topic string, err error)
+}
+
+// topics returns the topics which have a handler.
+func (h *Handlers) topics() []string {
+	var topics []string
+	if h.OnHashBlock != nil {
+		topics = append(topics, TopicHashBlock)
+	}
+	if h.OnHashTx != nil {
+		topics = append(topics, TopicHashTx)
+	}
+	if h.OnRawBlock != nil {
+		topics = append(topics, TopicRawBlock)
+	}
+	if h.OnRawChainLock != nil {
+		topics = append(topics, TopicRawChainLock)
+	}
+	if h.OnRawChainLockSig != nil {
+		topics = append(topics, TopicRawChainLockSig)
+	}
+	if h.OnRawTxLock != nil {
+		topics = append(topics, TopicRawTxLock)
+	}
+	if h.OnRawTxLockSig != nil {
+		topics = append(topics, TopicRawTxLockSig)
+	}
+	return topics
+}
+
+// Client represents a subscription to the ZMQ notifications of a dashd node.
+// It is created with New and delivers the notifications to its handlers until
+// it is shutdown or the connection is lost.
+type Client struct {
+	conn     net.Conn
+	handlers Handlers
+
+	mtx      sync.Mutex
+	err      error
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+}
+
+// parseAddress returns the host and port of the passed endpoint, which is
+// either of the form tcp://host:port used by dashd or a bare host:port.
+func parseAddress(address string) (string, error) {
+	if i := strings.Index(address, "://"); i >= 0 {
+		if address[:i] != "tcp" {
+			return "", fmt.Errorf("unsupported ZMQ transport %q",
+				address[:i])
+		}
+		address = address[i+3:]
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return "", err
+	}
+	return address, nil
+}
+
+// New connects to the ZMQ publisher at the passed address, subscribes to the
+// topics for which handlers are provided, and starts delivering notifications
+// to them.  The address is either of the form tcp://host:port, as reported by
+// the getzmqnotifications RPC, or host:port.
+func New(address string, handlers *Handlers) (*Client, error) {
+	if handlers == nil {
+		handlers = &Handlers{}
+	}
+	topics := handlers.topics()
+	if len(topics) == 0 {
+		return nil, errors.New("no notification handlers provided")
+	}
+
+	addr, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout("tcp", addr, handshakeTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		conn:     conn,
+		handlers: *handlers,
+		shutdown: make(chan struct{}),
+	}
+	r := bufio.NewReader(conn)
+	if err := c.handshake(r, topics); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	c.wg.Add(1)
+	go c.readHandler(r)
+	return c, nil
+}
+
+// handshake performs the ZMTP handshake of a SUB socket with the publisher and
+// subscribes to the passed topics.
+func (c *Client) handshake(r io.Reader, topics []string) error {
+	c.conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	defer c.conn.SetDeadline(time.Time{})
+
+	if _, err := c.conn.Write(greeting()); err != nil {
+		return err
+	}
+	peerGreeting := make([]byte, greetingSize)
+	if _, err := io.ReadFull(r, peerGreeting); err != nil {
+		return err
+	}
+	if err := checkGreeting(peerGreeting); err != nil {
+		return err
+	}
+
+	err := writeFrame(c.conn, flagCommand, readyCommand("SUB"))
+	if err != nil {
+		return err
+	}
+	flags, body, err := readFrame(r)
+	if err != nil {
+		return err
+	}
+	if flags&flagCommand == 0 {
+		return errors.New("publisher did not send a ZMTP READY command")
+	}
+	socketType, err := parseReadyCommand(body)
+	if err != nil {
+		return err
+	}
+	if socketType != "PUB" && socketType != "XPUB" {
+		return fmt.Errorf("unexpected ZMQ socket type %q, want PUB",
+			socketType)
+	}
+
+	for _, topic := range topics {
+		err := writeFrame(c.conn, 0, subscribeMessage(topic))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readMessage reads the frames of the next multipart message from r.  Command
+// frames, such as the heartbeats of the publisher, are skipped.
+func readMessage(r io.Reader) ([][]byte, error) {
+	var parts [][]byte
+	for {
+		flags, body, err := readFrame(r)
+		if err != nil {
+			return nil, err
+		}
+		if flags&flagCommand != 0 {
+			continue
+		}
+		parts = append(parts, body)
+		if flags&flagMore == 0 {
+			return parts, nil
+		}
+	}
+}
+
+// readHandler reads the notifications from the publisher and delivers them to
+// the handlers until the client is shutdown or the connection fails.  It must
+// be run as a goroutine.
+func (c *Client) readHandler(r io.Reader) {
+	defer c.wg.Done()
+
+	for {
+		parts, err := readMessage(r)
+		if err != nil {
+			select {
+			case <-c.shutdown:
+				err = ErrClientShutdown
+			default:
+			}
+			c.mtx.Lock()
+			if c.err == nil {
+				c.err = err
+			}
+			c.mtx.Unlock()
+			c.conn.Close()
+			return
+		}
+
+		// Notifications consist of the topic, the body, and the 4 byte
+		// sequence number of the notification for the topic.  Other
+		// messages are not sent by dashd and are ignored.
+		if len(parts) != 3 || len(parts[2]) != 4 {
+			continue
+		}
+		topic := string(parts[0])
+		if err := c.dispatch(topic, parts[1]); err != nil &&
+			c.handlers.OnError != nil {
+
+			c.handlers.OnError(topic, err)
+		}
+	}
+}
+
+// dispatch decodes the body of a notification of the passed topic and invokes
+// the associated handler.
+func (c *Client) dispatch(topic string, body []byte) error {
+	h := &c.handlers
+	switch topic {
+	case TopicHashBlock:
+		if h.OnHashBlock == nil {
+			return nil
+		}
+		hash, err := decodeHash(body)
+		if err != nil {
+			return err
+		}
+		h.OnHashBlock(hash)
+
+	case TopicHashTx:
+		if h.OnHashTx == nil {
+			return nil
+		}
+		hash, err := decodeHash(body)
+		if err != nil {
+			return err
+		}
+		h.OnHashTx(hash)
+
+	case TopicRawBlock, TopicRawChainLock:
+		handler := h.OnRawBlock
+		if topic == TopicRawChainLock {
+			handler = h.OnRawChainLock
+		}
+		if handler == nil {
+			return nil
+		}
+		r := bytes.NewReader(body)
+		block, err := decodeBlock(r)
+		if err != nil {
+			return err
+		}
+		if r.Len() != 0 {
+			return fmt.Errorf("%d unexpected bytes after the block",
+				r.Len())
+		}
+		handler(block)
+
+	case TopicRawChainLockSig:
+		if h.OnRawChainLockSig == nil {
+			return nil
+		}
+		r := bytes.NewReader(body)
+		block, err := decodeBlock(r)
+		if err != nil {
+			return err
+		}
+		var clsig wire.MsgCLSig
+		err = clsig.BtcDecode(r, wire.ProtocolVersion, wire.BaseEncoding)
+		if err != nil {
+			return err
+		}
+		if r.Len() != 0 {
+			return fmt.Errorf("%d unexpected bytes after the clsig",
+				r.Len())
+		}
+		h.OnRawChainLockSig(block, &clsig)
+
+	case TopicRawTxLock:
+		if h.OnRawTxLock == nil {
+			return nil
+		}
+		r := bytes.NewReader(body)
+		tx, err := decodeTx(r)
+		if err != nil {
+			return err
+		}
+		if r.Len() != 0 {
+			return fmt.Errorf("%d unexpected bytes after the "+
+				"transaction", r.Len())
+		}
+		h.OnRawTxLock(tx)
+
+	case TopicRawTxLockSig:
+		if h.OnRawTxLockSig == nil {
+			return nil
+		}
+		r := bytes.NewReader(body)
+		tx, err := decodeTx(r)
+		if err != nil {
+			return err
+		}
+		islock, err := decodeISLock(body[len(body)-r.Len():])
+		if err != nil {
+			return err
+		}
+		h.OnRawTxLockSig(tx, islock)
+	}
+	return nil
+}
+
+// decodeHash decodes the body of the hashblock and hashtx notifications, which
+// is the hash in the byte order it is displayed in.
+func decodeHash(body []byte) (*chainhash.Hash, error) {
+	if len(body) != chainhash.HashSize {
+		return nil, fmt.Errorf("hash of %d bytes, want %d", len(body),
+			chainhash.HashSize)
+	}
+	return chainhash.NewHashFromStr(hex.EncodeToString(body))
+}
+
+// decodeBlock decodes a serialized block from r.
+func decodeBlock(r io.Reader) (*wire.MsgBlock, error) {
+	var block wire.MsgBlock
+	if err := block.Deserialize(r); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// decodeTx decodes a serialized transaction from r.
+func decodeTx(r io.Reader) (*wire.MsgTx, error) {
+	var tx wire.MsgTx
+	if err := tx.Deserialize(r); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// decodeISLock decodes the serialized InstantSend lock which follows the
+// transaction in the rawtxlocksig notification.  Since the notification does
+// not identify the variant of the lock, the deterministic variant is tried
+// first and only accepted when it accounts for all of the bytes.
+func decodeISLock(b []byte) (wire.Message, error) {
+	r := bytes.NewReader(b)
+	var isdlock wire.MsgISDLock
+	err := isdlock.BtcDecode(r, wire.ProtocolVersion, wire.BaseEncoding)
+	if err == nil && r.Len() == 0 {
+		return &isdlock, nil
+	}
+
+	r = bytes.NewReader(b)
+	var islock wire.MsgISLock
+	err = islock.BtcDecode(r, wire.ProtocolVersion, wire.BaseEncoding)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%d unexpected bytes after the islock",
+			r.Len())
+	}
+	return &islock, nil
+}
+
+// Err returns the error which stopped the client, or nil while it is running.
+// It returns ErrClientShutdown after the client was shutdown.
+func (c *Client) Err() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.err
+}
+
+// Shutdown closes the connection to the publisher, which stops the delivery of
+// notifications.  Use WaitForShutdown to wait for a handler which is running to
+// return.
+func (c *Client) Shutdown() {
+	c.mtx.Lock()
+	select {
+	case <-c.shutdown:
+	default:
+		close(c.shutdown)
+	}
+	c.mtx.Unlock()
+	c.conn.Close()
+}
+
+// WaitForShutdown blocks until the client has stopped delivering
+// notifications, either because it was shutdown or the connection was lost.
+func (c *Client) WaitForShutdown() {
+	c.wg.Wait()
+}
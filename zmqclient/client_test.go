@@ -0,0 +1,366 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zmqclient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nargott/godash/chaincfg/chainhash"
+	"github.com/nargott/godash/wire"
+)
+
+// testPublisher is a ZMQ PUB socket for tests which accepts a single
+// subscriber.
+type testPublisher struct {
+	listener net.Listener
+	conn     net.Conn
+	sequence map[string]uint32
+}
+
+// newTestPublisher returns a publisher listening on a local port.
+func newTestPublisher(t *testing.T) *testPublisher {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	return &testPublisher{
+		listener: listener,
+		sequence: make(map[string]uint32),
+	}
+}
+
+// address returns the endpoint of the publisher in the form used by dashd.
+func (p *testPublisher) address() string {
+	return "tcp://" + p.listener.Addr().String()
+}
+
+// accept accepts the subscriber, performs the handshake, and returns the
+// topics it subscribed to.
+func (p *testPublisher) accept(numTopics int) ([]string, error) {
+	conn, err := p.listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	p.conn = conn
+	r := bufio.NewReader(conn)
+
+	if _, err := conn.Write(greeting()); err != nil {
+		return nil, err
+	}
+	peerGreeting := make([]byte, greetingSize)
+	if _, err := io.ReadFull(r, peerGreeting); err != nil {
+		return nil, err
+	}
+	if err := checkGreeting(peerGreeting); err != nil {
+		return nil, err
+	}
+
+	if err := writeFrame(conn, flagCommand, readyCommand("PUB")); err != nil {
+		return nil, err
+	}
+	_, body, err := readFrame(r)
+	if err != nil {
+		return nil, err
+	}
+	socketType, err := parseReadyCommand(body)
+	if err != nil || socketType != "SUB" {
+		return nil, err
+	}
+
+	topics := make([]string, 0, numTopics)
+	for len(topics) < numTopics {
+		_, body, err := readFrame(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(body) > 0 && body[0] == 0x01 {
+			topics = append(topics, string(body[1:]))
+		}
+	}
+	return topics, nil
+}
+
+// publish sends a notification with the passed topic and body.
+func (p *testPublisher) publish(topic string, body []byte) error {
+	var sequence [4]byte
+	binary.LittleEndian.PutUint32(sequence[:], p.sequence[topic])
+	p.sequence[topic]++
+
+	if err := writeFrame(p.conn, flagMore, []byte(topic)); err != nil {
+		return err
+	}
+	if err := writeFrame(p.conn, flagMore, body); err != nil {
+		return err
+	}
+	return writeFrame(p.conn, 0, sequence[:])
+}
+
+// close closes the publisher and the connection of its subscriber.
+func (p *testPublisher) close() {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.listener.Close()
+}
+
+// testBlock returns a block with a single coinbase transaction.
+func testBlock() *wire.MsgBlock {
+	tx := testTx()
+	block := wire.NewMsgBlock(&wire.BlockHeader{
+		Version:   0x20000000,
+		Timestamp: time.Unix(1573735892, 0),
+		Bits:      0x1e0ffff0,
+		Nonce:     1,
+	})
+	block.AddTransaction(tx)
+	block.Header.MerkleRoot = tx.TxHash()
+	return block
+}
+
+// testTx returns a transaction with a single input and output.
+func testTx() *wire.MsgTx {
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, []byte{0x51}, nil))
+	tx.AddTxOut(wire.NewTxOut(100000000, []byte{0x51}))
+	return tx
+}
+
+// serialize returns the serialization of the passed messages one after the
+// other.
+func serialize(t *testing.T, msgs ...wire.Message) []byte {
+	var buf bytes.Buffer
+	for _, msg := range msgs {
+		err := msg.BtcEncode(&buf, wire.ProtocolVersion, wire.BaseEncoding)
+		if err != nil {
+			t.Fatalf("BtcEncode %T: %v", msg, err)
+		}
+	}
+	return buf.Bytes()
+}
+
+// TestClient ensures the client subscribes to the topics of its handlers and
+// delivers the decoded notifications to them.
+func TestClient(t *testing.T) {
+	pub := newTestPublisher(t)
+	defer pub.close()
+
+	block := testBlock()
+	tx := testTx()
+	txHash := tx.TxHash()
+	blockHash := block.BlockHash()
+	clsig := wire.NewMsgCLSig(1000, &blockHash, [wire.BLSSignatureSize]byte{1})
+	cycleHash := chainhash.Hash{2}
+	isdlock := wire.NewMsgISDLock(1, []wire.OutPoint{tx.TxIn[0].PreviousOutPoint},
+		&txHash, &cycleHash, [wire.BLSSignatureSize]byte{3})
+	islock := wire.NewMsgISLock([]wire.OutPoint{tx.TxIn[0].PreviousOutPoint},
+		&txHash, [wire.BLSSignatureSize]byte{4})
+
+	// The hashes are published in the byte order they are displayed in.
+	reversed := func(hash chainhash.Hash) []byte {
+		b := make([]byte, chainhash.HashSize)
+		for i := range b {
+			b[i] = hash[chainhash.HashSize-1-i]
+		}
+		return b
+	}
+
+	events := make(chan interface{}, 10)
+	errs := make(chan error, 10)
+	handlers := &Handlers{
+		OnHashBlock: func(hash *chainhash.Hash) {
+			events <- *hash
+		},
+		OnRawChainLockSig: func(block *wire.MsgBlock, clsig *wire.MsgCLSig) {
+			events <- []interface{}{block, clsig}
+		},
+		OnRawTxLock: func(tx *wire.MsgTx) {
+			events <- tx
+		},
+		OnRawTxLockSig: func(tx *wire.MsgTx, islock wire.Message) {
+			events <- []interface{}{tx, islock}
+		},
+		OnError: func(topic string, err error) {
+			errs <- err
+		},
+	}
+
+	subscribed := make(chan []string, 1)
+	go func() {
+		topics, err := pub.accept(4)
+		if err != nil {
+			t.Errorf("accept: %v", err)
+		}
+		subscribed <- topics
+	}()
+
+	client, err := New(pub.address(), handlers)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer client.Shutdown()
+
+	topics := <-subscribed
+	wantTopics := []string{TopicHashBlock, TopicRawChainLockSig,
+		TopicRawTxLock, TopicRawTxLockSig}
+	if !reflect.DeepEqual(topics, wantTopics) {
+		t.Fatalf("subscribed to %v, want %v", topics, wantTopics)
+	}
+
+	tests := []struct {
+		name  string
+		topic string
+		body  []byte
+		want  interface{}
+	}{
+		{
+			name:  "hashblock",
+			topic: TopicHashBlock,
+			body:  reversed(blockHash),
+			want:  blockHash,
+		},
+		{
+			name:  "rawchainlocksig",
+			topic: TopicRawChainLockSig,
+			body:  serialize(t, block, clsig),
+			want:  []interface{}{block, clsig},
+		},
+		{
+			name:  "rawtxlock",
+			topic: TopicRawTxLock,
+			body:  serialize(t, tx),
+			want:  tx,
+		},
+		{
+			name:  "rawtxlocksig isdlock",
+			topic: TopicRawTxLockSig,
+			body:  serialize(t, tx, isdlock),
+			want:  []interface{}{tx, isdlock},
+		},
+		{
+			name:  "rawtxlocksig islock",
+			topic: TopicRawTxLockSig,
+			body:  serialize(t, tx, islock),
+			want:  []interface{}{tx, islock},
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		if err := pub.publish(test.topic, test.body); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+
+		select {
+		case got := <-events:
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Test #%d (%s) unexpected event - got %v, "+
+					"want %v", i, test.name, got, test.want)
+			}
+		case err := <-errs:
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Test #%d (%s) timed out", i, test.name)
+		}
+	}
+
+	// A notification which can't be decoded is reported and skipped.
+	if err := pub.publish(TopicRawTxLock, []byte{0x01}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if err := pub.publish(TopicHashBlock, reversed(blockHash)); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	select {
+	case <-errs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("undecodable notification was not reported")
+	}
+	select {
+	case got := <-events:
+		if got != blockHash {
+			t.Errorf("unexpected event after error - got %v, want %v",
+				got, blockHash)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification after error timed out")
+	}
+
+	client.Shutdown()
+	client.WaitForShutdown()
+	if err := client.Err(); err != ErrClientShutdown {
+		t.Errorf("Err: got %v, want %v", err, ErrClientShutdown)
+	}
+}
+
+// TestNew ensures New rejects invalid addresses and missing handlers.
+func TestNew(t *testing.T) {
+	handlers := &Handlers{OnHashTx: func(*chainhash.Hash) {}}
+	tests := []struct {
+		name     string
+		address  string
+		handlers *Handlers
+	}{
+		{"no handlers", "tcp://127.0.0.1:28332", nil},
+		{"unsupported transport", "ipc:///tmp/dashd.sock", handlers},
+		{"missing port", "tcp://127.0.0.1", handlers},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		if _, err := New(test.address, test.handlers); err == nil {
+			t.Errorf("Test #%d (%s) New succeeded", i, test.name)
+		}
+	}
+}
+
+// TestFrames ensures frames round trip with both the short and long size
+// encodings and that oversized frames are rejected.
+func TestFrames(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags byte
+		body  []byte
+	}{
+		{"empty", 0, nil},
+		{"short", flagMore, bytes.Repeat([]byte{0xaa}, 255)},
+		{"long", 0, bytes.Repeat([]byte{0xbb}, 256)},
+		{"command", flagCommand, readyCommand("SUB")},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		var buf bytes.Buffer
+		if err := writeFrame(&buf, test.flags, test.body); err != nil {
+			t.Errorf("Test #%d (%s) writeFrame: %v", i, test.name, err)
+			continue
+		}
+		flags, body, err := readFrame(&buf)
+		if err != nil {
+			t.Errorf("Test #%d (%s) readFrame: %v", i, test.name, err)
+			continue
+		}
+		if flags&^flagLong != test.flags {
+			t.Errorf("Test #%d (%s) flags: got %#x, want %#x", i,
+				test.name, flags, test.flags)
+		}
+		if !bytes.Equal(body, test.body) {
+			t.Errorf("Test #%d (%s) body mismatch", i, test.name)
+		}
+	}
+
+	// The size is checked before the body is allocated.
+	oversized := []byte{flagLong, 0, 0, 0, 0, 0xff, 0, 0, 0}
+	if _, _, err := readFrame(bytes.NewReader(oversized)); err == nil {
+		t.Error("readFrame accepted an oversized frame")
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package zmqclient implements a client for the ZMQ notifications published by
+dashd when it is started with options such as -zmqpubhashblock or
+-zmqpubrawchainlocksig.
+
+Unlike polling the RPC server, the client receives an event as soon as the
+node publishes it.  The notifications are decoded into the wire types, so a
+chainlock is delivered as the locked block together with its wire.MsgCLSig and
+an InstantSend lock as the locked transaction together with its lock message.
+
+The client speaks the ZMTP 3.0 protocol of a SUB socket using the NULL
+security mechanism, which is what dashd publishes with, and therefore has no
+dependency on the ZMQ C library.  It subscribes to the topics for which
+handlers are provided, and the GetZMQNotifications function of the rpcclient
+package can be used to discover the endpoints the node publishes them on.
+
+The client does not reconnect.  ZMQ publishers drop the notifications of
+subscribers which are not connected, so callers that need to see every
+notification should compare the node state over RPC after reconnecting.
+*/
+package zmqclient
@@ -0,0 +1,174 @@
+// Copyright (c) 2016 The Dash developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zmqclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nargott/godash/wire"
+)
+
+const (
+	// greetingSize is the size of the greeting exchanged by both peers
+	// when a ZMTP 3.0 connection is established.
+	greetingSize = 64
+
+	// mechanismNull is the name of the security mechanism without
+	// authentication or encryption used by dashd.
+	mechanismNull = "NULL"
+
+	// maxFrameSize is the maximum size of a frame the client accepts.  The
+	// largest notification is a raw block, which is bounded by the maximum
+	// payload of a message of the peer-to-peer protocol.
+	maxFrameSize = wire.MaxMessagePayload
+)
+
+// The flags of a ZMTP frame.
+const (
+	flagMore    = 0x01
+	flagLong    = 0x02
+	flagCommand = 0x04
+)
+
+// greeting returns the greeting sent by the client, which announces version
+// 3.0 of the protocol and the NULL security mechanism.
+func greeting() []byte {
+	g := make([]byte, greetingSize)
+	g[0] = 0xff
+	g[9] = 0x7f
+	g[10] = 3
+	g[11] = 0
+	copy(g[12:32], mechanismNull)
+	return g
+}
+
+// checkGreeting ensures the greeting received from the peer is one of a ZMTP
+// 3.x peer using the NULL security mechanism.
+func checkGreeting(g []byte) error {
+	if len(g) != greetingSize || g[0] != 0xff || g[9]&0x01 != 0x01 {
+		return fmt.Errorf("peer is not a ZMTP peer")
+	}
+	if g[10] < 3 {
+		return fmt.Errorf("unsupported ZMTP version %d.%d", g[10], g[11])
+	}
+	mechanism := string(bytes.TrimRight(g[12:32], "\x00"))
+	if mechanism != mechanismNull {
+		return fmt.Errorf("unsupported ZMTP security mechanism %q",
+			mechanism)
+	}
+	return nil
+}
+
+// writeFrame writes a single frame with the passed flags and body to w.  The
+// long flag is set as needed based on the size of the body.
+func writeFrame(w io.Writer, flags byte, body []byte) error {
+	var header []byte
+	if len(body) > 0xff {
+		header = make([]byte, 9)
+		header[0] = flags | flagLong
+		binary.BigEndian.PutUint64(header[1:], uint64(len(body)))
+	} else {
+		header = []byte{flags, byte(len(body))}
+	}
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(body)
+	return err
+}
+
+// readFrame reads a single frame from r and returns its flags and body.
+func readFrame(r io.Reader) (byte, []byte, error) {
+	var flags [1]byte
+	if _, err := io.ReadFull(r, flags[:]); err != nil {
+		return 0, nil, err
+	}
+
+	var size uint64
+	if flags[0]&flagLong != 0 {
+		var buf [8]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, nil, err
+		}
+		size = binary.BigEndian.Uint64(buf[:])
+	} else {
+		var buf [1]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, nil, err
+		}
+		size = uint64(buf[0])
+	}
+	if size > maxFrameSize {
+		return 0, nil, fmt.Errorf("frame of %d bytes exceeds the "+
+			"maximum of %d bytes", size, maxFrameSize)
+	}
+
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return flags[0], body, nil
+}
+
+// readyCommand returns the body of the READY command which announces the
+// passed socket type to the peer.
+func readyCommand(socketType string) []byte {
+	const name, property = "READY", "Socket-Type"
+
+	var buf bytes.Buffer
+	buf.WriteByte(byte(len(name)))
+	buf.WriteString(name)
+	buf.WriteByte(byte(len(property)))
+	buf.WriteString(property)
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(socketType)))
+	buf.Write(size[:])
+	buf.WriteString(socketType)
+	return buf.Bytes()
+}
+
+// parseReadyCommand parses the body of a READY command and returns the socket
+// type announced by the peer.
+func parseReadyCommand(body []byte) (string, error) {
+	if len(body) == 0 || len(body) < 1+int(body[0]) {
+		return "", fmt.Errorf("malformed ZMTP command")
+	}
+	name := string(body[1 : 1+body[0]])
+	if name != "READY" {
+		return "", fmt.Errorf("unexpected ZMTP command %q", name)
+	}
+
+	// The properties are a sequence of a short name followed by a value
+	// prefixed with its 4 byte length.
+	props := body[1+body[0]:]
+	for len(props) > 0 {
+		nameLen := int(props[0])
+		if len(props) < 1+nameLen+4 {
+			return "", fmt.Errorf("malformed ZMTP READY command")
+		}
+		propName := string(props[1 : 1+nameLen])
+		props = props[1+nameLen:]
+		valueLen := binary.BigEndian.Uint32(props)
+		props = props[4:]
+		if uint64(len(props)) < uint64(valueLen) {
+			return "", fmt.Errorf("malformed ZMTP READY command")
+		}
+		if strings.EqualFold(propName, "Socket-Type") {
+			return string(props[:valueLen]), nil
+		}
+		props = props[valueLen:]
+	}
+	return "", fmt.Errorf("ZMTP READY command has no socket type")
+}
+
+// subscribeMessage returns the body of the message which subscribes to the
+// passed topic.
+func subscribeMessage(topic string) []byte {
+	return append([]byte{0x01}, topic...)
+}